@@ -0,0 +1,328 @@
+// Package config loads the settings of the server, defaults are overridden by a JSON file
+// and the file is overridden by environment variables.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"ThreeLayer/entities"
+)
+
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+
+	redacted = "*****"
+)
+
+type Config struct {
+	Database Database `json:"database"`
+	Server   Server   `json:"server"`
+	Loans    Loans    `json:"loans"`
+	Fines    Fines    `json:"fines"`
+	Books    Books    `json:"books"`
+	LogLevel string   `json:"log_level"`
+}
+
+type Database struct {
+	Driver          string   `json:"driver"`
+	DSN             string   `json:"dsn"`
+	SQLitePath      string   `json:"sqlite_path"`
+	MaxOpenConns    int      `json:"max_open_conns"`
+	MaxIdleConns    int      `json:"max_idle_conns"`
+	ConnMaxLifetime Duration `json:"conn_max_lifetime"`
+}
+
+type Server struct {
+	Addr         string   `json:"addr"`
+	ReadTimeout  Duration `json:"read_timeout"`
+	WriteTimeout Duration `json:"write_timeout"`
+	IdleTimeout  Duration `json:"idle_timeout"`
+	// RequireIfMatch rejects the updates and deletes sent without the If-Match header
+	RequireIfMatch bool `json:"require_if_match"`
+}
+
+// Loans is the lending policy of the library
+type Loans struct {
+	// Days is the length of a loan, every renewal extends the loan by as many days
+	Days        int `json:"days"`
+	MaxRenewals int `json:"max_renewals"`
+	// HoldPickupDays is how long a returned copy is kept for the member of the hold it is assigned to
+	HoldPickupDays int `json:"hold_pickup_days"`
+}
+
+// Fines is the fining policy of the library in cents, keyed by the membership type
+type Fines struct {
+	// DailyRates is charged for every day a loan is overdue, up to Caps for the loan
+	DailyRates map[string]int `json:"daily_rates"`
+	Caps       map[string]int `json:"caps"`
+	// BlockAbove is the most a member can owe and still borrow
+	BlockAbove int `json:"block_above"`
+}
+
+// Books is the cataloguing policy of the library
+type Books struct {
+	// DuplicateKeys are the details which a new book can not share all of with a stored book,
+	// duplicates are not checked when it is empty
+	DuplicateKeys []string `json:"duplicate_keys"`
+}
+
+// Duration is a time.Duration written as a string such as "5s" in the config file
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// Default returns the config used when nothing is set
+func Default() Config {
+	return Config{
+		Database: Database{
+			Driver:          DriverMySQL,
+			DSN:             "root@tcp(localhost:3306)/test",
+			SQLitePath:      "library.db",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(5 * time.Minute),
+		},
+		Server: Server{
+			Addr:           ":8000",
+			ReadTimeout:    Duration(10 * time.Second),
+			WriteTimeout:   Duration(10 * time.Second),
+			IdleTimeout:    Duration(60 * time.Second),
+			RequireIfMatch: true,
+		},
+		Loans: Loans{
+			Days:           14,
+			MaxRenewals:    2,
+			HoldPickupDays: 3,
+		},
+		Fines: Fines{
+			DailyRates: map[string]int{entities.MembershipStandard: 25, entities.MembershipStudent: 10,
+				entities.MembershipPremium: 25},
+			Caps: map[string]int{entities.MembershipStandard: 1000, entities.MembershipStudent: 500,
+				entities.MembershipPremium: 2000},
+			BlockAbove: 1000,
+		},
+		Books: Books{
+			DuplicateKeys: []string{entities.DuplicateByTitle, entities.DuplicateByAuthor, entities.DuplicateByPublisher,
+				entities.DuplicateByPublishedDate},
+		},
+		LogLevel: "info",
+	}
+}
+
+// Load returns the default config overridden by the JSON file at path and then by the environment,
+// the file is optional and skipped when path is empty
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+
+		if err = json.Unmarshal(b, &cfg); err != nil {
+			return Config{}, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// loadEnv overrides every field whose environment variable is set
+func (c *Config) loadEnv() error {
+	setString(&c.Database.Driver, "DB_DRIVER")
+	setString(&c.Database.DSN, "DB_DSN")
+	setString(&c.Database.SQLitePath, "SQLITE_PATH")
+	setString(&c.Server.Addr, "HTTP_ADDR")
+	setString(&c.LogLevel, "LOG_LEVEL")
+
+	ints := map[string]*int{
+		"DB_MAX_OPEN_CONNS": &c.Database.MaxOpenConns,
+		"DB_MAX_IDLE_CONNS": &c.Database.MaxIdleConns,
+		"LOAN_DAYS":         &c.Loans.Days,
+		"LOAN_MAX_RENEWALS": &c.Loans.MaxRenewals,
+		"HOLD_PICKUP_DAYS":  &c.Loans.HoldPickupDays,
+		"FINE_BLOCK_ABOVE":  &c.Fines.BlockAbove,
+	}
+
+	for key, field := range ints {
+		if v, ok := os.LookupEnv(key); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+
+			*field = n
+		}
+	}
+
+	durations := map[string]*Duration{
+		"DB_CONN_MAX_LIFETIME": &c.Database.ConnMaxLifetime,
+		"HTTP_READ_TIMEOUT":    &c.Server.ReadTimeout,
+		"HTTP_WRITE_TIMEOUT":   &c.Server.WriteTimeout,
+		"HTTP_IDLE_TIMEOUT":    &c.Server.IdleTimeout,
+	}
+
+	for key, field := range durations {
+		if v, ok := os.LookupEnv(key); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+
+			*field = Duration(d)
+		}
+	}
+
+	// the keys are separated by commas, an empty value turns the check off
+	if v, ok := os.LookupEnv("BOOK_DUPLICATE_KEYS"); ok {
+		c.Books.DuplicateKeys = []string{}
+
+		for _, key := range strings.Split(v, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				c.Books.DuplicateKeys = append(c.Books.DuplicateKeys, key)
+			}
+		}
+	}
+
+	if v, ok := os.LookupEnv("REQUIRE_IF_MATCH"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("REQUIRE_IF_MATCH: %w", err)
+		}
+
+		c.Server.RequireIfMatch = b
+	}
+
+	return nil
+}
+
+func setString(field *string, key string) {
+	if v, ok := os.LookupEnv(key); ok {
+		*field = v
+	}
+}
+
+// Validate checks that every setting is usable, the first invalid setting is returned
+func (c Config) Validate() error {
+	switch c.Database.Driver {
+	case DriverMySQL:
+		if _, err := mysql.ParseDSN(c.Database.DSN); err != nil {
+			return fmt.Errorf("database.dsn: %w", err)
+		}
+	case DriverSQLite:
+		if c.Database.SQLitePath == "" {
+			return fmt.Errorf("database.sqlite_path is required for the sqlite driver")
+		}
+	case DriverMemory:
+	default:
+		return fmt.Errorf("database.driver must be one of mysql, sqlite, memory got %q", c.Database.Driver)
+	}
+
+	switch {
+	case c.Database.MaxOpenConns < 0:
+		return fmt.Errorf("database.max_open_conns can not be negative")
+	case c.Database.MaxIdleConns < 0:
+		return fmt.Errorf("database.max_idle_conns can not be negative")
+	case c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns:
+		return fmt.Errorf("database.max_idle_conns can not be more than database.max_open_conns")
+	case c.Database.ConnMaxLifetime < 0:
+		return fmt.Errorf("database.conn_max_lifetime can not be negative")
+	case c.Server.Addr == "":
+		return fmt.Errorf("server.addr is required")
+	case c.Server.ReadTimeout <= 0 || c.Server.WriteTimeout <= 0 || c.Server.IdleTimeout <= 0:
+		return fmt.Errorf("server timeouts must be positive")
+	case c.Loans.Days <= 0:
+		return fmt.Errorf("loans.days must be positive")
+	case c.Loans.MaxRenewals < 0:
+		return fmt.Errorf("loans.max_renewals can not be negative")
+	case c.Loans.HoldPickupDays <= 0:
+		return fmt.Errorf("loans.hold_pickup_days must be positive")
+	case c.Fines.BlockAbove < 0:
+		return fmt.Errorf("fines.block_above can not be negative")
+	}
+
+	for _, membership := range []string{entities.MembershipStandard, entities.MembershipStudent,
+		entities.MembershipPremium} {
+		if rate, ok := c.Fines.DailyRates[membership]; !ok || rate < 0 {
+			return fmt.Errorf("fines.daily_rates.%s is required and can not be negative", membership)
+		}
+
+		if limit, ok := c.Fines.Caps[membership]; !ok || limit < 0 {
+			return fmt.Errorf("fines.caps.%s is required and can not be negative", membership)
+		}
+	}
+
+	for _, key := range c.Books.DuplicateKeys {
+		switch key {
+		case entities.DuplicateByTitle, entities.DuplicateByAuthor, entities.DuplicateByPublisher,
+			entities.DuplicateByPublishedDate:
+		default:
+			return fmt.Errorf("books.duplicate_keys must be some of title, author, publisher, published_date got %q", key)
+		}
+	}
+
+	// debug logs every request on top of what info logs
+	switch c.LogLevel {
+	case "debug", "info":
+	default:
+		return fmt.Errorf("log_level must be one of debug, info got %q", c.LogLevel)
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the config which is safe to print, the password of the DSN is hidden
+func (c Config) Redacted() Config {
+	dsn, err := mysql.ParseDSN(c.Database.DSN)
+	if err != nil {
+		c.Database.DSN = redacted
+		return c
+	}
+
+	if dsn.Passwd != "" {
+		dsn.Passwd = redacted
+	}
+
+	c.Database.DSN = dsn.FormatDSN()
+
+	return c
+}
+
+// String returns the redacted config as JSON
+func (c Config) String() string {
+	b, _ := json.Marshal(c.Redacted())
+
+	return string(b)
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "config.json")
+	content := `{"database": {"driver": "sqlite", "sqlite_path": "from_file.db", "max_open_conns": 20},
+"server": {"addr": ":9000", "read_timeout": "3s"}, "fines": {"daily_rates": {"student": 5}}}`
+
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"server": {"read_timeout": "3 seconds"}}`), 0o600); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	fromFile := Default()
+	fromFile.Database.Driver = DriverSQLite
+	fromFile.Database.SQLitePath = "from_file.db"
+	fromFile.Database.MaxOpenConns = 20
+	fromFile.Server.Addr = ":9000"
+	fromFile.Server.ReadTimeout = Duration(3 * time.Second)
+	fromFile.Fines.DailyRates = map[string]int{"standard": 25, "student": 5, "premium": 25}
+
+	fromEnv := fromFile
+	fromEnv.Fines.BlockAbove = 500
+	fromEnv.Server.Addr = ":9100"
+	fromEnv.Server.WriteTimeout = Duration(time.Minute)
+	fromEnv.LogLevel = "debug"
+	fromEnv.Server.RequireIfMatch = false
+	fromEnv.Loans.Days = 21
+	fromEnv.Loans.HoldPickupDays = 5
+	fromEnv.Books.DuplicateKeys = []string{"title", "author"}
+
+	noDuplicates := Default()
+	noDuplicates.Books.DuplicateKeys = []string{}
+
+	testcases := []struct {
+		desc   string
+		path   string
+		env    map[string]string
+		expCfg Config
+		expErr bool
+	}{
+		{desc: "defaults", expCfg: Default()},
+		{desc: "file overrides defaults", path: file, expCfg: fromFile},
+		{desc: "env overrides file", path: file, env: map[string]string{"HTTP_ADDR": ":9100",
+			"HTTP_WRITE_TIMEOUT": "1m", "LOG_LEVEL": "debug", "REQUIRE_IF_MATCH": "false",
+			"LOAN_DAYS": "21", "HOLD_PICKUP_DAYS": "5", "FINE_BLOCK_ABOVE": "500",
+			"BOOK_DUPLICATE_KEYS": "title, author"}, expCfg: fromEnv},
+		{desc: "duplicates not checked", env: map[string]string{"BOOK_DUPLICATE_KEYS": ""}, expCfg: noDuplicates},
+		{desc: "missing file", path: filepath.Join(dir, "missing.json"), expErr: true},
+		{desc: "invalid duration in file", path: invalid, expErr: true},
+		{desc: "invalid number in env", env: map[string]string{"DB_MAX_OPEN_CONNS": "ten"}, expErr: true},
+		{desc: "invalid bool in env", env: map[string]string{"REQUIRE_IF_MATCH": "sometimes"}, expErr: true},
+		{desc: "invalid driver in env", env: map[string]string{"DB_DRIVER": "oracle"}, expErr: true},
+	}
+
+	for i, tc := range testcases {
+		for key, value := range tc.env {
+			t.Setenv(key, value)
+		}
+
+		cfg, err := Load(tc.path)
+
+		if (err != nil) != tc.expErr {
+			t.Errorf("[TEST%d]Failed. Got error %v\tExpected error %v", i, err, tc.expErr)
+		}
+
+		if !tc.expErr && !reflect.DeepEqual(cfg, tc.expCfg) {
+			t.Errorf("[TEST%d]Failed. Got %+v\tExpected %+v", i, cfg, tc.expCfg)
+		}
+
+		for key := range tc.env {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		modify func(c *Config)
+		expErr string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"memory driver needs no dsn", func(c *Config) { c.Database.Driver = DriverMemory; c.Database.DSN = "" }, ""},
+		{"invalid dsn", func(c *Config) { c.Database.DSN = "root@localhost" }, "database.dsn"},
+		{"sqlite without path", func(c *Config) { c.Database.Driver = DriverSQLite; c.Database.SQLitePath = "" },
+			"database.sqlite_path"},
+		{"negative pool", func(c *Config) { c.Database.MaxOpenConns = -1 }, "database.max_open_conns"},
+		{"idle more than open", func(c *Config) { c.Database.MaxIdleConns = 20 }, "database.max_idle_conns"},
+		{"no address", func(c *Config) { c.Server.Addr = "" }, "server.addr"},
+		{"zero timeout", func(c *Config) { c.Server.ReadTimeout = 0 }, "server timeouts"},
+		{"no loan days", func(c *Config) { c.Loans.Days = 0 }, "loans.days"},
+		{"negative renewals", func(c *Config) { c.Loans.MaxRenewals = -1 }, "loans.max_renewals"},
+		{"no pickup days", func(c *Config) { c.Loans.HoldPickupDays = 0 }, "loans.hold_pickup_days"},
+		{"negative block", func(c *Config) { c.Fines.BlockAbove = -1 }, "fines.block_above"},
+		{"missing rate", func(c *Config) { delete(c.Fines.DailyRates, "student") }, "fines.daily_rates.student"},
+		{"negative cap", func(c *Config) { c.Fines.Caps["premium"] = -1 }, "fines.caps.premium"},
+		{"unknown log level", func(c *Config) { c.LogLevel = "verbose" }, "log_level"},
+		{"log level without effect", func(c *Config) { c.LogLevel = "warn" }, "log_level"},
+		{"unknown duplicate key", func(c *Config) { c.Books.DuplicateKeys = []string{"title", "edition"} },
+			"books.duplicate_keys"},
+	}
+
+	for i, tc := range testcases {
+		cfg := Default()
+		tc.modify(&cfg)
+
+		err := cfg.Validate()
+
+		if tc.expErr == "" && err != nil {
+			t.Errorf("[TEST%d]Failed. Expected error to be nil Got %v", i, err)
+		}
+
+		if tc.expErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expErr)) {
+			t.Errorf("[TEST%d]Failed. Expected error about %s Got %v", i, tc.expErr, err)
+		}
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.DSN = "root:Secret@0848@tcp(localhost:3306)/test"
+
+	redactedCfg := cfg.Redacted()
+
+	if redactedCfg.Database.DSN != "root:*****@tcp(localhost:3306)/test" {
+		t.Errorf("Failed. Got %v", redactedCfg.Database.DSN)
+	}
+
+	if strings.Contains(cfg.String(), "Secret") {
+		t.Errorf("Failed. Expected the password to be hidden Got %v", cfg.String())
+	}
+
+	if cfg.Database.DSN != "root:Secret@0848@tcp(localhost:3306)/test" {
+		t.Errorf("Failed. Expected the config to be unchanged Got %v", cfg.Database.DSN)
+	}
+}
@@ -16,10 +16,19 @@ func New(db *sql.DB) Storer {
 	return Storer{db: db}
 }
 
+// scanAuthor reads an author selected with the columns of datastore.GetAuthor, it is used by all the author stores
+func scanAuthor(row datastore.Scanner) (entities.Author, error) {
+	var author entities.Author
+
+	err := row.Scan(&author.ID, &author.FirstName, &author.LastName, &author.Dob, &author.PenName, &author.Version)
+
+	return author, err
+}
+
 // get the list of authors
 func (a Storer) GetAuthor(ctx context.Context) ([]entities.Author, error) {
 
-	rows, err := a.db.QueryContext(ctx, datastore.GetAuthor)
+	rows, err := datastore.Conn(ctx, a.db).QueryContext(ctx, datastore.GetAuthor)
 	if err != nil {
 		return nil, err
 	}
@@ -29,9 +38,7 @@ func (a Storer) GetAuthor(ctx context.Context) ([]entities.Author, error) {
 	authors := make([]entities.Author, 0)
 
 	for rows.Next() {
-		var author entities.Author
-
-		err = rows.Scan(&author.ID, &author.FirstName, &author.LastName, &author.Dob, &author.PenName)
+		author, err := scanAuthor(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -45,10 +52,7 @@ func (a Storer) GetAuthor(ctx context.Context) ([]entities.Author, error) {
 //getAuthorByID  is used in book for checking the exixting author
 func (a Storer) GetAuthorByID(ctx context.Context, id int) (entities.Author, error) {
 
-	var author entities.Author
-
-	err := a.db.QueryRowContext(ctx, datastore.GetByIDAuthor, id).Scan(&author.ID, &author.FirstName, &author.LastName,
-		&author.Dob, &author.PenName)
+	author, err := scanAuthor(datastore.Conn(ctx, a.db).QueryRowContext(ctx, datastore.GetByIDAuthor, id))
 	if err != nil {
 		return entities.Author{}, errors.EntityNotFound{Entity: "Author"}
 	}
@@ -56,10 +60,43 @@ func (a Storer) GetAuthorByID(ctx context.Context, id int) (entities.Author, err
 	return author, nil
 }
 
+// GetAuthorsByIDs function is to perform a single DB Query to get all the authors having any of the ids,
+// ids which do not exist are skipped
+func (a Storer) GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error) {
+	return getAuthorsByIDs(ctx, datastore.Conn(ctx, a.db), ids)
+}
+
+func getAuthorsByIDs(ctx context.Context, db datastore.DBTX, ids []int) ([]entities.Author, error) {
+	authors := make([]entities.Author, 0, len(ids))
+	if len(ids) == 0 {
+		return authors, nil
+	}
+
+	query, args := datastore.AuthorsByIDsQuery(ids)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		authors = append(authors, author)
+	}
+
+	return authors, rows.Err()
+}
+
 // PostAuthor function is to perform DB execution to add a new author instance in database
 func (a Storer) CreateAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
 
-	res, err := a.db.ExecContext(ctx, datastore.InsertAuthor,
+	res, err := datastore.Conn(ctx, a.db).ExecContext(ctx, datastore.InsertAuthor,
 		author.FirstName, author.LastName, author.Dob, author.PenName)
 	if err != nil {
 		return entities.Author{}, err
@@ -68,26 +105,47 @@ func (a Storer) CreateAuthor(ctx context.Context, author entities.Author) (entit
 	id, _ := res.LastInsertId()
 
 	author.ID = int(id)
+	author.Version = 1
 
 	return author, nil
 }
 
-// PutAuthor function is to perform required DB Queries to edit an author instance in database.
+// PutAuthor function is to perform required DB Queries to edit an author instance in database,
+// the author is returned with its new version.
 func (a Storer) PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error) {
 
-	_, err := a.db.ExecContext(ctx, datastore.UpdateAuthor, author.FirstName, author.LastName, author.Dob, author.PenName, id)
+	version, err := datastore.MySQL.Update(ctx, datastore.Conn(ctx, a.db), "Author", "Authors", id, datastore.UpdateAuthor,
+		author.FirstName, author.LastName, author.Dob, author.PenName, id, author.Version)
 	if err != nil {
 		return entities.Author{}, err
 	}
 
+	author.ID = id
+	author.Version = version
+
 	return author, nil
 }
 
+// UpdateAuthorFields function is to perform DB Query to update only the given fields of an author instance in database
+func (a Storer) UpdateAuthorFields(ctx context.Context, id int, author entities.Author, fields []string) (int, error) {
+	query, args, err := datastore.UpdateAuthorFieldsQuery(datastore.MySQL, id, author, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	return datastore.MySQL.Update(ctx, datastore.Conn(ctx, a.db), "Author", "Authors", id, query, args...)
+}
+
 // DeleteAuthor function is to perform required DB Queries to remove an author instance from database.
 func (a Storer) DeleteAuthor(ctx context.Context, id int) error {
-	res, err := a.db.ExecContext(ctx, datastore.DeleteAuthor, id)
+	res, err := datastore.Conn(ctx, a.db).ExecContext(ctx, datastore.DeleteAuthor, id)
+	if err != nil {
+		// the author is still referenced by books
+		return errors.DB{Err: err}
+	}
+
 	r, _ := res.RowsAffected()
-	if int(r) == 0 || err != nil {
+	if int(r) == 0 {
 		return errors.EntityNotFound{Entity: "Author", ID: id}
 	}
 
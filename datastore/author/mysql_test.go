@@ -41,8 +41,9 @@ func TestStorer_CreateAuthor(t *testing.T) {
 		},
 		{
 			"Success Case",
-			entities.Author{FirstName: "MG", LastName: "Verma", Dob: "13/07/2000", PenName: "Verma"},
-			entities.Author{ID: 1, FirstName: "MG", LastName: "Verma", Dob: "13/07/2000", PenName: "Verma"},
+			entities.Author{FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"},
+			entities.Author{ID: 1, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13),
+				PenName: "Verma", Version: 1},
 			1,
 			nil,
 		},
@@ -80,25 +81,29 @@ func TestStorer_PutAuthor(t *testing.T) {
 	}{
 		{
 			"Valid case update firstname.", 1,
-			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: "22/07/2000", PenName: "ABC"},
-			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: "22/07/2000", PenName: "ABC"},
+			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22),
+				PenName: "ABC", Version: 2},
+			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22),
+				PenName: "ABC", Version: 3},
 			1, nil,
 		},
 		{
 			"Error Case", 2,
 			entities.Author{},
 			entities.Author{},
-			0, fmt.Errorf("query error"),
+			0, errors.DB{Err: fmt.Errorf("query error")},
 		},
 	}
 
 	for i, v := range testcases {
 		db, mock := NewMock()
 		a := New(db)
-		mock.ExpectExec(datastore.UpdateAuthor).
-			WithArgs(v.reqData.FirstName, v.reqData.LastName, v.reqData.Dob, v.reqData.PenName, v.reqID).
-			WillReturnResult(sqlmock.NewResult(0, v.rowsAffected)).
-			WillReturnError(v.expErr)
+		exec := mock.ExpectExec(datastore.UpdateAuthor).
+			WithArgs(v.reqData.FirstName, v.reqData.LastName, v.reqData.Dob, v.reqData.PenName, v.reqID, v.reqData.Version).
+			WillReturnResult(sqlmock.NewResult(3, v.rowsAffected))
+		if dbErr, ok := v.expErr.(errors.DB); ok {
+			exec.WillReturnError(dbErr.Err)
+		}
 
 		res, err := a.PutAuthor(context.Background(), v.reqID, v.reqData)
 
@@ -152,10 +157,10 @@ func TestAuthorStorer_GetAuthor(t *testing.T) {
 	}{
 		{
 			desc: "get all books",
-			expRows: sqlmock.NewRows([]string{"id", "first_name", "last_name", "dob", "pen_name"}).
-				AddRow(1, "MG", "Verma", "13/07/2000", "Verma"),
-			expRes: []entities.Author{{ID: 1, FirstName: "MG", LastName: "Verma", Dob: "13/07/2000",
-				PenName: "Verma"}},
+			expRows: sqlmock.NewRows([]string{"id", "first_name", "last_name", "dob", "pen_name", "version"}).
+				AddRow(1, "MG", "Verma", "2000-07-13", "Verma", 1),
+			expRes: []entities.Author{{ID: 1, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13),
+				PenName: "Verma", Version: 1}},
 		},
 	}
 	for i, v := range testcases {
@@ -184,14 +189,14 @@ func TestAuthorStore_GetAuthorByID(t *testing.T) {
 		expErr error
 	}{
 
-		{"get book", 1, entities.Author{ID: 1, FirstName: "MG", LastName: "Verma", Dob: "13/07/2000",
-			PenName: "Verma"},
-			sqlmock.NewRows([]string{"id", "first_name", "last_name", "dob", "pen_name"}).
-				AddRow(1, "MG", "Verma", "13/07/2000", "Verma"),
+		{"get book", 1, entities.Author{ID: 1, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13),
+			PenName: "Verma", Version: 1},
+			sqlmock.NewRows([]string{"id", "first_name", "last_name", "dob", "pen_name", "version"}).
+				AddRow(1, "MG", "Verma", "2000-07-13", "Verma", 1),
 			nil},
 		{"Id NotFOUND", 999, entities.Author{},
 			sqlmock.NewRows([]string{"id", "first_name", "last_name", "dob",
-				"pen_name"}), errors.EntityNotFound{Entity: "Author"}},
+				"pen_name", "version"}), errors.EntityNotFound{Entity: "Author"}},
 	}
 	for i, v := range testcases {
 		db, mock := NewMock()
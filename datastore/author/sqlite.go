@@ -0,0 +1,117 @@
+package author
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Author
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetAuthor function is to perform DB Queries to get the list of authors
+func (a SQLiteStorer) GetAuthor(ctx context.Context) ([]entities.Author, error) {
+	rows, err := datastore.Conn(ctx, a.db).QueryContext(ctx, datastore.GetAuthor)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	authors := make([]entities.Author, 0)
+
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		authors = append(authors, author)
+	}
+
+	return authors, nil
+}
+
+// GetAuthorByID function is to perform DB Queries to get an author instance using its ID
+func (a SQLiteStorer) GetAuthorByID(ctx context.Context, id int) (entities.Author, error) {
+	author, err := scanAuthor(datastore.Conn(ctx, a.db).QueryRowContext(ctx, datastore.GetByIDAuthor, id))
+	if err == sql.ErrNoRows {
+		return entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: id}
+	}
+
+	if err != nil {
+		return entities.Author{}, errors.DB{Err: err}
+	}
+
+	return author, nil
+}
+
+// GetAuthorsByIDs function is to perform a single DB Query to get all the authors having any of the ids,
+// ids which do not exist are skipped
+func (a SQLiteStorer) GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error) {
+	authors, err := getAuthorsByIDs(ctx, datastore.Conn(ctx, a.db), ids)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return authors, nil
+}
+
+// CreateAuthor function is to perform DB execution to add a new author instance in database
+func (a SQLiteStorer) CreateAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
+	err := datastore.Conn(ctx, a.db).QueryRowContext(ctx, datastore.InsertAuthorSQLite,
+		author.FirstName, author.LastName, author.Dob, author.PenName).Scan(&author.ID, &author.Version)
+	if err != nil {
+		return entities.Author{}, errors.DB{Err: err}
+	}
+
+	return author, nil
+}
+
+// PutAuthor function is to perform required DB Queries to edit an author instance in database,
+// the author is returned with its new version.
+func (a SQLiteStorer) PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error) {
+	version, err := datastore.SQLite.Update(ctx, datastore.Conn(ctx, a.db), "Author", "Authors", id,
+		datastore.UpdateAuthorSQLite, author.FirstName, author.LastName, author.Dob, author.PenName, id, author.Version)
+	if err != nil {
+		return entities.Author{}, err
+	}
+
+	author.ID = id
+	author.Version = version
+
+	return author, nil
+}
+
+// UpdateAuthorFields function is to perform DB Query to update only the given fields of an author instance in database
+func (a SQLiteStorer) UpdateAuthorFields(ctx context.Context, id int, author entities.Author,
+	fields []string) (int, error) {
+	query, args, err := datastore.UpdateAuthorFieldsQuery(datastore.SQLite, id, author, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	return datastore.SQLite.Update(ctx, datastore.Conn(ctx, a.db), "Author", "Authors", id, query, args...)
+}
+
+// DeleteAuthor function is to perform required DB Queries to remove an author instance from database.
+func (a SQLiteStorer) DeleteAuthor(ctx context.Context, id int) error {
+	res, err := datastore.Conn(ctx, a.db).ExecContext(ctx, datastore.DeleteAuthor, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Author", ID: id}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package author
+
+import (
+	"ThreeLayer/driver"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func newSQLite(t *testing.T) *sql.DB {
+	db, err := driver.ConnectToSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sqlite database", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestSQLiteStorer_CreateAuthor(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	testcases := []struct {
+		desc    string
+		reqBody entities.Author
+		expRes  entities.Author
+	}{
+		{"first author", entities.Author{FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13),
+			PenName: "Verma"},
+			entities.Author{ID: 1, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13), PenName: "Verma", Version: 1}},
+		{"second author", entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+			PenName: "HC"},
+			entities.Author{ID: 2, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "HC", Version: 1}},
+	}
+
+	for i, v := range testcases {
+		resp, err := a.CreateAuthor(context.Background(), v.reqBody)
+		if err != nil {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected nil\n", i+1, err)
+		}
+
+		if resp != v.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestSQLiteStorer_GetAuthor(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	resp, err := a.GetAuthor(context.Background())
+	if err != nil || len(resp) != 0 {
+		t.Errorf("[TEST1]Failed. Got %v, %v\tExpected no authors\n", resp, err)
+	}
+
+	author, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG", LastName: "Verma",
+		Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"})
+
+	resp, err = a.GetAuthor(context.Background())
+	if err != nil || !reflect.DeepEqual(resp, []entities.Author{author}) {
+		t.Errorf("[TEST2]Failed. Got %v, %v\tExpected %v\n", resp, err, []entities.Author{author})
+	}
+}
+
+func TestSQLiteStorer_GetAuthorByID(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	author, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG", LastName: "Verma",
+		Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expRes entities.Author
+		expErr error
+	}{
+		{"get author", author.ID, author, nil},
+		{"Id NotFOUND", 999, entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: 999}},
+	}
+
+	for i, v := range testcases {
+		resp, err := a.GetAuthorByID(context.Background(), v.reqID)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if resp != v.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestSQLiteStorer_PutAuthor(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	author, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG", LastName: "Verma",
+		Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"})
+
+	testcases := []struct {
+		desc    string
+		reqID   int
+		reqData entities.Author
+		expData entities.Author
+		expErr  error
+	}{
+		{"Valid case update firstname.", author.ID,
+			entities.Author{FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22), PenName: "ABC",
+				Version: author.Version},
+			entities.Author{ID: author.ID, FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22),
+				PenName: "ABC", Version: author.Version + 1},
+			nil},
+		{"Modified since read", author.ID, entities.Author{FirstName: "Rahul", Version: author.Version},
+			entities.Author{}, errors.PreconditionFailed{Entity: "Author", ID: author.ID}},
+		{"Id NotFOUND", 999, entities.Author{FirstName: "Rahul"}, entities.Author{},
+			errors.EntityNotFound{Entity: "Author", ID: 999}},
+	}
+
+	for i, v := range testcases {
+		res, err := a.PutAuthor(context.Background(), v.reqID, v.reqData)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if res != v.expData {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, v.expData)
+		}
+	}
+}
+
+func TestSQLiteStorer_DeleteAuthor(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	author, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG", LastName: "Verma",
+		Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expErr error
+	}{
+		{desc: "Success Case", reqID: author.ID},
+		{desc: "already deleted", reqID: author.ID, expErr: errors.EntityNotFound{Entity: "Author", ID: author.ID}},
+	}
+
+	for i, v := range testcases {
+		err := a.DeleteAuthor(context.Background(), v.reqID)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"strconv"
 )
 
 type Storer struct {
@@ -17,9 +18,19 @@ func New(db *sql.DB) Storer {
 	return Storer{db: db}
 }
 
+// scanBook reads a book selected with the columns of datastore.GetBook, it is used by all the book stores
+func scanBook(row datastore.Scanner) (entities.Book, error) {
+	var book entities.Book
+
+	err := row.Scan(&book.ID, &book.Title, &book.Publisher.ID, &book.PublishedDate, &book.Author.ID, &book.ISBN,
+		&book.Version)
+
+	return book, err
+}
+
 // GetALLBook function is to perform DB Queries to get one or multiple book instances from database
 func (a Storer) GetAllBook(ctx context.Context) ([]entities.Book, error) {
-	rows, err := a.db.QueryContext(ctx, datastore.GetBook)
+	rows, err := datastore.Conn(ctx, a.db).QueryContext(ctx, datastore.GetBook)
 	if err != nil {
 		return nil, err
 	}
@@ -34,9 +45,7 @@ func (a Storer) GetAllBook(ctx context.Context) ([]entities.Book, error) {
 	books := make([]entities.Book, 0)
 
 	for rows.Next() {
-		var book entities.Book
-
-		err = rows.Scan(&book.ID, &book.Title, &book.Publication, &book.PublishedDate, &book.Author.ID)
+		book, err := scanBook(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -47,13 +56,47 @@ func (a Storer) GetAllBook(ctx context.Context) ([]entities.Book, error) {
 	return books, nil
 }
 
+// GetBooks function is to perform DB Queries to get a page of the book instances matching the filter from database
+func (a Storer) GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	return getBooks(ctx, datastore.Conn(ctx, a.db), filter)
+}
+
+// getBooks runs the list and count queries of the filter
+func getBooks(ctx context.Context, db datastore.DBTX, filter entities.BookFilter) (entities.BookPage, error) {
+	list, count, args := datastore.BookListQuery(filter)
+
+	var page entities.BookPage
+
+	err := db.QueryRowContext(ctx, count, args[:len(args)-2]...).Scan(&page.Total)
+	if err != nil {
+		return entities.BookPage{}, err
+	}
+
+	rows, err := db.QueryContext(ctx, list, args...)
+	if err != nil {
+		return entities.BookPage{}, err
+	}
+
+	defer rows.Close()
+
+	page.Books = make([]entities.Book, 0)
+
+	for rows.Next() {
+		book, err := scanBook(rows)
+		if err != nil {
+			return entities.BookPage{}, err
+		}
+
+		page.Books = append(page.Books, book)
+	}
+
+	return page, rows.Err()
+}
+
 // GetBookByID function is to perform DB Queries to get a particular book instance using its ID number from database
 func (a Storer) GetBookByID(ctx context.Context, id int) (entities.Book, error) {
 
-	var book entities.Book
-
-	err := a.db.QueryRowContext(ctx, datastore.GetByIDBook, id).Scan(&book.ID, &book.Title, &book.Publication, &book.PublishedDate,
-		&book.Author.ID)
+	book, err := scanBook(datastore.Conn(ctx, a.db).QueryRowContext(ctx, datastore.GetByIDBook, id))
 	if err != nil {
 		return entities.Book{}, errors.EntityNotFound{Entity: "Book"}
 	}
@@ -61,40 +104,534 @@ func (a Storer) GetBookByID(ctx context.Context, id int) (entities.Book, error)
 	return book, err
 }
 
+// GetBookByISBN function is to perform DB Queries to get a particular book instance using its ISBN from database
+func (a Storer) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	return getBookByISBN(ctx, datastore.Conn(ctx, a.db), isbn)
+}
+
+// getBookByISBN reads the book with the isbn, it is used by all the book stores
+func getBookByISBN(ctx context.Context, db datastore.DBTX, isbn string) (entities.Book, error) {
+	book, err := scanBook(db.QueryRowContext(ctx, datastore.GetByISBNBook, isbn))
+	if err == sql.ErrNoRows {
+		return entities.Book{}, errors.EntityNotFound{Entity: "Book"}
+	}
+
+	if err != nil {
+		return entities.Book{}, errors.DB{Err: err}
+	}
+
+	return book, nil
+}
+
+// isbnTaken returns ExistAlready when the unique index of the isbns rejected the book and err otherwise, it is used
+// by all the book stores
+func isbnTaken(err error) error {
+	if datastore.Duplicate(err) {
+		return errors.ExistAlready{Entity: "Book", Field: "isbn"}
+	}
+
+	return err
+}
+
+// GetDuplicates function is to perform DB Queries to get the books having the same details as a book for the keys
+func (a Storer) GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error) {
+	return getDuplicates(ctx, datastore.Conn(ctx, a.db), book, keys)
+}
+
+// getDuplicates reads the books having the same details as the book for the keys with the index of the title keys,
+// it is used by all the book stores
+func getDuplicates(ctx context.Context, db datastore.DBTX, book entities.Book, keys []string) ([]entities.Book, error) {
+	books := make([]entities.Book, 0)
+
+	query, args := datastore.DuplicatesQuery(book, keys)
+
+	err := readRows(ctx, db, query, func(rows *sql.Rows) error {
+		b, err := scanBook(rows)
+		books = append(books, b)
+
+		return err
+	}, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return books, nil
+}
+
+// SearchBooks function is to perform DB Queries to get a page of the book instances matching the search from database,
+// the books are ranked with the FULLTEXT indexes
+func (a Storer) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	list, count, args := datastore.SearchBooksQuery(query)
+
+	db := datastore.Conn(ctx, a.db)
+
+	var page entities.SearchPage
+
+	err := db.QueryRowContext(ctx, count, args[:len(args)-2]...).Scan(&page.Total)
+	if err != nil {
+		return entities.SearchPage{}, errors.DB{Err: err}
+	}
+
+	rows, err := db.QueryContext(ctx, list, args...)
+	if err != nil {
+		return entities.SearchPage{}, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	page.Results = make([]entities.SearchResult, 0)
+
+	for rows.Next() {
+		var r entities.SearchResult
+
+		err = rows.Scan(&r.Book.ID, &r.Book.Title, &r.Book.Publisher.ID, &r.Book.PublishedDate, &r.Book.Author.ID,
+			&r.Book.ISBN, &r.Book.Version, &r.Score)
+		if err != nil {
+			return entities.SearchPage{}, errors.DB{Err: err}
+		}
+
+		page.Results = append(page.Results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return entities.SearchPage{}, errors.DB{Err: err}
+	}
+
+	return page, nil
+}
+
 // CreateBook function is to perform DB Executions to add new book instance in the database
 func (a Storer) CreateBook(ctx context.Context, book entities.Book) (entities.Book, error) {
 
-	res, err := a.db.ExecContext(ctx, datastore.InsertBook, book.Title, book.Publication, book.PublishedDate, book.Author.ID)
+	db := datastore.Conn(ctx, a.db)
+
+	res, err := db.ExecContext(ctx, datastore.InsertBook, book.Title, book.Publisher.ID, book.PublishedDate, book.Author.ID,
+		book.ISBN, entities.TitleKey(book.Title))
 	if err != nil {
-		return entities.Book{}, err
+		return entities.Book{}, isbnTaken(err)
 	}
 
 	id, _ := res.LastInsertId()
 
 	book.ID = int(id)
+	book.Version = 1
+
+	if err = addContributors(ctx, db, book.ID, book.Credits()); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = addGenres(ctx, db, book.ID, book.Genres); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = addSeries(ctx, db, book.ID, book.Series); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = addEdition(ctx, db, book.ID, book.Edition); err != nil {
+		return entities.Book{}, err
+	}
 
 	return book, nil
 }
 
-// Updatebook function is to perform required DB Queries to make changes to a book instance in database
+// Updatebook function is to perform required DB Queries to make changes to a book instance in database,
+// the book is returned with its new version
 func (a Storer) UpdateBook(ctx context.Context, id int, book entities.Book) (entities.Book, error) {
-	_, err := a.db.ExecContext(ctx, datastore.UpdateBook, book.Title, book.Publication, book.PublishedDate, book.Author.ID, id)
+	db := datastore.Conn(ctx, a.db)
+
+	version, err := datastore.MySQL.Update(ctx, db, "Book", "Books", id, datastore.UpdateBook, book.Title,
+		book.Publisher.ID, book.PublishedDate, book.Author.ID, book.ISBN, entities.TitleKey(book.Title), id,
+		book.Version)
 	if err != nil {
+		return entities.Book{}, isbnTaken(err)
+	}
+
+	if err = replaceContributors(ctx, db, id, book.Credits()); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = replaceGenres(ctx, db, id, book.Genres); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = replaceSeries(ctx, db, id, book.Series); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = replaceEdition(ctx, db, id, book.Edition); err != nil {
 		return entities.Book{}, err
 	}
 
 	book.ID = id
+	book.Version = version
 
 	return book, nil
 }
 
+// UpdateBookFields function is to perform DB Query to update only the given fields of a book instance in database
+func (a Storer) UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+	query, args, err := datastore.UpdateBookFieldsQuery(datastore.MySQL, id, book, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	db := datastore.Conn(ctx, a.db)
+
+	version, err := datastore.MySQL.Update(ctx, db, "Book", "Books", id, query, args...)
+	if err != nil {
+		return 0, isbnTaken(err)
+	}
+
+	return version, replaceFields(ctx, db, id, book, fields)
+}
+
 // DeleteBook function is to perform DB Queries to get a particular book instance using its ID number from database
 func (a Storer) DeleteBook(ctx context.Context, id int) error {
-	res, err := a.db.ExecContext(ctx, datastore.DeleteBook, id)
+	res, err := datastore.Conn(ctx, a.db).ExecContext(ctx, datastore.DeleteBook, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
 	r, _ := res.RowsAffected()
-	if r == 0 || err != nil {
+	if r == 0 {
 		return errors.EntityNotFound{Entity: "Book", ID: id}
 	}
 
 	return nil
 }
+
+// GetContributors function is to perform DB Queries to get the contributors of the books in the order of the credits
+func (a Storer) GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error) {
+	return getContributors(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// getContributors reads the contributors of the books, it is used by all the book stores
+func getContributors(ctx context.Context, db datastore.DBTX, bookIDs []int) (map[int][]entities.Contributor, error) {
+	contributors := make(map[int][]entities.Contributor)
+	if len(bookIDs) == 0 {
+		return contributors, nil
+	}
+
+	query, args := datastore.ContributorsQuery(bookIDs)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			bookID int
+			c      entities.Contributor
+		)
+
+		if err = rows.Scan(&bookID, &c.Author.ID, &c.Role); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		contributors[bookID] = append(contributors[bookID], c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return contributors, nil
+}
+
+// GetBookGenres function is to perform DB Queries to get the genres the books are tagged with
+func (a Storer) GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error) {
+	return getBookGenres(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// getBookGenres reads the genres of the books, it is used by all the book stores
+func getBookGenres(ctx context.Context, db datastore.DBTX, bookIDs []int) (map[int][]entities.Genre, error) {
+	genres := make(map[int][]entities.Genre)
+	if len(bookIDs) == 0 {
+		return genres, nil
+	}
+
+	query, args := datastore.BookGenresQuery(bookIDs)
+
+	err := readRows(ctx, db, query, func(rows *sql.Rows) error {
+		var (
+			bookID int
+			g      entities.Genre
+		)
+
+		err := rows.Scan(&bookID, &g.ID, &g.Name, &g.Kind, &g.ParentID, &g.Version)
+		genres[bookID] = append(genres[bookID], g)
+
+		return err
+	}, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return genres, nil
+}
+
+// GetBookFacets function is to perform DB Queries to count the book instances matching the filter by genre,
+// publisher and decade of publication
+func (a Storer) GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error) {
+	return getBookFacets(ctx, datastore.Conn(ctx, a.db), filter)
+}
+
+// getBookFacets runs the facet queries of the filter, it is used by all the book stores
+func getBookFacets(ctx context.Context, db datastore.DBTX, filter entities.BookFilter) (entities.BookFacets, error) {
+	genres, publishers, decades, args := datastore.BookFacetsQueries(filter)
+
+	facets := entities.BookFacets{Genres: make([]entities.Facet, 0), Publishers: make([]entities.Facet, 0),
+		Decades: make([]entities.DecadeFacet, 0)}
+
+	counts := []struct {
+		query  string
+		facets *[]entities.Facet
+	}{{genres, &facets.Genres}, {publishers, &facets.Publishers}}
+
+	for _, c := range counts {
+		err := readRows(ctx, db, c.query, func(rows *sql.Rows) error {
+			var f entities.Facet
+
+			err := rows.Scan(&f.ID, &f.Count)
+			*c.facets = append(*c.facets, f)
+
+			return err
+		}, args...)
+		if err != nil {
+			return entities.BookFacets{}, errors.DB{Err: err}
+		}
+	}
+
+	// the decades are counted by the first three digits of the year
+	err := readRows(ctx, db, decades, func(rows *sql.Rows) error {
+		var (
+			prefix string
+			f      entities.DecadeFacet
+		)
+
+		if err := rows.Scan(&prefix, &f.Count); err != nil {
+			return err
+		}
+
+		decade, err := strconv.Atoi(prefix)
+		f.Decade = decade * 10
+		facets.Decades = append(facets.Decades, f)
+
+		return err
+	}, args...)
+	if err != nil {
+		return entities.BookFacets{}, errors.DB{Err: err}
+	}
+
+	return facets, nil
+}
+
+// GetBookSeries function is to perform DB Queries to get the series of the books which are volumes of one
+func (a Storer) GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	return getBookSeries(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// getBookSeries reads the series of the books, it is used by all the book stores
+func getBookSeries(ctx context.Context, db datastore.DBTX, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	series := make(map[int]entities.SeriesVolume)
+	if len(bookIDs) == 0 {
+		return series, nil
+	}
+
+	query, args := datastore.BookSeriesQuery(bookIDs)
+
+	err := readRows(ctx, db, query, func(rows *sql.Rows) error {
+		var (
+			bookID int
+			s      entities.SeriesVolume
+		)
+
+		err := rows.Scan(&bookID, &s.ID, &s.Name, &s.Volume)
+		series[bookID] = s
+
+		return err
+	}, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return series, nil
+}
+
+// GetEditions function is to perform DB Queries to get the edition links of the books
+func (a Storer) GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error) {
+	return getEditions(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// getEditions reads the edition links of the books, it is used by all the book stores
+func getEditions(ctx context.Context, db datastore.DBTX, bookIDs []int) (map[int]entities.Edition, error) {
+	editions := make(map[int]entities.Edition)
+	if len(bookIDs) == 0 {
+		return editions, nil
+	}
+
+	query, args := datastore.EditionsQuery(bookIDs)
+
+	err := readRows(ctx, db, query, func(rows *sql.Rows) error {
+		var (
+			bookID int
+			e      entities.Edition
+		)
+
+		err := rows.Scan(&bookID, &e.WorkID, &e.Kind, &e.Language)
+		editions[bookID] = e
+
+		return err
+	}, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return editions, nil
+}
+
+// readRows runs the query with the args and calls scan for every row
+func readRows(ctx context.Context, db datastore.DBTX, query string, scan func(rows *sql.Rows) error,
+	args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// addContributors credits the contributors on the book with given id in their order
+func addContributors(ctx context.Context, db datastore.DBTX, id int, contributors []entities.Contributor) error {
+	for i, c := range contributors {
+		if _, err := db.ExecContext(ctx, datastore.InsertContributor, id, c.Author.ID, c.Role, i+1); err != nil {
+			return errors.DB{Err: err}
+		}
+	}
+
+	return nil
+}
+
+// replaceContributors removes the contributors of the book with given id and credits the given ones instead
+func replaceContributors(ctx context.Context, db datastore.DBTX, id int, contributors []entities.Contributor) error {
+	if _, err := db.ExecContext(ctx, datastore.DeleteContributors, id); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return addContributors(ctx, db, id, contributors)
+}
+
+// addGenres tags the book with given id with the genres
+func addGenres(ctx context.Context, db datastore.DBTX, id int, genres []entities.Genre) error {
+	for _, g := range genres {
+		if _, err := db.ExecContext(ctx, datastore.InsertBookGenre, id, g.ID); err != nil {
+			return errors.DB{Err: err}
+		}
+	}
+
+	return nil
+}
+
+// replaceGenres removes the genres of the book with given id and tags it with the given ones instead
+func replaceGenres(ctx context.Context, db datastore.DBTX, id int, genres []entities.Genre) error {
+	if _, err := db.ExecContext(ctx, datastore.DeleteBookGenres, id); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return addGenres(ctx, db, id, genres)
+}
+
+// addSeries adds the book with given id to the series as the volume, a book without a series is not added
+func addSeries(ctx context.Context, db datastore.DBTX, id int, series *entities.SeriesVolume) error {
+	if series == nil {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, datastore.InsertSeriesBook, id, series.ID, series.Volume); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// replaceSeries removes the book with given id from its series and adds it to the given one instead
+func replaceSeries(ctx context.Context, db datastore.DBTX, id int, series *entities.SeriesVolume) error {
+	if _, err := db.ExecContext(ctx, datastore.DeleteSeriesBook, id); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return addSeries(ctx, db, id, series)
+}
+
+// addEdition links the book with given id to the original of its work, an original is not linked
+func addEdition(ctx context.Context, db datastore.DBTX, id int, edition *entities.Edition) error {
+	if edition == nil {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, datastore.InsertEdition, id, edition.WorkID, edition.Kind, edition.Language)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// replaceEdition removes the edition link of the book with given id and links it as given instead
+func replaceEdition(ctx context.Context, db datastore.DBTX, id int, edition *entities.Edition) error {
+	if _, err := db.ExecContext(ctx, datastore.DeleteEdition, id); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return addEdition(ctx, db, id, edition)
+}
+
+// replaceFields replaces the contributors, the genres, the series and the edition of the book with given id when they
+// are among the fields, they are not columns of Books and so are not set by the update query
+func replaceFields(ctx context.Context, db datastore.DBTX, id int, book entities.Book, fields []string) error {
+	if hasField(fields, entities.BookContributors) {
+		if err := replaceContributors(ctx, db, id, book.Credits()); err != nil {
+			return err
+		}
+	}
+
+	if hasField(fields, entities.BookGenres) {
+		if err := replaceGenres(ctx, db, id, book.Genres); err != nil {
+			return err
+		}
+	}
+
+	if hasField(fields, entities.BookSeries) {
+		if err := replaceSeries(ctx, db, id, book.Series); err != nil {
+			return err
+		}
+	}
+
+	if hasField(fields, entities.BookEdition) {
+		return replaceEdition(ctx, db, id, book.Edition)
+	}
+
+	return nil
+}
+
+func hasField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -6,10 +6,13 @@ import (
 	"ThreeLayer/errors"
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"log"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -32,11 +35,11 @@ func TestStorer_GetAllBook(t *testing.T) {
 	}{
 		{
 			desc: "get all books",
-			expRows: sqlmock.NewRows([]string{"id", "title", "publication", "publication_date",
-				"author_id"}).AddRow(1, "Rahul", "Penguin", "22/07/2000", 1),
-			expRes: []entities.Book{{ID: 1, Title: "Rahul", Publication: "Penguin",
-				PublishedDate: "22/07/2000",
-				Author:        entities.Author{ID: 1}}},
+			expRows: sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date",
+				"author_id", "isbn", "version"}).AddRow(1, "Rahul", 3, "2000-07-22", 1, "", 1),
+			expRes: []entities.Book{{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3},
+				PublishedDate: entities.NewDate(2000, 7, 22),
+				Author:        entities.Author{ID: 1}, Version: 1}},
 		},
 	}
 	for i, v := range testcases {
@@ -56,6 +59,93 @@ func TestStorer_GetAllBook(t *testing.T) {
 	}
 }
 
+// TestStorer_GetBooks contains test cases for function to perform DB Queries to get a page of the books matching a filter
+func TestStorer_GetBooks(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		filter    entities.BookFilter
+		expList   string
+		expCount  string
+		expArgs   []driver.Value
+		expRows   *sqlmock.Rows
+		expResult entities.BookPage
+	}{
+		{
+			desc:     "no filter",
+			filter:   entities.BookFilter{Limit: 20},
+			expList:  "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books order by id asc limit ? offset ?;",
+			expCount: "select count(*) from Books;",
+			expArgs:  []driver.Value{20, 0},
+			expRows: sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version"}).
+				AddRow(1, "Rahul", 3, "2000-07-22", 1, "", 1),
+			expResult: entities.BookPage{Books: []entities.Book{{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3},
+				PublishedDate: entities.NewDate(2000, 7, 22), Author: entities.Author{ID: 1}, Version: 1}}, Total: 1},
+		},
+		{
+			desc: "filter and sort",
+			filter: entities.BookFilter{PublisherID: 3, AuthorID: 2, PublishedFrom: "2000-01-01",
+				Sort: entities.SortByPublishedDate, Desc: true, Limit: 5, Offset: 10},
+			expList: "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books where publisher_id = ? and " +
+				"id in (select book_id from BookAuthors where author_id = ?) and publication_date >= ? order by publication_date" +
+				" desc, id desc limit ? offset ?;",
+			expCount: "select count(*) from Books where publisher_id = ? and id in (select book_id from BookAuthors where author_id = ?) and " +
+				"publication_date >= ?;",
+			expArgs:   []driver.Value{3, 2, "2000-01-01", 5, 10},
+			expRows:   sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version"}),
+			expResult: entities.BookPage{Books: []entities.Book{}, Total: 1},
+		},
+		{
+			desc:   "genre and its descendants",
+			filter: entities.BookFilter{GenreID: 1, GenreIDs: []int{1, 4}, Limit: 5},
+			expList: "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books where " +
+				"id in (select book_id from BookGenres where genre_id in (?,?)) order by id asc limit ? offset ?;",
+			expCount:  "select count(*) from Books where id in (select book_id from BookGenres where genre_id in (?,?));",
+			expArgs:   []driver.Value{1, 4, 5, 0},
+			expRows:   sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version"}),
+			expResult: entities.BookPage{Books: []entities.Book{}, Total: 0},
+		},
+		{
+			desc:   "volumes of a series",
+			filter: entities.BookFilter{SeriesID: 2, Sort: entities.SortByVolume, Limit: 5},
+			expList: "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books where " +
+				"id in (select book_id from SeriesBooks where series_id = ?) order by (select volume from SeriesBooks" +
+				" where book_id = Books.id) asc, id asc limit ? offset ?;",
+			expCount:  "select count(*) from Books where id in (select book_id from SeriesBooks where series_id = ?);",
+			expArgs:   []driver.Value{2, 5, 0},
+			expRows:   sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version"}),
+			expResult: entities.BookPage{Books: []entities.Book{}, Total: 0},
+		},
+		{
+			desc:   "editions of a work",
+			filter: entities.BookFilter{WorkID: 3, Limit: 5},
+			expList: "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books where " +
+				"(id = ? or id in (select book_id from Editions where work_id = ?)) order by id asc limit ? offset ?;",
+			expCount:  "select count(*) from Books where (id = ? or id in (select book_id from Editions where work_id = ?));",
+			expArgs:   []driver.Value{3, 3, 5, 0},
+			expRows:   sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version"}),
+			expResult: entities.BookPage{Books: []entities.Book{}, Total: 0},
+		},
+	}
+	for i, v := range testcases {
+		db, mock := NewMock()
+		a := New(db)
+
+		mock.ExpectQuery(v.expCount).WithArgs(v.expArgs[:len(v.expArgs)-2]...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(v.expResult.Total))
+		mock.ExpectQuery(v.expList).WithArgs(v.expArgs...).WillReturnRows(v.expRows)
+
+		resp, err := a.GetBooks(context.Background(), v.filter)
+
+		if err != nil {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected nil\n", i+1, err)
+		}
+
+		if !reflect.DeepEqual(resp, v.expResult) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expResult)
+		}
+	}
+}
+
 // testGetByBookID contains test cases for function to perform DB Executions to get a book instance using its ID
 // from the database
 func TestStorer_GetBookByID(t *testing.T) {
@@ -67,12 +157,12 @@ func TestStorer_GetBookByID(t *testing.T) {
 		expErr error
 	}{
 		{desc: "get book", reqID: 1, expRes: entities.Book{ID: 1, Title: "Rahul",
-			Publication: "Penguin", PublishedDate: "22/07/2000", Author: entities.Author{ID: 1}},
-			expRow: sqlmock.NewRows([]string{"id", "title", "publication", "publication_date",
-				"author_id"}).AddRow(1, "Rahul", "Penguin", "22/07/2000", 1)},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22), Author: entities.Author{ID: 1}, Version: 1},
+			expRow: sqlmock.NewRows([]string{"id", "title", "publisher_id", "publication_date",
+				"author_id", "isbn", "version"}).AddRow(1, "Rahul", 3, "2000-07-22", 1, "", 1)},
 		{desc: "Id doesn't exist", reqID: 1000, expRow: sqlmock.NewRows([]string{"id", "title",
-			"publication", "publication_date",
-			"author_id"}), expErr: errors.EntityNotFound{Entity: "Book"}},
+			"publisher_id", "publication_date",
+			"author_id", "isbn", "version"}), expErr: errors.EntityNotFound{Entity: "Book"}},
 	}
 	for i, v := range testcases {
 		db, mock := NewMock()
@@ -86,7 +176,98 @@ func TestStorer_GetBookByID(t *testing.T) {
 			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
 		}
 
-		if resp != v.expRes {
+		if !reflect.DeepEqual(resp, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+// TestStorer_GetBookByISBN contains test cases for function to get a book instance using its ISBN from the database
+func TestStorer_GetBookByISBN(t *testing.T) {
+	columns := []string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version"}
+
+	testcases := []struct {
+		desc   string
+		expRow *sqlmock.Rows
+		dbErr  error
+		expRes entities.Book
+		expErr error
+	}{
+		{desc: "get book", expRow: sqlmock.NewRows(columns).AddRow(1, "Rahul", 3, "2000-07-22", 1, "9780306406157", 1),
+			expRes: entities.Book{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22),
+				Author: entities.Author{ID: 1}, ISBN: "9780306406157", Version: 1}},
+		{desc: "isbn doesn't exist", expRow: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Book"}},
+		{desc: "db error", dbErr: fmt.Errorf("query error"), expErr: errors.DB{Err: fmt.Errorf("query error")}},
+	}
+
+	for i, v := range testcases {
+		db, mock := NewMock()
+		a := New(db)
+
+		query := mock.ExpectQuery(datastore.GetByISBNBook).WithArgs("9780306406157")
+		if v.dbErr != nil {
+			query.WillReturnError(v.dbErr)
+		} else {
+			query.WillReturnRows(v.expRow)
+		}
+
+		resp, err := a.GetBookByISBN(context.Background(), "9780306406157")
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(resp, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+// TestStorer_SearchBooks contains test cases for function to get a page of the books matching a search from the database
+func TestStorer_SearchBooks(t *testing.T) {
+	columns := []string{"id", "title", "publisher_id", "publication_date", "author_id", "isbn", "version", "score"}
+	query := entities.SearchQuery{Terms: []string{"harry", "pot"}, Limit: 5}
+	list, count, _ := datastore.SearchBooksQuery(query)
+
+	testcases := []struct {
+		desc     string
+		expRows  *sqlmock.Rows
+		countErr error
+		expRes   entities.SearchPage
+		expErr   error
+	}{
+		{desc: "ranked books", expRows: sqlmock.NewRows(columns).AddRow(1, "Harry Potter", 3, "2000-07-22", 1, "", 1, 4.5).
+			AddRow(2, "Potted Plants", 3, "2001-01-01", 2, "", 1, 0.5),
+			expRes: entities.SearchPage{Results: []entities.SearchResult{
+				{Book: entities.Book{ID: 1, Title: "Harry Potter", Publisher: entities.Publisher{ID: 3},
+					PublishedDate: entities.NewDate(2000, 7, 22), Author: entities.Author{ID: 1}, Version: 1},
+					Score: 4.5},
+				{Book: entities.Book{ID: 2, Title: "Potted Plants", Publisher: entities.Publisher{ID: 3},
+					PublishedDate: entities.NewDate(2001, 1, 1), Author: entities.Author{ID: 2}, Version: 1},
+					Score: 0.5},
+			}, Total: 2}},
+		{desc: "db error", countErr: fmt.Errorf("query error"), expErr: errors.DB{Err: fmt.Errorf("query error")}},
+	}
+
+	for i, v := range testcases {
+		db, mock := NewMock()
+		a := New(db)
+
+		countQuery := mock.ExpectQuery(count).WithArgs("harry* pot*", "harry* pot*", "harry* pot*")
+		if v.countErr != nil {
+			countQuery.WillReturnError(v.countErr)
+		} else {
+			countQuery.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(v.expRes.Total))
+			mock.ExpectQuery(list).WithArgs("harry* pot*", "harry* pot*", "harry* pot*", 5, 0).WillReturnRows(v.expRows)
+		}
+
+		resp, err := a.SearchBooks(context.Background(), query)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(resp, v.expRes) {
 			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
 		}
 	}
@@ -98,30 +279,53 @@ func TestStorer_CreateBook(t *testing.T) {
 		reqBody      entities.Book
 		expRes       entities.Book
 		lastInsertID int64
+		dbErr        error
 		expErr       error
 	}{
 		{
 			"Valid Details",
 			entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
-				Publication: "Penguin", PublishedDate: "22/07/2000"},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22),
+				ISBN: "9780306406157", Series: &entities.SeriesVolume{ID: 2, Volume: 3},
+				Edition: &entities.Edition{WorkID: 4, Kind: entities.KindTranslation, Language: "hi"}},
 			entities.Book{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1},
-				Publication: "Penguin", PublishedDate: "22/07/2000"},
-			1, nil,
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22),
+				ISBN: "9780306406157", Series: &entities.SeriesVolume{ID: 2, Volume: 3},
+				Edition: &entities.Edition{WorkID: 4, Kind: entities.KindTranslation, Language: "hi"}, Version: 1},
+			1, nil, nil,
 		},
 		{
 			"Error Case",
 			entities.Book{},
 			entities.Book{},
-			0, fmt.Errorf("query error"),
+			0, fmt.Errorf("query error"), fmt.Errorf("query error"),
+		},
+		{
+			"Repeated ISBN",
+			entities.Book{Title: "Rahul", Author: entities.Author{ID: 1}, ISBN: "9780306406157"},
+			entities.Book{},
+			0, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '9780306406157' for key 'idx_books_isbn'"},
+			errors.ExistAlready{Entity: "Book", Field: "isbn"},
 		},
 	}
 	for i, v := range testcases {
 		db, mock := NewMock()
 		a := New(db)
 		mock.ExpectExec(datastore.InsertBook).
-			WithArgs(v.reqBody.Title, v.reqBody.Publication, v.reqBody.PublishedDate, v.reqBody.Author.ID).
+			WithArgs(v.reqBody.Title, v.reqBody.Publisher.ID, v.reqBody.PublishedDate, v.reqBody.Author.ID, v.reqBody.ISBN,
+				entities.TitleKey(v.reqBody.Title)).
 			WillReturnResult(sqlmock.NewResult(v.lastInsertID, 0)).
-			WillReturnError(v.expErr)
+			WillReturnError(v.dbErr)
+
+		if v.dbErr == nil {
+			mock.ExpectExec(datastore.InsertContributor).
+				WithArgs(v.lastInsertID, v.reqBody.Author.ID, entities.RoleAuthor, 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.InsertSeriesBook).WithArgs(v.lastInsertID, 2, 3).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.InsertEdition).WithArgs(v.lastInsertID, 4, entities.KindTranslation, "hi").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
 
 		resp, err := a.CreateBook(context.Background(), v.reqBody)
 
@@ -129,7 +333,7 @@ func TestStorer_CreateBook(t *testing.T) {
 			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
 		}
 
-		if resp != v.expRes {
+		if !reflect.DeepEqual(resp, v.expRes) {
 			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
 		}
 	}
@@ -138,34 +342,65 @@ func TestStorer_CreateBook(t *testing.T) {
 // testUpdateBook contains test cases for function to perform DB Executions to make changes to
 // a book instance in the database
 func TestStorer_UpdateBook(t *testing.T) {
+	book := entities.Book{ID: 1, Title: "title", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+		PublishedDate: entities.NewDate(1999, 8, 22), Genres: []entities.Genre{{ID: 2}}, Version: 2}
+
+	updated := book
+	updated.Version = 3
+
 	testcases := []struct {
-		desc        string
-		reqID       int
-		reqBody     entities.Book
-		expBody     entities.Book
-		affectedRow int64
-		expErr      error
+		desc    string
+		reqID   int
+		reqBody entities.Book
+		expBody entities.Book
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		updated bool
+		stored  int
+		dbErr   error
+		expErr  error
 	}{
-		{
-			desc: "valid case id exist", reqID: 1,
-			reqBody: entities.Book{ID: 1, Title: "title", Author: entities.Author{ID: 1},
-				Publication: "Arihanth", PublishedDate: "22/08/1999"},
-			expBody: entities.Book{ID: 1, Title: "title", Author: entities.Author{ID: 1},
-				Publication: "Arihanth", PublishedDate: "22/08/1999"},
-			affectedRow: 1,
-		},
-		{
-			desc: "error case", reqID: 1,
-			affectedRow: 1, expErr: fmt.Errorf("query error"),
-		},
+		{desc: "valid case id exist", reqID: 1, reqBody: book, expBody: updated, updated: true},
+		{desc: "modified since read", reqID: 1, reqBody: book, stored: 3,
+			expErr: errors.PreconditionFailed{Entity: "Book", ID: 1}},
+		{desc: "id does not exist", reqID: 1, reqBody: book, expErr: errors.EntityNotFound{Entity: "Book", ID: 1}},
+		{desc: "error case", reqID: 1, reqBody: book, dbErr: fmt.Errorf("query error"),
+			expErr: errors.DB{Err: fmt.Errorf("query error")}},
 	}
 	for i, v := range testcases {
 		db, mock := NewMock()
 		a := New(db)
 
-		mock.ExpectExec(datastore.UpdateBook).
-			WithArgs(v.reqBody.Title, v.reqBody.Publication, v.reqBody.PublishedDate, v.reqBody.ID, v.reqID).
-			WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(v.expErr)
+		exec := mock.ExpectExec(datastore.UpdateBook).
+			WithArgs(v.reqBody.Title, v.reqBody.Publisher.ID, v.reqBody.PublishedDate, v.reqBody.Author.ID, v.reqBody.ISBN,
+				entities.TitleKey(v.reqBody.Title), v.reqID, v.reqBody.Version)
+
+		switch {
+		case v.dbErr != nil:
+			exec.WillReturnError(v.dbErr)
+		case v.updated:
+			// the new version is read back as the id set with LAST_INSERT_ID
+			exec.WillReturnResult(sqlmock.NewResult(3, 1))
+		default:
+			exec.WillReturnResult(sqlmock.NewResult(0, 0))
+
+			rows := sqlmock.NewRows([]string{"version"})
+			if v.stored != 0 {
+				rows.AddRow(v.stored)
+			}
+
+			mock.ExpectQuery("select version from Books where id=?").WithArgs(v.reqID).WillReturnRows(rows)
+		}
+
+		if v.updated {
+			mock.ExpectExec(datastore.DeleteContributors).WithArgs(v.reqID).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.InsertContributor).
+				WithArgs(v.reqID, v.reqBody.Author.ID, entities.RoleAuthor, 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.DeleteBookGenres).WithArgs(v.reqID).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.InsertBookGenre).WithArgs(v.reqID, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.DeleteSeriesBook).WithArgs(v.reqID).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(datastore.DeleteEdition).WithArgs(v.reqID).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
 
 		res, err := a.UpdateBook(context.Background(), testcases[i].reqID, testcases[i].reqBody)
 
@@ -173,9 +408,217 @@ func TestStorer_UpdateBook(t *testing.T) {
 			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, testcases[i].expErr)
 		}
 
-		if res != v.expBody {
+		if !reflect.DeepEqual(res, v.expBody) {
 			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, testcases[i].expBody)
 		}
+
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("[TEST%d]Failed. %v", i+1, err)
+		}
+	}
+}
+
+// TestStorer_UpdateBookFields contains test cases for function to update only some of the columns of a book
+func TestStorer_UpdateBookFields(t *testing.T) {
+	book := entities.Book{Title: "title", Author: entities.Author{ID: 2}, Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(1999, 8, 22)}
+	book.Genres = []entities.Genre{{ID: 4}}
+	book.Version = 3
+	book.Series = &entities.SeriesVolume{ID: 2, Volume: 1}
+
+	// the new version is set with LAST_INSERT_ID to be read back from the result
+	set := "version = LAST_INSERT_ID(version + 1) WHERE id = ? AND version = ?"
+
+	testcases := []struct {
+		desc     string
+		fields   []string
+		expQuery string
+		expArgs  []driver.Value
+		// contributors, genres, series and editions are replaced after the update of the book
+		contributors bool
+		genres       bool
+		links        bool
+		dbErr        error
+		expErr       error
+	}{
+		{desc: "title only", fields: []string{entities.BookTitle},
+			expQuery: "UPDATE Books SET title = ?, title_key = ?, " + set,
+			expArgs:  []driver.Value{"title", "title", 1, 3}},
+		{desc: "contributors and date", fields: []string{entities.BookContributors, entities.BookPublishedDate},
+			expQuery: "UPDATE Books SET author_id = ?, publication_date = ?, " + set,
+			expArgs:  []driver.Value{2, "1999-08-22", 1, 3}, contributors: true},
+		{desc: "isbn removed", fields: []string{entities.BookISBN},
+			expQuery: "UPDATE Books SET isbn = ?, " + set, expArgs: []driver.Value{nil, 1, 3}},
+		{desc: "genres only", fields: []string{entities.BookGenres},
+			expQuery: "UPDATE Books SET " + set, expArgs: []driver.Value{1, 3}, genres: true},
+		{desc: "series and edition", fields: []string{entities.BookSeries, entities.BookEdition},
+			expQuery: "UPDATE Books SET " + set, expArgs: []driver.Value{1, 3}, links: true},
+		{desc: "error case", fields: []string{entities.BookTitle},
+			expQuery: "UPDATE Books SET title = ?, title_key = ?, " + set,
+			expArgs:  []driver.Value{"title", "title", 1, 3},
+			dbErr:    fmt.Errorf("query error"), expErr: errors.DB{Err: fmt.Errorf("query error")}},
+	}
+
+	for i, v := range testcases {
+		db, mock := NewMock()
+		a := New(db)
+
+		mock.ExpectExec(v.expQuery).WithArgs(v.expArgs...).WillReturnResult(sqlmock.NewResult(4, 1)).WillReturnError(v.dbErr)
+
+		if v.contributors {
+			mock.ExpectExec(datastore.DeleteContributors).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.InsertContributor).WithArgs(1, 2, entities.RoleAuthor, 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		if v.genres {
+			mock.ExpectExec(datastore.DeleteBookGenres).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.InsertBookGenre).WithArgs(1, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		// the book is a volume of a series and the original of its work
+		if v.links {
+			mock.ExpectExec(datastore.DeleteSeriesBook).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(datastore.InsertSeriesBook).WithArgs(1, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(datastore.DeleteEdition).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		version, err := a.UpdateBookFields(context.Background(), 1, book, v.fields)
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if err == nil && version != 4 {
+			t.Errorf("[TEST%d]Failed. Got version %v\tExpected 4\n", i+1, version)
+		}
+
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("[TEST%d]Failed. %v", i+1, err)
+		}
+	}
+}
+
+// TestStorer_GetBookGenres contains test cases for function to get the genres the books are tagged with
+func TestStorer_GetBookGenres(t *testing.T) {
+	query := "select bg.book_id,g.id,g.name,g.kind,COALESCE(g.parent_id, 0),g.version from BookGenres bg join Genres g" +
+		" on g.id = bg.genre_id where bg.book_id in (?,?) order by bg.book_id,g.id;"
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes map[int][]entities.Genre
+		expErr error
+	}{
+		{desc: "tagged", rows: sqlmock.NewRows([]string{"book_id", "id", "name", "kind", "parent_id", "version"}).
+			AddRow(1, 2, "Mystery", entities.KindGenre, 1, 1).AddRow(1, 3, "History", entities.KindSubject, 0, 2),
+			expRes: map[int][]entities.Genre{1: {{ID: 2, Name: "Mystery", Kind: entities.KindGenre, ParentID: 1, Version: 1},
+				{ID: 3, Name: "History", Kind: entities.KindSubject, Version: 2}}}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, v := range testcases {
+		db, mock := NewMock()
+
+		exp := mock.ExpectQuery(query).WithArgs(1, 2)
+		if v.dbErr != nil {
+			exp.WillReturnError(v.dbErr)
+		} else {
+			exp.WillReturnRows(v.rows)
+		}
+
+		res, err := New(db).GetBookGenres(context.Background(), []int{1, 2})
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(res, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, v.expRes)
+		}
+	}
+}
+
+// TestStorer_GetBookSeries contains test cases for function to get the series of the books
+func TestStorer_GetBookSeries(t *testing.T) {
+	query := "select sb.book_id,s.id,s.name,sb.volume from SeriesBooks sb join Series s on s.id = sb.series_id" +
+		" where sb.book_id in (?,?);"
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes map[int]entities.SeriesVolume
+		expErr error
+	}{
+		{desc: "volume of a series", rows: sqlmock.NewRows([]string{"book_id", "id", "name", "volume"}).
+			AddRow(2, 1, "Discworld", 4), expRes: map[int]entities.SeriesVolume{2: {ID: 1, Name: "Discworld", Volume: 4}}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, v := range testcases {
+		db, mock := NewMock()
+
+		exp := mock.ExpectQuery(query).WithArgs(1, 2)
+		if v.dbErr != nil {
+			exp.WillReturnError(v.dbErr)
+		} else {
+			exp.WillReturnRows(v.rows)
+		}
+
+		res, err := New(db).GetBookSeries(context.Background(), []int{1, 2})
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(res, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, v.expRes)
+		}
+	}
+}
+
+// TestStorer_GetEditions contains test cases for function to get the edition links of the books
+func TestStorer_GetEditions(t *testing.T) {
+	db, mock := NewMock()
+
+	mock.ExpectQuery("select book_id,work_id,kind,language from Editions where book_id in (?,?);").WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "work_id", "kind", "language"}).
+			AddRow(2, 1, entities.KindTranslation, "fr"))
+
+	res, err := New(db).GetEditions(context.Background(), []int{1, 2})
+
+	exp := map[int]entities.Edition{2: {WorkID: 1, Kind: entities.KindTranslation, Language: "fr"}}
+	if err != nil || !reflect.DeepEqual(res, exp) {
+		t.Errorf("Failed. Got %v, %v\tExpected %v\n", res, err, exp)
+	}
+}
+
+// TestStorer_GetBookFacets contains test cases for function to count the books matching a filter by genre,
+// publisher and decade
+func TestStorer_GetBookFacets(t *testing.T) {
+	db, mock := NewMock()
+
+	genres, publishers, decades, _ := datastore.BookFacetsQueries(entities.BookFilter{PublisherID: 3})
+
+	mock.ExpectQuery(genres).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"ancestor_id", "count"}).AddRow(1, 2).AddRow(2, 1))
+	mock.ExpectQuery(publishers).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"publisher_id", "count"}).AddRow(3, 2))
+	mock.ExpectQuery(decades).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"decade", "count"}).AddRow("198", 1).AddRow("200", 1))
+
+	res, err := New(db).GetBookFacets(context.Background(), entities.BookFilter{PublisherID: 3})
+
+	exp := entities.BookFacets{Genres: []entities.Facet{{ID: 1, Count: 2}, {ID: 2, Count: 1}},
+		Publishers: []entities.Facet{{ID: 3, Count: 2}},
+		Decades:    []entities.DecadeFacet{{Decade: 1980, Count: 1}, {Decade: 2000, Count: 1}}}
+
+	if err != nil || !reflect.DeepEqual(res, exp) {
+		t.Errorf("Failed. Got %v, %v\tExpected %v\n", res, err, exp)
+	}
+
+	if !strings.HasSuffix(decades, " where publisher_id = ? and publication_date is not null"+
+		" group by substr(publication_date, 1, 3);") {
+		t.Errorf("Failed. Expected the books without a publication date to be left out Got %v", decades)
 	}
 }
 
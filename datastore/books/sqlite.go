@@ -0,0 +1,289 @@
+package book
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Book
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetAllBook function is to perform DB Queries to get all the book instances from database
+func (a SQLiteStorer) GetAllBook(ctx context.Context) ([]entities.Book, error) {
+	rows, err := datastore.Conn(ctx, a.db).QueryContext(ctx, datastore.GetBook)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	books := make([]entities.Book, 0)
+
+	for rows.Next() {
+		book, err := scanBook(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		books = append(books, book)
+	}
+
+	return books, nil
+}
+
+// GetBooks function is to perform DB Queries to get a page of the book instances matching the filter from database
+func (a SQLiteStorer) GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	page, err := getBooks(ctx, datastore.Conn(ctx, a.db), filter)
+	if err != nil {
+		return entities.BookPage{}, errors.DB{Err: err}
+	}
+
+	return page, nil
+}
+
+// GetBookByID function is to perform DB Queries to get a particular book instance using its ID number from database
+func (a SQLiteStorer) GetBookByID(ctx context.Context, id int) (entities.Book, error) {
+	book, err := scanBook(datastore.Conn(ctx, a.db).QueryRowContext(ctx, datastore.GetByIDBook, id))
+	if err == sql.ErrNoRows {
+		return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
+	}
+
+	if err != nil {
+		return entities.Book{}, errors.DB{Err: err}
+	}
+
+	return book, nil
+}
+
+// GetBookByISBN function is to perform DB Queries to get a particular book instance using its ISBN from database
+func (a SQLiteStorer) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	return getBookByISBN(ctx, datastore.Conn(ctx, a.db), isbn)
+}
+
+// GetDuplicates function is to perform DB Queries to get the books having the same details as a book for the keys
+func (a SQLiteStorer) GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error) {
+	return getDuplicates(ctx, datastore.Conn(ctx, a.db), book, keys)
+}
+
+// SearchBooks function is to perform DB Queries to get a page of the book instances matching the search from database,
+// SQLite has no FULLTEXT indexes so the books are matched with the FTS4 tables of the migrations and ranked by a
+// datastore.SearchIndex of the matching books
+func (a SQLiteStorer) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	index, err := searchIndex(ctx, datastore.Conn(ctx, a.db), query)
+	if err != nil {
+		return entities.SearchPage{}, err
+	}
+
+	return index.Search(query), nil
+}
+
+// searchIndex reads the books matching the search, their contributors and the names of their authors and publishers
+// into an index, a search without matches has an empty index
+func searchIndex(ctx context.Context, db datastore.DBTX, query entities.SearchQuery) (datastore.SearchIndex, error) {
+	var books []entities.Book
+
+	match, args := datastore.SearchMatchesQuery(query)
+
+	err := readRows(ctx, db, match, func(rows *sql.Rows) error {
+		book, err := scanBook(rows)
+		books = append(books, book)
+
+		return err
+	}, args...)
+	if err != nil {
+		return datastore.SearchIndex{}, errors.DB{Err: err}
+	}
+
+	if len(books) == 0 {
+		return datastore.SearchIndex{}, nil
+	}
+
+	bookIDs := make([]int, len(books))
+	publisherIDs := make([]int, len(books))
+
+	for i := range books {
+		bookIDs[i] = books[i].ID
+		publisherIDs[i] = books[i].Publisher.ID
+	}
+
+	contributors, err := getContributors(ctx, db, bookIDs)
+	if err != nil {
+		return datastore.SearchIndex{}, err
+	}
+
+	var authorIDs []int
+
+	for _, credits := range contributors {
+		for _, c := range credits {
+			authorIDs = append(authorIDs, c.Author.ID)
+		}
+	}
+
+	var (
+		authors    []entities.Author
+		publishers []entities.Publisher
+	)
+
+	authorNames, authorArgs := datastore.AuthorNamesQuery(authorIDs)
+	publisherNames, publisherArgs := datastore.PublisherNamesQuery(publisherIDs)
+
+	reads := []struct {
+		query string
+		args  []interface{}
+		scan  func(rows *sql.Rows) error
+	}{
+		{authorNames, authorArgs, func(rows *sql.Rows) error {
+			var author entities.Author
+
+			err := rows.Scan(&author.ID, &author.FirstName, &author.LastName, &author.PenName)
+			authors = append(authors, author)
+
+			return err
+		}},
+		{publisherNames, publisherArgs, func(rows *sql.Rows) error {
+			var publisher entities.Publisher
+
+			err := rows.Scan(&publisher.ID, &publisher.Name)
+			publishers = append(publishers, publisher)
+
+			return err
+		}},
+	}
+
+	for _, read := range reads {
+		if err := readRows(ctx, db, read.query, read.scan, read.args...); err != nil {
+			return datastore.SearchIndex{}, errors.DB{Err: err}
+		}
+	}
+
+	return datastore.NewSearchIndex(books, contributors, authors, publishers), nil
+}
+
+// CreateBook function is to perform DB Executions to add new book instance in the database
+func (a SQLiteStorer) CreateBook(ctx context.Context, book entities.Book) (entities.Book, error) {
+	db := datastore.Conn(ctx, a.db)
+
+	err := db.QueryRowContext(ctx, datastore.InsertBookSQLite, book.Title, book.Publisher.ID, book.PublishedDate,
+		book.Author.ID, book.ISBN, entities.TitleKey(book.Title)).Scan(&book.ID, &book.Version)
+	if err != nil {
+		return entities.Book{}, isbnTaken(errors.DB{Err: err})
+	}
+
+	if err = addContributors(ctx, db, book.ID, book.Credits()); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = addGenres(ctx, db, book.ID, book.Genres); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = addSeries(ctx, db, book.ID, book.Series); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = addEdition(ctx, db, book.ID, book.Edition); err != nil {
+		return entities.Book{}, err
+	}
+
+	return book, nil
+}
+
+// UpdateBook function is to perform required DB Queries to make changes to a book instance in database,
+// the book is returned with its new version
+func (a SQLiteStorer) UpdateBook(ctx context.Context, id int, book entities.Book) (entities.Book, error) {
+	db := datastore.Conn(ctx, a.db)
+
+	version, err := datastore.SQLite.Update(ctx, db, "Book", "Books", id, datastore.UpdateBookSQLite, book.Title,
+		book.Publisher.ID, book.PublishedDate, book.Author.ID, book.ISBN, entities.TitleKey(book.Title), id,
+		book.Version)
+	if err != nil {
+		return entities.Book{}, isbnTaken(err)
+	}
+
+	if err = replaceContributors(ctx, db, id, book.Credits()); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = replaceGenres(ctx, db, id, book.Genres); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = replaceSeries(ctx, db, id, book.Series); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = replaceEdition(ctx, db, id, book.Edition); err != nil {
+		return entities.Book{}, err
+	}
+
+	book.ID = id
+	book.Version = version
+
+	return book, nil
+}
+
+// UpdateBookFields function is to perform DB Query to update only the given fields of a book instance in database
+func (a SQLiteStorer) UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+	query, args, err := datastore.UpdateBookFieldsQuery(datastore.SQLite, id, book, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	db := datastore.Conn(ctx, a.db)
+
+	version, err := datastore.SQLite.Update(ctx, db, "Book", "Books", id, query, args...)
+	if err != nil {
+		return 0, isbnTaken(err)
+	}
+
+	return version, replaceFields(ctx, db, id, book, fields)
+}
+
+// DeleteBook function is to perform DB Queries to remove a particular book instance using its ID from database
+func (a SQLiteStorer) DeleteBook(ctx context.Context, id int) error {
+	res, err := datastore.Conn(ctx, a.db).ExecContext(ctx, datastore.DeleteBook, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Book", ID: id}
+	}
+
+	return nil
+}
+
+// GetContributors function is to perform DB Queries to get the contributors of the books in the order of the credits
+func (a SQLiteStorer) GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error) {
+	return getContributors(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// GetBookGenres function is to perform DB Queries to get the genres the books are tagged with
+func (a SQLiteStorer) GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error) {
+	return getBookGenres(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// GetBookFacets function is to perform DB Queries to count the book instances matching the filter by genre,
+// publisher and decade of publication
+func (a SQLiteStorer) GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error) {
+	return getBookFacets(ctx, datastore.Conn(ctx, a.db), filter)
+}
+
+// GetBookSeries function is to perform DB Queries to get the series of the books which are volumes of one
+func (a SQLiteStorer) GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	return getBookSeries(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
+
+// GetEditions function is to perform DB Queries to get the edition links of the books
+func (a SQLiteStorer) GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error) {
+	return getEditions(ctx, datastore.Conn(ctx, a.db), bookIDs)
+}
@@ -0,0 +1,226 @@
+package book
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/driver"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+// newSQLite returns an in memory sqlite database having a single author with id 1
+func newSQLite(t *testing.T) *sql.DB {
+	db, err := driver.ConnectToSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sqlite database", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES ('MG','Verma','13/07/2000','Verma')")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when adding an author", err)
+	}
+
+	return db
+}
+
+func TestSQLiteStorer_CreateBook(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	testcases := []struct {
+		desc    string
+		reqBody entities.Book
+		expRes  entities.Book
+		expErr  bool
+	}{
+		{desc: "Valid Details", reqBody: entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			expRes: entities.Book{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22), Version: 1}},
+		{desc: "author does not exist", reqBody: entities.Book{Title: "Rahul", Author: entities.Author{ID: 99},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)}, expErr: true},
+	}
+
+	for i, v := range testcases {
+		resp, err := a.CreateBook(context.Background(), v.reqBody)
+		if (err != nil) != v.expErr {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected error %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(resp, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestSQLiteStorer_GetAllBook(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	book, _ := a.CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+
+	resp, err := a.GetAllBook(context.Background())
+	if err != nil || !reflect.DeepEqual(resp, []entities.Book{book}) {
+		t.Errorf("[TEST1]Failed. Got %v, %v\tExpected %v\n", resp, err, []entities.Book{book})
+	}
+}
+
+func TestSQLiteStorer_GetBookByID(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	book, _ := a.CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expRes entities.Book
+		expErr error
+	}{
+		{"get book", book.ID, book, nil},
+		{"Id doesn't exist", 1000, entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: 1000}},
+	}
+
+	for i, v := range testcases {
+		resp, err := a.GetBookByID(context.Background(), v.reqID)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(resp, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestSQLiteStorer_UpdateBook(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	book, _ := a.CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+
+	testcases := []struct {
+		desc    string
+		reqID   int
+		reqBody entities.Book
+		expBody entities.Book
+		expErr  error
+	}{
+		{desc: "valid case id exist", reqID: book.ID,
+			reqBody: entities.Book{Title: "title", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(1999, 8, 22), Version: book.Version},
+			expBody: entities.Book{ID: book.ID, Title: "title", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(1999, 8, 22),
+				Version: book.Version + 1}},
+		{desc: "modified since read", reqID: book.ID,
+			reqBody: entities.Book{Title: "title", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+				Version: book.Version},
+			expErr: errors.PreconditionFailed{Entity: "Book", ID: book.ID}},
+		{desc: "id does not exist", reqID: 1000, reqBody: entities.Book{Title: "title", Author: entities.Author{ID: 1}},
+			expErr: errors.EntityNotFound{Entity: "Book", ID: 1000}},
+	}
+
+	for i, v := range testcases {
+		res, err := a.UpdateBook(context.Background(), v.reqID, v.reqBody)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(res, v.expBody) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, v.expBody)
+		}
+	}
+}
+
+func TestSQLiteStorer_DeleteBook(t *testing.T) {
+	a := NewSQLite(newSQLite(t))
+
+	book, _ := a.CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expErr error
+	}{
+		{"Valid Details", book.ID, nil},
+		{"Book does not exists", book.ID, errors.EntityNotFound{Entity: "Book", ID: book.ID}},
+	}
+
+	for i, v := range testcases {
+		err := a.DeleteBook(context.Background(), v.reqID)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+	}
+}
+
+func TestSQLiteStorer_WithinTx(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLite(t)
+	a := NewSQLite(db)
+
+	book, err := a.CreateBook(ctx, entities.Book{Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when adding a book", err)
+	}
+
+	expErr := errors.InValidDetails{Details: "Title"}
+
+	err = datastore.NewSQLTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+		if err := a.DeleteBook(ctx, book.ID); err != nil {
+			return err
+		}
+
+		return expErr
+	})
+	if err != expErr {
+		t.Errorf("Failed. Expected %v\tGot %v", expErr, err)
+	}
+
+	res, err := a.GetBookByID(ctx, book.ID)
+	if err != nil || !reflect.DeepEqual(res, book) {
+		t.Errorf("Failed. Expected the delete to be rolled back %v\tGot %v, %v", book, res, err)
+	}
+}
+
+// TestSQLiteStorer_SearchBooks checks the words which the FULLTEXT indexes of MySQL leave out, the words shorter than
+// innodb_ft_min_token_size and the stopwords, are found by the full-text tables of SQLite
+func TestSQLiteStorer_SearchBooks(t *testing.T) {
+	ctx := context.Background()
+	a := NewSQLite(newSQLite(t))
+
+	book, err := a.CreateBook(ctx, entities.Book{Title: "The Hobbit, or Back Again", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(1937, 9, 21)})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when adding a book", err)
+	}
+
+	testcases := []struct {
+		desc     string
+		term     string
+		expScore float64
+	}{
+		{"stopword of the title", "the", datastore.TitleWeight},
+		{"short word of the title", "or", datastore.TitleWeight},
+		{"short name of the author", "mg", datastore.AuthorWeight},
+		{"prefix of a word", "hob", datastore.TitleWeight / 2.0},
+	}
+
+	for i, tc := range testcases {
+		page, err := a.SearchBooks(ctx, entities.SearchQuery{Terms: []string{tc.term}, Limit: 10})
+
+		exp := []entities.SearchResult{{Book: book, Score: tc.expScore}}
+		if err != nil || !reflect.DeepEqual(page.Results, exp) || page.Total != 1 {
+			t.Errorf("[TEST%d]Failed. %s Expected %v Got %v, %v", i+1, tc.desc, exp, page, err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Package copies stores the physical copies of the books
+package copies
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Copy
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanCopy reads a copy selected with the columns of datastore.GetCopies, it is used by all the copy stores
+func scanCopy(row datastore.Scanner) (entities.Copy, error) {
+	var c entities.Copy
+
+	err := row.Scan(&c.ID, &c.BookID, &c.Barcode, &c.Condition, &c.ShelfLocation, &c.AcquiredOn, &c.Status, &c.Version)
+
+	return c, err
+}
+
+// GetCopies function is to perform DB Queries to get the copies of a book
+func (s Storer) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	return getCopies(ctx, datastore.Conn(ctx, s.db), bookID)
+}
+
+func getCopies(ctx context.Context, db datastore.DBTX, bookID int) ([]entities.Copy, error) {
+	rows, err := db.QueryContext(ctx, datastore.GetCopies, bookID)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	copies := make([]entities.Copy, 0)
+
+	for rows.Next() {
+		c, err := scanCopy(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		copies = append(copies, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return copies, nil
+}
+
+// GetCopyByID function is to perform DB Queries to get a copy instance using its ID
+func (s Storer) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	return getCopy(ctx, datastore.Conn(ctx, s.db), datastore.GetByIDCopy, id)
+}
+
+// GetCopyByBarcode function is to perform DB Queries to get a copy instance using its barcode
+func (s Storer) GetCopyByBarcode(ctx context.Context, barcode string) (entities.Copy, error) {
+	return getCopy(ctx, datastore.Conn(ctx, s.db), datastore.GetByBarcodeCopy, barcode)
+}
+
+// getCopy reads the single copy selected by query, the id of the not found error is set only when key is an id
+func getCopy(ctx context.Context, db datastore.DBTX, query string, key interface{}) (entities.Copy, error) {
+	c, err := scanCopy(db.QueryRowContext(ctx, query, key))
+	if err == sql.ErrNoRows {
+		id, _ := key.(int)
+
+		return entities.Copy{}, errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	if err != nil {
+		return entities.Copy{}, errors.DB{Err: err}
+	}
+
+	return c, nil
+}
+
+// GetAvailability function is to perform DB Queries to count the copies of the books by status,
+// the books without copies are left out
+func (s Storer) GetAvailability(ctx context.Context, bookIDs []int) (map[int]entities.Availability, error) {
+	return getAvailability(ctx, datastore.Conn(ctx, s.db), bookIDs)
+}
+
+func getAvailability(ctx context.Context, db datastore.DBTX, bookIDs []int) (map[int]entities.Availability, error) {
+	availability := make(map[int]entities.Availability)
+	if len(bookIDs) == 0 {
+		return availability, nil
+	}
+
+	query, args := datastore.AvailabilityQuery(bookIDs)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			bookID, count int
+			status        string
+		)
+
+		if err = rows.Scan(&bookID, &status, &count); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		availability[bookID] = availability[bookID].Add(status, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return availability, nil
+}
+
+// CreateCopy function is to perform DB execution to add a new copy instance in database
+func (s Storer) CreateCopy(ctx context.Context, c entities.Copy) (entities.Copy, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertCopy, c.BookID, c.Barcode, c.Condition,
+		c.ShelfLocation, c.AcquiredOn, c.Status)
+	if err != nil {
+		return entities.Copy{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Copy{}, errors.DB{Err: err}
+	}
+
+	c.ID = int(id)
+	c.Version = 1
+
+	return c, nil
+}
+
+// UpdateCopy function is to perform required DB Queries to replace a copy instance in database,
+// the copy is returned with its new version
+func (s Storer) UpdateCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+	return updateCopy(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdateCopy, id, c)
+}
+
+func updateCopy(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	c entities.Copy) (entities.Copy, error) {
+	version, err := d.Update(ctx, db, "Copy", "Copies", id, query, c.BookID, c.Barcode, c.Condition, c.ShelfLocation,
+		c.AcquiredOn, c.Status, id, c.Version)
+	if err != nil {
+		return entities.Copy{}, err
+	}
+
+	c.ID = id
+	c.Version = version
+
+	return c, nil
+}
+
+// UpdateCopyStatus function is to perform required DB Queries to change the status of a copy only when
+// it has the status from, so that two requests can not both lend the same copy
+func (s Storer) UpdateCopyStatus(ctx context.Context, id int, from, to string) error {
+	return updateCopyStatus(ctx, datastore.Conn(ctx, s.db), id, from, to)
+}
+
+func updateCopyStatus(ctx context.Context, db datastore.DBTX, id int, from, to string) error {
+	res, err := db.ExecContext(ctx, datastore.UpdateCopyStatus, to, id, from)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	return nil
+}
+
+// DeleteCopy function is to perform required DB Queries to remove a copy instance from database
+func (s Storer) DeleteCopy(ctx context.Context, id int) error {
+	return deleteCopy(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func deleteCopy(ctx context.Context, db datastore.DBTX, id int) error {
+	res, err := db.ExecContext(ctx, datastore.DeleteCopy, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	return nil
+}
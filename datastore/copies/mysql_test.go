@@ -0,0 +1,212 @@
+package copies
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "book_id", "barcode", "copy_condition", "shelf_location", "acquired_on", "status",
+	"version"}
+
+func bookCopy() entities.Copy {
+	return entities.Copy{BookID: 2, Barcode: "LIB-0001", Condition: entities.ConditionGood, ShelfLocation: "A-12",
+		AcquiredOn: entities.NewDate(2020, 2, 1), Status: entities.CopyAvailable}
+}
+
+func TestStorer_CreateCopy(t *testing.T) {
+	created := bookCopy()
+	created.ID, created.Version = 4, 1
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.Copy
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "barcode is taken", dbErr: fmt.Errorf("duplicate entry"),
+			expErr: errors.DB{Err: fmt.Errorf("duplicate entry")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		c := bookCopy()
+
+		mock.ExpectExec(datastore.InsertCopy).
+			WithArgs(c.BookID, c.Barcode, c.Condition, c.ShelfLocation, c.AcquiredOn, c.Status).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreateCopy(context.Background(), c)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetCopyByBarcode(t *testing.T) {
+	stored := bookCopy()
+	stored.ID, stored.Version = 1, 3
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes entities.Copy
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.BookID, stored.Barcode, stored.Condition,
+			stored.ShelfLocation, stored.AcquiredOn, stored.Status, 3), expRes: stored},
+		{desc: "not found", rows: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Copy"}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetByBarcodeCopy).WithArgs("LIB-0001")
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetCopyByBarcode(context.Background(), "LIB-0001")
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetAvailability(t *testing.T) {
+	query, _ := datastore.AvailabilityQuery([]int{1, 2})
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes map[int]entities.Availability
+		expErr error
+	}{
+		{desc: "counted", rows: sqlmock.NewRows([]string{"book_id", "status", "count"}).
+			AddRow(1, entities.CopyAvailable, 2).AddRow(1, entities.CopyOnLoan, 1).AddRow(1, entities.CopyLost, 4),
+			expRes: map[int]entities.Availability{1: {Total: 3, Available: 2, OnLoan: 1}}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		exp := mock.ExpectQuery(query).WithArgs(1, 2)
+		if tc.dbErr != nil {
+			exp.WillReturnError(tc.dbErr)
+		} else {
+			exp.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetAvailability(context.Background(), []int{1, 2})
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdateCopy(t *testing.T) {
+	updated := bookCopy()
+	updated.ID, updated.Version = 1, 1
+
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		stored int
+		expRes entities.Copy
+		expErr error
+	}{
+		{desc: "updated", rowsAffected: 1, expRes: updated},
+		{desc: "modified since read", stored: 2, expErr: errors.PreconditionFailed{Entity: "Copy", ID: 1}},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Copy", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		c := bookCopy()
+
+		mock.ExpectExec(datastore.UpdateCopy).
+			WithArgs(c.BookID, c.Barcode, c.Condition, c.ShelfLocation, c.AcquiredOn, c.Status, 1, c.Version).
+			WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+
+		if tc.rowsAffected == 0 {
+			rows := sqlmock.NewRows([]string{"version"})
+			if tc.stored != 0 {
+				rows.AddRow(tc.stored)
+			}
+
+			mock.ExpectQuery("select version from Copies where id=?").WithArgs(1).WillReturnRows(rows)
+		}
+
+		res, err := New(db).UpdateCopy(context.Background(), 1, c)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdateCopyStatus(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		expErr       error
+	}{
+		{desc: "updated", rowsAffected: 1},
+		{desc: "copy has other status", expErr: errors.EntityNotFound{Entity: "Copy", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.UpdateCopyStatus).WithArgs(entities.CopyOnLoan, 1, entities.CopyAvailable).
+			WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+
+		err := New(db).UpdateCopyStatus(context.Background(), 1, entities.CopyAvailable, entities.CopyOnLoan)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+	}
+}
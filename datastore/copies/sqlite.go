@@ -0,0 +1,68 @@
+package copies
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Copy, it differs from Storer only in reading
+// the generated id of a new copy
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetCopies function is to perform DB Queries to get the copies of a book
+func (s SQLiteStorer) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	return getCopies(ctx, datastore.Conn(ctx, s.db), bookID)
+}
+
+// GetCopyByID function is to perform DB Queries to get a copy instance using its ID
+func (s SQLiteStorer) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	return getCopy(ctx, datastore.Conn(ctx, s.db), datastore.GetByIDCopy, id)
+}
+
+// GetCopyByBarcode function is to perform DB Queries to get a copy instance using its barcode
+func (s SQLiteStorer) GetCopyByBarcode(ctx context.Context, barcode string) (entities.Copy, error) {
+	return getCopy(ctx, datastore.Conn(ctx, s.db), datastore.GetByBarcodeCopy, barcode)
+}
+
+// GetAvailability function is to perform DB Queries to count the copies of the books by status,
+// the books without copies are left out
+func (s SQLiteStorer) GetAvailability(ctx context.Context, bookIDs []int) (map[int]entities.Availability, error) {
+	return getAvailability(ctx, datastore.Conn(ctx, s.db), bookIDs)
+}
+
+// CreateCopy function is to perform DB execution to add a new copy instance in database
+func (s SQLiteStorer) CreateCopy(ctx context.Context, c entities.Copy) (entities.Copy, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertCopySQLite, c.BookID, c.Barcode,
+		c.Condition, c.ShelfLocation, c.AcquiredOn, c.Status).Scan(&c.ID, &c.Version)
+	if err != nil {
+		return entities.Copy{}, errors.DB{Err: err}
+	}
+
+	return c, nil
+}
+
+// UpdateCopy function is to perform required DB Queries to replace a copy instance in database,
+// the copy is returned with its new version
+func (s SQLiteStorer) UpdateCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+	return updateCopy(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdateCopySQLite, id, c)
+}
+
+// UpdateCopyStatus function is to perform required DB Queries to change the status of a copy only when
+// it has the status from, so that two requests can not both lend the same copy
+func (s SQLiteStorer) UpdateCopyStatus(ctx context.Context, id int, from, to string) error {
+	return updateCopyStatus(ctx, datastore.Conn(ctx, s.db), id, from, to)
+}
+
+// DeleteCopy function is to perform required DB Queries to remove a copy instance from database
+func (s SQLiteStorer) DeleteCopy(ctx context.Context, id int) error {
+	return deleteCopy(ctx, datastore.Conn(ctx, s.db), id)
+}
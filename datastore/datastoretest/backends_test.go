@@ -0,0 +1,94 @@
+package datastoretest
+
+import (
+	datastoreAuthor "ThreeLayer/datastore/author"
+	datastoreBook "ThreeLayer/datastore/books"
+	datastoreCopy "ThreeLayer/datastore/copies"
+	datastoreGenre "ThreeLayer/datastore/genre"
+	datastoreHold "ThreeLayer/datastore/holds"
+	datastoreLedger "ThreeLayer/datastore/ledger"
+	datastoreLoan "ThreeLayer/datastore/loans"
+	datastoreMember "ThreeLayer/datastore/member"
+	"ThreeLayer/datastore/memory"
+	datastorePublisher "ThreeLayer/datastore/publisher"
+	datastoreSeries "ThreeLayer/datastore/series"
+	"ThreeLayer/driver"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func TestMemory(t *testing.T) {
+	Run(t, func(t *testing.T) Stores {
+		db := memory.New()
+
+		return Stores{Author: memory.NewAuthor(db), Book: memory.NewBook(db), Publisher: memory.NewPublisher(db),
+			Genre: memory.NewGenre(db), Series: memory.NewSeries(db), Copy: memory.NewCopy(db),
+			Member: memory.NewMember(db), Loan: memory.NewLoan(db), Hold: memory.NewHold(db),
+			Ledger: memory.NewLedger(db)}
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	Run(t, func(t *testing.T) Stores {
+		db := newSQLite(t)
+
+		return Stores{Author: datastoreAuthor.NewSQLite(db), Book: datastoreBook.NewSQLite(db),
+			Publisher: datastorePublisher.NewSQLite(db), Genre: datastoreGenre.NewSQLite(db),
+			Series: datastoreSeries.NewSQLite(db), Copy: datastoreCopy.NewSQLite(db),
+			Member: datastoreMember.NewSQLite(db), Loan: datastoreLoan.NewSQLite(db), Hold: datastoreHold.NewSQLite(db),
+			Ledger: datastoreLedger.NewSQLite(db)}
+	})
+}
+
+// TestMySQL runs the suite against the database in MYSQL_TEST_DSN, the tables are emptied before every check
+func TestMySQL(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a mysql database", err)
+	}
+
+	defer db.Close()
+
+	// emptyTables deletes the rows of every table, the tables referring to others first. The genres lose their parents
+	// before they are deleted and the publishers which the migrations add are kept
+	emptyTables := func(t *testing.T) {
+		for _, query := range []string{"DELETE FROM LedgerEntries", "DELETE FROM Holds", "DELETE FROM Loans",
+			"DELETE FROM Copies", "DELETE FROM Members", "DELETE FROM BookAuthors", "DELETE FROM BookGenres",
+			"DELETE FROM Editions", "DELETE FROM SeriesBooks", "DELETE FROM Books", "DELETE FROM Series",
+			"DELETE FROM Authors", "DELETE FROM Publishers WHERE id > 3", "UPDATE Genres SET parent_id = NULL",
+			"DELETE FROM Genres"} {
+			if _, err := db.Exec(query); err != nil {
+				t.Fatalf("an error '%s' was not expected when emptying the tables", err)
+			}
+		}
+	}
+
+	Run(t, func(t *testing.T) Stores {
+		emptyTables(t)
+
+		return Stores{Author: datastoreAuthor.New(db), Book: datastoreBook.New(db),
+			Publisher: datastorePublisher.New(db), Genre: datastoreGenre.New(db), Series: datastoreSeries.New(db),
+			Copy: datastoreCopy.New(db), Member: datastoreMember.New(db), Loan: datastoreLoan.New(db),
+			Hold: datastoreHold.New(db), Ledger: datastoreLedger.New(db)}
+	})
+}
+
+// newSQLite returns a migrated in memory sqlite database which is closed at the end of the test
+func newSQLite(t *testing.T) *sql.DB {
+	db, err := driver.ConnectToSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sqlite database", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
@@ -0,0 +1,820 @@
+// Package datastoretest is a conformance suite for the store implementations of the datastore package, every backend
+// is run through the same checks so that the service can rely on identical behaviour.
+package datastoretest
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	stdErrors "errors"
+	"reflect"
+	"testing"
+)
+
+// Stores are the stores of every entity sharing the same database
+type Stores struct {
+	Author    datastore.Author
+	Book      datastore.Book
+	Publisher datastore.Publisher
+	Genre     datastore.Genre
+	Series    datastore.Series
+	Copy      datastore.Copy
+	Member    datastore.Member
+	Loan      datastore.Loan
+	Hold      datastore.Hold
+	Ledger    datastore.Ledger
+}
+
+// StoresFactory returns the Stores of a database which does not have any rows other than the publishers which the
+// migrations add
+type StoresFactory func(t *testing.T) Stores
+
+// suites are the checks of every store in the order they are run
+var suites = []struct {
+	name string
+	run  func(t *testing.T, newStores StoresFactory)
+}{
+	{"Author", RunAuthor},
+	{"Book", RunBook},
+	{"Cascade", RunCascade},
+	{"Publisher", RunPublisher},
+	{"Genre", RunGenre},
+	{"Series", RunSeries},
+	{"Copy", RunCopy},
+	{"Member", RunMember},
+	{"Loan", RunLoan},
+	{"Hold", RunHold},
+	{"Ledger", RunLedger},
+}
+
+// Run runs the whole suite against the stores returned by newStores, fresh stores are created for every check
+func Run(t *testing.T, newStores StoresFactory) {
+	for _, suite := range suites {
+		suite := suite
+		t.Run(suite.name, func(t *testing.T) { suite.run(t, newStores) })
+	}
+}
+
+// RunAuthor checks the CRUD semantics of datastore.Author
+func RunAuthor(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		first := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		second := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		if first.FirstName != "MG" || second.FirstName != "HC" {
+			t.Errorf("Failed. Expected the created authors to be returned Got %v and %v", first, second)
+		}
+	})
+
+	t.Run("GetAuthor", func(t *testing.T) {
+		s := newStores(t)
+
+		res, err := s.Author.GetAuthor(ctx)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no authors Got %v, %v", res, err)
+		}
+
+		first := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		second := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+
+		res, err = s.Author.GetAuthor(ctx)
+		if err != nil || !reflect.DeepEqual(res, []entities.Author{first, second}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Author{first, second}, res, err)
+		}
+	})
+
+	t.Run("GetAuthorByID", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		res, err := s.Author.GetAuthorByID(ctx, author.ID)
+		if err != nil || res != author {
+			t.Errorf("Failed. Expected %v Got %v, %v", author, res, err)
+		}
+
+		_, err = s.Author.GetAuthorByID(ctx, author.ID+1000)
+		expectNotFound(t, err, "Author")
+	})
+
+	t.Run("GetAuthorsByIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		res, err := s.Author.GetAuthorsByIDs(ctx, nil)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no authors for no ids Got %v, %v", res, err)
+		}
+
+		first := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		second := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+		mustCreate(t, s.Author.CreateAuthor, newAuthor("RS"))
+
+		// missing ids are skipped and the result is ordered by id
+		res, err = s.Author.GetAuthorsByIDs(ctx, []int{second.ID, second.ID + 1000, first.ID})
+		if err != nil || !reflect.DeepEqual(res, []entities.Author{first, second}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Author{first, second}, res, err)
+		}
+	})
+
+	t.Run("PutAuthor", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		other := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+
+		update := entities.Author{FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22),
+			PenName: "ABC", Version: author.Version}
+
+		res, err := s.Author.PutAuthor(ctx, author.ID, update)
+
+		// every update increments the stored version
+		update.ID, update.Version = author.ID, author.Version+1
+
+		if err != nil || !reflect.DeepEqual(res, update) {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Author.GetAuthorByID(ctx, author.ID)
+		if !reflect.DeepEqual(res, update) {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		res, _ = s.Author.GetAuthorByID(ctx, other.ID)
+		if res != other {
+			t.Errorf("Failed. Expected other authors to be unchanged %v Got %v", other, res)
+		}
+
+		// the author is updated only while it has the version it is updated with
+		_, err = s.Author.PutAuthor(ctx, author.ID, entities.Author{FirstName: "Stale", Version: author.Version})
+		expectPreconditionFailed(t, err, "Author", author.ID)
+
+		_, err = s.Author.PutAuthor(ctx, author.ID+1000, update)
+		expectNotFound(t, err, "Author")
+	})
+
+	t.Run("UpdateAuthorFields", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		update := entities.Author{FirstName: "Rahul", PenName: "ABC", Version: author.Version}
+
+		version, err := s.Author.UpdateAuthorFields(ctx, author.ID, update, []string{entities.AuthorPenName})
+		if err != nil || version != author.Version+1 {
+			t.Errorf("Failed. Expected version %v Got %v, %v", author.Version+1, version, err)
+		}
+
+		author.PenName = "ABC"
+		author.Version++
+
+		res, _ := s.Author.GetAuthorByID(ctx, author.ID)
+		if res != author {
+			t.Errorf("Failed. Expected only the pen name to be updated %v Got %v", author, res)
+		}
+
+		_, err = s.Author.UpdateAuthorFields(ctx, author.ID, update, []string{entities.AuthorPenName})
+		expectPreconditionFailed(t, err, "Author", author.ID)
+	})
+
+	t.Run("DeleteAuthor", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		if err := s.Author.DeleteAuthor(ctx, author.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err := s.Author.GetAuthorByID(ctx, author.ID)
+		expectNotFound(t, err, "Author")
+
+		err = s.Author.DeleteAuthor(ctx, author.ID)
+		expectNotFound(t, err, "Author")
+	})
+}
+
+// RunBook checks the CRUD semantics of datastore.Book
+func RunBook(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		first := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		second := mustCreate(t, s.Book.CreateBook, newBook("second", author.ID))
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		if first.Title != "first" || first.Author.ID != author.ID {
+			t.Errorf("Failed. Expected the created book to be returned Got %v", first)
+		}
+	})
+
+	t.Run("GetAllBook", func(t *testing.T) {
+		s := newStores(t)
+
+		res, err := s.Book.GetAllBook(ctx)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no books Got %v, %v", res, err)
+		}
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		first := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		second := mustCreate(t, s.Book.CreateBook, newBook("second", author.ID))
+
+		// only the id of the author is set on the stored books, the service fills in the rest
+		res, err = s.Book.GetAllBook(ctx)
+		if err != nil || !reflect.DeepEqual(res, []entities.Book{first, second}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Book{first, second}, res, err)
+		}
+	})
+
+	t.Run("GetBooks", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		other := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+
+		// the publishers 1 to 3 are added by the migrations
+		create := func(title string, publisherID int, date entities.Date, authorID int) entities.Book {
+			book, err := s.Book.CreateBook(ctx, entities.Book{Title: title, Author: entities.Author{ID: authorID},
+				Publisher: entities.Publisher{ID: publisherID}, PublishedDate: date})
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when creating a book", err)
+			}
+
+			return book
+		}
+
+		b1 := create("Clean Code", 3, entities.NewDate(2008, 8, 1), author.ID)
+		b2 := create("Algorithms", 1, entities.NewDate(2000, 7, 22), author.ID)
+		b3 := create("Brave", 3, entities.NewDate(1999, 12, 5), other.ID)
+		b4 := create("Algorithms", 2, entities.NewDate(2010, 1, 15), other.ID)
+
+		testcases := []struct {
+			desc     string
+			filter   entities.BookFilter
+			expBooks []entities.Book
+			expTotal int
+		}{
+			{"all books", entities.BookFilter{Limit: 10}, []entities.Book{b1, b2, b3, b4}, 4},
+			{"by title", entities.BookFilter{Title: "Algorithms", Limit: 10}, []entities.Book{b2, b4}, 2},
+			{"by publisher", entities.BookFilter{PublisherID: 3, Limit: 10}, []entities.Book{b1, b3}, 2},
+			{"by author", entities.BookFilter{AuthorID: other.ID, Limit: 10}, []entities.Book{b3, b4}, 2},
+			{"by published date range", entities.BookFilter{PublishedFrom: "2000-07-22", PublishedTo: "2008-08-01",
+				Limit: 10}, []entities.Book{b1, b2}, 2},
+			{"sort by title with id as tie breaker", entities.BookFilter{Sort: entities.SortByTitle, Limit: 10},
+				[]entities.Book{b2, b4, b3, b1}, 4},
+			{"sort by published date descending", entities.BookFilter{Sort: entities.SortByPublishedDate,
+				Desc: true, Limit: 10}, []entities.Book{b4, b1, b2, b3}, 4},
+			{"first page", entities.BookFilter{Sort: entities.SortByPublishedDate, Limit: 2},
+				[]entities.Book{b3, b2}, 4},
+			{"second page", entities.BookFilter{Sort: entities.SortByPublishedDate, Limit: 2, Offset: 2},
+				[]entities.Book{b1, b4}, 4},
+			{"page after the last", entities.BookFilter{Limit: 2, Offset: 4}, []entities.Book{}, 4},
+			{"no match", entities.BookFilter{Title: "Missing", Limit: 2}, []entities.Book{}, 0},
+			{"without a limit", entities.BookFilter{AuthorID: author.ID}, []entities.Book{b1, b2}, 2},
+		}
+
+		for i, tc := range testcases {
+			page, err := s.Book.GetBooks(ctx, tc.filter)
+			if err != nil {
+				t.Errorf("[TEST%d]Failed. Expected error to be nil Got %v", i, err)
+			}
+
+			if !reflect.DeepEqual(page.Books, tc.expBooks) || page.Total != tc.expTotal {
+				t.Errorf("[TEST%d]Failed. %s Expected %v of %d Got %v of %d", i, tc.desc, tc.expBooks, tc.expTotal,
+					page.Books, page.Total)
+			}
+		}
+	})
+
+	t.Run("GetBookByID", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+
+		res, err := s.Book.GetBookByID(ctx, book.ID)
+		if err != nil || !reflect.DeepEqual(res, book) {
+			t.Errorf("Failed. Expected %v Got %v, %v", book, res, err)
+		}
+
+		_, err = s.Book.GetBookByID(ctx, book.ID+1000)
+		expectNotFound(t, err, "Book")
+	})
+
+	t.Run("ISBN", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		mustCreate(t, s.Book.CreateBook, newBook("without isbn", author.ID))
+		mustCreate(t, s.Book.CreateBook, newBook("also without isbn", author.ID))
+
+		book := entities.Book{Title: "first", Author: entities.Author{ID: author.ID}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(2000, 7, 22), ISBN: "9780306406157"}
+
+		book, err := s.Book.CreateBook(ctx, book)
+		if err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, err := s.Book.GetBookByISBN(ctx, book.ISBN)
+		if err != nil || !reflect.DeepEqual(res, book) {
+			t.Errorf("Failed. Expected %v Got %v, %v", book, res, err)
+		}
+
+		_, err = s.Book.GetBookByISBN(ctx, "9781861972712")
+		expectNotFound(t, err, "Book")
+
+		// the isbn is unique
+		other := mustCreate(t, s.Book.CreateBook, newBook("second", author.ID))
+		other.ISBN = book.ISBN
+
+		_, err = s.Book.CreateBook(ctx, other)
+		expectExistAlready(t, err, "Book", "isbn")
+
+		_, err = s.Book.UpdateBook(ctx, other.ID, other)
+		expectExistAlready(t, err, "Book", "isbn")
+
+		_, err = s.Book.UpdateBookFields(ctx, other.ID, other, []string{entities.BookISBN})
+		expectExistAlready(t, err, "Book", "isbn")
+
+		// a removed isbn is found no more
+		book.ISBN = ""
+		if _, err = s.Book.UpdateBookFields(ctx, book.ID, book, []string{entities.BookISBN}); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err = s.Book.GetBookByISBN(ctx, "9780306406157")
+		expectNotFound(t, err, "Book")
+	})
+
+	t.Run("GetDuplicates", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("JRR"))
+
+		stored, err := s.Book.CreateBook(ctx, entities.Book{Title: "The Hobbit", Author: entities.Author{ID: author.ID},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(1937, 9, 21)})
+		if err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		other := mustCreate(t, s.Book.CreateBook, newBook("The Silmarillion", author.ID))
+
+		undated, err := s.Book.CreateBook(ctx, entities.Book{Title: "Letters", Author: entities.Author{ID: author.ID},
+			Publisher: entities.Publisher{ID: 1}})
+		if err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		reprint := entities.Book{Title: " the  HOBBIT", Author: entities.Author{ID: author.ID},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(1951, 7, 1)}
+
+		testcases := []struct {
+			desc   string
+			book   entities.Book
+			keys   []string
+			expRes []entities.Book
+		}{
+			{desc: "title and author", book: reprint, keys: []string{entities.DuplicateByTitle, entities.DuplicateByAuthor},
+				expRes: []entities.Book{stored}},
+			{desc: "publisher", book: reprint, keys: []string{entities.DuplicateByPublisher},
+				expRes: []entities.Book{stored, other}},
+			{desc: "another edition", book: reprint, keys: []string{entities.DuplicateByTitle, entities.DuplicateByAuthor,
+				entities.DuplicateByPublishedDate}, expRes: []entities.Book{}},
+			{desc: "without a publication date", book: entities.Book{Title: "letters"},
+				keys: []string{entities.DuplicateByTitle, entities.DuplicateByPublishedDate}, expRes: []entities.Book{undated}},
+		}
+
+		for i, tc := range testcases {
+			res, err := s.Book.GetDuplicates(ctx, tc.book, tc.keys)
+			if err != nil || !reflect.DeepEqual(res, tc.expRes) {
+				t.Errorf("[TEST%d]Failed. %s Expected %v Got %v, %v", i, tc.desc, tc.expRes, res, err)
+			}
+		}
+
+		// the key follows the title when only the title is updated
+		other.Title = "The  hobbit"
+
+		if other.Version, err = s.Book.UpdateBookFields(ctx, other.ID, other, []string{entities.BookTitle}); err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, err := s.Book.GetDuplicates(ctx, reprint, []string{entities.DuplicateByTitle})
+		if exp := []entities.Book{stored, other}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res, err)
+		}
+	})
+
+	t.Run("UpdateBook", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		other := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+
+		update := entities.Book{Title: "title", Author: entities.Author{ID: other.ID}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(1999, 8, 22), Version: book.Version}
+
+		res, err := s.Book.UpdateBook(ctx, book.ID, update)
+		update.ID, update.Version = book.ID, book.Version+1
+
+		if err != nil || !reflect.DeepEqual(res, update) {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Book.GetBookByID(ctx, book.ID)
+		if !reflect.DeepEqual(res, update) {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the book is updated only while it has the version it is updated with
+		update.Version = book.Version
+
+		_, err = s.Book.UpdateBook(ctx, book.ID, update)
+		expectPreconditionFailed(t, err, "Book", book.ID)
+
+		_, err = s.Book.UpdateBook(ctx, book.ID+1000, update)
+		expectNotFound(t, err, "Book")
+	})
+
+	t.Run("UpdateBookFields", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+
+		// only the title is taken from the update
+		update := entities.Book{Title: "title", Author: entities.Author{ID: author.ID + 1000},
+			Publisher: entities.Publisher{ID: 1}, Version: book.Version}
+
+		version, err := s.Book.UpdateBookFields(ctx, book.ID, update, []string{entities.BookTitle})
+		if err != nil || version != book.Version+1 {
+			t.Errorf("Failed. Expected version %v Got %v, %v", book.Version+1, version, err)
+		}
+
+		book.Title = "title"
+		book.Version++
+
+		res, _ := s.Book.GetBookByID(ctx, book.ID)
+		if !reflect.DeepEqual(res, book) {
+			t.Errorf("Failed. Expected only the title to be updated %v Got %v", book, res)
+		}
+
+		_, err = s.Book.UpdateBookFields(ctx, book.ID, update, []string{entities.BookTitle})
+		expectPreconditionFailed(t, err, "Book", book.ID)
+
+		update.Version = book.Version
+
+		_, err = s.Book.UpdateBookFields(ctx, book.ID, update, []string{"id"})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a field which can not be updated")
+		}
+	})
+
+	t.Run("Contributors", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		translator := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+		illustrator := mustCreate(t, s.Author.CreateAuthor, newAuthor("RD"))
+		alone := mustCreate(t, s.Book.CreateBook, newBook("alone", illustrator.ID))
+
+		credits := []entities.Contributor{{Author: entities.Author{ID: author.ID}, Role: entities.RoleAuthor},
+			{Author: entities.Author{ID: translator.ID}, Role: entities.RoleTranslator},
+			{Author: entities.Author{ID: illustrator.ID}, Role: entities.RoleIllustrator}}
+
+		book, err := s.Book.CreateBook(ctx, entities.Book{Title: "first", Author: entities.Author{ID: author.ID},
+			Contributors: credits, Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+		if err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		// a book created without contributors is credited to its author alone
+		exp := map[int][]entities.Contributor{book.ID: credits, alone.ID: {{Author: entities.Author{ID: illustrator.ID},
+			Role: entities.RoleAuthor}}}
+
+		res, err := s.Book.GetContributors(ctx, []int{book.ID, alone.ID, book.ID + 1000})
+		if err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res, err)
+		}
+
+		page, err := s.Book.GetBooks(ctx, entities.BookFilter{AuthorID: translator.ID, Limit: 10})
+		if err != nil || page.Total != 1 || page.Books[0].ID != book.ID {
+			t.Errorf("Failed. Expected the book to be listed for the translator Got %v, %v", page, err)
+		}
+
+		// the contributors are replaced and the first of them becomes the author
+		replaced := []entities.Contributor{{Author: entities.Author{ID: translator.ID}, Role: entities.RoleAuthor},
+			{Author: entities.Author{ID: author.ID}, Role: entities.RoleEditor}}
+
+		_, err = s.Book.UpdateBookFields(ctx, book.ID, entities.Book{Author: entities.Author{ID: translator.ID},
+			Contributors: replaced, Version: book.Version}, []string{entities.BookContributors})
+		if err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, _ = s.Book.GetContributors(ctx, []int{book.ID})
+		if !reflect.DeepEqual(res[book.ID], replaced) {
+			t.Errorf("Failed. Expected %v Got %v", replaced, res[book.ID])
+		}
+
+		if stored, _ := s.Book.GetBookByID(ctx, book.ID); stored.Author.ID != translator.ID {
+			t.Errorf("Failed. Expected the author %d Got %v", translator.ID, stored.Author)
+		}
+
+		page, _ = s.Book.GetBooks(ctx, entities.BookFilter{AuthorID: illustrator.ID, Limit: 10})
+		if page.Total != 1 || page.Books[0].ID != alone.ID {
+			t.Errorf("Failed. Expected only the book of the illustrator Got %v", page)
+		}
+
+		if err = s.Book.DeleteBook(ctx, book.ID); err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, err = s.Book.GetContributors(ctx, []int{book.ID})
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected the contributors to be removed with the book Got %v, %v", res, err)
+		}
+	})
+
+	t.Run("SearchBooks", func(t *testing.T) {
+		s := newStores(t)
+
+		author, err := s.Author.CreateAuthor(ctx, entities.Author{FirstName: "Joanne", LastName: "Rowling",
+			Dob: entities.NewDate(1965, 7, 31), PenName: "JK"})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when creating an author", err)
+		}
+
+		other := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		// the publishers 1 to 3 are Arihanth, Scholastic and Penguin
+		create := func(title string, publisherID, authorID int) entities.Book {
+			book, err := s.Book.CreateBook(ctx, entities.Book{Title: title, Author: entities.Author{ID: authorID},
+				Publisher: entities.Publisher{ID: publisherID}, PublishedDate: entities.NewDate(2000, 7, 22)})
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when creating a book", err)
+			}
+
+			return book
+		}
+
+		b1 := create("Harry Potter and the Philosopher's Stone", 2, author.ID)
+		b2 := create("Potted Plants", 3, other.ID)
+		b3 := create("Rowling, a Biography", 1, other.ID)
+
+		// the scores are up to the backend, so only the matching books are compared
+		testcases := []struct {
+			desc     string
+			query    entities.SearchQuery
+			expIDs   map[int]bool
+			expTotal int
+		}{
+			{"title", entities.SearchQuery{Terms: []string{"harry"}, Limit: 10}, map[int]bool{b1.ID: true}, 1},
+			{"prefix of a word", entities.SearchQuery{Terms: []string{"pott"}, Limit: 10},
+				map[int]bool{b1.ID: true, b2.ID: true}, 2},
+			{"name of the author and title", entities.SearchQuery{Terms: []string{"rowling"}, Limit: 10},
+				map[int]bool{b1.ID: true, b3.ID: true}, 2},
+			{"publisher", entities.SearchQuery{Terms: []string{"penguin"}, Limit: 10}, map[int]bool{b2.ID: true}, 1},
+			{"any of the terms", entities.SearchQuery{Terms: []string{"plants", "scholastic"}, Limit: 10},
+				map[int]bool{b1.ID: true, b2.ID: true}, 2},
+			{"no match", entities.SearchQuery{Terms: []string{"missing"}, Limit: 10}, map[int]bool{}, 0},
+		}
+
+		for i, tc := range testcases {
+			page, err := s.Book.SearchBooks(ctx, tc.query)
+
+			ids := make(map[int]bool)
+			for _, r := range page.Results {
+				ids[r.Book.ID] = true
+			}
+
+			if err != nil || !reflect.DeepEqual(ids, tc.expIDs) || page.Total != tc.expTotal {
+				t.Errorf("[TEST%d]Failed. %s Expected %v of %d Got %v, %v", i, tc.desc, tc.expIDs, tc.expTotal, page, err)
+			}
+		}
+
+		page, err := s.Book.SearchBooks(ctx, entities.SearchQuery{Terms: []string{"verma"}, Limit: 1, Offset: 1})
+		if err != nil || len(page.Results) != 1 || page.Total != 2 {
+			t.Errorf("Failed. Expected the second of 2 books Got %v, %v", page, err)
+		}
+
+		// the books are returned the same way as by GetBookByID
+		page, _ = s.Book.SearchBooks(ctx, entities.SearchQuery{Terms: []string{"harry"}, Limit: 10})
+		if len(page.Results) != 1 || !reflect.DeepEqual(page.Results[0].Book, b1) || page.Results[0].Score <= 0 {
+			t.Errorf("Failed. Expected %v with a positive score Got %v", b1, page.Results)
+		}
+	})
+
+	t.Run("SearchBooksAfterWrites", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+
+		expect := func(desc, term string, expTotal int) {
+			t.Helper()
+
+			page, err := s.Book.SearchBooks(ctx, entities.SearchQuery{Terms: []string{term}, Limit: 10})
+			if err != nil || page.Total != expTotal {
+				t.Errorf("Failed. %s Expected %d books for %q Got %v, %v", desc, expTotal, term, page, err)
+			}
+		}
+
+		book.Title = "renamed"
+		if _, err := s.Book.UpdateBookFields(ctx, book.ID, book, []string{entities.BookTitle}); err != nil {
+			t.Fatalf("an error '%s' was not expected when updating a book", err)
+		}
+
+		expect("new title", "renamed", 1)
+		expect("old title", "first", 0)
+
+		author.PenName = "Penned"
+		if _, err := s.Author.UpdateAuthorFields(ctx, author.ID, author, []string{entities.AuthorPenName}); err != nil {
+			t.Fatalf("an error '%s' was not expected when updating an author", err)
+		}
+
+		expect("new pen name", "penned", 1)
+
+		publisher, err := s.Publisher.GetPublisherByID(ctx, book.Publisher.ID)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when reading a publisher", err)
+		}
+
+		publisher.Name = "Bloomsbury"
+		if _, err = s.Publisher.UpdatePublisher(ctx, publisher.ID, publisher); err != nil {
+			t.Fatalf("an error '%s' was not expected when updating a publisher", err)
+		}
+
+		expect("new publisher name", "bloomsbury", 1)
+
+		if err = s.Book.DeleteBook(ctx, book.ID); err != nil {
+			t.Fatalf("an error '%s' was not expected when deleting a book", err)
+		}
+
+		expect("deleted book", "renamed", 0)
+	})
+
+	t.Run("DeleteBook", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+
+		if err := s.Book.DeleteBook(ctx, book.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err := s.Book.GetBookByID(ctx, book.ID)
+		expectNotFound(t, err, "Book")
+
+		err = s.Book.DeleteBook(ctx, book.ID)
+		expectNotFound(t, err, "Book")
+	})
+}
+
+// RunCascade checks the references between books and authors which the service depends on
+// when an author is deleted along with the books
+func RunCascade(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("BookNeedsAuthor", func(t *testing.T) {
+		s := newStores(t)
+
+		_, err := s.Book.CreateBook(ctx, entities.Book{Title: "first", Author: entities.Author{ID: 1000},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a book whose author does not exist")
+		}
+	})
+
+	t.Run("AuthorWithBooksIsKept", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+
+		if err := s.Author.DeleteAuthor(ctx, author.ID); err == nil {
+			t.Errorf("Failed. Expected an error when deleting an author who has books")
+		}
+
+		if res, err := s.Book.GetBookByID(ctx, book.ID); err != nil || !reflect.DeepEqual(res, book) {
+			t.Errorf("Failed. Expected the book to be kept %v Got %v, %v", book, res, err)
+		}
+	})
+
+	t.Run("ContributorIsRemovedWithAuthor", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		editor := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+
+		book, err := s.Book.CreateBook(ctx, entities.Book{Title: "first", Author: entities.Author{ID: author.ID},
+			Contributors: []entities.Contributor{{Author: entities.Author{ID: author.ID}, Role: entities.RoleAuthor},
+				{Author: entities.Author{ID: editor.ID}, Role: entities.RoleEditor}},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+		if err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		if err = s.Author.DeleteAuthor(ctx, editor.ID); err != nil {
+			t.Errorf("Failed. Expected an author who only contributes to books to be removed Got %v", err)
+		}
+
+		exp := []entities.Contributor{{Author: entities.Author{ID: author.ID}, Role: entities.RoleAuthor}}
+
+		res, err := s.Book.GetContributors(ctx, []int{book.ID})
+		if err != nil || !reflect.DeepEqual(res[book.ID], exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res[book.ID], err)
+		}
+	})
+
+	t.Run("DeleteBooksThenAuthor", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		other := mustCreate(t, s.Author.CreateAuthor, newAuthor("HC"))
+		first := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		second := mustCreate(t, s.Book.CreateBook, newBook("second", author.ID))
+		kept := mustCreate(t, s.Book.CreateBook, newBook("kept", other.ID))
+
+		for _, id := range []int{first.ID, second.ID} {
+			if err := s.Book.DeleteBook(ctx, id); err != nil {
+				t.Fatalf("Failed. Expected error to be nil Got %v", err)
+			}
+		}
+
+		if err := s.Author.DeleteAuthor(ctx, author.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, err := s.Book.GetAllBook(ctx)
+		if err != nil || !reflect.DeepEqual(res, []entities.Book{kept}) {
+			t.Errorf("Failed. Expected only the books of other authors to be kept %v Got %v, %v",
+				[]entities.Book{kept}, res, err)
+		}
+	})
+}
+
+// mustCreate creates v with the create method of a store and returns what the store returns
+func mustCreate[T any](t *testing.T, create func(ctx context.Context, v T) (T, error), v T) T {
+	t.Helper()
+
+	created, err := create(context.Background(), v)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the %T", err, v)
+	}
+
+	return created
+}
+
+func newAuthor(firstName string) entities.Author {
+	return entities.Author{FirstName: firstName, LastName: "Verma", Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"}
+}
+
+func newBook(title string, authorID int) entities.Book {
+	return entities.Book{Title: title, Author: entities.Author{ID: authorID}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+}
+
+func expectNotFound(t *testing.T, err error, entity string) {
+	t.Helper()
+
+	var notFound errors.EntityNotFound
+	if !stdErrors.As(err, &notFound) || notFound.Entity != entity {
+		t.Errorf("Failed. Expected %s not found Got %v", entity, err)
+	}
+}
+
+func expectExistAlready(t *testing.T, err error, entity, field string) {
+	t.Helper()
+
+	exp := errors.ExistAlready{Entity: entity, Field: field}
+	if !reflect.DeepEqual(err, exp) {
+		t.Errorf("Failed. Expected %v Got %v", exp, err)
+	}
+}
+
+func expectPreconditionFailed(t *testing.T, err error, entity string, id int) {
+	t.Helper()
+
+	exp := errors.PreconditionFailed{Entity: entity, ID: id}
+	if !reflect.DeepEqual(err, exp) {
+		t.Errorf("Failed. Expected %v Got %v", exp, err)
+	}
+}
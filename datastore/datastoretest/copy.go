@@ -0,0 +1,216 @@
+package datastoretest
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// RunCopy checks the CRUD semantics of datastore.Copy along with the counts of the copies of the books
+func RunCopy(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	// newStoresWithBook returns the stores along with the id of a new book
+	newStoresWithBook := func(t *testing.T) (Stores, int) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		return s, mustCreate(t, s.Book.CreateBook, newBook("first", author.ID)).ID
+	}
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		first := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+		second := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-2", entities.CopyAvailable))
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		if first.Barcode != "B-1" || first.BookID != bookID || first.Version != 1 {
+			t.Errorf("Failed. Expected the created copy to be returned with version 1 Got %v", first)
+		}
+	})
+
+	t.Run("CopyNeedsBook", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		_, err := s.Copy.CreateCopy(ctx, newCopy(bookID+1000, "B-1", entities.CopyAvailable))
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a copy whose book does not exist")
+		}
+	})
+
+	t.Run("BarcodeIsUnique", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		first := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+
+		_, err := s.Copy.CreateCopy(ctx, newCopy(bookID, "B-1", entities.CopyAvailable))
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a barcode which is taken")
+		}
+
+		second := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-2", entities.CopyAvailable))
+		second.Barcode = first.Barcode
+
+		if _, err = s.Copy.UpdateCopy(ctx, second.ID, second); err == nil {
+			t.Errorf("Failed. Expected an error for an update to a barcode which is taken")
+		}
+	})
+
+	t.Run("GetCopies", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		res, err := s.Copy.GetCopies(ctx, bookID)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no copies Got %v, %v", res, err)
+		}
+
+		other, _ := s.Book.GetBookByID(ctx, bookID)
+		other.ID = 0
+		other.Title = "second"
+
+		other, err = s.Book.CreateBook(ctx, other)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when creating a book", err)
+		}
+
+		first := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+		mustCreate(t, s.Copy.CreateCopy, newCopy(other.ID, "B-2", entities.CopyAvailable))
+		third := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-3", entities.CopyLost))
+
+		res, err = s.Copy.GetCopies(ctx, bookID)
+		if err != nil || !reflect.DeepEqual(res, []entities.Copy{first, third}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Copy{first, third}, res, err)
+		}
+	})
+
+	t.Run("GetCopy", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+
+		res, err := s.Copy.GetCopyByID(ctx, cp.ID)
+		if err != nil || res != cp {
+			t.Errorf("Failed. Expected %v Got %v, %v", cp, res, err)
+		}
+
+		res, err = s.Copy.GetCopyByBarcode(ctx, "B-1")
+		if err != nil || res != cp {
+			t.Errorf("Failed. Expected %v Got %v, %v", cp, res, err)
+		}
+
+		_, err = s.Copy.GetCopyByID(ctx, cp.ID+1000)
+		expectNotFound(t, err, "Copy")
+
+		_, err = s.Copy.GetCopyByBarcode(ctx, "B-2")
+		expectNotFound(t, err, "Copy")
+	})
+
+	t.Run("UpdateCopy", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+
+		update := newCopy(bookID, "B-2", entities.CopyWithdrawn)
+		update.Version = cp.Version
+
+		res, err := s.Copy.UpdateCopy(ctx, cp.ID, update)
+		update.ID, update.Version = cp.ID, cp.Version+1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Copy.GetCopyByID(ctx, cp.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the copy is updated only while it has the version it is updated with
+		_, err = s.Copy.UpdateCopy(ctx, cp.ID, cp)
+		expectPreconditionFailed(t, err, "Copy", cp.ID)
+
+		_, err = s.Copy.UpdateCopy(ctx, cp.ID+1000, update)
+		expectNotFound(t, err, "Copy")
+	})
+
+	t.Run("UpdateCopyStatus", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+
+		if err := s.Copy.UpdateCopyStatus(ctx, cp.ID, entities.CopyAvailable, entities.CopyOnLoan); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		err := s.Copy.UpdateCopyStatus(ctx, cp.ID, entities.CopyAvailable, entities.CopyOnLoan)
+		expectNotFound(t, err, "Copy")
+
+		cp.Status, cp.Version = entities.CopyOnLoan, cp.Version+1
+
+		if res, err := s.Copy.GetCopyByID(ctx, cp.ID); err != nil || res != cp {
+			t.Errorf("Failed. Expected only the status and the version to change %v Got %v, %v", cp, res, err)
+		}
+	})
+
+	t.Run("DeleteCopy", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+
+		if err := s.Copy.DeleteCopy(ctx, cp.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err := s.Copy.GetCopyByID(ctx, cp.ID)
+		expectNotFound(t, err, "Copy")
+
+		err = s.Copy.DeleteCopy(ctx, cp.ID)
+		expectNotFound(t, err, "Copy")
+	})
+
+	t.Run("GetAvailability", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		for i, status := range []string{entities.CopyAvailable, entities.CopyAvailable, entities.CopyOnLoan,
+			entities.CopyOnHold, entities.CopyLost, entities.CopyWithdrawn} {
+			mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, fmt.Sprintf("B-%d", i), status))
+		}
+
+		exp := map[int]entities.Availability{bookID: {Total: 4, Available: 2, OnLoan: 1, OnHold: 1}}
+
+		res, err := s.Copy.GetAvailability(ctx, []int{bookID, bookID + 1000})
+		if err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res, err)
+		}
+
+		res, err = s.Copy.GetAvailability(ctx, nil)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no counts Got %v, %v", res, err)
+		}
+	})
+
+	t.Run("DeleteBookDeletesCopies", func(t *testing.T) {
+		s, bookID := newStoresWithBook(t)
+
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(bookID, "B-1", entities.CopyAvailable))
+
+		if err := s.Book.DeleteBook(ctx, bookID); err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err := s.Copy.GetCopyByID(ctx, cp.ID)
+		expectNotFound(t, err, "Copy")
+	})
+}
+
+func newCopy(bookID int, barcode, status string) entities.Copy {
+	return entities.Copy{BookID: bookID, Barcode: barcode, Condition: entities.ConditionGood, ShelfLocation: "A-12",
+		AcquiredOn: entities.NewDate(2020, 2, 1), Status: status}
+}
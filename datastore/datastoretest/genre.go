@@ -0,0 +1,278 @@
+package datastoretest
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunGenre checks the CRUD semantics of datastore.Genre along with the tagging of the books and their facets
+func RunGenre(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		mystery := mustCreate(t, s.Genre.CreateGenre, newGenre("Mystery", entities.KindGenre, fiction.ID))
+
+		if fiction.ID <= 0 || mystery.ID <= 0 || fiction.ID == mystery.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", fiction.ID, mystery.ID)
+		}
+
+		res, err := s.Genre.GetGenreByID(ctx, mystery.ID)
+		if err != nil || res != mystery || res.ParentID != fiction.ID || res.Version != 1 {
+			t.Errorf("Failed. Expected %v with version 1 Got %v, %v", mystery, res, err)
+		}
+	})
+
+	t.Run("NameIsUniquePerKind", func(t *testing.T) {
+		s := newStores(t)
+
+		mustCreate(t, s.Genre.CreateGenre, newGenre("History", entities.KindGenre, 0))
+		mustCreate(t, s.Genre.CreateGenre, newGenre("History", entities.KindSubject, 0))
+
+		_, err := s.Genre.CreateGenre(ctx, entities.Genre{Name: "History", Kind: entities.KindGenre})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a name which is taken")
+		}
+	})
+
+	t.Run("ParentMustExist", func(t *testing.T) {
+		s := newStores(t)
+
+		_, err := s.Genre.CreateGenre(ctx, entities.Genre{Name: "Mystery", Kind: entities.KindGenre, ParentID: 1000})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a parent which does not exist")
+		}
+	})
+
+	t.Run("GetGenres", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		mystery := mustCreate(t, s.Genre.CreateGenre, newGenre("Mystery", entities.KindGenre, fiction.ID))
+
+		res, err := s.Genre.GetGenres(ctx)
+		if err != nil || !reflect.DeepEqual(res, []entities.Genre{fiction, mystery}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Genre{fiction, mystery}, res, err)
+		}
+
+		_, err = s.Genre.GetGenreByID(ctx, mystery.ID+1000)
+		expectNotFound(t, err, "Genre")
+	})
+
+	t.Run("UpdateGenre", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		crime := mustCreate(t, s.Genre.CreateGenre, newGenre("Crime", entities.KindGenre, 0))
+
+		update := entities.Genre{Name: "Crime Fiction", Kind: entities.KindGenre, ParentID: fiction.ID}
+		update.Version = crime.Version
+
+		res, err := s.Genre.UpdateGenre(ctx, crime.ID, update)
+		update.ID, update.Version = crime.ID, crime.Version+1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Genre.GetGenreByID(ctx, crime.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the genre is updated only while it has the version it is updated with
+		_, err = s.Genre.UpdateGenre(ctx, crime.ID, crime)
+		expectPreconditionFailed(t, err, "Genre", crime.ID)
+
+		_, err = s.Genre.UpdateGenre(ctx, crime.ID+1000, update)
+		expectNotFound(t, err, "Genre")
+	})
+
+	t.Run("DeleteGenre", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		mystery := mustCreate(t, s.Genre.CreateGenre, newGenre("Mystery", entities.KindGenre, fiction.ID))
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		book.Genres = []entities.Genre{{ID: mystery.ID}}
+
+		if _, err := s.Book.UpdateBookFields(ctx, book.ID, book, []string{entities.BookGenres}); err != nil {
+			t.Fatalf("an error '%s' was not expected when tagging a book", err)
+		}
+
+		if err := s.Genre.DeleteGenre(ctx, fiction.ID); err == nil {
+			t.Errorf("Failed. Expected an error for a genre having children")
+		}
+
+		if err := s.Genre.DeleteGenre(ctx, mystery.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, err := s.Book.GetBookGenres(ctx, []int{book.ID})
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected the tags of the genre to be removed Got %v, %v", res, err)
+		}
+
+		_, err = s.Genre.GetGenreByID(ctx, mystery.ID)
+		expectNotFound(t, err, "Genre")
+
+		err = s.Genre.DeleteGenre(ctx, mystery.ID)
+		expectNotFound(t, err, "Genre")
+	})
+
+	t.Run("BookGenres", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		mystery := mustCreate(t, s.Genre.CreateGenre, newGenre("Mystery", entities.KindGenre, fiction.ID))
+		history := mustCreate(t, s.Genre.CreateGenre, newGenre("History", entities.KindSubject, 0))
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		book, err := s.Book.CreateBook(ctx, entities.Book{Title: "first", Author: author,
+			Publisher: entities.Publisher{ID: 3}, Genres: []entities.Genre{{ID: history.ID}, {ID: mystery.ID}}})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when creating a book", err)
+		}
+
+		untagged := mustCreate(t, s.Book.CreateBook, newBook("second", author.ID))
+
+		res, err := s.Book.GetBookGenres(ctx, []int{book.ID, untagged.ID})
+		exp := map[int][]entities.Genre{book.ID: {mystery, history}}
+
+		if err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res, err)
+		}
+
+		book.Genres = []entities.Genre{{ID: fiction.ID}}
+
+		if _, err = s.Book.UpdateBook(ctx, book.ID, book); err != nil {
+			t.Fatalf("an error '%s' was not expected when updating a book", err)
+		}
+
+		res, _ = s.Book.GetBookGenres(ctx, []int{book.ID})
+		if !reflect.DeepEqual(res, map[int][]entities.Genre{book.ID: {fiction}}) {
+			t.Errorf("Failed. Expected the genres to be replaced by %v Got %v", fiction, res)
+		}
+
+		untagged.Genres = []entities.Genre{{ID: mystery.ID + 1000}}
+
+		_, err = s.Book.UpdateBookFields(ctx, untagged.ID, untagged, []string{entities.BookGenres})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a genre which does not exist")
+		}
+	})
+
+	t.Run("GetBooksByGenre", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		mystery := mustCreate(t, s.Genre.CreateGenre, newGenre("Mystery", entities.KindGenre, fiction.ID))
+		history := mustCreate(t, s.Genre.CreateGenre, newGenre("History", entities.KindSubject, 0))
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		b1 := mustCreateTaggedBook(t, s.Book, author.ID, 1, entities.NewDate(1985, 1, 1), mystery, history)
+		b2 := mustCreateTaggedBook(t, s.Book, author.ID, 2, entities.NewDate(1999, 1, 1), fiction)
+		mustCreateTaggedBook(t, s.Book, author.ID, 1, entities.NewDate(2003, 1, 1), history)
+
+		testcases := []struct {
+			desc     string
+			filter   entities.BookFilter
+			expBooks []entities.Book
+		}{
+			{"by a genre and its descendants", entities.BookFilter{GenreID: fiction.ID,
+				GenreIDs: []int{fiction.ID, mystery.ID}, Limit: 10}, []entities.Book{b1, b2}},
+			{"by a leaf genre", entities.BookFilter{GenreID: mystery.ID, Limit: 10}, []entities.Book{b1}},
+			{"by genre and publisher", entities.BookFilter{GenreIDs: []int{fiction.ID, mystery.ID}, PublisherID: 2,
+				Limit: 10}, []entities.Book{b2}},
+		}
+
+		for i, tc := range testcases {
+			page, err := s.Book.GetBooks(ctx, tc.filter)
+			if err != nil || !reflect.DeepEqual(page.Books, tc.expBooks) || page.Total != len(tc.expBooks) {
+				t.Errorf("[TEST%d]Failed. %s Expected %v Got %v, %v", i, tc.desc, tc.expBooks, page.Books, err)
+			}
+		}
+	})
+
+	t.Run("GetBookFacets", func(t *testing.T) {
+		s := newStores(t)
+
+		fiction := mustCreate(t, s.Genre.CreateGenre, newGenre("Fiction", entities.KindGenre, 0))
+		mystery := mustCreate(t, s.Genre.CreateGenre, newGenre("Mystery", entities.KindGenre, fiction.ID))
+		history := mustCreate(t, s.Genre.CreateGenre, newGenre("History", entities.KindSubject, 0))
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		mustCreateTaggedBook(t, s.Book, author.ID, 1, entities.NewDate(1985, 1, 1), mystery, fiction, history)
+		mustCreateTaggedBook(t, s.Book, author.ID, 2, entities.NewDate(1989, 1, 1), mystery)
+		mustCreateTaggedBook(t, s.Book, author.ID, 1, entities.NewDate(2003, 1, 1), history)
+		mustCreateTaggedBook(t, s.Book, author.ID, 1, entities.Date{})
+
+		testcases := []struct {
+			desc          string
+			filter        entities.BookFilter
+			expGenres     map[int]int
+			expPublishers map[int]int
+			expDecades    map[int]int
+		}{
+			{"all books", entities.BookFilter{}, map[int]int{fiction.ID: 2, mystery.ID: 2, history.ID: 2},
+				map[int]int{1: 3, 2: 1}, map[int]int{1980: 2, 2000: 1}},
+			{"books of a genre", entities.BookFilter{GenreIDs: []int{history.ID}},
+				map[int]int{fiction.ID: 1, mystery.ID: 1, history.ID: 2}, map[int]int{1: 2},
+				map[int]int{1980: 1, 2000: 1}},
+			{"no match", entities.BookFilter{Title: "Missing"}, map[int]int{}, map[int]int{}, map[int]int{}},
+		}
+
+		for i, tc := range testcases {
+			facets, err := s.Book.GetBookFacets(ctx, tc.filter)
+			if err != nil {
+				t.Errorf("[TEST%d]Failed. Expected error to be nil Got %v", i, err)
+			}
+
+			// the order of the facets is up to the service
+			genreCounts, publisherCounts, decadeCounts := map[int]int{}, map[int]int{}, map[int]int{}
+
+			for _, f := range facets.Genres {
+				genreCounts[f.ID] = f.Count
+			}
+
+			for _, f := range facets.Publishers {
+				publisherCounts[f.ID] = f.Count
+			}
+
+			for _, f := range facets.Decades {
+				decadeCounts[f.Decade] = f.Count
+			}
+
+			if !reflect.DeepEqual(genreCounts, tc.expGenres) || !reflect.DeepEqual(publisherCounts, tc.expPublishers) ||
+				!reflect.DeepEqual(decadeCounts, tc.expDecades) {
+				t.Errorf("[TEST%d]Failed. %s Expected %v, %v, %v Got %v, %v, %v", i, tc.desc, tc.expGenres,
+					tc.expPublishers, tc.expDecades, genreCounts, publisherCounts, decadeCounts)
+			}
+		}
+	})
+}
+
+func newGenre(name, kind string, parentID int) entities.Genre {
+	return entities.Genre{Name: name, Kind: kind, ParentID: parentID}
+}
+
+// mustCreateTaggedBook creates a book of the publisher with given id tagged with the genres, the book is returned
+// without its genres as the stores read it
+func mustCreateTaggedBook(t *testing.T, books datastore.Book, authorID, publisherID int, date entities.Date,
+	genres ...entities.Genre) entities.Book {
+	t.Helper()
+
+	book := mustCreate(t, books.CreateBook, entities.Book{Title: "tagged", Author: entities.Author{ID: authorID},
+		Publisher: entities.Publisher{ID: publisherID}, PublishedDate: date, Genres: genres})
+	book.Genres = nil
+
+	return book
+}
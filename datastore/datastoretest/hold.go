@@ -0,0 +1,172 @@
+package datastoretest
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunHold checks the semantics of datastore.Hold along with the holds removed with the books and the members
+// and kept without the copy when the copy is deleted
+func RunHold(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	// newHolds returns the stores along with a waiting hold of a new book by a new member which is not stored yet
+	newHolds := func(t *testing.T) (Stores, entities.Hold) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+
+		return s, entities.Hold{BookID: book.ID, MemberID: member.ID, Status: entities.HoldWaiting,
+			PlacedOn: entities.NewDate(2022, 3, 1)}
+	}
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s, hold := newHolds(t)
+
+		first := mustCreate(t, s.Hold.CreateHold, hold)
+		second := mustCreate(t, s.Hold.CreateHold, hold)
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		hold.ID, hold.Version = first.ID, 1
+		if first != hold {
+			t.Errorf("Failed. Expected the created hold to be returned with version 1 %v Got %v", hold, first)
+		}
+
+		res, err := s.Hold.GetHoldByID(ctx, first.ID)
+		if err != nil || res != hold {
+			t.Errorf("Failed. Expected the hold without a copy to be read back %v Got %v, %v", hold, res, err)
+		}
+	})
+
+	t.Run("HoldNeedsBookAndMember", func(t *testing.T) {
+		s, hold := newHolds(t)
+
+		missingBook := hold
+		missingBook.BookID += 1000
+
+		if _, err := s.Hold.CreateHold(ctx, missingBook); err == nil {
+			t.Errorf("Failed. Expected an error for a hold whose book does not exist")
+		}
+
+		missingMember := hold
+		missingMember.MemberID += 1000
+
+		if _, err := s.Hold.CreateHold(ctx, missingMember); err == nil {
+			t.Errorf("Failed. Expected an error for a hold whose member does not exist")
+		}
+	})
+
+	t.Run("GetHolds", func(t *testing.T) {
+		s, hold := newHolds(t)
+
+		res, err := s.Hold.GetBookHolds(ctx, hold.BookID)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected an empty queue Got %v, %v", res, err)
+		}
+
+		cancelled := hold
+		cancelled.Status = entities.HoldCancelled
+
+		ready := hold
+		ready.CopyID = mustCreate(t, s.Copy.CreateCopy, newCopy(hold.BookID, "B-1", entities.CopyOnHold)).ID
+		ready.Status = entities.HoldReady
+		ready.ReadyOn, ready.ExpiresOn = entities.NewDate(2022, 3, 5), entities.NewDate(2022, 3, 8)
+
+		other := hold
+		other.MemberID = mustCreate(t, s.Member.CreateMember, newMember("hc@example.com")).ID
+
+		first := mustCreate(t, s.Hold.CreateHold, cancelled)
+		second := mustCreate(t, s.Hold.CreateHold, ready)
+		third := mustCreate(t, s.Hold.CreateHold, other)
+
+		res, err = s.Hold.GetBookHolds(ctx, hold.BookID)
+		if exp := []entities.Hold{second, third}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected the queue of the book in order %v Got %v, %v", exp, res, err)
+		}
+
+		res, err = s.Hold.GetMemberHolds(ctx, hold.MemberID)
+		if exp := []entities.Hold{second}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected the queued holds of the member %v Got %v, %v", exp, res, err)
+		}
+
+		res, err = s.Hold.GetHoldsByStatus(ctx, entities.HoldCancelled)
+		if exp := []entities.Hold{first}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected the cancelled holds %v Got %v, %v", exp, res, err)
+		}
+	})
+
+	t.Run("UpdateHold", func(t *testing.T) {
+		s, hold := newHolds(t)
+
+		created := mustCreate(t, s.Hold.CreateHold, hold)
+
+		update := created
+		update.CopyID = mustCreate(t, s.Copy.CreateCopy, newCopy(hold.BookID, "B-1", entities.CopyOnHold)).ID
+		update.Status = entities.HoldReady
+		update.ReadyOn, update.ExpiresOn = entities.NewDate(2022, 3, 5), entities.NewDate(2022, 3, 8)
+
+		res, err := s.Hold.UpdateHold(ctx, created.ID, update)
+		update.Version = created.Version + 1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Hold.GetHoldByID(ctx, created.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the hold is updated only while it has the version it is updated with
+		_, err = s.Hold.UpdateHold(ctx, created.ID, created)
+		expectPreconditionFailed(t, err, "Hold", created.ID)
+
+		_, err = s.Hold.UpdateHold(ctx, created.ID+1000, update)
+		expectNotFound(t, err, "Hold")
+
+		_, err = s.Hold.GetHoldByID(ctx, created.ID+1000)
+		expectNotFound(t, err, "Hold")
+	})
+
+	t.Run("HoldsFollowDeletes", func(t *testing.T) {
+		s, hold := newHolds(t)
+
+		hold.CopyID = mustCreate(t, s.Copy.CreateCopy, newCopy(hold.BookID, "B-1", entities.CopyOnHold)).ID
+		hold.Status = entities.HoldReady
+
+		first := mustCreate(t, s.Hold.CreateHold, hold)
+
+		if err := s.Copy.DeleteCopy(ctx, hold.CopyID); err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		res, err := s.Hold.GetHoldByID(ctx, first.ID)
+		if err != nil || res.CopyID != 0 {
+			t.Errorf("Failed. Expected the hold to be kept without the copy Got %v, %v", res, err)
+		}
+
+		if err = s.Member.DeleteMember(ctx, hold.MemberID); err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err = s.Hold.GetHoldByID(ctx, first.ID)
+		expectNotFound(t, err, "Hold")
+
+		hold.CopyID, hold.MemberID = 0, mustCreate(t, s.Member.CreateMember, newMember("hc@example.com")).ID
+		second := mustCreate(t, s.Hold.CreateHold, hold)
+
+		if err = s.Book.DeleteBook(ctx, hold.BookID); err != nil {
+			t.Fatalf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err = s.Hold.GetHoldByID(ctx, second.ID)
+		expectNotFound(t, err, "Hold")
+	})
+}
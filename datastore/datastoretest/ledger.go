@@ -0,0 +1,120 @@
+package datastoretest
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunLedger checks the semantics of datastore.Ledger along with the entries removed with the members
+// and kept with their loans
+func RunLedger(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	// newFine returns the stores along with a fine for a new loan of a new member which is not stored yet
+	newFine := func(t *testing.T) (Stores, entities.LedgerEntry) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(book.ID, "B-1", entities.CopyAvailable))
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+		loan := mustCreate(t, s.Loan.CreateLoan, entities.Loan{CopyID: cp.ID, MemberID: member.ID, BookID: book.ID,
+			CheckedOutOn: entities.NewDate(2022, 3, 1), DueOn: entities.NewDate(2022, 3, 15),
+			ReturnedOn: entities.NewDate(2022, 3, 20)})
+
+		return s, entities.LedgerEntry{MemberID: member.ID, LoanID: loan.ID, Type: entities.LedgerFine, Amount: 300,
+			CreatedOn: entities.NewDate(2022, 3, 20)}
+	}
+
+	t.Run("GetEntries", func(t *testing.T) {
+		s, fine := newFine(t)
+
+		res, err := s.Ledger.GetEntries(ctx, fine.MemberID)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no entries Got %v, %v", res, err)
+		}
+
+		payment := entities.LedgerEntry{MemberID: fine.MemberID, Type: entities.LedgerPayment, Amount: 100,
+			Note: "cash", CreatedOn: entities.NewDate(2022, 3, 21)}
+
+		first := mustCreate(t, s.Ledger.CreateEntry, fine)
+		second := mustCreate(t, s.Ledger.CreateEntry, payment)
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		other := mustCreate(t, s.Member.CreateMember, newMember("hc@example.com"))
+		mustCreate(t, s.Ledger.CreateEntry, entities.LedgerEntry{MemberID: other.ID, Type: entities.LedgerFine,
+			Amount: 500, CreatedOn: entities.NewDate(2022, 3, 21)})
+
+		fine.ID, payment.ID = first.ID, second.ID
+
+		res, err = s.Ledger.GetEntries(ctx, fine.MemberID)
+		if exp := []entities.LedgerEntry{fine, payment}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected the entries of the member in order %v Got %v, %v", exp, res, err)
+		}
+	})
+
+	t.Run("GetBalance", func(t *testing.T) {
+		s, fine := newFine(t)
+
+		balance, err := s.Ledger.GetBalance(ctx, fine.MemberID)
+		if err != nil || balance != 0 {
+			t.Errorf("Failed. Expected an empty account to have no balance Got %v, %v", balance, err)
+		}
+
+		mustCreate(t, s.Ledger.CreateEntry, fine)
+		mustCreate(t, s.Ledger.CreateEntry, entities.LedgerEntry{MemberID: fine.MemberID, Type: entities.LedgerPayment,
+			Amount: 100, CreatedOn: entities.NewDate(2022, 3, 21)})
+		mustCreate(t, s.Ledger.CreateEntry, entities.LedgerEntry{MemberID: fine.MemberID, Type: entities.LedgerWaiver,
+			Amount: 50, CreatedOn: entities.NewDate(2022, 3, 21)})
+
+		balance, err = s.Ledger.GetBalance(ctx, fine.MemberID)
+		if err != nil || balance != 150 {
+			t.Errorf("Failed. Expected the fines less the payments and the waivers 150 Got %v, %v", balance, err)
+		}
+	})
+
+	t.Run("EntryNeedsMember", func(t *testing.T) {
+		s, fine := newFine(t)
+
+		fine.MemberID += 1000
+
+		if _, err := s.Ledger.CreateEntry(ctx, fine); err == nil {
+			t.Errorf("Failed. Expected an error for an entry whose member does not exist")
+		}
+	})
+
+	t.Run("EntriesAreKept", func(t *testing.T) {
+		s, fine := newFine(t)
+
+		loan, _ := s.Loan.GetLoanByID(ctx, fine.LoanID)
+		created := mustCreate(t, s.Ledger.CreateEntry, fine)
+
+		if err := s.Copy.DeleteCopy(ctx, loan.CopyID); err == nil {
+			t.Errorf("Failed. Expected an error for a copy which has been lent")
+		}
+
+		res, err := s.Ledger.GetEntries(ctx, fine.MemberID)
+		if exp := []entities.LedgerEntry{created}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected the fine to be kept with the loan %v Got %v, %v", exp, res, err)
+		}
+
+		// a member who has never borrowed a copy is kept along with the payments
+		payment := entities.LedgerEntry{MemberID: mustCreate(t, s.Member.CreateMember, newMember("hc@example.com")).ID,
+			Type: entities.LedgerPayment, Amount: 100, CreatedOn: entities.NewDate(2022, 3, 21)}
+		payment = mustCreate(t, s.Ledger.CreateEntry, payment)
+
+		if err = s.Member.DeleteMember(ctx, payment.MemberID); err == nil {
+			t.Errorf("Failed. Expected an error for a member who has ledger entries")
+		}
+
+		res, err = s.Ledger.GetEntries(ctx, payment.MemberID)
+		if exp := []entities.LedgerEntry{payment}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected the payment to be kept %v Got %v, %v", exp, res, err)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package datastoretest
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunLoan checks the semantics of datastore.Loan along with the loans keeping their copies, members and books
+func RunLoan(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	// newLoans returns the stores along with a loan of a new copy to a new member which is not stored yet
+	newLoans := func(t *testing.T) (Stores, entities.Loan) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		book := mustCreate(t, s.Book.CreateBook, newBook("first", author.ID))
+		cp := mustCreate(t, s.Copy.CreateCopy, newCopy(book.ID, "B-1", entities.CopyOnLoan))
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+
+		return s, entities.Loan{CopyID: cp.ID, MemberID: member.ID, BookID: book.ID,
+			CheckedOutOn: entities.NewDate(2022, 3, 1), DueOn: entities.NewDate(2022, 3, 15)}
+	}
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s, loan := newLoans(t)
+
+		first := mustCreate(t, s.Loan.CreateLoan, loan)
+		second := mustCreate(t, s.Loan.CreateLoan, loan)
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		loan.ID, loan.Version = first.ID, 1
+		if first != loan {
+			t.Errorf("Failed. Expected the created loan to be returned with version 1 %v Got %v", loan, first)
+		}
+	})
+
+	t.Run("LoanNeedsCopyAndMember", func(t *testing.T) {
+		s, loan := newLoans(t)
+
+		missingCopy := loan
+		missingCopy.CopyID += 1000
+
+		if _, err := s.Loan.CreateLoan(ctx, missingCopy); err == nil {
+			t.Errorf("Failed. Expected an error for a loan whose copy does not exist")
+		}
+
+		missingMember := loan
+		missingMember.MemberID += 1000
+
+		if _, err := s.Loan.CreateLoan(ctx, missingMember); err == nil {
+			t.Errorf("Failed. Expected an error for a loan whose member does not exist")
+		}
+	})
+
+	t.Run("GetLoans", func(t *testing.T) {
+		s, loan := newLoans(t)
+
+		res, err := s.Loan.GetActiveLoans(ctx, loan.MemberID)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no loans Got %v, %v", res, err)
+		}
+
+		returned := loan
+		returned.ReturnedOn = entities.NewDate(2022, 3, 10)
+
+		other := loan
+		other.MemberID = mustCreate(t, s.Member.CreateMember, newMember("hc@example.com")).ID
+
+		first := mustCreate(t, s.Loan.CreateLoan, returned)
+		second := mustCreate(t, s.Loan.CreateLoan, loan)
+		third := mustCreate(t, s.Loan.CreateLoan, other)
+
+		res, err = s.Loan.GetActiveLoans(ctx, loan.MemberID)
+		if err != nil || !reflect.DeepEqual(res, []entities.Loan{second}) {
+			t.Errorf("Failed. Expected the active loans of the member %v Got %v, %v", []entities.Loan{second}, res, err)
+		}
+
+		res, err = s.Loan.GetLoansByBook(ctx, loan.BookID)
+		if exp := []entities.Loan{first, second, third}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected all the loans of the book %v Got %v, %v", exp, res, err)
+		}
+
+		res, err = s.Loan.GetMemberLoans(ctx, loan.MemberID)
+		if exp := []entities.Loan{first, second}; err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected all the loans of the member %v Got %v, %v", exp, res, err)
+		}
+	})
+
+	t.Run("UpdateLoan", func(t *testing.T) {
+		s, loan := newLoans(t)
+
+		created := mustCreate(t, s.Loan.CreateLoan, loan)
+
+		update := created
+		update.DueOn, update.ReturnedOn = entities.NewDate(2022, 3, 29), entities.NewDate(2022, 3, 20)
+		update.Renewals = 1
+
+		res, err := s.Loan.UpdateLoan(ctx, created.ID, update)
+		update.Version = created.Version + 1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Loan.GetLoanByID(ctx, created.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the loan is updated only while it has the version it is updated with
+		_, err = s.Loan.UpdateLoan(ctx, created.ID, created)
+		expectPreconditionFailed(t, err, "Loan", created.ID)
+
+		_, err = s.Loan.UpdateLoan(ctx, created.ID+1000, update)
+		expectNotFound(t, err, "Loan")
+
+		_, err = s.Loan.GetLoanByID(ctx, created.ID+1000)
+		expectNotFound(t, err, "Loan")
+	})
+
+	t.Run("LoansKeepCopyMemberAndBook", func(t *testing.T) {
+		s, loan := newLoans(t)
+
+		loan.ReturnedOn = entities.NewDate(2022, 3, 10)
+		returned := mustCreate(t, s.Loan.CreateLoan, loan)
+
+		if err := s.Copy.DeleteCopy(ctx, loan.CopyID); err == nil {
+			t.Errorf("Failed. Expected an error for a copy which has been lent")
+		}
+
+		if err := s.Member.DeleteMember(ctx, loan.MemberID); err == nil {
+			t.Errorf("Failed. Expected an error for a member who has borrowed a copy")
+		}
+
+		if err := s.Book.DeleteBook(ctx, loan.BookID); err == nil {
+			t.Errorf("Failed. Expected an error for a book whose copy has been lent")
+		}
+
+		res, err := s.Loan.GetLoanByID(ctx, returned.ID)
+		if err != nil || res != returned {
+			t.Errorf("Failed. Expected the returned loan to be kept %v Got %v, %v", returned, res, err)
+		}
+
+		if _, err = s.Copy.GetCopyByID(ctx, loan.CopyID); err != nil {
+			t.Errorf("Failed. Expected the copy to be kept Got %v", err)
+		}
+
+		if _, err = s.Member.GetMemberByID(ctx, loan.MemberID); err != nil {
+			t.Errorf("Failed. Expected the member to be kept Got %v", err)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package datastoretest
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunMember checks the CRUD semantics of datastore.Member
+func RunMember(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		first := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+		second := mustCreate(t, s.Member.CreateMember, newMember("hc@example.com"))
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		if first.Email != "mg@example.com" || first.Version != 1 {
+			t.Errorf("Failed. Expected the created member to be returned with version 1 Got %v", first)
+		}
+	})
+
+	t.Run("EmailIsUnique", func(t *testing.T) {
+		s := newStores(t)
+
+		first := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+
+		_, err := s.Member.CreateMember(ctx, newMember("mg@example.com"))
+		if err == nil {
+			t.Errorf("Failed. Expected an error for an email which is taken")
+		}
+
+		second := mustCreate(t, s.Member.CreateMember, newMember("hc@example.com"))
+		second.Email = first.Email
+
+		if _, err = s.Member.UpdateMember(ctx, second.ID, second); err == nil {
+			t.Errorf("Failed. Expected an error for an update to an email which is taken")
+		}
+	})
+
+	t.Run("GetMembers", func(t *testing.T) {
+		s := newStores(t)
+
+		res, err := s.Member.GetMembers(ctx)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no members Got %v, %v", res, err)
+		}
+
+		first := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+		second := mustCreate(t, s.Member.CreateMember, newMember("hc@example.com"))
+
+		res, err = s.Member.GetMembers(ctx)
+		if err != nil || !reflect.DeepEqual(res, []entities.Member{first, second}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Member{first, second}, res, err)
+		}
+	})
+
+	t.Run("GetMemberByID", func(t *testing.T) {
+		s := newStores(t)
+
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+
+		res, err := s.Member.GetMemberByID(ctx, member.ID)
+		if err != nil || res != member {
+			t.Errorf("Failed. Expected %v Got %v, %v", member, res, err)
+		}
+
+		_, err = s.Member.GetMemberByID(ctx, member.ID+1000)
+		expectNotFound(t, err, "Member")
+	})
+
+	t.Run("GetMemberByEmail", func(t *testing.T) {
+		s := newStores(t)
+
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+		mustCreate(t, s.Member.CreateMember, newMember("hc@example.com"))
+
+		res, err := s.Member.GetMemberByEmail(ctx, "mg@example.com")
+		if err != nil || res != member {
+			t.Errorf("Failed. Expected %v Got %v, %v", member, res, err)
+		}
+
+		_, err = s.Member.GetMemberByEmail(ctx, "rahul@example.com")
+		expectNotFound(t, err, "Member")
+	})
+
+	t.Run("UpdateMember", func(t *testing.T) {
+		s := newStores(t)
+
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+
+		update := newMember("rahul@example.com")
+		update.Status = entities.MemberSuspended
+		update.Version = member.Version
+
+		res, err := s.Member.UpdateMember(ctx, member.ID, update)
+		update.ID, update.Version = member.ID, member.Version+1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Member.GetMemberByID(ctx, member.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the member is updated only while it has the version it is updated with
+		_, err = s.Member.UpdateMember(ctx, member.ID, member)
+		expectPreconditionFailed(t, err, "Member", member.ID)
+
+		_, err = s.Member.UpdateMember(ctx, member.ID+1000, update)
+		expectNotFound(t, err, "Member")
+	})
+
+	t.Run("DeleteMember", func(t *testing.T) {
+		s := newStores(t)
+
+		member := mustCreate(t, s.Member.CreateMember, newMember("mg@example.com"))
+
+		if err := s.Member.DeleteMember(ctx, member.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err := s.Member.GetMemberByID(ctx, member.ID)
+		expectNotFound(t, err, "Member")
+
+		err = s.Member.DeleteMember(ctx, member.ID)
+		expectNotFound(t, err, "Member")
+	})
+}
+
+func newMember(email string) entities.Member {
+	return entities.Member{FirstName: "Rahul", LastName: "Saini", Email: email, Phone: "+919876543210",
+		Address: "Bangalore", MembershipType: entities.MembershipStandard,
+		ExpiresOn: entities.NewDate(2030, 12, 31),
+		Status:    entities.MemberActive}
+}
@@ -0,0 +1,160 @@
+package datastoretest
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunPublisher checks the CRUD semantics of datastore.Publisher
+func RunPublisher(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		first := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+		second := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Harper"))
+
+		if first.ID <= 0 || second.ID <= 0 || first.ID == second.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", first.ID, second.ID)
+		}
+
+		if first.Name != "Oxford" || first.Version != 1 {
+			t.Errorf("Failed. Expected the created publisher to be returned with version 1 Got %v", first)
+		}
+	})
+
+	t.Run("NameIsUnique", func(t *testing.T) {
+		s := newStores(t)
+
+		first := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+
+		_, err := s.Publisher.CreatePublisher(ctx, entities.Publisher{Name: "Oxford"})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a name which is taken")
+		}
+
+		_, err = s.Publisher.CreatePublisher(ctx, entities.Publisher{Name: "OXFORD"})
+		if err == nil {
+			t.Errorf("Failed. Expected an error for a name which is taken in another case")
+		}
+
+		second := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Harper"))
+		second.Name = first.Name
+
+		if _, err = s.Publisher.UpdatePublisher(ctx, second.ID, second); err == nil {
+			t.Errorf("Failed. Expected an error for an update to a name which is taken")
+		}
+	})
+
+	t.Run("GetPublishers", func(t *testing.T) {
+		s := newStores(t)
+
+		res, err := s.Publisher.GetPublishers(ctx)
+		if err != nil || len(res) != 3 || res[0].Name != "Arihanth" {
+			t.Errorf("Failed. Expected the publishers added by the migrations Got %v, %v", res, err)
+		}
+
+		publisher := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+
+		res, err = s.Publisher.GetPublishers(ctx)
+		if err != nil || len(res) != 4 || res[3] != publisher {
+			t.Errorf("Failed. Expected %v to be listed last Got %v, %v", publisher, res, err)
+		}
+	})
+
+	t.Run("GetPublisherByID", func(t *testing.T) {
+		s := newStores(t)
+
+		publisher := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+
+		res, err := s.Publisher.GetPublisherByID(ctx, publisher.ID)
+		if err != nil || res != publisher {
+			t.Errorf("Failed. Expected %v Got %v, %v", publisher, res, err)
+		}
+
+		_, err = s.Publisher.GetPublisherByID(ctx, publisher.ID+1000)
+		expectNotFound(t, err, "Publisher")
+	})
+
+	t.Run("GetPublishersByIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		res, err := s.Publisher.GetPublishersByIDs(ctx, nil)
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected no publishers for no ids Got %v, %v", res, err)
+		}
+
+		first := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+		second := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Harper"))
+
+		res, err = s.Publisher.GetPublishersByIDs(ctx, []int{second.ID, second.ID + 1000, first.ID})
+		if err != nil || !reflect.DeepEqual(res, []entities.Publisher{first, second}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Publisher{first, second}, res, err)
+		}
+	})
+
+	t.Run("GetPublisherByName", func(t *testing.T) {
+		s := newStores(t)
+
+		publisher := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+
+		res, err := s.Publisher.GetPublisherByName(ctx, "OXFORD")
+		if err != nil || res != publisher {
+			t.Errorf("Failed. Expected the name to be matched ignoring the case Got %v, %v", res, err)
+		}
+
+		_, err = s.Publisher.GetPublisherByName(ctx, "Harper")
+		expectNotFound(t, err, "Publisher")
+	})
+
+	t.Run("UpdatePublisher", func(t *testing.T) {
+		s := newStores(t)
+
+		publisher := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+
+		update := entities.Publisher{Name: "Oxford University Press", Website: "https://global.oup.com"}
+		update.Version = publisher.Version
+
+		res, err := s.Publisher.UpdatePublisher(ctx, publisher.ID, update)
+		update.ID, update.Version = publisher.ID, publisher.Version+1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Publisher.GetPublisherByID(ctx, publisher.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the publisher is updated only while it has the version it is updated with
+		_, err = s.Publisher.UpdatePublisher(ctx, publisher.ID, publisher)
+		expectPreconditionFailed(t, err, "Publisher", publisher.ID)
+
+		_, err = s.Publisher.UpdatePublisher(ctx, publisher.ID+1000, update)
+		expectNotFound(t, err, "Publisher")
+	})
+
+	t.Run("DeletePublisher", func(t *testing.T) {
+		s := newStores(t)
+
+		publisher := mustCreate(t, s.Publisher.CreatePublisher, newPublisher("Oxford"))
+
+		if err := s.Publisher.DeletePublisher(ctx, publisher.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		_, err := s.Publisher.GetPublisherByID(ctx, publisher.ID)
+		expectNotFound(t, err, "Publisher")
+
+		err = s.Publisher.DeletePublisher(ctx, publisher.ID)
+		expectNotFound(t, err, "Publisher")
+	})
+}
+
+func newPublisher(name string) entities.Publisher {
+	return entities.Publisher{Name: name, Website: "https://example.com"}
+}
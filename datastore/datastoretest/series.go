@@ -0,0 +1,203 @@
+package datastoretest
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// RunSeries checks the CRUD semantics of datastore.Series along with the volumes of the series and the editions
+// of the books
+func RunSeries(t *testing.T, newStores StoresFactory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDs", func(t *testing.T) {
+		s := newStores(t)
+
+		dune := mustCreate(t, s.Series.CreateSeries, newSeries("Dune"))
+		foundation := mustCreate(t, s.Series.CreateSeries, newSeries("Foundation"))
+
+		if dune.ID <= 0 || foundation.ID <= 0 || dune.ID == foundation.ID {
+			t.Errorf("Failed. Expected distinct positive ids Got %v and %v", dune.ID, foundation.ID)
+		}
+
+		res, err := s.Series.GetSeriesByID(ctx, foundation.ID)
+		if err != nil || res != foundation || res.Version != 1 {
+			t.Errorf("Failed. Expected %v with version 1 Got %v, %v", foundation, res, err)
+		}
+
+		all, err := s.Series.GetSeries(ctx)
+		if err != nil || !reflect.DeepEqual(all, []entities.Series{dune, foundation}) {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Series{dune, foundation}, all, err)
+		}
+
+		_, err = s.Series.GetSeriesByID(ctx, foundation.ID+1000)
+		expectNotFound(t, err, "Series")
+	})
+
+	t.Run("UpdateSeries", func(t *testing.T) {
+		s := newStores(t)
+
+		dune := mustCreate(t, s.Series.CreateSeries, newSeries("Dune"))
+		update := entities.Series{Name: "Dune Chronicles"}
+		update.Version = dune.Version
+
+		res, err := s.Series.UpdateSeries(ctx, dune.ID, update)
+		update.ID, update.Version = dune.ID, dune.Version+1
+
+		if err != nil || res != update {
+			t.Errorf("Failed. Expected %v Got %v, %v", update, res, err)
+		}
+
+		res, _ = s.Series.GetSeriesByID(ctx, dune.ID)
+		if res != update {
+			t.Errorf("Failed. Expected the update to be stored %v Got %v", update, res)
+		}
+
+		// the series is updated only while it has the version it is updated with
+		_, err = s.Series.UpdateSeries(ctx, dune.ID, dune)
+		expectPreconditionFailed(t, err, "Series", dune.ID)
+
+		_, err = s.Series.UpdateSeries(ctx, dune.ID+1000, update)
+		expectNotFound(t, err, "Series")
+	})
+
+	t.Run("Volumes", func(t *testing.T) {
+		s := newStores(t)
+
+		dune := mustCreate(t, s.Series.CreateSeries, newSeries("Dune"))
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+
+		second := mustCreateVolume(t, s.Book, author.ID, dune.ID, 2)
+		first := mustCreateVolume(t, s.Book, author.ID, dune.ID, 1)
+		other := mustCreate(t, s.Book.CreateBook, newBook("other", author.ID))
+
+		res, err := s.Book.GetBookSeries(ctx, []int{first.ID, second.ID, other.ID})
+		exp := map[int]entities.SeriesVolume{first.ID: {ID: dune.ID, Name: "Dune", Volume: 1},
+			second.ID: {ID: dune.ID, Name: "Dune", Volume: 2}}
+
+		if err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res, err)
+		}
+
+		volumes, err := s.Series.GetVolumes(ctx, dune.ID)
+		if err != nil || !reflect.DeepEqual(volumes, map[int]int{1: first.ID, 2: second.ID}) {
+			t.Errorf("Failed. Expected the volumes %v Got %v, %v", map[int]int{1: first.ID, 2: second.ID}, volumes, err)
+		}
+
+		other.Series = &entities.SeriesVolume{ID: dune.ID, Volume: 2}
+
+		if _, err = s.Book.UpdateBookFields(ctx, other.ID, other, []string{entities.BookSeries}); err == nil {
+			t.Errorf("Failed. Expected an error for a volume which is taken")
+		}
+
+		other.Series = &entities.SeriesVolume{ID: dune.ID + 1000, Volume: 1}
+
+		if _, err = s.Book.UpdateBookFields(ctx, other.ID, other, []string{entities.BookSeries}); err == nil {
+			t.Errorf("Failed. Expected an error for a series which does not exist")
+		}
+
+		page, err := s.Book.GetBooks(ctx, entities.BookFilter{SeriesID: dune.ID, Sort: entities.SortByVolume, Limit: 10})
+		if err != nil || !reflect.DeepEqual(page.Books, []entities.Book{first, second}) || page.Total != 2 {
+			t.Errorf("Failed. Expected %v Got %v, %v", []entities.Book{first, second}, page.Books, err)
+		}
+
+		if err = s.Series.DeleteSeries(ctx, dune.ID); err == nil {
+			t.Errorf("Failed. Expected an error for a series having volumes")
+		}
+
+		second.Series = nil
+
+		if _, err = s.Book.UpdateBook(ctx, second.ID, second); err != nil {
+			t.Fatalf("an error '%s' was not expected when updating a book", err)
+		}
+
+		if err = s.Book.DeleteBook(ctx, first.ID); err != nil {
+			t.Fatalf("an error '%s' was not expected when deleting a book", err)
+		}
+
+		volumes, _ = s.Series.GetVolumes(ctx, dune.ID)
+		if len(volumes) != 0 {
+			t.Errorf("Failed. Expected the series to have no volumes Got %v", volumes)
+		}
+
+		if err = s.Series.DeleteSeries(ctx, dune.ID); err != nil {
+			t.Errorf("Failed. Expected error to be nil Got %v", err)
+		}
+
+		err = s.Series.DeleteSeries(ctx, dune.ID)
+		expectNotFound(t, err, "Series")
+	})
+
+	t.Run("Editions", func(t *testing.T) {
+		s := newStores(t)
+
+		author := mustCreate(t, s.Author.CreateAuthor, newAuthor("MG"))
+		original := mustCreate(t, s.Book.CreateBook, newBook("original", author.ID))
+		other := mustCreate(t, s.Book.CreateBook, newBook("other", author.ID))
+
+		translation, err := s.Book.CreateBook(ctx, entities.Book{Title: "translation", Author: author,
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2010, 1, 1),
+			Edition: &entities.Edition{WorkID: original.ID, Kind: entities.KindTranslation, Language: "hi"}})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when creating a book", err)
+		}
+
+		other.Edition = &entities.Edition{WorkID: original.ID, Kind: entities.KindEdition}
+
+		if _, err = s.Book.UpdateBookFields(ctx, other.ID, other, []string{entities.BookEdition}); err != nil {
+			t.Fatalf("an error '%s' was not expected when linking an edition", err)
+		}
+
+		res, err := s.Book.GetEditions(ctx, []int{original.ID, other.ID, translation.ID})
+		exp := map[int]entities.Edition{other.ID: *other.Edition, translation.ID: *translation.Edition}
+
+		if err != nil || !reflect.DeepEqual(res, exp) {
+			t.Errorf("Failed. Expected %v Got %v, %v", exp, res, err)
+		}
+
+		translation.Edition, translation.Author = nil, entities.Author{ID: author.ID}
+		other.Edition, other.Version = nil, other.Version+1
+		expBooks := []entities.Book{original, other, translation}
+
+		page, err := s.Book.GetBooks(ctx, entities.BookFilter{WorkID: original.ID, Limit: 10})
+		if err != nil || !reflect.DeepEqual(page.Books, expBooks) || page.Total != 3 {
+			t.Errorf("Failed. Expected %v Got %v, %v", expBooks, page.Books, err)
+		}
+
+		other.Edition = &entities.Edition{WorkID: other.ID + 1000, Kind: entities.KindEdition}
+
+		if _, err = s.Book.UpdateBookFields(ctx, other.ID, other, []string{entities.BookEdition}); err == nil {
+			t.Errorf("Failed. Expected an error for an original which does not exist")
+		}
+
+		if err = s.Book.DeleteBook(ctx, original.ID); err != nil {
+			t.Fatalf("an error '%s' was not expected when deleting a book", err)
+		}
+
+		res, err = s.Book.GetEditions(ctx, []int{other.ID, translation.ID})
+		if err != nil || len(res) != 0 {
+			t.Errorf("Failed. Expected the links to the deleted original to be removed Got %v, %v", res, err)
+		}
+	})
+}
+
+func newSeries(name string) entities.Series {
+	return entities.Series{Name: name}
+}
+
+// mustCreateVolume creates a book as the volume of the series with given id, the book is returned without its series
+// as the stores read it
+func mustCreateVolume(t *testing.T, books datastore.Book, authorID, seriesID, volume int) entities.Book {
+	t.Helper()
+
+	book := newBook("volume", authorID)
+	book.Series = &entities.SeriesVolume{ID: seriesID, Volume: volume}
+
+	book = mustCreate(t, books.CreateBook, book)
+	book.Series = nil
+
+	return book
+}
@@ -0,0 +1,251 @@
+package datastore
+
+import (
+	"ThreeLayer/entities"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	selectAuthorsByIDs    = "select id,first_name,last_name,dob,pen_name,version from Authors where id in (%s) order by id;"
+	selectPublishersByIDs = "select id,name,website,version from Publishers where id in (%s) order by id;"
+	selectAvailability    = "select book_id,status,count(*) from Copies where book_id in (%s) group by book_id,status;"
+	selectContributors    = "select book_id,author_id,role from BookAuthors where book_id in (%s) order by book_id,position;"
+	selectBookGenres      = "select bg.book_id,g.id,g.name,g.kind,COALESCE(g.parent_id, 0),g.version from BookGenres bg" +
+		" join Genres g on g.id = bg.genre_id where bg.book_id in (%s) order by bg.book_id,g.id;"
+	selectBookSeries = "select sb.book_id,s.id,s.name,sb.volume from SeriesBooks sb join Series s on s.id = sb.series_id" +
+		" where sb.book_id in (%s);"
+	selectEditions = "select book_id,work_id,kind,language from Editions where book_id in (%s);"
+
+	selectBooks = "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books"
+	countBooks  = "select count(*) from Books"
+
+	// the facets count the books matching the conditions of a filter, a genre counts the books tagged with it or with
+	// any of its descendants and the decades are read as the first three digits of the year
+	countGenres = "with recursive tree(ancestor_id, genre_id) as (select id, id from Genres" +
+		" union all select tree.ancestor_id, g.id from tree join Genres g on g.parent_id = tree.genre_id)" +
+		" select tree.ancestor_id, count(distinct bg.book_id) from tree join BookGenres bg on bg.genre_id = tree.genre_id" +
+		" where bg.book_id in (select id from Books%s) group by tree.ancestor_id;"
+	countPublishers = "select publisher_id, count(*) from Books%s group by publisher_id;"
+	countDecades    = "select substr(publication_date, 1, 3), count(*) from Books%s group by substr(publication_date, 1, 3);"
+)
+
+// BookListQuery returns the query for a page of the books matching the filter along with the query counting all of them,
+// the publication dates are compared with the yyyy-mm-dd dates of the filter. The count query uses all the args except
+// the last two which are the limit and offset. A filter without a limit lists all the books the same as the memory store.
+func BookListQuery(filter entities.BookFilter) (list, count string, args []interface{}) {
+	where, args := bookConditions(filter)
+
+	column := "id"
+
+	switch filter.Sort {
+	case entities.SortByTitle:
+		column = "title"
+	case entities.SortByPublishedDate:
+		column = "publication_date"
+	case entities.SortByVolume:
+		// the books not in a series have no volume and come first
+		column = "(select volume from SeriesBooks where book_id = Books.id)"
+	}
+
+	direction := " asc"
+	if filter.Desc {
+		direction = " desc"
+	}
+
+	// id breaks the ties so that the pages do not overlap
+	order := " order by " + column + direction
+	if column != "id" {
+		order += ", id" + direction
+	}
+
+	list = selectBooks + where + order + " limit ? offset ?;"
+	count = countBooks + where + ";"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+
+	args = append(args, limit, filter.Offset)
+
+	return list, count, args
+}
+
+// BookFacetsQueries returns the queries counting the books matching the filter by genre, by publisher and by decade
+// of publication along with their args, the sort and the page of the filter are not used
+func BookFacetsQueries(filter entities.BookFilter) (genres, publishers, decades string, args []interface{}) {
+	where, args := bookConditions(filter)
+
+	// a book without a publication date has no decade
+	dated := " where publication_date is not null"
+	if where != "" {
+		dated = where + " and publication_date is not null"
+	}
+
+	return fmt.Sprintf(countGenres, where), fmt.Sprintf(countPublishers, where), fmt.Sprintf(countDecades, dated), args
+}
+
+// bookConditions returns the where clause of the books matching the filter along with its args, the clause is empty
+// when the filter has no conditions
+func bookConditions(filter entities.BookFilter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if filter.Title != "" {
+		conditions = append(conditions, "title = ?")
+		args = append(args, filter.Title)
+	}
+
+	if filter.PublisherID != 0 {
+		conditions = append(conditions, "publisher_id = ?")
+		args = append(args, filter.PublisherID)
+	}
+
+	// a book is listed for every author it credits, in any role
+	if filter.AuthorID != 0 {
+		conditions = append(conditions, "id in (select book_id from BookAuthors where author_id = ?)")
+		args = append(args, filter.AuthorID)
+	}
+
+	// a book is listed for a genre when it is tagged with the genre or any of its descendants
+	if genreIDs := FilterGenres(filter); len(genreIDs) > 0 {
+		placeholders, genreArgs := inArgs(genreIDs)
+		conditions = append(conditions, "id in (select book_id from BookGenres where genre_id in ("+placeholders+"))")
+		args = append(args, genreArgs...)
+	}
+
+	if filter.SeriesID != 0 {
+		conditions = append(conditions, "id in (select book_id from SeriesBooks where series_id = ?)")
+		args = append(args, filter.SeriesID)
+	}
+
+	// the books of a work are the original and its editions
+	if filter.WorkID != 0 {
+		conditions = append(conditions, "(id = ? or id in (select book_id from Editions where work_id = ?))")
+		args = append(args, filter.WorkID, filter.WorkID)
+	}
+
+	if filter.PublishedFrom != "" {
+		conditions = append(conditions, "publication_date >= ?")
+		args = append(args, filter.PublishedFrom)
+	}
+
+	if filter.PublishedTo != "" {
+		conditions = append(conditions, "publication_date <= ?")
+		args = append(args, filter.PublishedTo)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " where " + strings.Join(conditions, " and "), args
+}
+
+// FilterGenres returns the ids of the genres the books of the filter are tagged with, the genre of the filter alone
+// is used when the service has not set its descendants
+func FilterGenres(filter entities.BookFilter) []int {
+	if len(filter.GenreIDs) == 0 && filter.GenreID != 0 {
+		return []int{filter.GenreID}
+	}
+
+	return filter.GenreIDs
+}
+
+// DuplicatesQuery returns the query for the books having the same details as the book for all the duplicate keys
+// along with its args, the titles are compared by their keys. A book without a publication date has the same date
+// as the books without one, keys must not be empty
+func DuplicatesQuery(book entities.Book, keys []string) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	for _, key := range keys {
+		switch key {
+		case entities.DuplicateByTitle:
+			conditions = append(conditions, "title_key = ?")
+			args = append(args, entities.TitleKey(book.Title))
+		case entities.DuplicateByAuthor:
+			conditions = append(conditions, "author_id = ?")
+			args = append(args, book.Author.ID)
+		case entities.DuplicateByPublisher:
+			conditions = append(conditions, "publisher_id = ?")
+			args = append(args, book.Publisher.ID)
+		case entities.DuplicateByPublishedDate:
+			if book.PublishedDate.IsZero() {
+				conditions = append(conditions, "publication_date is null")
+			} else {
+				conditions = append(conditions, "publication_date = ?")
+				args = append(args, book.PublishedDate)
+			}
+		}
+	}
+
+	return selectBooks + " where " + strings.Join(conditions, " and ") + " order by id;", args
+}
+
+// AuthorsByIDsQuery returns the query for the authors having any of the ids along with its args, ids must not be empty
+func AuthorsByIDsQuery(ids []int) (string, []interface{}) {
+	placeholders, args := inArgs(ids)
+
+	return fmt.Sprintf(selectAuthorsByIDs, placeholders), args
+}
+
+// PublishersByIDsQuery returns the query for the publishers having any of the ids along with its args, ids must not
+// be empty
+func PublishersByIDsQuery(ids []int) (string, []interface{}) {
+	placeholders, args := inArgs(ids)
+
+	return fmt.Sprintf(selectPublishersByIDs, placeholders), args
+}
+
+// AvailabilityQuery returns the query counting the copies of the books by status along with its args,
+// bookIDs must not be empty
+func AvailabilityQuery(bookIDs []int) (string, []interface{}) {
+	placeholders, args := inArgs(bookIDs)
+
+	return fmt.Sprintf(selectAvailability, placeholders), args
+}
+
+// ContributorsQuery returns the query for the contributors of the books in the order of the credits along with
+// its args, bookIDs must not be empty
+func ContributorsQuery(bookIDs []int) (string, []interface{}) {
+	placeholders, args := inArgs(bookIDs)
+
+	return fmt.Sprintf(selectContributors, placeholders), args
+}
+
+// BookGenresQuery returns the query for the genres of the books along with its args, bookIDs must not be empty
+func BookGenresQuery(bookIDs []int) (string, []interface{}) {
+	placeholders, args := inArgs(bookIDs)
+
+	return fmt.Sprintf(selectBookGenres, placeholders), args
+}
+
+// BookSeriesQuery returns the query for the series of the books along with its args, bookIDs must not be empty
+func BookSeriesQuery(bookIDs []int) (string, []interface{}) {
+	placeholders, args := inArgs(bookIDs)
+
+	return fmt.Sprintf(selectBookSeries, placeholders), args
+}
+
+// EditionsQuery returns the query for the edition links of the books along with its args, bookIDs must not be empty
+func EditionsQuery(bookIDs []int) (string, []interface{}) {
+	placeholders, args := inArgs(bookIDs)
+
+	return fmt.Sprintf(selectEditions, placeholders), args
+}
+
+// inArgs returns the placeholders of an in condition for the ids along with the args
+func inArgs(ids []int) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i := range ids {
+		args[i] = ids[i]
+	}
+
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
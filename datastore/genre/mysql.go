@@ -0,0 +1,132 @@
+package genre
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Genre
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanGenre reads a genre selected with the columns of datastore.GetGenre, it is used by all the genre stores
+func scanGenre(row datastore.Scanner) (entities.Genre, error) {
+	var g entities.Genre
+
+	err := row.Scan(&g.ID, &g.Name, &g.Kind, &g.ParentID, &g.Version)
+
+	return g, err
+}
+
+// GetGenres function is to perform DB Queries to get the list of genres
+func (s Storer) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	return getGenres(ctx, datastore.Conn(ctx, s.db))
+}
+
+func getGenres(ctx context.Context, db datastore.DBTX) ([]entities.Genre, error) {
+	rows, err := db.QueryContext(ctx, datastore.GetGenre)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	genres := make([]entities.Genre, 0)
+
+	for rows.Next() {
+		g, err := scanGenre(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		genres = append(genres, g)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return genres, nil
+}
+
+// GetGenreByID function is to perform DB Queries to get a genre instance using its ID
+func (s Storer) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	return getGenreByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func getGenreByID(ctx context.Context, db datastore.DBTX, id int) (entities.Genre, error) {
+	g, err := scanGenre(db.QueryRowContext(ctx, datastore.GetByIDGenre, id))
+	if err == sql.ErrNoRows {
+		return entities.Genre{}, errors.EntityNotFound{Entity: "Genre", ID: id}
+	}
+
+	if err != nil {
+		return entities.Genre{}, errors.DB{Err: err}
+	}
+
+	return g, nil
+}
+
+// CreateGenre function is to perform DB execution to add a new genre instance in database
+func (s Storer) CreateGenre(ctx context.Context, g entities.Genre) (entities.Genre, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertGenre, g.Name, g.Kind, g.ParentID)
+	if err != nil {
+		return entities.Genre{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Genre{}, errors.DB{Err: err}
+	}
+
+	g.ID = int(id)
+	g.Version = 1
+
+	return g, nil
+}
+
+// UpdateGenre function is to perform required DB Queries to replace a genre instance in database,
+// the genre is returned with its new version
+func (s Storer) UpdateGenre(ctx context.Context, id int, g entities.Genre) (entities.Genre, error) {
+	return updateGenre(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdateGenre, id, g)
+}
+
+func updateGenre(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	g entities.Genre) (entities.Genre, error) {
+	version, err := d.Update(ctx, db, "Genre", "Genres", id, query, g.Name, g.Kind, g.ParentID, id, g.Version)
+	if err != nil {
+		return entities.Genre{}, err
+	}
+
+	g.ID = id
+	g.Version = version
+
+	return g, nil
+}
+
+// DeleteGenre function is to perform required DB Queries to remove a genre instance from database,
+// the books tagged with the genre lose the tag and a genre having children can not be removed
+func (s Storer) DeleteGenre(ctx context.Context, id int) error {
+	return deleteGenre(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func deleteGenre(ctx context.Context, db datastore.DBTX, id int) error {
+	res, err := db.ExecContext(ctx, datastore.DeleteGenre, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Genre", ID: id}
+	}
+
+	return nil
+}
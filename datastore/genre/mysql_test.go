@@ -0,0 +1,212 @@
+package genre
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "name", "kind", "parent_id", "version"}
+
+func genre() entities.Genre {
+	return entities.Genre{Name: "Mystery", Kind: entities.KindGenre, ParentID: 1}
+}
+
+func TestStorer_CreateGenre(t *testing.T) {
+	created := genre()
+	created.ID, created.Version = 4, 1
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.Genre
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "name is taken", dbErr: fmt.Errorf("duplicate entry"), expErr: errors.DB{Err: fmt.Errorf("duplicate entry")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		g := genre()
+
+		mock.ExpectExec(datastore.InsertGenre).
+			WithArgs(g.Name, g.Kind, g.ParentID).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreateGenre(context.Background(), g)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetGenres(t *testing.T) {
+	stored := genre()
+	stored.ID, stored.Version = 1, 2
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes []entities.Genre
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.Name, stored.Kind, stored.ParentID, 2),
+			expRes: []entities.Genre{stored}},
+		{desc: "empty", rows: sqlmock.NewRows(columns), expRes: []entities.Genre{}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetGenre)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetGenres(context.Background())
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetGenreByID(t *testing.T) {
+	stored := genre()
+	stored.ID, stored.Version = 1, 3
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes entities.Genre
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.Name, stored.Kind, stored.ParentID, 3), expRes: stored},
+		{desc: "not found", rows: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Genre", ID: 1}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetByIDGenre).WithArgs(1)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetGenreByID(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdateGenre(t *testing.T) {
+	updated := genre()
+	updated.ID, updated.Version = 1, 1
+
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		stored int
+		expRes entities.Genre
+		expErr error
+	}{
+		{desc: "updated", rowsAffected: 1, expRes: updated},
+		{desc: "modified since read", stored: 2, expErr: errors.PreconditionFailed{Entity: "Genre", ID: 1}},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Genre", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		g := genre()
+
+		mock.ExpectExec(datastore.UpdateGenre).
+			WithArgs(g.Name, g.Kind, g.ParentID, 1, g.Version).
+			WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+
+		if tc.rowsAffected == 0 {
+			rows := sqlmock.NewRows([]string{"version"})
+			if tc.stored != 0 {
+				rows.AddRow(tc.stored)
+			}
+
+			mock.ExpectQuery("select version from Genres where id=?").WithArgs(1).WillReturnRows(rows)
+		}
+
+		res, err := New(db).UpdateGenre(context.Background(), 1, g)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_DeleteGenre(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		dbErr        error
+		expErr       error
+	}{
+		{desc: "deleted", rowsAffected: 1},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Genre", ID: 1}},
+		{desc: "parent of genres", dbErr: fmt.Errorf("foreign key constraint fails"),
+			expErr: errors.DB{Err: fmt.Errorf("foreign key constraint fails")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.DeleteGenre).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected)).
+			WillReturnError(tc.dbErr)
+
+		err := New(db).DeleteGenre(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package genre
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Genre, it differs from Storer only in reading
+// the generated id of a new genre
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetGenres function is to perform DB Queries to get the list of genres
+func (s SQLiteStorer) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	return getGenres(ctx, datastore.Conn(ctx, s.db))
+}
+
+// GetGenreByID function is to perform DB Queries to get a genre instance using its ID
+func (s SQLiteStorer) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	return getGenreByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+// CreateGenre function is to perform DB execution to add a new genre instance in database
+func (s SQLiteStorer) CreateGenre(ctx context.Context, g entities.Genre) (entities.Genre, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertGenreSQLite, g.Name, g.Kind, g.ParentID).
+		Scan(&g.ID, &g.Version)
+	if err != nil {
+		return entities.Genre{}, errors.DB{Err: err}
+	}
+
+	return g, nil
+}
+
+// UpdateGenre function is to perform required DB Queries to replace a genre instance in database,
+// the genre is returned with its new version
+func (s SQLiteStorer) UpdateGenre(ctx context.Context, id int, g entities.Genre) (entities.Genre, error) {
+	return updateGenre(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdateGenreSQLite, id, g)
+}
+
+// DeleteGenre function is to perform required DB Queries to remove a genre instance from database,
+// the books tagged with the genre lose the tag and a genre having children can not be removed
+func (s SQLiteStorer) DeleteGenre(ctx context.Context, id int) error {
+	return deleteGenre(ctx, datastore.Conn(ctx, s.db), id)
+}
@@ -0,0 +1,127 @@
+// Package holds stores the reservations of the books by the members
+package holds
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Hold
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanHold reads a hold selected with the columns of datastore.GetByIDHold, it is used by all the hold stores
+func scanHold(row datastore.Scanner) (entities.Hold, error) {
+	var h entities.Hold
+
+	err := row.Scan(&h.ID, &h.BookID, &h.MemberID, &h.CopyID, &h.Status, &h.PlacedOn, &h.ReadyOn, &h.ExpiresOn,
+		&h.Version)
+
+	return h, err
+}
+
+// GetHoldByID function is to perform DB Queries to get a hold instance using its ID
+func (s Storer) GetHoldByID(ctx context.Context, id int) (entities.Hold, error) {
+	return getHoldByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func getHoldByID(ctx context.Context, db datastore.DBTX, id int) (entities.Hold, error) {
+	h, err := scanHold(db.QueryRowContext(ctx, datastore.GetByIDHold, id))
+	if err == sql.ErrNoRows {
+		return entities.Hold{}, errors.EntityNotFound{Entity: "Hold", ID: id}
+	}
+
+	if err != nil {
+		return entities.Hold{}, errors.DB{Err: err}
+	}
+
+	return h, nil
+}
+
+// GetBookHolds function is to perform DB Queries to get the queue of a book
+func (s Storer) GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error) {
+	return getHolds(ctx, datastore.Conn(ctx, s.db), datastore.GetHoldsByBook, bookID)
+}
+
+// GetMemberHolds function is to perform DB Queries to get the holds of a member which are still queued
+func (s Storer) GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error) {
+	return getHolds(ctx, datastore.Conn(ctx, s.db), datastore.GetHoldsByMember, memberID)
+}
+
+// GetHoldsByStatus function is to perform DB Queries to get all the holds having a status
+func (s Storer) GetHoldsByStatus(ctx context.Context, status string) ([]entities.Hold, error) {
+	return getHolds(ctx, datastore.Conn(ctx, s.db), datastore.GetHoldsByStatus, status)
+}
+
+func getHolds(ctx context.Context, db datastore.DBTX, query string, args ...interface{}) ([]entities.Hold, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	holds := make([]entities.Hold, 0)
+
+	for rows.Next() {
+		h, err := scanHold(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		holds = append(holds, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return holds, nil
+}
+
+// CreateHold function is to perform DB execution to add a new hold instance in database
+func (s Storer) CreateHold(ctx context.Context, h entities.Hold) (entities.Hold, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertHold, h.BookID, h.MemberID, h.CopyID,
+		h.Status, h.PlacedOn, h.ReadyOn, h.ExpiresOn)
+	if err != nil {
+		return entities.Hold{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Hold{}, errors.DB{Err: err}
+	}
+
+	h.ID = int(id)
+	h.Version = 1
+
+	return h, nil
+}
+
+// UpdateHold function is to perform required DB Queries to set the copy, the status and the dates of a hold,
+// the hold is returned with its new version
+func (s Storer) UpdateHold(ctx context.Context, id int, h entities.Hold) (entities.Hold, error) {
+	return updateHold(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdateHold, id, h)
+}
+
+func updateHold(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	h entities.Hold) (entities.Hold, error) {
+	version, err := d.Update(ctx, db, "Hold", "Holds", id, query, h.CopyID, h.Status, h.ReadyOn, h.ExpiresOn, id,
+		h.Version)
+	if err != nil {
+		return entities.Hold{}, err
+	}
+
+	h.ID = id
+	h.Version = version
+
+	return h, nil
+}
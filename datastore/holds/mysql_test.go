@@ -0,0 +1,160 @@
+package holds
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "book_id", "member_id", "copy_id", "status", "placed_on", "ready_on", "expires_on",
+	"version"}
+
+func hold() entities.Hold {
+	return entities.Hold{BookID: 1, MemberID: 2, Status: entities.HoldWaiting,
+		PlacedOn: entities.NewDate(2022, 3, 1)}
+}
+
+func TestStorer_CreateHold(t *testing.T) {
+	created := hold()
+	created.ID, created.Version = 4, 1
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.Hold
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "member does not exist", dbErr: fmt.Errorf("foreign key constraint fails"),
+			expErr: errors.DB{Err: fmt.Errorf("foreign key constraint fails")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		h := hold()
+
+		mock.ExpectExec(datastore.InsertHold).
+			WithArgs(h.BookID, h.MemberID, 0, h.Status, h.PlacedOn, nil, nil).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreateHold(context.Background(), h)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetBookHolds(t *testing.T) {
+	waiting := hold()
+	waiting.ID, waiting.Version = 2, 1
+
+	ready := entities.Hold{ID: 1, BookID: 1, MemberID: 3, CopyID: 5, Status: entities.HoldReady,
+		PlacedOn: entities.NewDate(2022, 2, 1), ReadyOn: entities.NewDate(2022, 3, 1),
+		ExpiresOn: entities.NewDate(2022, 3, 4), Version: 2}
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes []entities.Hold
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).
+			AddRow(1, 1, 3, 5, entities.HoldReady, "2022-02-01", "2022-03-01", "2022-03-04", 2).
+			AddRow(2, 1, 2, 0, entities.HoldWaiting, "2022-03-01", nil, nil, 1),
+			expRes: []entities.Hold{ready, waiting}},
+		{desc: "empty queue", rows: sqlmock.NewRows(columns), expRes: []entities.Hold{}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetHoldsByBook).WithArgs(1)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetBookHolds(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdateHold(t *testing.T) {
+	ready := hold()
+	ready.CopyID, ready.Status = 5, entities.HoldReady
+	ready.ReadyOn, ready.ExpiresOn = entities.NewDate(2022, 3, 10), entities.NewDate(2022, 3, 13)
+
+	updated := ready
+	updated.ID, updated.Version = 1, 1
+
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		stored int
+		expRes entities.Hold
+		expErr error
+	}{
+		{desc: "updated", rowsAffected: 1, expRes: updated},
+		{desc: "modified since read", stored: 2, expErr: errors.PreconditionFailed{Entity: "Hold", ID: 1}},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Hold", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.UpdateHold).
+			WithArgs(ready.CopyID, ready.Status, ready.ReadyOn, ready.ExpiresOn, 1, ready.Version).
+			WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+
+		if tc.rowsAffected == 0 {
+			rows := sqlmock.NewRows([]string{"version"})
+			if tc.stored != 0 {
+				rows.AddRow(tc.stored)
+			}
+
+			mock.ExpectQuery("select version from Holds where id=?").WithArgs(1).WillReturnRows(rows)
+		}
+
+		res, err := New(db).UpdateHold(context.Background(), 1, ready)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
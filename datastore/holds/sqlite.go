@@ -0,0 +1,56 @@
+package holds
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Hold, it differs from Storer only in reading
+// the generated id of a new hold
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetHoldByID function is to perform DB Queries to get a hold instance using its ID
+func (s SQLiteStorer) GetHoldByID(ctx context.Context, id int) (entities.Hold, error) {
+	return getHoldByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+// GetBookHolds function is to perform DB Queries to get the queue of a book
+func (s SQLiteStorer) GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error) {
+	return getHolds(ctx, datastore.Conn(ctx, s.db), datastore.GetHoldsByBook, bookID)
+}
+
+// GetMemberHolds function is to perform DB Queries to get the holds of a member which are still queued
+func (s SQLiteStorer) GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error) {
+	return getHolds(ctx, datastore.Conn(ctx, s.db), datastore.GetHoldsByMember, memberID)
+}
+
+// GetHoldsByStatus function is to perform DB Queries to get all the holds having a status
+func (s SQLiteStorer) GetHoldsByStatus(ctx context.Context, status string) ([]entities.Hold, error) {
+	return getHolds(ctx, datastore.Conn(ctx, s.db), datastore.GetHoldsByStatus, status)
+}
+
+// CreateHold function is to perform DB execution to add a new hold instance in database
+func (s SQLiteStorer) CreateHold(ctx context.Context, h entities.Hold) (entities.Hold, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertHoldSQLite, h.BookID, h.MemberID, h.CopyID,
+		h.Status, h.PlacedOn, h.ReadyOn, h.ExpiresOn).Scan(&h.ID, &h.Version)
+	if err != nil {
+		return entities.Hold{}, errors.DB{Err: err}
+	}
+
+	return h, nil
+}
+
+// UpdateHold function is to perform required DB Queries to set the copy, the status and the dates of a hold,
+// the hold is returned with its new version
+func (s SQLiteStorer) UpdateHold(ctx context.Context, id int, h entities.Hold) (entities.Hold, error) {
+	return updateHold(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdateHoldSQLite, id, h)
+}
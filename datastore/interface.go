@@ -5,18 +5,130 @@ import (
 	"context"
 )
 
+// The updates of the stores change an entity only when it still has the version it is given with, the new version is
+// returned. EntityNotFound is returned when there is no entity with the id and PreconditionFailed when it has another
+// version
+
 type Author interface {
 	GetAuthor(context.Context) ([]entities.Author, error)
 	GetAuthorByID(ctx context.Context, id int) (entities.Author, error)
+	GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error)
 	CreateAuthor(ctx context.Context, author entities.Author) (entities.Author, error) //post
 	PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error)
+	UpdateAuthorFields(ctx context.Context, id int, author entities.Author, fields []string) (int, error)
 	DeleteAuthor(ctx context.Context, id int) error
 }
 
 type Book interface {
 	GetAllBook(ctx context.Context) ([]entities.Book, error)
+	GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error)
 	GetBookByID(ctx context.Context, id int) (entities.Book, error)
+	// GetBookByISBN returns the book with the normalized ISBN-13, the error is EntityNotFound without an id when
+	// there is none
+	GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error)
+	// SearchBooks returns a page of the books matching any of the terms of the query ranked by relevance, only the ids
+	// of the authors and the publishers of the books are set
+	SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error)
+	// GetDuplicates returns the books having the same details as the book for all the keys of the duplicate
+	// detection ordered by id, the titles are compared regardless of case and spacing
+	GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error)
 	CreateBook(ctx context.Context, book entities.Book) (entities.Book, error)
 	UpdateBook(ctx context.Context, id int, book entities.Book) (entities.Book, error)
+	// UpdateBookFields updates only the given fields, the contributors of the book are replaced when
+	// entities.BookContributors is one of the fields, its genres when entities.BookGenres is and likewise its series
+	// and its edition
+	UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error)
 	DeleteBook(ctx context.Context, id int) error
+	// GetContributors returns the contributors of the books in the order of the credits, only the ids of the
+	// authors are set
+	GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error)
+	// GetBookGenres returns the genres the books are tagged with ordered by id
+	GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error)
+	// GetBookFacets counts all the books matching the filter by genre, publisher and decade, only the ids of the
+	// genres and the publishers are set
+	GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error)
+	// GetBookSeries returns the series of the books which are volumes of one, with the names of the series
+	GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error)
+	// GetEditions returns the edition links of the books which are editions or translations of another book
+	GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error)
+}
+
+type Publisher interface {
+	GetPublishers(ctx context.Context) ([]entities.Publisher, error)
+	GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error)
+	GetPublishersByIDs(ctx context.Context, ids []int) ([]entities.Publisher, error)
+	GetPublisherByName(ctx context.Context, name string) (entities.Publisher, error)
+	CreatePublisher(ctx context.Context, publisher entities.Publisher) (entities.Publisher, error)
+	UpdatePublisher(ctx context.Context, id int, publisher entities.Publisher) (entities.Publisher, error)
+	DeletePublisher(ctx context.Context, id int) error
+}
+
+type Genre interface {
+	GetGenres(ctx context.Context) ([]entities.Genre, error)
+	GetGenreByID(ctx context.Context, id int) (entities.Genre, error)
+	CreateGenre(ctx context.Context, genre entities.Genre) (entities.Genre, error)
+	UpdateGenre(ctx context.Context, id int, genre entities.Genre) (entities.Genre, error)
+	// DeleteGenre removes the genre along with its tags on the books
+	DeleteGenre(ctx context.Context, id int) error
+}
+
+type Series interface {
+	GetSeries(ctx context.Context) ([]entities.Series, error)
+	GetSeriesByID(ctx context.Context, id int) (entities.Series, error)
+	CreateSeries(ctx context.Context, series entities.Series) (entities.Series, error)
+	UpdateSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error)
+	DeleteSeries(ctx context.Context, id int) error
+	// GetVolumes returns the ids of the books of the series by their volume numbers
+	GetVolumes(ctx context.Context, id int) (map[int]int, error)
+}
+
+type Member interface {
+	GetMembers(ctx context.Context) ([]entities.Member, error)
+	GetMemberByID(ctx context.Context, id int) (entities.Member, error)
+	GetMemberByEmail(ctx context.Context, email string) (entities.Member, error)
+	CreateMember(ctx context.Context, member entities.Member) (entities.Member, error)
+	UpdateMember(ctx context.Context, id int, member entities.Member) (entities.Member, error)
+	DeleteMember(ctx context.Context, id int) error
+}
+
+type Copy interface {
+	GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error)
+	GetCopyByID(ctx context.Context, id int) (entities.Copy, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (entities.Copy, error)
+	GetAvailability(ctx context.Context, bookIDs []int) (map[int]entities.Availability, error)
+	CreateCopy(ctx context.Context, c entities.Copy) (entities.Copy, error)
+	UpdateCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error)
+	// UpdateCopyStatus sets the status of the copy only when it has the status from, EntityNotFound is returned
+	// when no copy with the id has the status
+	UpdateCopyStatus(ctx context.Context, id int, from, to string) error
+	DeleteCopy(ctx context.Context, id int) error
+}
+
+type Loan interface {
+	GetLoanByID(ctx context.Context, id int) (entities.Loan, error)
+	GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error)
+	GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error)
+	// GetMemberLoans returns the active and the returned loans of the member
+	GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error)
+	CreateLoan(ctx context.Context, l entities.Loan) (entities.Loan, error)
+	// UpdateLoan sets the due and return dates and the renewals of the loan, the loan is returned with its new version
+	UpdateLoan(ctx context.Context, id int, l entities.Loan) (entities.Loan, error)
+}
+
+type Hold interface {
+	GetHoldByID(ctx context.Context, id int) (entities.Hold, error)
+	// GetBookHolds returns the queue of the book, the waiting and ready holds in the order they were placed
+	GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error)
+	GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error)
+	GetHoldsByStatus(ctx context.Context, status string) ([]entities.Hold, error)
+	CreateHold(ctx context.Context, h entities.Hold) (entities.Hold, error)
+	// UpdateHold sets the copy, the status and the dates of the hold, the hold is returned with its new version
+	UpdateHold(ctx context.Context, id int, h entities.Hold) (entities.Hold, error)
+}
+
+type Ledger interface {
+	GetEntries(ctx context.Context, memberID int) ([]entities.LedgerEntry, error)
+	// GetBalance returns the sum of the fines less the payments and the waivers of the member
+	GetBalance(ctx context.Context, memberID int) (int, error)
+	CreateEntry(ctx context.Context, e entities.LedgerEntry) (entities.LedgerEntry, error)
 }
@@ -0,0 +1,85 @@
+// Package ledger stores the accounts of the members, the fines charged along with the payments and the waivers
+package ledger
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Ledger
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// GetEntries function is to perform DB Queries to get the ledger entries of a member in the order they were made
+func (s Storer) GetEntries(ctx context.Context, memberID int) ([]entities.LedgerEntry, error) {
+	return getEntries(ctx, datastore.Conn(ctx, s.db), memberID)
+}
+
+func getEntries(ctx context.Context, db datastore.DBTX, memberID int) ([]entities.LedgerEntry, error) {
+	rows, err := db.QueryContext(ctx, datastore.GetLedgerEntries, memberID)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	entries := make([]entities.LedgerEntry, 0)
+
+	for rows.Next() {
+		var e entities.LedgerEntry
+
+		err = rows.Scan(&e.ID, &e.MemberID, &e.LoanID, &e.Type, &e.Amount, &e.Note, &e.CreatedOn)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return entries, nil
+}
+
+// GetBalance function is to perform DB Queries to get the balance of the account of a member
+func (s Storer) GetBalance(ctx context.Context, memberID int) (int, error) {
+	return getBalance(ctx, datastore.Conn(ctx, s.db), memberID)
+}
+
+func getBalance(ctx context.Context, db datastore.DBTX, memberID int) (int, error) {
+	var balance int
+
+	if err := db.QueryRowContext(ctx, datastore.GetBalance, memberID).Scan(&balance); err != nil {
+		return 0, errors.DB{Err: err}
+	}
+
+	return balance, nil
+}
+
+// CreateEntry function is to perform DB execution to add a new entry to the ledger
+func (s Storer) CreateEntry(ctx context.Context, e entities.LedgerEntry) (entities.LedgerEntry, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertLedger, e.MemberID, e.LoanID, e.Type,
+		e.Amount, e.Note, e.CreatedOn)
+	if err != nil {
+		return entities.LedgerEntry{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.LedgerEntry{}, errors.DB{Err: err}
+	}
+
+	e.ID = int(id)
+
+	return e, nil
+}
@@ -0,0 +1,138 @@
+package ledger
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "member_id", "loan_id", "entry_type", "amount", "note", "created_on"}
+
+func TestStorer_CreateEntry(t *testing.T) {
+	fine := entities.LedgerEntry{MemberID: 2, LoanID: 3, Type: entities.LedgerFine, Amount: 150,
+		CreatedOn: entities.NewDate(2022, 3, 10)}
+
+	created := fine
+	created.ID = 4
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.LedgerEntry
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "member does not exist", dbErr: fmt.Errorf("foreign key constraint fails"),
+			expErr: errors.DB{Err: fmt.Errorf("foreign key constraint fails")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.InsertLedger).
+			WithArgs(fine.MemberID, fine.LoanID, fine.Type, fine.Amount, fine.Note, fine.CreatedOn).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreateEntry(context.Background(), fine)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetEntries(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes []entities.LedgerEntry
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).
+			AddRow(1, 2, 3, entities.LedgerFine, 150, "", "2022-03-10").
+			AddRow(2, 2, 0, entities.LedgerPayment, 100, "cash", "2022-03-11"),
+			expRes: []entities.LedgerEntry{
+				{ID: 1, MemberID: 2, LoanID: 3, Type: entities.LedgerFine, Amount: 150,
+					CreatedOn: entities.NewDate(2022, 3, 10)},
+				{ID: 2, MemberID: 2, Type: entities.LedgerPayment, Amount: 100, Note: "cash",
+					CreatedOn: entities.NewDate(2022, 3, 11)}}},
+		{desc: "no entries", rows: sqlmock.NewRows(columns), expRes: []entities.LedgerEntry{}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetLedgerEntries).WithArgs(2)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetEntries(context.Background(), 2)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetBalance(t *testing.T) {
+	testcases := []struct {
+		desc       string
+		dbErr      error
+		expBalance int
+		expErr     error
+	}{
+		{desc: "found", expBalance: 50},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetBalance).WithArgs(2)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(50))
+		}
+
+		balance, err := New(db).GetBalance(context.Background(), 2)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if balance != tc.expBalance {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, balance, tc.expBalance)
+		}
+	}
+}
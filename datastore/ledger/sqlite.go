@@ -0,0 +1,40 @@
+package ledger
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Ledger, it differs from Storer only in reading
+// the generated id of a new entry
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetEntries function is to perform DB Queries to get the ledger entries of a member in the order they were made
+func (s SQLiteStorer) GetEntries(ctx context.Context, memberID int) ([]entities.LedgerEntry, error) {
+	return getEntries(ctx, datastore.Conn(ctx, s.db), memberID)
+}
+
+// GetBalance function is to perform DB Queries to get the balance of the account of a member
+func (s SQLiteStorer) GetBalance(ctx context.Context, memberID int) (int, error) {
+	return getBalance(ctx, datastore.Conn(ctx, s.db), memberID)
+}
+
+// CreateEntry function is to perform DB execution to add a new entry to the ledger
+func (s SQLiteStorer) CreateEntry(ctx context.Context, e entities.LedgerEntry) (entities.LedgerEntry, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertLedgerSQLite, e.MemberID, e.LoanID, e.Type,
+		e.Amount, e.Note, e.CreatedOn).Scan(&e.ID)
+	if err != nil {
+		return entities.LedgerEntry{}, errors.DB{Err: err}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,126 @@
+// Package loans stores the lending of the copies to the members
+package loans
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Loan
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanLoan reads a loan selected with the columns of datastore.GetByIDLoan, it is used by all the loan stores
+func scanLoan(row datastore.Scanner) (entities.Loan, error) {
+	var l entities.Loan
+
+	err := row.Scan(&l.ID, &l.CopyID, &l.MemberID, &l.BookID, &l.CheckedOutOn, &l.DueOn, &l.ReturnedOn, &l.Renewals,
+		&l.Version)
+
+	return l, err
+}
+
+// GetLoanByID function is to perform DB Queries to get a loan instance using its ID
+func (s Storer) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	return getLoanByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func getLoanByID(ctx context.Context, db datastore.DBTX, id int) (entities.Loan, error) {
+	l, err := scanLoan(db.QueryRowContext(ctx, datastore.GetByIDLoan, id))
+	if err == sql.ErrNoRows {
+		return entities.Loan{}, errors.EntityNotFound{Entity: "Loan", ID: id}
+	}
+
+	if err != nil {
+		return entities.Loan{}, errors.DB{Err: err}
+	}
+
+	return l, nil
+}
+
+// GetActiveLoans function is to perform DB Queries to get the loans of a member which are not returned yet
+func (s Storer) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return getLoans(ctx, datastore.Conn(ctx, s.db), datastore.GetActiveLoans, memberID)
+}
+
+// GetLoansByBook function is to perform DB Queries to get all the loans of the copies of a book
+func (s Storer) GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	return getLoans(ctx, datastore.Conn(ctx, s.db), datastore.GetLoansByBook, bookID)
+}
+
+// GetMemberLoans function is to perform DB Queries to get all the loans of a member, returned or not
+func (s Storer) GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return getLoans(ctx, datastore.Conn(ctx, s.db), datastore.GetMemberLoans, memberID)
+}
+
+func getLoans(ctx context.Context, db datastore.DBTX, query string, args ...interface{}) ([]entities.Loan, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	loans := make([]entities.Loan, 0)
+
+	for rows.Next() {
+		l, err := scanLoan(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		loans = append(loans, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return loans, nil
+}
+
+// CreateLoan function is to perform DB execution to add a new loan instance in database
+func (s Storer) CreateLoan(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertLoan, l.CopyID, l.MemberID, l.BookID,
+		l.CheckedOutOn, l.DueOn, l.ReturnedOn, l.Renewals)
+	if err != nil {
+		return entities.Loan{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Loan{}, errors.DB{Err: err}
+	}
+
+	l.ID = int(id)
+	l.Version = 1
+
+	return l, nil
+}
+
+// UpdateLoan function is to perform required DB Queries to set the due and return dates and the renewals of a loan,
+// the loan is returned with its new version
+func (s Storer) UpdateLoan(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+	return updateLoan(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdateLoan, id, l)
+}
+
+func updateLoan(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	l entities.Loan) (entities.Loan, error) {
+	version, err := d.Update(ctx, db, "Loan", "Loans", id, query, l.DueOn, l.ReturnedOn, l.Renewals, id, l.Version)
+	if err != nil {
+		return entities.Loan{}, err
+	}
+
+	l.ID = id
+	l.Version = version
+
+	return l, nil
+}
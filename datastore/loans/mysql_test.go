@@ -0,0 +1,153 @@
+package loans
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "copy_id", "member_id", "book_id", "checked_out_on", "due_on", "returned_on", "renewals",
+	"version"}
+
+func loan() entities.Loan {
+	return entities.Loan{CopyID: 3, MemberID: 2, BookID: 1, CheckedOutOn: entities.NewDate(2022, 3, 1),
+		DueOn: entities.NewDate(2022, 3, 15)}
+}
+
+func TestStorer_CreateLoan(t *testing.T) {
+	created := loan()
+	created.ID, created.Version = 4, 1
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.Loan
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "member does not exist", dbErr: fmt.Errorf("foreign key constraint fails"),
+			expErr: errors.DB{Err: fmt.Errorf("foreign key constraint fails")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		l := loan()
+
+		mock.ExpectExec(datastore.InsertLoan).
+			WithArgs(l.CopyID, l.MemberID, l.BookID, l.CheckedOutOn, l.DueOn, l.ReturnedOn, l.Renewals).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreateLoan(context.Background(), l)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetActiveLoans(t *testing.T) {
+	stored := loan()
+	stored.ID, stored.Version = 1, 2
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes []entities.Loan
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.CopyID, stored.MemberID, stored.BookID,
+			stored.CheckedOutOn, stored.DueOn, "", 0, 2), expRes: []entities.Loan{stored}},
+		{desc: "no loans", rows: sqlmock.NewRows(columns), expRes: []entities.Loan{}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetActiveLoans).WithArgs(2)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetActiveLoans(context.Background(), 2)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdateLoan(t *testing.T) {
+	returned := loan()
+	returned.ReturnedOn = entities.NewDate(2022, 3, 10)
+
+	updated := returned
+	updated.ID, updated.Version = 1, 1
+
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		stored int
+		expRes entities.Loan
+		expErr error
+	}{
+		{desc: "updated", rowsAffected: 1, expRes: updated},
+		{desc: "modified since read", stored: 2, expErr: errors.PreconditionFailed{Entity: "Loan", ID: 1}},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Loan", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.UpdateLoan).
+			WithArgs(returned.DueOn, returned.ReturnedOn, returned.Renewals, 1, returned.Version).
+			WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+
+		if tc.rowsAffected == 0 {
+			rows := sqlmock.NewRows([]string{"version"})
+			if tc.stored != 0 {
+				rows.AddRow(tc.stored)
+			}
+
+			mock.ExpectQuery("select version from Loans where id=?").WithArgs(1).WillReturnRows(rows)
+		}
+
+		res, err := New(db).UpdateLoan(context.Background(), 1, returned)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
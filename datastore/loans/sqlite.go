@@ -0,0 +1,56 @@
+package loans
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Loan, it differs from Storer only in reading
+// the generated id of a new loan
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetLoanByID function is to perform DB Queries to get a loan instance using its ID
+func (s SQLiteStorer) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	return getLoanByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+// GetActiveLoans function is to perform DB Queries to get the loans of a member which are not returned yet
+func (s SQLiteStorer) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return getLoans(ctx, datastore.Conn(ctx, s.db), datastore.GetActiveLoans, memberID)
+}
+
+// GetLoansByBook function is to perform DB Queries to get all the loans of the copies of a book
+func (s SQLiteStorer) GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	return getLoans(ctx, datastore.Conn(ctx, s.db), datastore.GetLoansByBook, bookID)
+}
+
+// GetMemberLoans function is to perform DB Queries to get all the loans of a member, returned or not
+func (s SQLiteStorer) GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return getLoans(ctx, datastore.Conn(ctx, s.db), datastore.GetMemberLoans, memberID)
+}
+
+// CreateLoan function is to perform DB execution to add a new loan instance in database
+func (s SQLiteStorer) CreateLoan(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertLoanSQLite, l.CopyID, l.MemberID, l.BookID,
+		l.CheckedOutOn, l.DueOn, l.ReturnedOn, l.Renewals).Scan(&l.ID, &l.Version)
+	if err != nil {
+		return entities.Loan{}, errors.DB{Err: err}
+	}
+
+	return l, nil
+}
+
+// UpdateLoan function is to perform required DB Queries to set the due and return dates and the renewals of a loan,
+// the loan is returned with its new version
+func (s SQLiteStorer) UpdateLoan(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+	return updateLoan(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdateLoanSQLite, id, l)
+}
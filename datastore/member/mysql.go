@@ -0,0 +1,142 @@
+package member
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Member
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanMember reads a member selected with the columns of datastore.GetMember, it is used by all the member stores
+func scanMember(row datastore.Scanner) (entities.Member, error) {
+	var m entities.Member
+
+	err := row.Scan(&m.ID, &m.FirstName, &m.LastName, &m.Email, &m.Phone, &m.Address, &m.MembershipType,
+		&m.ExpiresOn, &m.Status, &m.Version)
+
+	return m, err
+}
+
+// GetMembers function is to perform DB Queries to get the list of members
+func (s Storer) GetMembers(ctx context.Context) ([]entities.Member, error) {
+	return getMembers(ctx, datastore.Conn(ctx, s.db))
+}
+
+func getMembers(ctx context.Context, db datastore.DBTX) ([]entities.Member, error) {
+	rows, err := db.QueryContext(ctx, datastore.GetMember)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	members := make([]entities.Member, 0)
+
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		members = append(members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return members, nil
+}
+
+// GetMemberByID function is to perform DB Queries to get a member instance using its ID
+func (s Storer) GetMemberByID(ctx context.Context, id int) (entities.Member, error) {
+	return getMember(ctx, datastore.Conn(ctx, s.db), datastore.GetByIDMember, id)
+}
+
+// GetMemberByEmail function is to perform DB Queries to get a member instance using its email
+func (s Storer) GetMemberByEmail(ctx context.Context, email string) (entities.Member, error) {
+	return getMember(ctx, datastore.Conn(ctx, s.db), datastore.GetByEmailMember, email)
+}
+
+// getMember reads the single member selected by query, the id of the not found error is set only when key is an id
+func getMember(ctx context.Context, db datastore.DBTX, query string, key interface{}) (entities.Member, error) {
+	m, err := scanMember(db.QueryRowContext(ctx, query, key))
+	if err == sql.ErrNoRows {
+		id, _ := key.(int)
+
+		return entities.Member{}, errors.EntityNotFound{Entity: "Member", ID: id}
+	}
+
+	if err != nil {
+		return entities.Member{}, errors.DB{Err: err}
+	}
+
+	return m, nil
+}
+
+// CreateMember function is to perform DB execution to add a new member instance in database
+func (s Storer) CreateMember(ctx context.Context, m entities.Member) (entities.Member, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertMember, m.FirstName, m.LastName, m.Email,
+		m.Phone, m.Address, m.MembershipType, m.ExpiresOn, m.Status)
+	if err != nil {
+		return entities.Member{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Member{}, errors.DB{Err: err}
+	}
+
+	m.ID = int(id)
+	m.Version = 1
+
+	return m, nil
+}
+
+// UpdateMember function is to perform required DB Queries to replace a member instance in database,
+// the member is returned with its new version
+func (s Storer) UpdateMember(ctx context.Context, id int, m entities.Member) (entities.Member, error) {
+	return updateMember(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdateMember, id, m)
+}
+
+func updateMember(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	m entities.Member) (entities.Member, error) {
+	version, err := d.Update(ctx, db, "Member", "Members", id, query, m.FirstName, m.LastName, m.Email, m.Phone,
+		m.Address, m.MembershipType, m.ExpiresOn, m.Status, id, m.Version)
+	if err != nil {
+		return entities.Member{}, err
+	}
+
+	m.ID = id
+	m.Version = version
+
+	return m, nil
+}
+
+// DeleteMember function is to perform required DB Queries to remove a member instance from database
+func (s Storer) DeleteMember(ctx context.Context, id int) error {
+	return deleteMember(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func deleteMember(ctx context.Context, db datastore.DBTX, id int) error {
+	res, err := db.ExecContext(ctx, datastore.DeleteMember, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Member", ID: id}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package member
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "first_name", "last_name", "email", "phone", "address", "membership_type", "expires_on",
+	"status", "version"}
+
+func member() entities.Member {
+	return entities.Member{FirstName: "Rahul", LastName: "Saini", Email: "rahul@example.com", Phone: "9876543210",
+		Address: "Bangalore", MembershipType: entities.MembershipStudent,
+		ExpiresOn: entities.NewDate(2030, 12, 31),
+		Status:    entities.MemberActive}
+}
+
+func TestStorer_CreateMember(t *testing.T) {
+	created := member()
+	created.ID, created.Version = 4, 1
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.Member
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "email is taken", dbErr: fmt.Errorf("duplicate entry"), expErr: errors.DB{Err: fmt.Errorf("duplicate entry")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		m := member()
+
+		mock.ExpectExec(datastore.InsertMember).
+			WithArgs(m.FirstName, m.LastName, m.Email, m.Phone, m.Address, m.MembershipType, m.ExpiresOn, m.Status).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreateMember(context.Background(), m)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetMemberByID(t *testing.T) {
+	stored := member()
+	stored.ID, stored.Version = 1, 3
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes entities.Member
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.FirstName, stored.LastName, stored.Email,
+			stored.Phone, stored.Address, stored.MembershipType, stored.ExpiresOn, stored.Status, 3), expRes: stored},
+		{desc: "not found", rows: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Member", ID: 1}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetByIDMember).WithArgs(1)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetMemberByID(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetMemberByEmail(t *testing.T) {
+	stored := member()
+	stored.ID, stored.Version = 1, 3
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes entities.Member
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.FirstName, stored.LastName, stored.Email,
+			stored.Phone, stored.Address, stored.MembershipType, stored.ExpiresOn, stored.Status, 3), expRes: stored},
+		{desc: "not found", rows: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Member"}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetByEmailMember).WithArgs(stored.Email)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetMemberByEmail(context.Background(), stored.Email)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdateMember(t *testing.T) {
+	updated := member()
+	updated.ID, updated.Version = 1, 1
+
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		stored int
+		expRes entities.Member
+		expErr error
+	}{
+		{desc: "updated", rowsAffected: 1, expRes: updated},
+		{desc: "modified since read", stored: 2, expErr: errors.PreconditionFailed{Entity: "Member", ID: 1}},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Member", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		m := member()
+
+		mock.ExpectExec(datastore.UpdateMember).
+			WithArgs(m.FirstName, m.LastName, m.Email, m.Phone, m.Address, m.MembershipType, m.ExpiresOn, m.Status, 1,
+				m.Version).
+			WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+
+		if tc.rowsAffected == 0 {
+			rows := sqlmock.NewRows([]string{"version"})
+			if tc.stored != 0 {
+				rows.AddRow(tc.stored)
+			}
+
+			mock.ExpectQuery("select version from Members where id=?").WithArgs(1).WillReturnRows(rows)
+		}
+
+		res, err := New(db).UpdateMember(context.Background(), 1, m)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_DeleteMember(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		expErr       error
+	}{
+		{desc: "deleted", rowsAffected: 1},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Member", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.DeleteMember).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+
+		err := New(db).DeleteMember(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package member
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Member, it differs from Storer only in reading
+// the generated id of a new member
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetMembers function is to perform DB Queries to get the list of members
+func (s SQLiteStorer) GetMembers(ctx context.Context) ([]entities.Member, error) {
+	return getMembers(ctx, datastore.Conn(ctx, s.db))
+}
+
+// GetMemberByID function is to perform DB Queries to get a member instance using its ID
+func (s SQLiteStorer) GetMemberByID(ctx context.Context, id int) (entities.Member, error) {
+	return getMember(ctx, datastore.Conn(ctx, s.db), datastore.GetByIDMember, id)
+}
+
+// GetMemberByEmail function is to perform DB Queries to get a member instance using its email
+func (s SQLiteStorer) GetMemberByEmail(ctx context.Context, email string) (entities.Member, error) {
+	return getMember(ctx, datastore.Conn(ctx, s.db), datastore.GetByEmailMember, email)
+}
+
+// CreateMember function is to perform DB execution to add a new member instance in database
+func (s SQLiteStorer) CreateMember(ctx context.Context, m entities.Member) (entities.Member, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertMemberSQLite, m.FirstName, m.LastName,
+		m.Email, m.Phone, m.Address, m.MembershipType, m.ExpiresOn, m.Status).Scan(&m.ID, &m.Version)
+	if err != nil {
+		return entities.Member{}, errors.DB{Err: err}
+	}
+
+	return m, nil
+}
+
+// UpdateMember function is to perform required DB Queries to replace a member instance in database,
+// the member is returned with its new version
+func (s SQLiteStorer) UpdateMember(ctx context.Context, id int, m entities.Member) (entities.Member, error) {
+	return updateMember(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdateMemberSQLite, id, m)
+}
+
+// DeleteMember function is to perform required DB Queries to remove a member instance from database
+func (s SQLiteStorer) DeleteMember(ctx context.Context, id int) error {
+	return deleteMember(ctx, datastore.Conn(ctx, s.db), id)
+}
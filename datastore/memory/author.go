@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// AuthorStorer is the in memory implementation of datastore.Author
+type AuthorStorer struct {
+	db *DB
+}
+
+func NewAuthor(db *DB) AuthorStorer {
+	return AuthorStorer{db: db}
+}
+
+// GetAuthor returns all the authors ordered by id
+func (a AuthorStorer) GetAuthor(ctx context.Context) ([]entities.Author, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	authors := make([]entities.Author, 0, len(a.db.authors))
+	for _, author := range a.db.authors {
+		authors = append(authors, author)
+	}
+
+	sort.Slice(authors, func(i, j int) bool { return authors[i].ID < authors[j].ID })
+
+	return authors, nil
+}
+
+// GetAuthorByID returns the author with given id
+func (a AuthorStorer) GetAuthorByID(ctx context.Context, id int) (entities.Author, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	author, ok := a.db.authors[id]
+	if !ok {
+		return entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: id}
+	}
+
+	return author, nil
+}
+
+// GetAuthorsByIDs returns the authors having any of the ids ordered by id, ids which do not exist are skipped
+func (a AuthorStorer) GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	seen := make(map[int]bool, len(ids))
+	authors := make([]entities.Author, 0, len(ids))
+
+	for _, id := range ids {
+		if author, ok := a.db.authors[id]; ok && !seen[id] {
+			seen[id] = true
+			authors = append(authors, author)
+		}
+	}
+
+	sort.Slice(authors, func(i, j int) bool { return authors[i].ID < authors[j].ID })
+
+	return authors, nil
+}
+
+// CreateAuthor adds a new author with the next id, an author which already has an id
+// can be added only when the id is not taken
+func (a AuthorStorer) CreateAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
+	defer a.db.lock(ctx)()
+
+	if author.ID == 0 {
+		a.db.lastAuthorID++
+		author.ID = a.db.lastAuthorID
+	} else if _, ok := a.db.authors[author.ID]; ok {
+		return entities.Author{}, errors.ExistAlready{Entity: "Author"}
+	}
+
+	if author.ID > a.db.lastAuthorID {
+		a.db.lastAuthorID = author.ID
+	}
+
+	author.Version = 1
+	a.db.authors[author.ID] = author
+
+	return author, nil
+}
+
+// PutAuthor replaces the author with given id when it still has the version of author, the author is returned
+// with its new version the same way as the sql stores do
+func (a AuthorStorer) PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error) {
+	defer a.db.lock(ctx)()
+
+	stored, ok := a.db.authors[id]
+	if !ok {
+		return entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: id}
+	}
+
+	if stored.Version != author.Version {
+		return entities.Author{}, errors.PreconditionFailed{Entity: "Author", ID: id}
+	}
+
+	author.ID = id
+
+	author.Version = stored.Version + 1
+
+	updated := author
+	a.db.authors[id] = updated
+
+	return author, nil
+}
+
+// UpdateAuthorFields sets only the given fields of the author with given id when it still has the version of author,
+// the new version is returned
+func (a AuthorStorer) UpdateAuthorFields(ctx context.Context, id int, author entities.Author,
+	fields []string) (int, error) {
+	defer a.db.lock(ctx)()
+
+	stored, ok := a.db.authors[id]
+	if !ok {
+		return 0, errors.EntityNotFound{Entity: "Author", ID: id}
+	}
+
+	if stored.Version != author.Version {
+		return 0, errors.PreconditionFailed{Entity: "Author", ID: id}
+	}
+
+	for _, field := range fields {
+		switch field {
+		case entities.AuthorFirstName:
+			stored.FirstName = author.FirstName
+		case entities.AuthorLastName:
+			stored.LastName = author.LastName
+		case entities.AuthorDob:
+			stored.Dob = author.Dob
+		case entities.AuthorPenName:
+			stored.PenName = author.PenName
+		default:
+			return 0, fmt.Errorf("author field %q can not be updated", field)
+		}
+	}
+
+	stored.Version++
+	a.db.authors[id] = stored
+
+	return stored.Version, nil
+}
+
+// DeleteAuthor removes the author with given id along with the contributions to books of other authors,
+// an author who is still the author of a book can not be removed
+func (a AuthorStorer) DeleteAuthor(ctx context.Context, id int) error {
+	defer a.db.lock(ctx)()
+
+	if _, ok := a.db.authors[id]; !ok {
+		return errors.EntityNotFound{Entity: "Author", ID: id}
+	}
+
+	for _, book := range a.db.books {
+		if book.Author.ID == id {
+			return errors.DB{Err: fmt.Errorf("author %d is referenced by book %d", id, book.ID)}
+		}
+	}
+
+	for bookID, contributors := range a.db.contributors {
+		if credits(contributors, id) {
+			kept := make([]entities.Contributor, 0, len(contributors))
+			for _, c := range contributors {
+				if c.Author.ID != id {
+					kept = append(kept, c)
+				}
+			}
+
+			a.db.contributors[bookID] = kept
+		}
+	}
+
+	delete(a.db.authors, id)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAuthorStorer_CreateAuthor(t *testing.T) {
+	a := NewAuthor(New())
+
+	testcases := []struct {
+		desc    string
+		reqBody entities.Author
+		expRes  entities.Author
+		expErr  error
+	}{
+		{"first author", entities.Author{FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13),
+			PenName: "Verma"},
+			entities.Author{ID: 1, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13), PenName: "Verma", Version: 1}, nil},
+		{"second author", entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+			PenName: "HC"},
+			entities.Author{ID: 2, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "HC", Version: 1}, nil},
+		{"id already taken", entities.Author{ID: 2, FirstName: "HC"}, entities.Author{},
+			errors.ExistAlready{Entity: "Author"}},
+	}
+
+	for i, v := range testcases {
+		resp, err := a.CreateAuthor(context.Background(), v.reqBody)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if resp != v.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestAuthorStorer_GetAuthorByID(t *testing.T) {
+	a := NewAuthor(New())
+
+	author, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG", LastName: "Verma",
+		Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expRes entities.Author
+		expErr error
+	}{
+		{"get author", author.ID, author, nil},
+		{"Id NotFOUND", 999, entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: 999}},
+	}
+
+	for i, v := range testcases {
+		resp, err := a.GetAuthorByID(context.Background(), v.reqID)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if resp != v.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestAuthorStorer_DeleteAuthor(t *testing.T) {
+	db := New()
+	a := NewAuthor(db)
+
+	author, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG"})
+	withBook, _ := a.CreateAuthor(context.Background(), entities.Author{FirstName: "HC"})
+	_, _ = NewBook(db).CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: withBook,
+		Publisher: entities.Publisher{ID: 1}})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expErr bool
+	}{
+		{"Success Case", author.ID, false},
+		{"already deleted", author.ID, true},
+		{"author has books", withBook.ID, true},
+	}
+
+	for i, v := range testcases {
+		err := a.DeleteAuthor(context.Background(), v.reqID)
+
+		if (err != nil) != v.expErr {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected error %v\n", i+1, err, v.expErr)
+		}
+	}
+}
+
+func TestAuthorStorer_Concurrent(t *testing.T) {
+	a := NewAuthor(New())
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, _ = a.CreateAuthor(context.Background(), entities.Author{FirstName: "MG"})
+			_, _ = a.GetAuthor(context.Background())
+		}()
+	}
+
+	wg.Wait()
+
+	authors, _ := a.GetAuthor(context.Background())
+	if len(authors) != 50 || authors[49].ID != 50 {
+		t.Errorf("Failed. Expected 50 authors with ids 1 to 50, Got %v", authors)
+	}
+}
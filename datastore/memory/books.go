@@ -0,0 +1,694 @@
+package memory
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// BookStorer is the in memory implementation of datastore.Book
+type BookStorer struct {
+	db *DB
+}
+
+func NewBook(db *DB) BookStorer {
+	return BookStorer{db: db}
+}
+
+// GetAllBook returns all the books ordered by id, only the id of the author is set as done by the sql stores
+func (b BookStorer) GetAllBook(ctx context.Context) ([]entities.Book, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	books := make([]entities.Book, 0, len(b.db.books))
+	for _, book := range b.db.books {
+		books = append(books, book)
+	}
+
+	sort.Slice(books, func(i, j int) bool { return books[i].ID < books[j].ID })
+
+	return books, nil
+}
+
+// GetBooks returns a page of the books matching the filter, the books are filtered and sorted the same way as the sql stores do
+func (b BookStorer) GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	books := b.matchingBooks(ctx, filter)
+
+	// the books not in a series have the volume 0 and come first
+	volumes := make(map[int]int, len(books))
+
+	b.db.mu.RLock()
+	for _, book := range books {
+		volumes[book.ID] = b.db.seriesBooks[book.ID].Volume
+	}
+	b.db.mu.RUnlock()
+
+	sort.SliceStable(books, func(i, j int) bool {
+		less, equal := books[i].ID < books[j].ID, books[i].ID == books[j].ID
+
+		switch filter.Sort {
+		case entities.SortByTitle:
+			if books[i].Title != books[j].Title {
+				less, equal = books[i].Title < books[j].Title, false
+			}
+		case entities.SortByPublishedDate:
+			di, dj := books[i].PublishedDate.String(), books[j].PublishedDate.String()
+			if di != dj {
+				less, equal = di < dj, false
+			}
+		case entities.SortByVolume:
+			if vi, vj := volumes[books[i].ID], volumes[books[j].ID]; vi != vj {
+				less, equal = vi < vj, false
+			}
+		}
+
+		if filter.Desc {
+			return !less && !equal
+		}
+
+		return less
+	})
+
+	page := entities.BookPage{Books: make([]entities.Book, 0), Total: len(books)}
+
+	if filter.Offset < len(books) {
+		end := len(books)
+		if filter.Limit > 0 && filter.Offset+filter.Limit < end {
+			end = filter.Offset + filter.Limit
+		}
+
+		page.Books = append(page.Books, books[filter.Offset:end]...)
+	}
+
+	return page, nil
+}
+
+// matchingBooks returns the books matching the filter ordered by id
+func (b BookStorer) matchingBooks(ctx context.Context, filter entities.BookFilter) []entities.Book {
+	books, _ := b.GetAllBook(ctx)
+
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	count := 0
+
+	for _, book := range books {
+		if matchFilter(book, b.db.contributors[book.ID], b.db.bookGenres[book.ID], filter) &&
+			b.matchLinks(book.ID, filter) {
+			books[count] = book
+			count++
+		}
+	}
+
+	return books[:count]
+}
+
+// matchFilter tells whether the book has all the details set in the filter, contributors are the contributors
+// of the book and genreIDs the ids of the genres it is tagged with
+func matchFilter(book entities.Book, contributors []entities.Contributor, genreIDs []int,
+	filter entities.BookFilter) bool {
+	date := book.PublishedDate.String()
+	filterGenres := datastore.FilterGenres(filter)
+
+	switch {
+	case filter.Title != "" && book.Title != filter.Title:
+		return false
+	case filter.PublisherID != 0 && book.Publisher.ID != filter.PublisherID:
+		return false
+	case filter.AuthorID != 0 && !credits(contributors, filter.AuthorID):
+		return false
+	case len(filterGenres) > 0 && !tagged(genreIDs, filterGenres):
+		return false
+	case filter.PublishedFrom != "" && (date == "" || date < filter.PublishedFrom):
+		return false
+	case filter.PublishedTo != "" && (date == "" || date > filter.PublishedTo):
+		return false
+	default:
+		return true
+	}
+}
+
+// matchLinks tells whether the book with given id is a volume of the series of the filter and one of the books of its
+// work, the original or an edition, when the filter has them. db must be locked
+func (b BookStorer) matchLinks(id int, filter entities.BookFilter) bool {
+	if filter.SeriesID != 0 && b.db.seriesBooks[id].ID != filter.SeriesID {
+		return false
+	}
+
+	return filter.WorkID == 0 || id == filter.WorkID || b.db.editions[id].WorkID == filter.WorkID
+}
+
+// GetBookFacets counts the books matching the filter by genre, publisher and decade the same way as the sql stores do,
+// the facets are ordered by id and by decade
+func (b BookStorer) GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error) {
+	books := b.matchingBooks(ctx, filter)
+
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	genres, publishers, decades := make(map[int]int), make(map[int]int), make(map[int]int)
+
+	for _, book := range books {
+		// a genre counts the book once however many of its descendants the book is tagged with
+		ancestors := make(map[int]bool)
+
+		for _, id := range b.db.bookGenres[book.ID] {
+			for ; id != 0 && !ancestors[id]; id = b.db.genres[id].ParentID {
+				ancestors[id] = true
+			}
+		}
+
+		for id := range ancestors {
+			genres[id]++
+		}
+
+		publishers[book.Publisher.ID]++
+
+		if !book.PublishedDate.IsZero() {
+			decades[book.PublishedDate.Year()/10*10]++
+		}
+	}
+
+	facets := entities.BookFacets{Genres: idFacets(genres), Publishers: idFacets(publishers),
+		Decades: make([]entities.DecadeFacet, 0, len(decades))}
+
+	for decade, count := range decades {
+		facets.Decades = append(facets.Decades, entities.DecadeFacet{Decade: decade, Count: count})
+	}
+
+	sort.Slice(facets.Decades, func(i, j int) bool { return facets.Decades[i].Decade < facets.Decades[j].Decade })
+
+	return facets, nil
+}
+
+// idFacets returns the counts by id as facets ordered by id
+func idFacets(counts map[int]int) []entities.Facet {
+	facets := make([]entities.Facet, 0, len(counts))
+	for id, count := range counts {
+		facets = append(facets, entities.Facet{ID: id, Count: count})
+	}
+
+	sort.Slice(facets, func(i, j int) bool { return facets[i].ID < facets[j].ID })
+
+	return facets
+}
+
+// GetBookByID returns the book with given id
+func (b BookStorer) GetBookByID(ctx context.Context, id int) (entities.Book, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	book, ok := b.db.books[id]
+	if !ok {
+		return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
+	}
+
+	return book, nil
+}
+
+// GetBookByISBN returns the book with given isbn
+func (b BookStorer) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	for _, book := range b.db.books {
+		if isbn != "" && book.ISBN == isbn {
+			return book, nil
+		}
+	}
+
+	return entities.Book{}, errors.EntityNotFound{Entity: "Book"}
+}
+
+// GetDuplicates returns the books having the same details as the book for all the keys ordered by id, the titles are
+// compared by their keys the same way as in the sql stores
+func (b BookStorer) GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error) {
+	books, _ := b.GetAllBook(ctx)
+
+	duplicates := make([]entities.Book, 0)
+
+	for i := range books {
+		same := true
+
+		for _, key := range keys {
+			switch key {
+			case entities.DuplicateByTitle:
+				same = same && entities.TitleKey(books[i].Title) == entities.TitleKey(book.Title)
+			case entities.DuplicateByAuthor:
+				same = same && books[i].Author.ID == book.Author.ID
+			case entities.DuplicateByPublisher:
+				same = same && books[i].Publisher.ID == book.Publisher.ID
+			case entities.DuplicateByPublishedDate:
+				same = same && books[i].PublishedDate == book.PublishedDate
+			}
+		}
+
+		if same {
+			duplicates = append(duplicates, books[i])
+		}
+	}
+
+	return duplicates, nil
+}
+
+// SearchBooks returns a page of the books matching the search, the books are ranked by a datastore.SearchIndex
+// the same way as in the sql stores without full-text search
+func (b BookStorer) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	books, _ := b.GetAllBook(ctx)
+
+	b.db.mu.RLock()
+	contributors := make(map[int][]entities.Contributor, len(books))
+	for _, book := range books {
+		contributors[book.ID] = b.db.contributors[book.ID]
+	}
+
+	authors := make([]entities.Author, 0, len(b.db.authors))
+	for _, author := range b.db.authors {
+		authors = append(authors, author)
+	}
+
+	publishers := make([]entities.Publisher, 0, len(b.db.publishers))
+	for _, publisher := range b.db.publishers {
+		publishers = append(publishers, publisher)
+	}
+	b.db.mu.RUnlock()
+
+	return datastore.NewSearchIndex(books, contributors, authors, publishers).Search(query), nil
+}
+
+// CreateBook adds a new book with the next id, the author and the publisher of the book must exist
+func (b BookStorer) CreateBook(ctx context.Context, book entities.Book) (entities.Book, error) {
+	defer b.db.lock(ctx)()
+
+	if err := b.checkReferences(book.ID, book); err != nil {
+		return entities.Book{}, err
+	}
+
+	if book.ID == 0 {
+		b.db.lastBookID++
+		book.ID = b.db.lastBookID
+	} else if _, ok := b.db.books[book.ID]; ok {
+		return entities.Book{}, errors.ExistAlready{Entity: "Book"}
+	}
+
+	if book.ID > b.db.lastBookID {
+		b.db.lastBookID = book.ID
+	}
+
+	book.Author = entities.Author{ID: book.Author.ID}
+	book.Publisher = entities.Publisher{ID: book.Publisher.ID}
+	book.Availability = nil
+	book.Version = 1
+
+	stored := book
+	stored.Contributors, stored.Genres, stored.Series, stored.Edition = nil, nil, nil, nil
+	b.db.books[book.ID] = stored
+	b.db.contributors[book.ID] = contributorIDs(book.Credits())
+	b.db.bookGenres[book.ID] = genreIDs(book.Genres)
+	b.db.setSeries(book.ID, book.Series)
+	b.db.setEdition(book.ID, book.Edition)
+
+	return book, nil
+}
+
+// UpdateBook replaces the book with given id when it still has the version of book, the book is returned with its new
+// version. The author and the publisher of the book must exist
+func (b BookStorer) UpdateBook(ctx context.Context, id int, book entities.Book) (entities.Book, error) {
+	defer b.db.lock(ctx)()
+
+	stored, ok := b.db.books[id]
+	if !ok {
+		return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
+	}
+
+	if stored.Version != book.Version {
+		return entities.Book{}, errors.PreconditionFailed{Entity: "Book", ID: id}
+	}
+
+	if err := b.checkReferences(id, book); err != nil {
+		return entities.Book{}, err
+	}
+
+	book.ID = id
+	book.Author = entities.Author{ID: book.Author.ID}
+	book.Publisher = entities.Publisher{ID: book.Publisher.ID}
+	book.Availability = nil
+
+	book.Version = stored.Version + 1
+
+	updated := book
+	updated.Contributors, updated.Genres, updated.Series, updated.Edition = nil, nil, nil, nil
+	b.db.books[id] = updated
+	b.db.contributors[id] = contributorIDs(book.Credits())
+	b.db.bookGenres[id] = genreIDs(book.Genres)
+	b.db.setSeries(id, book.Series)
+	b.db.setEdition(id, book.Edition)
+
+	return book, nil
+}
+
+// UpdateBookFields sets only the given fields of the book with given id when it still has the version of book, the new
+// version is returned. The author and the publisher of the book must exist
+func (b BookStorer) UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+	defer b.db.lock(ctx)()
+
+	stored, ok := b.db.books[id]
+	if !ok {
+		return 0, errors.EntityNotFound{Entity: "Book", ID: id}
+	}
+
+	if stored.Version != book.Version {
+		return 0, errors.PreconditionFailed{Entity: "Book", ID: id}
+	}
+
+	for _, field := range fields {
+		switch field {
+		case entities.BookTitle:
+			stored.Title = book.Title
+		case entities.BookPublisher:
+			if _, ok := b.db.publishers[book.Publisher.ID]; !ok {
+				return 0, errors.DB{Err: fmt.Errorf("publisher %d does not exist", book.Publisher.ID)}
+			}
+
+			stored.Publisher = entities.Publisher{ID: book.Publisher.ID}
+		case entities.BookPublishedDate:
+			stored.PublishedDate = book.PublishedDate
+		case entities.BookISBN:
+			if err := b.checkISBN(id, book.ISBN); err != nil {
+				return 0, err
+			}
+
+			stored.ISBN = book.ISBN
+		case entities.BookContributors:
+			if err := b.checkAuthors(book); err != nil {
+				return 0, err
+			}
+
+			stored.Author = entities.Author{ID: book.Author.ID}
+			b.db.contributors[id] = contributorIDs(book.Credits())
+		case entities.BookGenres:
+			if err := b.checkGenres(book); err != nil {
+				return 0, err
+			}
+
+			b.db.bookGenres[id] = genreIDs(book.Genres)
+		case entities.BookSeries:
+			if err := b.checkSeries(id, book.Series); err != nil {
+				return 0, err
+			}
+
+			b.db.setSeries(id, book.Series)
+		case entities.BookEdition:
+			if err := b.checkEdition(book.Edition); err != nil {
+				return 0, err
+			}
+
+			b.db.setEdition(id, book.Edition)
+		default:
+			return 0, fmt.Errorf("book field %q can not be updated", field)
+		}
+	}
+
+	stored.Version++
+	b.db.books[id] = stored
+
+	return stored.Version, nil
+}
+
+// checkReferences fails the same way as the constraints of the sql tables when the author, the publisher, any of
+// the genres, the series or the original of the book with given id does not exist or another book has its isbn or its
+// volume, db must be locked
+func (b BookStorer) checkReferences(id int, book entities.Book) error {
+	if err := b.checkAuthors(book); err != nil {
+		return err
+	}
+
+	if err := b.checkGenres(book); err != nil {
+		return err
+	}
+
+	if err := b.checkSeries(id, book.Series); err != nil {
+		return err
+	}
+
+	if err := b.checkEdition(book.Edition); err != nil {
+		return err
+	}
+
+	if _, ok := b.db.publishers[book.Publisher.ID]; !ok {
+		return errors.DB{Err: fmt.Errorf("publisher %d does not exist", book.Publisher.ID)}
+	}
+
+	return b.checkISBN(id, book.ISBN)
+}
+
+// checkISBN fails the same way as the unique index of the sql tables when a book other than the one with given id
+// has the isbn, books without an isbn never clash, db must be locked
+func (b BookStorer) checkISBN(id int, isbn string) error {
+	if isbn == "" {
+		return nil
+	}
+
+	for _, other := range b.db.books {
+		if other.ID != id && other.ISBN == isbn {
+			return errors.ExistAlready{Entity: "Book", Field: "isbn"}
+		}
+	}
+
+	return nil
+}
+
+// checkAuthors fails the same way as the foreign keys of the sql tables when the author or any of the contributors
+// of the book does not exist, db must be locked
+func (b BookStorer) checkAuthors(book entities.Book) error {
+	if _, ok := b.db.authors[book.Author.ID]; !ok {
+		return errors.DB{Err: fmt.Errorf("author %d does not exist", book.Author.ID)}
+	}
+
+	for _, c := range book.Contributors {
+		if _, ok := b.db.authors[c.Author.ID]; !ok {
+			return errors.DB{Err: fmt.Errorf("author %d does not exist", c.Author.ID)}
+		}
+	}
+
+	return nil
+}
+
+// checkGenres fails the same way as the foreign keys of the sql tables when any of the genres of the book does not
+// exist, db must be locked
+func (b BookStorer) checkGenres(book entities.Book) error {
+	for _, g := range book.Genres {
+		if _, ok := b.db.genres[g.ID]; !ok {
+			return errors.DB{Err: fmt.Errorf("genre %d does not exist", g.ID)}
+		}
+	}
+
+	return nil
+}
+
+// checkSeries fails the same way as the constraints of the sql tables when the series does not exist or a book other
+// than the one with given id is its volume, db must be locked
+func (b BookStorer) checkSeries(id int, series *entities.SeriesVolume) error {
+	if series == nil {
+		return nil
+	}
+
+	if _, ok := b.db.series[series.ID]; !ok {
+		return errors.DB{Err: fmt.Errorf("series %d does not exist", series.ID)}
+	}
+
+	for bookID, volume := range b.db.seriesBooks {
+		if bookID != id && volume.ID == series.ID && volume.Volume == series.Volume {
+			return errors.DB{Err: fmt.Errorf("volume %d of series %d is book %d", series.Volume, series.ID, bookID)}
+		}
+	}
+
+	return nil
+}
+
+// checkEdition fails the same way as the foreign key of the sql tables when the original of the edition does not
+// exist, db must be locked
+func (b BookStorer) checkEdition(edition *entities.Edition) error {
+	if edition == nil {
+		return nil
+	}
+
+	if _, ok := b.db.books[edition.WorkID]; !ok {
+		return errors.DB{Err: fmt.Errorf("book %d does not exist", edition.WorkID)}
+	}
+
+	return nil
+}
+
+// GetContributors returns the contributors of the books in the order of the credits, only the ids of the authors
+// are set as done by the sql stores
+func (b BookStorer) GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	contributors := make(map[int][]entities.Contributor)
+
+	for _, id := range bookIDs {
+		if c, ok := b.db.contributors[id]; ok && len(c) > 0 {
+			contributors[id] = append([]entities.Contributor(nil), c...)
+		}
+	}
+
+	return contributors, nil
+}
+
+// GetBookGenres returns the genres the books are tagged with ordered by id
+func (b BookStorer) GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	genres := make(map[int][]entities.Genre)
+
+	for _, id := range bookIDs {
+		for _, genreID := range b.db.bookGenres[id] {
+			genres[id] = append(genres[id], b.db.genres[genreID])
+		}
+
+		sort.Slice(genres[id], func(i, j int) bool { return genres[id][i].ID < genres[id][j].ID })
+	}
+
+	return genres, nil
+}
+
+// GetBookSeries returns the series of the books which are volumes of one, with the names of the series
+func (b BookStorer) GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	series := make(map[int]entities.SeriesVolume)
+
+	for _, id := range bookIDs {
+		if volume, ok := b.db.seriesBooks[id]; ok {
+			volume.Name = b.db.series[volume.ID].Name
+			series[id] = volume
+		}
+	}
+
+	return series, nil
+}
+
+// GetEditions returns the edition links of the books which are editions or translations of another book
+func (b BookStorer) GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error) {
+	b.db.mu.RLock()
+	defer b.db.mu.RUnlock()
+
+	editions := make(map[int]entities.Edition)
+
+	for _, id := range bookIDs {
+		if edition, ok := b.db.editions[id]; ok {
+			editions[id] = edition
+		}
+	}
+
+	return editions, nil
+}
+
+// setSeries keeps the book with given id as the volume of the series with only its id set, a book without a series
+// is removed from its series. db must be locked
+func (db *DB) setSeries(id int, series *entities.SeriesVolume) {
+	if series == nil {
+		delete(db.seriesBooks, id)
+		return
+	}
+
+	db.seriesBooks[id] = entities.SeriesVolume{ID: series.ID, Volume: series.Volume}
+}
+
+// setEdition keeps the edition link of the book with given id, the link of a book without an edition is removed.
+// db must be locked
+func (db *DB) setEdition(id int, edition *entities.Edition) {
+	if edition == nil {
+		delete(db.editions, id)
+		return
+	}
+
+	db.editions[id] = *edition
+}
+
+// contributorIDs returns a copy of the contributors having only the ids of the authors
+func contributorIDs(contributors []entities.Contributor) []entities.Contributor {
+	ids := make([]entities.Contributor, len(contributors))
+	for i, c := range contributors {
+		ids[i] = entities.Contributor{Author: entities.Author{ID: c.Author.ID}, Role: c.Role}
+	}
+
+	return ids
+}
+
+// genreIDs returns the ids of the genres
+func genreIDs(genres []entities.Genre) []int {
+	ids := make([]int, len(genres))
+	for i := range genres {
+		ids[i] = genres[i].ID
+	}
+
+	return ids
+}
+
+// tagged tells whether any of the genres with the ids of genreIDs is one of the filter
+func tagged(genreIDs, filter []int) bool {
+	for _, id := range genreIDs {
+		for _, f := range filter {
+			if id == f {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// credits tells whether the author with given id is any of the contributors
+func credits(contributors []entities.Contributor, authorID int) bool {
+	for _, c := range contributors {
+		if c.Author.ID == authorID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteBook removes the book with given id along with its copies, the editions of the book lose their links
+// the same way as in the sql tables. A book whose copies have been lent can not be removed
+func (b BookStorer) DeleteBook(ctx context.Context, id int) error {
+	defer b.db.lock(ctx)()
+
+	if _, ok := b.db.books[id]; !ok {
+		return errors.EntityNotFound{Entity: "Book", ID: id}
+	}
+
+	if loan, ok := b.db.findLoan(func(loan entities.Loan) bool { return loan.BookID == id }); ok {
+		return errors.DB{Err: fmt.Errorf("book %d has loan %d", id, loan.ID)}
+	}
+
+	delete(b.db.books, id)
+	delete(b.db.contributors, id)
+	delete(b.db.bookGenres, id)
+	delete(b.db.seriesBooks, id)
+	delete(b.db.editions, id)
+
+	for bookID, edition := range b.db.editions {
+		if edition.WorkID == id {
+			delete(b.db.editions, bookID)
+		}
+	}
+
+	for copyID, cp := range b.db.copies {
+		if cp.BookID == id {
+			delete(b.db.copies, copyID)
+		}
+	}
+
+	b.db.deleteHolds(func(hold entities.Hold) bool { return hold.BookID == id })
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// newBookStore returns a book store whose database has a single author with id 1
+func newBookStore() BookStorer {
+	db := New()
+	_, _ = NewAuthor(db).CreateAuthor(context.Background(), entities.Author{FirstName: "MG", LastName: "Verma",
+		Dob: entities.NewDate(2000, 7, 13), PenName: "Verma"})
+
+	return NewBook(db)
+}
+
+func TestBookStorer_CreateBook(t *testing.T) {
+	b := newBookStore()
+
+	testcases := []struct {
+		desc    string
+		reqBody entities.Book
+		expRes  entities.Book
+		expErr  bool
+	}{
+		{desc: "Valid Details", reqBody: entities.Book{Title: "Rahul", Author: entities.Author{ID: 1, FirstName: "MG"},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			expRes: entities.Book{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22), Version: 1}},
+		{desc: "author does not exist", reqBody: entities.Book{Title: "Rahul", Author: entities.Author{ID: 99}},
+			expErr: true},
+		{desc: "publisher does not exist", reqBody: entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 99}}, expErr: true},
+	}
+
+	for i, v := range testcases {
+		resp, err := b.CreateBook(context.Background(), v.reqBody)
+
+		if (err != nil) != v.expErr {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected error %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(resp, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, resp, v.expRes)
+		}
+	}
+}
+
+func TestBookStorer_GetAllBook(t *testing.T) {
+	b := newBookStore()
+
+	first, _ := b.CreateBook(context.Background(), entities.Book{Title: "first", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 1}})
+	second, _ := b.CreateBook(context.Background(), entities.Book{Title: "second", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 1}})
+
+	resp, err := b.GetAllBook(context.Background())
+	if err != nil || !reflect.DeepEqual(resp, []entities.Book{first, second}) {
+		t.Errorf("[TEST1]Failed. Got %v, %v\tExpected %v\n", resp, err, []entities.Book{first, second})
+	}
+}
+
+func TestBookStorer_UpdateBook(t *testing.T) {
+	b := newBookStore()
+
+	book, _ := b.CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 1}})
+
+	testcases := []struct {
+		desc    string
+		reqID   int
+		reqBody entities.Book
+		expBody entities.Book
+		expErr  error
+	}{
+		{desc: "valid case id exist", reqID: book.ID,
+			reqBody: entities.Book{Title: "title", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+				Version: book.Version},
+			expBody: entities.Book{ID: book.ID, Title: "title", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 1}, Version: book.Version + 1}},
+		{desc: "modified since read", reqID: book.ID,
+			reqBody: entities.Book{Title: "title", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+				Version: book.Version},
+			expErr: errors.PreconditionFailed{Entity: "Book", ID: book.ID}},
+		{desc: "id does not exist", reqID: 1000, reqBody: entities.Book{Title: "title"},
+			expErr: errors.EntityNotFound{Entity: "Book", ID: 1000}},
+	}
+
+	for i, v := range testcases {
+		res, err := b.UpdateBook(context.Background(), v.reqID, v.reqBody)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+
+		if !reflect.DeepEqual(res, v.expBody) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, v.expBody)
+		}
+	}
+}
+
+func TestBookStorer_DeleteBook(t *testing.T) {
+	b := newBookStore()
+
+	book, _ := b.CreateBook(context.Background(), entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 1}})
+
+	testcases := []struct {
+		desc   string
+		reqID  int
+		expErr error
+	}{
+		{"Valid Details", book.ID, nil},
+		{"Book does not exists", book.ID, errors.EntityNotFound{Entity: "Book", ID: book.ID}},
+	}
+
+	for i, v := range testcases {
+		err := b.DeleteBook(context.Background(), v.reqID)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, v.expErr)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// CopyStorer is the in memory implementation of datastore.Copy
+type CopyStorer struct {
+	db *DB
+}
+
+func NewCopy(db *DB) CopyStorer {
+	return CopyStorer{db: db}
+}
+
+// GetCopies returns the copies of the book ordered by id
+func (c CopyStorer) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	c.db.mu.RLock()
+	defer c.db.mu.RUnlock()
+
+	copies := make([]entities.Copy, 0)
+
+	for _, cp := range c.db.copies {
+		if cp.BookID == bookID {
+			copies = append(copies, cp)
+		}
+	}
+
+	sort.Slice(copies, func(i, j int) bool { return copies[i].ID < copies[j].ID })
+
+	return copies, nil
+}
+
+// GetCopyByID returns the copy with given id
+func (c CopyStorer) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	c.db.mu.RLock()
+	defer c.db.mu.RUnlock()
+
+	cp, ok := c.db.copies[id]
+	if !ok {
+		return entities.Copy{}, errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	return cp, nil
+}
+
+// GetCopyByBarcode returns the copy with given barcode
+func (c CopyStorer) GetCopyByBarcode(ctx context.Context, barcode string) (entities.Copy, error) {
+	c.db.mu.RLock()
+	defer c.db.mu.RUnlock()
+
+	for _, cp := range c.db.copies {
+		if cp.Barcode == barcode {
+			return cp, nil
+		}
+	}
+
+	return entities.Copy{}, errors.EntityNotFound{Entity: "Copy"}
+}
+
+// GetAvailability counts the copies of the books by status, the books without copies are left out
+func (c CopyStorer) GetAvailability(ctx context.Context, bookIDs []int) (map[int]entities.Availability, error) {
+	c.db.mu.RLock()
+	defer c.db.mu.RUnlock()
+
+	ids := make(map[int]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		ids[id] = true
+	}
+
+	availability := make(map[int]entities.Availability)
+
+	for _, cp := range c.db.copies {
+		if ids[cp.BookID] {
+			availability[cp.BookID] = availability[cp.BookID].Add(cp.Status, 1)
+		}
+	}
+
+	return availability, nil
+}
+
+// CreateCopy adds a new copy with the next id, the book must exist and the barcode has to be unique
+// the same as in the sql tables
+func (c CopyStorer) CreateCopy(ctx context.Context, cp entities.Copy) (entities.Copy, error) {
+	defer c.db.lock(ctx)()
+
+	if err := c.check(0, cp); err != nil {
+		return entities.Copy{}, err
+	}
+
+	c.db.lastCopyID++
+	cp.ID = c.db.lastCopyID
+	cp.Version = 1
+
+	c.db.copies[cp.ID] = cp
+
+	return cp, nil
+}
+
+// UpdateCopy replaces the copy with given id when it still has the version of cp, the copy is returned
+// with its new version
+func (c CopyStorer) UpdateCopy(ctx context.Context, id int, cp entities.Copy) (entities.Copy, error) {
+	defer c.db.lock(ctx)()
+
+	stored, ok := c.db.copies[id]
+	if !ok {
+		return entities.Copy{}, errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	if stored.Version != cp.Version {
+		return entities.Copy{}, errors.PreconditionFailed{Entity: "Copy", ID: id}
+	}
+
+	if err := c.check(id, cp); err != nil {
+		return entities.Copy{}, err
+	}
+
+	cp.ID = id
+
+	cp.Version = stored.Version + 1
+
+	updated := cp
+	c.db.copies[id] = updated
+
+	return cp, nil
+}
+
+// UpdateCopyStatus sets the status of the copy with given id only when it has the status from
+func (c CopyStorer) UpdateCopyStatus(ctx context.Context, id int, from, to string) error {
+	defer c.db.lock(ctx)()
+
+	cp, ok := c.db.copies[id]
+	if !ok || cp.Status != from {
+		return errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	cp.Status = to
+	cp.Version++
+	c.db.copies[id] = cp
+
+	return nil
+}
+
+// DeleteCopy removes the copy with given id, a copy which has been lent can not be removed
+func (c CopyStorer) DeleteCopy(ctx context.Context, id int) error {
+	defer c.db.lock(ctx)()
+
+	if _, ok := c.db.copies[id]; !ok {
+		return errors.EntityNotFound{Entity: "Copy", ID: id}
+	}
+
+	if loan, ok := c.db.findLoan(func(loan entities.Loan) bool { return loan.CopyID == id }); ok {
+		return errors.DB{Err: fmt.Errorf("copy %d has loan %d", id, loan.ID)}
+	}
+
+	delete(c.db.copies, id)
+
+	// the holds are kept without the copy as the foreign key of the sql table sets it to NULL
+	for holdID, hold := range c.db.holds {
+		if hold.CopyID == id {
+			hold.CopyID = 0
+			c.db.holds[holdID] = hold
+		}
+	}
+
+	return nil
+}
+
+// check fails the same way as the constraints of the sql tables when the book of the copy does not exist
+// or another copy has the barcode
+func (c CopyStorer) check(id int, cp entities.Copy) error {
+	if _, ok := c.db.books[cp.BookID]; !ok {
+		return errors.DB{Err: fmt.Errorf("book %d does not exist", cp.BookID)}
+	}
+
+	for _, other := range c.db.copies {
+		if other.ID != id && other.Barcode == cp.Barcode {
+			return errors.DB{Err: fmt.Errorf("barcode %q is taken by copy %d", cp.Barcode, other.ID)}
+		}
+	}
+
+	return nil
+}
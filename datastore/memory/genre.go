@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GenreStorer is the in memory implementation of datastore.Genre
+type GenreStorer struct {
+	db *DB
+}
+
+func NewGenre(db *DB) GenreStorer {
+	return GenreStorer{db: db}
+}
+
+// GetGenres returns all the genres ordered by id
+func (g GenreStorer) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	genres := make([]entities.Genre, 0, len(g.db.genres))
+	for _, genre := range g.db.genres {
+		genres = append(genres, genre)
+	}
+
+	sort.Slice(genres, func(i, j int) bool { return genres[i].ID < genres[j].ID })
+
+	return genres, nil
+}
+
+// GetGenreByID returns the genre with given id
+func (g GenreStorer) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	genre, ok := g.db.genres[id]
+	if !ok {
+		return entities.Genre{}, errors.EntityNotFound{Entity: "Genre", ID: id}
+	}
+
+	return genre, nil
+}
+
+// CreateGenre adds a new genre with the next id, the parent must exist and the name has to be unique among
+// the genres of the kind the same as in the sql tables
+func (g GenreStorer) CreateGenre(ctx context.Context, genre entities.Genre) (entities.Genre, error) {
+	defer g.db.lock(ctx)()
+
+	if err := g.checkGenre(0, genre); err != nil {
+		return entities.Genre{}, err
+	}
+
+	g.db.lastGenreID++
+	genre.ID = g.db.lastGenreID
+	genre.Version = 1
+
+	g.db.genres[genre.ID] = genre
+
+	return genre, nil
+}
+
+// UpdateGenre replaces the genre with given id when it still has the version of genre, the genre is returned
+// with its new version
+func (g GenreStorer) UpdateGenre(ctx context.Context, id int, genre entities.Genre) (entities.Genre, error) {
+	defer g.db.lock(ctx)()
+
+	stored, ok := g.db.genres[id]
+	if !ok {
+		return entities.Genre{}, errors.EntityNotFound{Entity: "Genre", ID: id}
+	}
+
+	if stored.Version != genre.Version {
+		return entities.Genre{}, errors.PreconditionFailed{Entity: "Genre", ID: id}
+	}
+
+	if err := g.checkGenre(id, genre); err != nil {
+		return entities.Genre{}, err
+	}
+
+	genre.ID = id
+
+	genre.Version = stored.Version + 1
+
+	updated := genre
+	g.db.genres[id] = updated
+
+	return genre, nil
+}
+
+// DeleteGenre removes the genre with given id along with its tags on the books, it fails the same way as
+// the foreign key of the sql tables when the genre has children
+func (g GenreStorer) DeleteGenre(ctx context.Context, id int) error {
+	defer g.db.lock(ctx)()
+
+	if _, ok := g.db.genres[id]; !ok {
+		return errors.EntityNotFound{Entity: "Genre", ID: id}
+	}
+
+	for _, genre := range g.db.genres {
+		if genre.ParentID == id {
+			return errors.DB{Err: fmt.Errorf("genre %d has genre %d", id, genre.ID)}
+		}
+	}
+
+	delete(g.db.genres, id)
+
+	for bookID, genreIDs := range g.db.bookGenres {
+		kept := make([]int, 0, len(genreIDs))
+
+		for _, genreID := range genreIDs {
+			if genreID != id {
+				kept = append(kept, genreID)
+			}
+		}
+
+		g.db.bookGenres[bookID] = kept
+	}
+
+	return nil
+}
+
+// checkGenre fails the same way as the constraints of the sql tables when the parent of the genre with given id
+// does not exist or another genre of the kind has the name
+func (g GenreStorer) checkGenre(id int, genre entities.Genre) error {
+	if _, ok := g.db.genres[genre.ParentID]; genre.ParentID != 0 && !ok {
+		return errors.DB{Err: fmt.Errorf("genre %d does not exist", genre.ParentID)}
+	}
+
+	for _, other := range g.db.genres {
+		if other.ID != id && other.Kind == genre.Kind && strings.EqualFold(other.Name, genre.Name) {
+			return errors.DB{Err: fmt.Errorf("name %q is taken by genre %d", genre.Name, other.ID)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// HoldStorer is the in memory implementation of datastore.Hold
+type HoldStorer struct {
+	db *DB
+}
+
+func NewHold(db *DB) HoldStorer {
+	return HoldStorer{db: db}
+}
+
+// GetHoldByID returns the hold with given id
+func (h HoldStorer) GetHoldByID(ctx context.Context, id int) (entities.Hold, error) {
+	h.db.mu.RLock()
+	defer h.db.mu.RUnlock()
+
+	hold, ok := h.db.holds[id]
+	if !ok {
+		return entities.Hold{}, errors.EntityNotFound{Entity: "Hold", ID: id}
+	}
+
+	return hold, nil
+}
+
+// GetBookHolds returns the waiting and ready holds of the book ordered by id
+func (h HoldStorer) GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error) {
+	return h.holds(func(hold entities.Hold) bool { return hold.BookID == bookID && hold.Queued() }), nil
+}
+
+// GetMemberHolds returns the waiting and ready holds of the member ordered by id
+func (h HoldStorer) GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error) {
+	return h.holds(func(hold entities.Hold) bool { return hold.MemberID == memberID && hold.Queued() }), nil
+}
+
+// GetHoldsByStatus returns the holds having the status ordered by id
+func (h HoldStorer) GetHoldsByStatus(ctx context.Context, status string) ([]entities.Hold, error) {
+	return h.holds(func(hold entities.Hold) bool { return hold.Status == status }), nil
+}
+
+func (h HoldStorer) holds(match func(hold entities.Hold) bool) []entities.Hold {
+	h.db.mu.RLock()
+	defer h.db.mu.RUnlock()
+
+	holds := make([]entities.Hold, 0)
+
+	for _, hold := range h.db.holds {
+		if match(hold) {
+			holds = append(holds, hold)
+		}
+	}
+
+	sort.Slice(holds, func(i, j int) bool { return holds[i].ID < holds[j].ID })
+
+	return holds
+}
+
+// CreateHold adds a new hold with the next id, the book, the member and the copy when set must exist
+func (h HoldStorer) CreateHold(ctx context.Context, hold entities.Hold) (entities.Hold, error) {
+	defer h.db.lock(ctx)()
+
+	if err := h.db.checkHold(hold); err != nil {
+		return entities.Hold{}, err
+	}
+
+	h.db.lastHoldID++
+	hold.ID = h.db.lastHoldID
+	hold.Version = 1
+
+	h.db.holds[hold.ID] = hold
+
+	return hold, nil
+}
+
+// UpdateHold sets the copy, the status and the dates of the hold with given id when it still has the version
+// of hold, the hold is returned with its new version
+func (h HoldStorer) UpdateHold(ctx context.Context, id int, hold entities.Hold) (entities.Hold, error) {
+	defer h.db.lock(ctx)()
+
+	stored, ok := h.db.holds[id]
+	if !ok {
+		return entities.Hold{}, errors.EntityNotFound{Entity: "Hold", ID: id}
+	}
+
+	if stored.Version != hold.Version {
+		return entities.Hold{}, errors.PreconditionFailed{Entity: "Hold", ID: id}
+	}
+
+	if _, ok = h.db.copies[hold.CopyID]; hold.CopyID != 0 && !ok {
+		return entities.Hold{}, errors.DB{Err: fmt.Errorf("copy %d does not exist", hold.CopyID)}
+	}
+
+	stored.CopyID, stored.Status, stored.ReadyOn, stored.ExpiresOn = hold.CopyID, hold.Status, hold.ReadyOn,
+		hold.ExpiresOn
+	stored.Version++
+	h.db.holds[id] = stored
+
+	hold.ID = id
+	hold.Version = stored.Version
+
+	return hold, nil
+}
+
+// checkHold returns an error when the book, the member or the set copy of the hold does not exist,
+// db must be locked
+func (db *DB) checkHold(hold entities.Hold) error {
+	_, bookOK := db.books[hold.BookID]
+	_, memberOK := db.members[hold.MemberID]
+	_, copyOK := db.copies[hold.CopyID]
+
+	if !bookOK || !memberOK || (hold.CopyID != 0 && !copyOK) {
+		return errors.DB{Err: fmt.Errorf("book %d, member %d or copy %d does not exist", hold.BookID, hold.MemberID,
+			hold.CopyID)}
+	}
+
+	return nil
+}
+
+// deleteHolds removes the matching holds the same way as the foreign keys of the sql tables cascade,
+// db must be locked for a write
+func (db *DB) deleteHolds(match func(hold entities.Hold) bool) {
+	for id, hold := range db.holds {
+		if match(hold) {
+			delete(db.holds, id)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// LedgerStorer is the in memory implementation of datastore.Ledger
+type LedgerStorer struct {
+	db *DB
+}
+
+func NewLedger(db *DB) LedgerStorer {
+	return LedgerStorer{db: db}
+}
+
+// GetEntries returns the entries of the member ordered by id
+func (l LedgerStorer) GetEntries(ctx context.Context, memberID int) ([]entities.LedgerEntry, error) {
+	l.db.mu.RLock()
+	defer l.db.mu.RUnlock()
+
+	entries := make([]entities.LedgerEntry, 0)
+
+	for _, entry := range l.db.ledger {
+		if entry.MemberID == memberID {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	return entries, nil
+}
+
+// GetBalance returns the sum of the fines less the payments and the waivers of the member
+func (l LedgerStorer) GetBalance(ctx context.Context, memberID int) (int, error) {
+	l.db.mu.RLock()
+	defer l.db.mu.RUnlock()
+
+	balance := 0
+
+	for _, entry := range l.db.ledger {
+		if entry.MemberID == memberID {
+			balance += entry.Signed()
+		}
+	}
+
+	return balance, nil
+}
+
+// CreateEntry adds a new entry with the next id, the member and the loan when set must exist
+func (l LedgerStorer) CreateEntry(ctx context.Context, entry entities.LedgerEntry) (entities.LedgerEntry, error) {
+	defer l.db.lock(ctx)()
+
+	_, memberOK := l.db.members[entry.MemberID]
+	_, loanOK := l.db.loans[entry.LoanID]
+
+	if !memberOK || (entry.LoanID != 0 && !loanOK) {
+		return entities.LedgerEntry{}, errors.DB{Err: fmt.Errorf("member %d or loan %d does not exist",
+			entry.MemberID, entry.LoanID)}
+	}
+
+	l.db.lastEntryID++
+	entry.ID = l.db.lastEntryID
+
+	l.db.ledger[entry.ID] = entry
+
+	return entry, nil
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// LoanStorer is the in memory implementation of datastore.Loan
+type LoanStorer struct {
+	db *DB
+}
+
+func NewLoan(db *DB) LoanStorer {
+	return LoanStorer{db: db}
+}
+
+// GetLoanByID returns the loan with given id
+func (l LoanStorer) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	l.db.mu.RLock()
+	defer l.db.mu.RUnlock()
+
+	loan, ok := l.db.loans[id]
+	if !ok {
+		return entities.Loan{}, errors.EntityNotFound{Entity: "Loan", ID: id}
+	}
+
+	return loan, nil
+}
+
+// GetActiveLoans returns the loans of the member which are not returned yet ordered by id
+func (l LoanStorer) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return l.loans(func(loan entities.Loan) bool { return loan.MemberID == memberID && loan.Active() }), nil
+}
+
+// GetLoansByBook returns all the loans of the copies of the book ordered by id
+func (l LoanStorer) GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	return l.loans(func(loan entities.Loan) bool { return loan.BookID == bookID }), nil
+}
+
+// GetMemberLoans returns the active and the returned loans of the member ordered by id
+func (l LoanStorer) GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return l.loans(func(loan entities.Loan) bool { return loan.MemberID == memberID }), nil
+}
+
+func (l LoanStorer) loans(match func(loan entities.Loan) bool) []entities.Loan {
+	l.db.mu.RLock()
+	defer l.db.mu.RUnlock()
+
+	loans := make([]entities.Loan, 0)
+
+	for _, loan := range l.db.loans {
+		if match(loan) {
+			loans = append(loans, loan)
+		}
+	}
+
+	sort.Slice(loans, func(i, j int) bool { return loans[i].ID < loans[j].ID })
+
+	return loans
+}
+
+// CreateLoan adds a new loan with the next id, the copy, the member and the book must exist
+func (l LoanStorer) CreateLoan(ctx context.Context, loan entities.Loan) (entities.Loan, error) {
+	defer l.db.lock(ctx)()
+
+	_, copyOK := l.db.copies[loan.CopyID]
+	_, memberOK := l.db.members[loan.MemberID]
+	_, bookOK := l.db.books[loan.BookID]
+
+	if !copyOK || !memberOK || !bookOK {
+		return entities.Loan{}, errors.DB{Err: fmt.Errorf("copy %d, member %d or book %d does not exist",
+			loan.CopyID, loan.MemberID, loan.BookID)}
+	}
+
+	l.db.lastLoanID++
+	loan.ID = l.db.lastLoanID
+	loan.Version = 1
+
+	l.db.loans[loan.ID] = loan
+
+	return loan, nil
+}
+
+// UpdateLoan sets the due and return dates and the renewals of the loan with given id when it still has
+// the version of loan, the loan is returned with its new version
+func (l LoanStorer) UpdateLoan(ctx context.Context, id int, loan entities.Loan) (entities.Loan, error) {
+	defer l.db.lock(ctx)()
+
+	stored, ok := l.db.loans[id]
+	if !ok {
+		return entities.Loan{}, errors.EntityNotFound{Entity: "Loan", ID: id}
+	}
+
+	if stored.Version != loan.Version {
+		return entities.Loan{}, errors.PreconditionFailed{Entity: "Loan", ID: id}
+	}
+
+	stored.DueOn, stored.ReturnedOn, stored.Renewals = loan.DueOn, loan.ReturnedOn, loan.Renewals
+	stored.Version++
+	l.db.loans[id] = stored
+
+	loan.ID = id
+	loan.Version = stored.Version
+
+	return loan, nil
+}
+
+// findLoan returns any loan which matches, the loans keep their copy, member and book from being removed the same
+// way as the foreign keys of the sql tables. db must be locked
+func (db *DB) findLoan(match func(loan entities.Loan) bool) (entities.Loan, bool) {
+	for _, loan := range db.loans {
+		if match(loan) {
+			return loan, true
+		}
+	}
+
+	return entities.Loan{}, false
+}
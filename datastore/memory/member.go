@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MemberStorer is the in memory implementation of datastore.Member
+type MemberStorer struct {
+	db *DB
+}
+
+func NewMember(db *DB) MemberStorer {
+	return MemberStorer{db: db}
+}
+
+// GetMembers returns all the members ordered by id
+func (m MemberStorer) GetMembers(ctx context.Context) ([]entities.Member, error) {
+	m.db.mu.RLock()
+	defer m.db.mu.RUnlock()
+
+	members := make([]entities.Member, 0, len(m.db.members))
+	for _, member := range m.db.members {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+
+	return members, nil
+}
+
+// GetMemberByID returns the member with given id
+func (m MemberStorer) GetMemberByID(ctx context.Context, id int) (entities.Member, error) {
+	m.db.mu.RLock()
+	defer m.db.mu.RUnlock()
+
+	member, ok := m.db.members[id]
+	if !ok {
+		return entities.Member{}, errors.EntityNotFound{Entity: "Member", ID: id}
+	}
+
+	return member, nil
+}
+
+// GetMemberByEmail returns the member with given email, the emails are compared ignoring the case the same as
+// the unique index of the sql tables
+func (m MemberStorer) GetMemberByEmail(ctx context.Context, email string) (entities.Member, error) {
+	m.db.mu.RLock()
+	defer m.db.mu.RUnlock()
+
+	for _, member := range m.db.members {
+		if strings.EqualFold(member.Email, email) {
+			return member, nil
+		}
+	}
+
+	return entities.Member{}, errors.EntityNotFound{Entity: "Member"}
+}
+
+// CreateMember adds a new member with the next id, the email has to be unique the same as in the sql tables
+func (m MemberStorer) CreateMember(ctx context.Context, member entities.Member) (entities.Member, error) {
+	defer m.db.lock(ctx)()
+
+	if err := m.checkEmail(0, member.Email); err != nil {
+		return entities.Member{}, err
+	}
+
+	m.db.lastMemberID++
+	member.ID = m.db.lastMemberID
+	member.Version = 1
+
+	m.db.members[member.ID] = member
+
+	return member, nil
+}
+
+// UpdateMember replaces the member with given id when it still has the version of member, the member is returned
+// with its new version
+func (m MemberStorer) UpdateMember(ctx context.Context, id int, member entities.Member) (entities.Member, error) {
+	defer m.db.lock(ctx)()
+
+	stored, ok := m.db.members[id]
+	if !ok {
+		return entities.Member{}, errors.EntityNotFound{Entity: "Member", ID: id}
+	}
+
+	if stored.Version != member.Version {
+		return entities.Member{}, errors.PreconditionFailed{Entity: "Member", ID: id}
+	}
+
+	if err := m.checkEmail(id, member.Email); err != nil {
+		return entities.Member{}, err
+	}
+
+	member.ID = id
+
+	member.Version = stored.Version + 1
+
+	updated := member
+	m.db.members[id] = updated
+
+	return member, nil
+}
+
+// DeleteMember removes the member with given id along with the holds of the member, a member who has borrowed a copy
+// or has ledger entries can not be removed
+func (m MemberStorer) DeleteMember(ctx context.Context, id int) error {
+	defer m.db.lock(ctx)()
+
+	if _, ok := m.db.members[id]; !ok {
+		return errors.EntityNotFound{Entity: "Member", ID: id}
+	}
+
+	if loan, ok := m.db.findLoan(func(loan entities.Loan) bool { return loan.MemberID == id }); ok {
+		return errors.DB{Err: fmt.Errorf("member %d has loan %d", id, loan.ID)}
+	}
+
+	for entryID, entry := range m.db.ledger {
+		if entry.MemberID == id {
+			return errors.DB{Err: fmt.Errorf("member %d has ledger entry %d", id, entryID)}
+		}
+	}
+
+	delete(m.db.members, id)
+	m.db.deleteHolds(func(hold entities.Hold) bool { return hold.MemberID == id })
+
+	return nil
+}
+
+// checkEmail fails the same way as the unique index of the sql tables when another member has the email
+func (m MemberStorer) checkEmail(id int, email string) error {
+	for _, member := range m.db.members {
+		if member.ID != id && strings.EqualFold(member.Email, email) {
+			return errors.DB{Err: fmt.Errorf("email %q is taken by member %d", email, member.ID)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+// Package memory is a thread safe in memory implementation of the datastore interfaces,
+// it is meant for tests and demos where no database is available.
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"sync"
+)
+
+// DB holds the rows shared by the stores, so that the stores can keep the same references
+// between authors and books as the sql tables do. The contributors and the genres of a book are replaced and never
+// changed in place, so that a clone can share them. The series and the edition of a book are kept by the id of the book
+type DB struct {
+	mu sync.RWMutex
+	// txMu is held for the whole of a transaction
+	txMu sync.Mutex
+
+	authors         map[int]entities.Author
+	books           map[int]entities.Book
+	contributors    map[int][]entities.Contributor
+	publishers      map[int]entities.Publisher
+	genres          map[int]entities.Genre
+	bookGenres      map[int][]int
+	series          map[int]entities.Series
+	seriesBooks     map[int]entities.SeriesVolume
+	editions        map[int]entities.Edition
+	members         map[int]entities.Member
+	copies          map[int]entities.Copy
+	loans           map[int]entities.Loan
+	holds           map[int]entities.Hold
+	ledger          map[int]entities.LedgerEntry
+	lastAuthorID    int
+	lastBookID      int
+	lastPublisherID int
+	lastGenreID     int
+	lastSeriesID    int
+	lastMemberID    int
+	lastCopyID      int
+	lastLoanID      int
+	lastHoldID      int
+	lastEntryID     int
+}
+
+// New returns an empty db having only the publishers which the migrations add to the sql tables
+func New() *DB {
+	db := &DB{
+		authors:      make(map[int]entities.Author),
+		books:        make(map[int]entities.Book),
+		contributors: make(map[int][]entities.Contributor),
+		publishers:   make(map[int]entities.Publisher),
+		genres:       make(map[int]entities.Genre),
+		bookGenres:   make(map[int][]int),
+		series:       make(map[int]entities.Series),
+		seriesBooks:  make(map[int]entities.SeriesVolume),
+		editions:     make(map[int]entities.Edition),
+		members:      make(map[int]entities.Member),
+		copies:       make(map[int]entities.Copy),
+		loans:        make(map[int]entities.Loan),
+		holds:        make(map[int]entities.Hold),
+		ledger:       make(map[int]entities.LedgerEntry),
+	}
+
+	for _, name := range []string{"Arihanth", "Scholastic", "Penguin"} {
+		db.lastPublisherID++
+		db.publishers[db.lastPublisherID] = entities.Publisher{ID: db.lastPublisherID, Name: name, Version: 1}
+	}
+
+	return db
+}
+
+type txKey struct{}
+
+// WithinTx runs fn as a single unit of work, the changes made through the stores are undone when fn fails or panics.
+// Transactions and the writes done outside of them wait for each other, reads are not blocked and see the
+// changes of a running transaction
+func (db *DB) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if ctx.Value(txKey{}) == db {
+		return fn(ctx)
+	}
+
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+
+	db.mu.RLock()
+	snapshot := db.clone()
+	db.mu.RUnlock()
+
+	defer func() {
+		p := recover()
+		if p != nil || err != nil {
+			db.mu.Lock()
+			db.restore(snapshot)
+			db.mu.Unlock()
+		}
+
+		if p != nil {
+			panic(p)
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, db))
+}
+
+// lock locks db for a write and returns the unlock function, a write outside a transaction
+// first waits for the running transaction to finish
+func (db *DB) lock(ctx context.Context) func() {
+	if ctx.Value(txKey{}) == db {
+		db.mu.Lock()
+
+		return db.mu.Unlock
+	}
+
+	db.txMu.Lock()
+	db.mu.Lock()
+
+	return func() {
+		db.mu.Unlock()
+		db.txMu.Unlock()
+	}
+}
+
+func (db *DB) clone() *DB {
+	c := &DB{
+		authors:         make(map[int]entities.Author, len(db.authors)),
+		books:           make(map[int]entities.Book, len(db.books)),
+		contributors:    make(map[int][]entities.Contributor, len(db.contributors)),
+		publishers:      make(map[int]entities.Publisher, len(db.publishers)),
+		genres:          make(map[int]entities.Genre, len(db.genres)),
+		bookGenres:      make(map[int][]int, len(db.bookGenres)),
+		series:          make(map[int]entities.Series, len(db.series)),
+		seriesBooks:     make(map[int]entities.SeriesVolume, len(db.seriesBooks)),
+		editions:        make(map[int]entities.Edition, len(db.editions)),
+		members:         make(map[int]entities.Member, len(db.members)),
+		copies:          make(map[int]entities.Copy, len(db.copies)),
+		loans:           make(map[int]entities.Loan, len(db.loans)),
+		holds:           make(map[int]entities.Hold, len(db.holds)),
+		ledger:          make(map[int]entities.LedgerEntry, len(db.ledger)),
+		lastAuthorID:    db.lastAuthorID,
+		lastBookID:      db.lastBookID,
+		lastPublisherID: db.lastPublisherID,
+		lastGenreID:     db.lastGenreID,
+		lastSeriesID:    db.lastSeriesID,
+		lastMemberID:    db.lastMemberID,
+		lastCopyID:      db.lastCopyID,
+		lastLoanID:      db.lastLoanID,
+		lastHoldID:      db.lastHoldID,
+		lastEntryID:     db.lastEntryID,
+	}
+
+	for id, author := range db.authors {
+		c.authors[id] = author
+	}
+
+	for id, book := range db.books {
+		c.books[id] = book
+	}
+
+	for id, contributors := range db.contributors {
+		c.contributors[id] = contributors
+	}
+
+	for id, publisher := range db.publishers {
+		c.publishers[id] = publisher
+	}
+
+	for id, genre := range db.genres {
+		c.genres[id] = genre
+	}
+
+	for id, genres := range db.bookGenres {
+		c.bookGenres[id] = genres
+	}
+
+	for id, series := range db.series {
+		c.series[id] = series
+	}
+
+	for id, volume := range db.seriesBooks {
+		c.seriesBooks[id] = volume
+	}
+
+	for id, edition := range db.editions {
+		c.editions[id] = edition
+	}
+
+	for id, member := range db.members {
+		c.members[id] = member
+	}
+
+	for id, cp := range db.copies {
+		c.copies[id] = cp
+	}
+
+	for id, loan := range db.loans {
+		c.loans[id] = loan
+	}
+
+	for id, hold := range db.holds {
+		c.holds[id] = hold
+	}
+
+	for id, entry := range db.ledger {
+		c.ledger[id] = entry
+	}
+
+	return c
+}
+
+func (db *DB) restore(c *DB) {
+	db.authors, db.books, db.contributors, db.publishers, db.members, db.copies, db.loans, db.holds, db.ledger =
+		c.authors, c.books, c.contributors, c.publishers, c.members, c.copies, c.loans, c.holds, c.ledger
+	db.genres, db.bookGenres = c.genres, c.bookGenres
+	db.series, db.seriesBooks, db.editions = c.series, c.seriesBooks, c.editions
+	db.lastAuthorID, db.lastBookID, db.lastPublisherID, db.lastMemberID, db.lastCopyID, db.lastLoanID, db.lastHoldID,
+		db.lastEntryID = c.lastAuthorID, c.lastBookID, c.lastPublisherID, c.lastMemberID, c.lastCopyID, c.lastLoanID,
+		c.lastHoldID, c.lastEntryID
+	db.lastGenreID, db.lastSeriesID = c.lastGenreID, c.lastSeriesID
+}
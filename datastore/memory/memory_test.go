@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDB_WithinTx(t *testing.T) {
+	ctx := context.Background()
+
+	db := New()
+	authors, books := NewAuthor(db), NewBook(db)
+
+	author, _ := authors.CreateAuthor(ctx, entities.Author{FirstName: "MG"})
+	book, _ := books.CreateBook(ctx, entities.Book{Title: "Go", Author: author, Publisher: entities.Publisher{ID: 1}})
+
+	testcases := []struct {
+		desc      string
+		fnErr     error
+		expAuthor bool
+	}{
+		{"changes are undone when fn fails", fmt.Errorf("delete failed"), true},
+		{"changes are kept when fn succeeds", nil, false},
+	}
+
+	for i, v := range testcases {
+		err := db.WithinTx(ctx, func(ctx context.Context) error {
+			if err := books.DeleteBook(ctx, book.ID); err != nil {
+				return err
+			}
+
+			if err := authors.DeleteAuthor(ctx, author.ID); err != nil {
+				return err
+			}
+
+			return v.fnErr
+		})
+		if err != v.fnErr {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.fnErr, err)
+		}
+
+		_, err = authors.GetAuthorByID(ctx, author.ID)
+		if (err == nil) != v.expAuthor {
+			t.Errorf("[TEST%d]Failed. Expected author to be kept %v\tGot %v", i, v.expAuthor, err)
+		}
+
+		res, _ := books.GetBookByID(ctx, book.ID)
+		if v.expAuthor && !reflect.DeepEqual(res, book) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, book, res)
+		}
+	}
+
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PublisherStorer is the in memory implementation of datastore.Publisher
+type PublisherStorer struct {
+	db *DB
+}
+
+func NewPublisher(db *DB) PublisherStorer {
+	return PublisherStorer{db: db}
+}
+
+// GetPublishers returns all the publishers ordered by id
+func (p PublisherStorer) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	publishers := make([]entities.Publisher, 0, len(p.db.publishers))
+	for _, publisher := range p.db.publishers {
+		publishers = append(publishers, publisher)
+	}
+
+	sort.Slice(publishers, func(i, j int) bool { return publishers[i].ID < publishers[j].ID })
+
+	return publishers, nil
+}
+
+// GetPublisherByID returns the publisher with given id
+func (p PublisherStorer) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	publisher, ok := p.db.publishers[id]
+	if !ok {
+		return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher", ID: id}
+	}
+
+	return publisher, nil
+}
+
+// GetPublishersByIDs returns the publishers having any of the ids ordered by id, ids which do not exist are skipped
+func (p PublisherStorer) GetPublishersByIDs(ctx context.Context, ids []int) ([]entities.Publisher, error) {
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	seen := make(map[int]bool, len(ids))
+	publishers := make([]entities.Publisher, 0, len(ids))
+
+	for _, id := range ids {
+		if publisher, ok := p.db.publishers[id]; ok && !seen[id] {
+			seen[id] = true
+			publishers = append(publishers, publisher)
+		}
+	}
+
+	sort.Slice(publishers, func(i, j int) bool { return publishers[i].ID < publishers[j].ID })
+
+	return publishers, nil
+}
+
+// GetPublisherByName returns the publisher with given name, the names are compared ignoring the case the same as
+// the unique index of the sql tables
+func (p PublisherStorer) GetPublisherByName(ctx context.Context, name string) (entities.Publisher, error) {
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	for _, publisher := range p.db.publishers {
+		if strings.EqualFold(publisher.Name, name) {
+			return publisher, nil
+		}
+	}
+
+	return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher"}
+}
+
+// CreatePublisher adds a new publisher with the next id, the name has to be unique the same as in the sql tables
+func (p PublisherStorer) CreatePublisher(ctx context.Context, publisher entities.Publisher) (entities.Publisher,
+	error) {
+	defer p.db.lock(ctx)()
+
+	if err := p.checkName(0, publisher.Name); err != nil {
+		return entities.Publisher{}, err
+	}
+
+	p.db.lastPublisherID++
+	publisher.ID = p.db.lastPublisherID
+	publisher.Version = 1
+
+	p.db.publishers[publisher.ID] = publisher
+
+	return publisher, nil
+}
+
+// UpdatePublisher replaces the publisher with given id when it still has the version of publisher, the publisher
+// is returned with its new version
+func (p PublisherStorer) UpdatePublisher(ctx context.Context, id int, publisher entities.Publisher) (
+	entities.Publisher, error) {
+	defer p.db.lock(ctx)()
+
+	stored, ok := p.db.publishers[id]
+	if !ok {
+		return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher", ID: id}
+	}
+
+	if stored.Version != publisher.Version {
+		return entities.Publisher{}, errors.PreconditionFailed{Entity: "Publisher", ID: id}
+	}
+
+	if err := p.checkName(id, publisher.Name); err != nil {
+		return entities.Publisher{}, err
+	}
+
+	publisher.ID = id
+
+	publisher.Version = stored.Version + 1
+
+	updated := publisher
+	p.db.publishers[id] = updated
+
+	return publisher, nil
+}
+
+// DeletePublisher removes the publisher with given id, it fails the same way as the foreign key of the sql
+// tables when the publisher has books
+func (p PublisherStorer) DeletePublisher(ctx context.Context, id int) error {
+	defer p.db.lock(ctx)()
+
+	if _, ok := p.db.publishers[id]; !ok {
+		return errors.EntityNotFound{Entity: "Publisher", ID: id}
+	}
+
+	for _, book := range p.db.books {
+		if book.Publisher.ID == id {
+			return errors.DB{Err: fmt.Errorf("publisher %d has book %d", id, book.ID)}
+		}
+	}
+
+	delete(p.db.publishers, id)
+
+	return nil
+}
+
+// checkName fails the same way as the unique index of the sql tables when another publisher has the name
+func (p PublisherStorer) checkName(id int, name string) error {
+	for _, publisher := range p.db.publishers {
+		if publisher.ID != id && strings.EqualFold(publisher.Name, name) {
+			return errors.DB{Err: fmt.Errorf("name %q is taken by publisher %d", name, publisher.ID)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SeriesStorer is the in memory implementation of datastore.Series
+type SeriesStorer struct {
+	db *DB
+}
+
+func NewSeries(db *DB) SeriesStorer {
+	return SeriesStorer{db: db}
+}
+
+// GetSeries returns all the series ordered by id
+func (s SeriesStorer) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	series := make([]entities.Series, 0, len(s.db.series))
+	for _, sr := range s.db.series {
+		series = append(series, sr)
+	}
+
+	sort.Slice(series, func(i, j int) bool { return series[i].ID < series[j].ID })
+
+	return series, nil
+}
+
+// GetSeriesByID returns the series with given id
+func (s SeriesStorer) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	series, ok := s.db.series[id]
+	if !ok {
+		return entities.Series{}, errors.EntityNotFound{Entity: "Series", ID: id}
+	}
+
+	return series, nil
+}
+
+// CreateSeries adds a new series with the next id
+func (s SeriesStorer) CreateSeries(ctx context.Context, series entities.Series) (entities.Series, error) {
+	defer s.db.lock(ctx)()
+
+	s.db.lastSeriesID++
+	series.ID = s.db.lastSeriesID
+	series.Version = 1
+
+	s.db.series[series.ID] = series
+
+	return series, nil
+}
+
+// UpdateSeries replaces the series with given id when it still has the version of series, the series is returned
+// with its new version
+func (s SeriesStorer) UpdateSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error) {
+	defer s.db.lock(ctx)()
+
+	stored, ok := s.db.series[id]
+	if !ok {
+		return entities.Series{}, errors.EntityNotFound{Entity: "Series", ID: id}
+	}
+
+	if stored.Version != series.Version {
+		return entities.Series{}, errors.PreconditionFailed{Entity: "Series", ID: id}
+	}
+
+	series.ID = id
+
+	series.Version = stored.Version + 1
+
+	updated := series
+	s.db.series[id] = updated
+
+	return series, nil
+}
+
+// DeleteSeries removes the series with given id, it fails the same way as the foreign key of the sql tables
+// when the series has volumes
+func (s SeriesStorer) DeleteSeries(ctx context.Context, id int) error {
+	defer s.db.lock(ctx)()
+
+	if _, ok := s.db.series[id]; !ok {
+		return errors.EntityNotFound{Entity: "Series", ID: id}
+	}
+
+	for bookID, volume := range s.db.seriesBooks {
+		if volume.ID == id {
+			return errors.DB{Err: fmt.Errorf("series %d has book %d", id, bookID)}
+		}
+	}
+
+	delete(s.db.series, id)
+
+	return nil
+}
+
+// GetVolumes returns the ids of the books of the series with given id by their volume numbers
+func (s SeriesStorer) GetVolumes(ctx context.Context, id int) (map[int]int, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	volumes := make(map[int]int)
+
+	for bookID, volume := range s.db.seriesBooks {
+		if volume.ID == id {
+			volumes[volume.Volume] = bookID
+		}
+	}
+
+	return volumes, nil
+}
@@ -94,6 +94,21 @@ func (mr *MockAuthorMockRecorder) GetAuthorByID(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorByID", reflect.TypeOf((*MockAuthor)(nil).GetAuthorByID), ctx, id)
 }
 
+// GetAuthorsByIDs mocks base method.
+func (m *MockAuthor) GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]entities.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorsByIDs indicates an expected call of GetAuthorsByIDs.
+func (mr *MockAuthorMockRecorder) GetAuthorsByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorsByIDs", reflect.TypeOf((*MockAuthor)(nil).GetAuthorsByIDs), ctx, ids)
+}
+
 // PutAuthor mocks base method.
 func (m *MockAuthor) PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +124,21 @@ func (mr *MockAuthorMockRecorder) PutAuthor(ctx, id, author interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAuthor", reflect.TypeOf((*MockAuthor)(nil).PutAuthor), ctx, id, author)
 }
 
+// UpdateAuthorFields mocks base method.
+func (m *MockAuthor) UpdateAuthorFields(ctx context.Context, id int, author entities.Author, fields []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthorFields", ctx, id, author, fields)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAuthorFields indicates an expected call of UpdateAuthorFields.
+func (mr *MockAuthorMockRecorder) UpdateAuthorFields(ctx, id, author, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthorFields", reflect.TypeOf((*MockAuthor)(nil).UpdateAuthorFields), ctx, id, author, fields)
+}
+
 // MockBook is a mock of Book interface.
 type MockBook struct {
 	ctrl     *gomock.Controller
@@ -191,6 +221,141 @@ func (mr *MockBookMockRecorder) GetBookByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByID", reflect.TypeOf((*MockBook)(nil).GetBookByID), ctx, id)
 }
 
+// GetBookByISBN mocks base method.
+func (m *MockBook) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookByISBN", ctx, isbn)
+	ret0, _ := ret[0].(entities.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookByISBN indicates an expected call of GetBookByISBN.
+func (mr *MockBookMockRecorder) GetBookByISBN(ctx, isbn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByISBN", reflect.TypeOf((*MockBook)(nil).GetBookByISBN), ctx, isbn)
+}
+
+// GetBookFacets mocks base method.
+func (m *MockBook) GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookFacets", ctx, filter)
+	ret0, _ := ret[0].(entities.BookFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookFacets indicates an expected call of GetBookFacets.
+func (mr *MockBookMockRecorder) GetBookFacets(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookFacets", reflect.TypeOf((*MockBook)(nil).GetBookFacets), ctx, filter)
+}
+
+// GetBookGenres mocks base method.
+func (m *MockBook) GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookGenres", ctx, bookIDs)
+	ret0, _ := ret[0].(map[int][]entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookGenres indicates an expected call of GetBookGenres.
+func (mr *MockBookMockRecorder) GetBookGenres(ctx, bookIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookGenres", reflect.TypeOf((*MockBook)(nil).GetBookGenres), ctx, bookIDs)
+}
+
+// GetBookSeries mocks base method.
+func (m *MockBook) GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookSeries", ctx, bookIDs)
+	ret0, _ := ret[0].(map[int]entities.SeriesVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookSeries indicates an expected call of GetBookSeries.
+func (mr *MockBookMockRecorder) GetBookSeries(ctx, bookIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookSeries", reflect.TypeOf((*MockBook)(nil).GetBookSeries), ctx, bookIDs)
+}
+
+// GetBooks mocks base method.
+func (m *MockBook) GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooks", ctx, filter)
+	ret0, _ := ret[0].(entities.BookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooks indicates an expected call of GetBooks.
+func (mr *MockBookMockRecorder) GetBooks(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooks", reflect.TypeOf((*MockBook)(nil).GetBooks), ctx, filter)
+}
+
+// GetContributors mocks base method.
+func (m *MockBook) GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributors", ctx, bookIDs)
+	ret0, _ := ret[0].(map[int][]entities.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContributors indicates an expected call of GetContributors.
+func (mr *MockBookMockRecorder) GetContributors(ctx, bookIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributors", reflect.TypeOf((*MockBook)(nil).GetContributors), ctx, bookIDs)
+}
+
+// GetDuplicates mocks base method.
+func (m *MockBook) GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", ctx, book, keys)
+	ret0, _ := ret[0].([]entities.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockBookMockRecorder) GetDuplicates(ctx, book, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockBook)(nil).GetDuplicates), ctx, book, keys)
+}
+
+// GetEditions mocks base method.
+func (m *MockBook) GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEditions", ctx, bookIDs)
+	ret0, _ := ret[0].(map[int]entities.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEditions indicates an expected call of GetEditions.
+func (mr *MockBookMockRecorder) GetEditions(ctx, bookIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEditions", reflect.TypeOf((*MockBook)(nil).GetEditions), ctx, bookIDs)
+}
+
+// SearchBooks mocks base method.
+func (m *MockBook) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBooks", ctx, query)
+	ret0, _ := ret[0].(entities.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBooks indicates an expected call of SearchBooks.
+func (mr *MockBookMockRecorder) SearchBooks(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBook)(nil).SearchBooks), ctx, query)
+}
+
 // UpdateBook mocks base method.
 func (m *MockBook) UpdateBook(ctx context.Context, id int, book entities.Book) (entities.Book, error) {
 	m.ctrl.T.Helper()
@@ -205,3 +370,901 @@ func (mr *MockBookMockRecorder) UpdateBook(ctx, id, book interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBook)(nil).UpdateBook), ctx, id, book)
 }
+
+// UpdateBookFields mocks base method.
+func (m *MockBook) UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookFields", ctx, id, book, fields)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBookFields indicates an expected call of UpdateBookFields.
+func (mr *MockBookMockRecorder) UpdateBookFields(ctx, id, book, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookFields", reflect.TypeOf((*MockBook)(nil).UpdateBookFields), ctx, id, book, fields)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// CreatePublisher mocks base method.
+func (m *MockPublisher) CreatePublisher(ctx context.Context, publisher entities.Publisher) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePublisher", ctx, publisher)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePublisher indicates an expected call of CreatePublisher.
+func (mr *MockPublisherMockRecorder) CreatePublisher(ctx, publisher interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePublisher", reflect.TypeOf((*MockPublisher)(nil).CreatePublisher), ctx, publisher)
+}
+
+// DeletePublisher mocks base method.
+func (m *MockPublisher) DeletePublisher(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublisher", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePublisher indicates an expected call of DeletePublisher.
+func (mr *MockPublisherMockRecorder) DeletePublisher(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublisher", reflect.TypeOf((*MockPublisher)(nil).DeletePublisher), ctx, id)
+}
+
+// GetPublisherByID mocks base method.
+func (m *MockPublisher) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublisherByID", ctx, id)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublisherByID indicates an expected call of GetPublisherByID.
+func (mr *MockPublisherMockRecorder) GetPublisherByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublisherByID", reflect.TypeOf((*MockPublisher)(nil).GetPublisherByID), ctx, id)
+}
+
+// GetPublisherByName mocks base method.
+func (m *MockPublisher) GetPublisherByName(ctx context.Context, name string) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublisherByName", ctx, name)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublisherByName indicates an expected call of GetPublisherByName.
+func (mr *MockPublisherMockRecorder) GetPublisherByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublisherByName", reflect.TypeOf((*MockPublisher)(nil).GetPublisherByName), ctx, name)
+}
+
+// GetPublishers mocks base method.
+func (m *MockPublisher) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishers", ctx)
+	ret0, _ := ret[0].([]entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishers indicates an expected call of GetPublishers.
+func (mr *MockPublisherMockRecorder) GetPublishers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishers", reflect.TypeOf((*MockPublisher)(nil).GetPublishers), ctx)
+}
+
+// GetPublishersByIDs mocks base method.
+func (m *MockPublisher) GetPublishersByIDs(ctx context.Context, ids []int) ([]entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishersByIDs", ctx, ids)
+	ret0, _ := ret[0].([]entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishersByIDs indicates an expected call of GetPublishersByIDs.
+func (mr *MockPublisherMockRecorder) GetPublishersByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishersByIDs", reflect.TypeOf((*MockPublisher)(nil).GetPublishersByIDs), ctx, ids)
+}
+
+// UpdatePublisher mocks base method.
+func (m *MockPublisher) UpdatePublisher(ctx context.Context, id int, publisher entities.Publisher) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePublisher", ctx, id, publisher)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePublisher indicates an expected call of UpdatePublisher.
+func (mr *MockPublisherMockRecorder) UpdatePublisher(ctx, id, publisher interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePublisher", reflect.TypeOf((*MockPublisher)(nil).UpdatePublisher), ctx, id, publisher)
+}
+
+// MockGenre is a mock of Genre interface.
+type MockGenre struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreMockRecorder
+}
+
+// MockGenreMockRecorder is the mock recorder for MockGenre.
+type MockGenreMockRecorder struct {
+	mock *MockGenre
+}
+
+// NewMockGenre creates a new mock instance.
+func NewMockGenre(ctrl *gomock.Controller) *MockGenre {
+	mock := &MockGenre{ctrl: ctrl}
+	mock.recorder = &MockGenreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenre) EXPECT() *MockGenreMockRecorder {
+	return m.recorder
+}
+
+// CreateGenre mocks base method.
+func (m *MockGenre) CreateGenre(ctx context.Context, genre entities.Genre) (entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, genre)
+	ret0, _ := ret[0].(entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockGenreMockRecorder) CreateGenre(ctx, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenre)(nil).CreateGenre), ctx, genre)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockGenre) DeleteGenre(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreMockRecorder) DeleteGenre(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenre)(nil).DeleteGenre), ctx, id)
+}
+
+// GetGenreByID mocks base method.
+func (m *MockGenre) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreByID", ctx, id)
+	ret0, _ := ret[0].(entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreByID indicates an expected call of GetGenreByID.
+func (mr *MockGenreMockRecorder) GetGenreByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreByID", reflect.TypeOf((*MockGenre)(nil).GetGenreByID), ctx, id)
+}
+
+// GetGenres mocks base method.
+func (m *MockGenre) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreMockRecorder) GetGenres(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenre)(nil).GetGenres), ctx)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockGenre) UpdateGenre(ctx context.Context, id int, genre entities.Genre) (entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, id, genre)
+	ret0, _ := ret[0].(entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockGenreMockRecorder) UpdateGenre(ctx, id, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenre)(nil).UpdateGenre), ctx, id, genre)
+}
+
+// MockSeries is a mock of Series interface.
+type MockSeries struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesMockRecorder
+}
+
+// MockSeriesMockRecorder is the mock recorder for MockSeries.
+type MockSeriesMockRecorder struct {
+	mock *MockSeries
+}
+
+// NewMockSeries creates a new mock instance.
+func NewMockSeries(ctrl *gomock.Controller) *MockSeries {
+	mock := &MockSeries{ctrl: ctrl}
+	mock.recorder = &MockSeriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeries) EXPECT() *MockSeriesMockRecorder {
+	return m.recorder
+}
+
+// CreateSeries mocks base method.
+func (m *MockSeries) CreateSeries(ctx context.Context, series entities.Series) (entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", ctx, series)
+	ret0, _ := ret[0].(entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockSeriesMockRecorder) CreateSeries(ctx, series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockSeries)(nil).CreateSeries), ctx, series)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockSeries) DeleteSeries(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockSeriesMockRecorder) DeleteSeries(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockSeries)(nil).DeleteSeries), ctx, id)
+}
+
+// GetSeries mocks base method.
+func (m *MockSeries) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries", ctx)
+	ret0, _ := ret[0].([]entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockSeriesMockRecorder) GetSeries(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockSeries)(nil).GetSeries), ctx)
+}
+
+// GetSeriesByID mocks base method.
+func (m *MockSeries) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesByID", ctx, id)
+	ret0, _ := ret[0].(entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesByID indicates an expected call of GetSeriesByID.
+func (mr *MockSeriesMockRecorder) GetSeriesByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesByID", reflect.TypeOf((*MockSeries)(nil).GetSeriesByID), ctx, id)
+}
+
+// GetVolumes mocks base method.
+func (m *MockSeries) GetVolumes(ctx context.Context, id int) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolumes", ctx, id)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolumes indicates an expected call of GetVolumes.
+func (mr *MockSeriesMockRecorder) GetVolumes(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumes", reflect.TypeOf((*MockSeries)(nil).GetVolumes), ctx, id)
+}
+
+// UpdateSeries mocks base method.
+func (m *MockSeries) UpdateSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeries", ctx, id, series)
+	ret0, _ := ret[0].(entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSeries indicates an expected call of UpdateSeries.
+func (mr *MockSeriesMockRecorder) UpdateSeries(ctx, id, series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockSeries)(nil).UpdateSeries), ctx, id, series)
+}
+
+// MockMember is a mock of Member interface.
+type MockMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberMockRecorder
+}
+
+// MockMemberMockRecorder is the mock recorder for MockMember.
+type MockMemberMockRecorder struct {
+	mock *MockMember
+}
+
+// NewMockMember creates a new mock instance.
+func NewMockMember(ctrl *gomock.Controller) *MockMember {
+	mock := &MockMember{ctrl: ctrl}
+	mock.recorder = &MockMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMember) EXPECT() *MockMemberMockRecorder {
+	return m.recorder
+}
+
+// CreateMember mocks base method.
+func (m *MockMember) CreateMember(ctx context.Context, member entities.Member) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMember", ctx, member)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMember indicates an expected call of CreateMember.
+func (mr *MockMemberMockRecorder) CreateMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMember", reflect.TypeOf((*MockMember)(nil).CreateMember), ctx, member)
+}
+
+// DeleteMember mocks base method.
+func (m *MockMember) DeleteMember(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockMemberMockRecorder) DeleteMember(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockMember)(nil).DeleteMember), ctx, id)
+}
+
+// GetMemberByEmail mocks base method.
+func (m *MockMember) GetMemberByEmail(ctx context.Context, email string) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberByEmail", ctx, email)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberByEmail indicates an expected call of GetMemberByEmail.
+func (mr *MockMemberMockRecorder) GetMemberByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberByEmail", reflect.TypeOf((*MockMember)(nil).GetMemberByEmail), ctx, email)
+}
+
+// GetMemberByID mocks base method.
+func (m *MockMember) GetMemberByID(ctx context.Context, id int) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberByID", ctx, id)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberByID indicates an expected call of GetMemberByID.
+func (mr *MockMemberMockRecorder) GetMemberByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberByID", reflect.TypeOf((*MockMember)(nil).GetMemberByID), ctx, id)
+}
+
+// GetMembers mocks base method.
+func (m *MockMember) GetMembers(ctx context.Context) ([]entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx)
+	ret0, _ := ret[0].([]entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockMemberMockRecorder) GetMembers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockMember)(nil).GetMembers), ctx)
+}
+
+// UpdateMember mocks base method.
+func (m *MockMember) UpdateMember(ctx context.Context, id int, member entities.Member) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", ctx, id, member)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockMemberMockRecorder) UpdateMember(ctx, id, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockMember)(nil).UpdateMember), ctx, id, member)
+}
+
+// MockCopy is a mock of Copy interface.
+type MockCopy struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyMockRecorder
+}
+
+// MockCopyMockRecorder is the mock recorder for MockCopy.
+type MockCopyMockRecorder struct {
+	mock *MockCopy
+}
+
+// NewMockCopy creates a new mock instance.
+func NewMockCopy(ctrl *gomock.Controller) *MockCopy {
+	mock := &MockCopy{ctrl: ctrl}
+	mock.recorder = &MockCopyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopy) EXPECT() *MockCopyMockRecorder {
+	return m.recorder
+}
+
+// CreateCopy mocks base method.
+func (m *MockCopy) CreateCopy(ctx context.Context, c entities.Copy) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCopy", ctx, c)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCopy indicates an expected call of CreateCopy.
+func (mr *MockCopyMockRecorder) CreateCopy(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCopy", reflect.TypeOf((*MockCopy)(nil).CreateCopy), ctx, c)
+}
+
+// DeleteCopy mocks base method.
+func (m *MockCopy) DeleteCopy(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCopy", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCopy indicates an expected call of DeleteCopy.
+func (mr *MockCopyMockRecorder) DeleteCopy(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCopy", reflect.TypeOf((*MockCopy)(nil).DeleteCopy), ctx, id)
+}
+
+// GetAvailability mocks base method.
+func (m *MockCopy) GetAvailability(ctx context.Context, bookIDs []int) (map[int]entities.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, bookIDs)
+	ret0, _ := ret[0].(map[int]entities.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockCopyMockRecorder) GetAvailability(ctx, bookIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockCopy)(nil).GetAvailability), ctx, bookIDs)
+}
+
+// GetCopies mocks base method.
+func (m *MockCopy) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopies", ctx, bookID)
+	ret0, _ := ret[0].([]entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopies indicates an expected call of GetCopies.
+func (mr *MockCopyMockRecorder) GetCopies(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopies", reflect.TypeOf((*MockCopy)(nil).GetCopies), ctx, bookID)
+}
+
+// GetCopyByBarcode mocks base method.
+func (m *MockCopy) GetCopyByBarcode(ctx context.Context, barcode string) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopyByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopyByBarcode indicates an expected call of GetCopyByBarcode.
+func (mr *MockCopyMockRecorder) GetCopyByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopyByBarcode", reflect.TypeOf((*MockCopy)(nil).GetCopyByBarcode), ctx, barcode)
+}
+
+// GetCopyByID mocks base method.
+func (m *MockCopy) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopyByID", ctx, id)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopyByID indicates an expected call of GetCopyByID.
+func (mr *MockCopyMockRecorder) GetCopyByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopyByID", reflect.TypeOf((*MockCopy)(nil).GetCopyByID), ctx, id)
+}
+
+// UpdateCopy mocks base method.
+func (m *MockCopy) UpdateCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCopy", ctx, id, c)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCopy indicates an expected call of UpdateCopy.
+func (mr *MockCopyMockRecorder) UpdateCopy(ctx, id, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCopy", reflect.TypeOf((*MockCopy)(nil).UpdateCopy), ctx, id, c)
+}
+
+// UpdateCopyStatus mocks base method.
+func (m *MockCopy) UpdateCopyStatus(ctx context.Context, id int, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCopyStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCopyStatus indicates an expected call of UpdateCopyStatus.
+func (mr *MockCopyMockRecorder) UpdateCopyStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCopyStatus", reflect.TypeOf((*MockCopy)(nil).UpdateCopyStatus), ctx, id, from, to)
+}
+
+// MockLoan is a mock of Loan interface.
+type MockLoan struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanMockRecorder
+}
+
+// MockLoanMockRecorder is the mock recorder for MockLoan.
+type MockLoanMockRecorder struct {
+	mock *MockLoan
+}
+
+// NewMockLoan creates a new mock instance.
+func NewMockLoan(ctrl *gomock.Controller) *MockLoan {
+	mock := &MockLoan{ctrl: ctrl}
+	mock.recorder = &MockLoanMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoan) EXPECT() *MockLoanMockRecorder {
+	return m.recorder
+}
+
+// CreateLoan mocks base method.
+func (m *MockLoan) CreateLoan(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoan", ctx, l)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoan indicates an expected call of CreateLoan.
+func (mr *MockLoanMockRecorder) CreateLoan(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockLoan)(nil).CreateLoan), ctx, l)
+}
+
+// GetActiveLoans mocks base method.
+func (m *MockLoan) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveLoans", ctx, memberID)
+	ret0, _ := ret[0].([]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveLoans indicates an expected call of GetActiveLoans.
+func (mr *MockLoanMockRecorder) GetActiveLoans(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveLoans", reflect.TypeOf((*MockLoan)(nil).GetActiveLoans), ctx, memberID)
+}
+
+// GetLoanByID mocks base method.
+func (m *MockLoan) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanByID", ctx, id)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanByID indicates an expected call of GetLoanByID.
+func (mr *MockLoanMockRecorder) GetLoanByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanByID", reflect.TypeOf((*MockLoan)(nil).GetLoanByID), ctx, id)
+}
+
+// GetLoansByBook mocks base method.
+func (m *MockLoan) GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoansByBook", ctx, bookID)
+	ret0, _ := ret[0].([]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoansByBook indicates an expected call of GetLoansByBook.
+func (mr *MockLoanMockRecorder) GetLoansByBook(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoansByBook", reflect.TypeOf((*MockLoan)(nil).GetLoansByBook), ctx, bookID)
+}
+
+// GetMemberLoans mocks base method.
+func (m *MockLoan) GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberLoans", ctx, memberID)
+	ret0, _ := ret[0].([]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberLoans indicates an expected call of GetMemberLoans.
+func (mr *MockLoanMockRecorder) GetMemberLoans(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberLoans", reflect.TypeOf((*MockLoan)(nil).GetMemberLoans), ctx, memberID)
+}
+
+// UpdateLoan mocks base method.
+func (m *MockLoan) UpdateLoan(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoan", ctx, id, l)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoan indicates an expected call of UpdateLoan.
+func (mr *MockLoanMockRecorder) UpdateLoan(ctx, id, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoan", reflect.TypeOf((*MockLoan)(nil).UpdateLoan), ctx, id, l)
+}
+
+// MockHold is a mock of Hold interface.
+type MockHold struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldMockRecorder
+}
+
+// MockHoldMockRecorder is the mock recorder for MockHold.
+type MockHoldMockRecorder struct {
+	mock *MockHold
+}
+
+// NewMockHold creates a new mock instance.
+func NewMockHold(ctrl *gomock.Controller) *MockHold {
+	mock := &MockHold{ctrl: ctrl}
+	mock.recorder = &MockHoldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHold) EXPECT() *MockHoldMockRecorder {
+	return m.recorder
+}
+
+// CreateHold mocks base method.
+func (m *MockHold) CreateHold(ctx context.Context, h entities.Hold) (entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, h)
+	ret0, _ := ret[0].(entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockHoldMockRecorder) CreateHold(ctx, h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockHold)(nil).CreateHold), ctx, h)
+}
+
+// GetBookHolds mocks base method.
+func (m *MockHold) GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookHolds", ctx, bookID)
+	ret0, _ := ret[0].([]entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookHolds indicates an expected call of GetBookHolds.
+func (mr *MockHoldMockRecorder) GetBookHolds(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookHolds", reflect.TypeOf((*MockHold)(nil).GetBookHolds), ctx, bookID)
+}
+
+// GetHoldByID mocks base method.
+func (m *MockHold) GetHoldByID(ctx context.Context, id int) (entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldByID", ctx, id)
+	ret0, _ := ret[0].(entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldByID indicates an expected call of GetHoldByID.
+func (mr *MockHoldMockRecorder) GetHoldByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldByID", reflect.TypeOf((*MockHold)(nil).GetHoldByID), ctx, id)
+}
+
+// GetHoldsByStatus mocks base method.
+func (m *MockHold) GetHoldsByStatus(ctx context.Context, status string) ([]entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldsByStatus", ctx, status)
+	ret0, _ := ret[0].([]entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldsByStatus indicates an expected call of GetHoldsByStatus.
+func (mr *MockHoldMockRecorder) GetHoldsByStatus(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldsByStatus", reflect.TypeOf((*MockHold)(nil).GetHoldsByStatus), ctx, status)
+}
+
+// GetMemberHolds mocks base method.
+func (m *MockHold) GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberHolds", ctx, memberID)
+	ret0, _ := ret[0].([]entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberHolds indicates an expected call of GetMemberHolds.
+func (mr *MockHoldMockRecorder) GetMemberHolds(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberHolds", reflect.TypeOf((*MockHold)(nil).GetMemberHolds), ctx, memberID)
+}
+
+// UpdateHold mocks base method.
+func (m *MockHold) UpdateHold(ctx context.Context, id int, h entities.Hold) (entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHold", ctx, id, h)
+	ret0, _ := ret[0].(entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHold indicates an expected call of UpdateHold.
+func (mr *MockHoldMockRecorder) UpdateHold(ctx, id, h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHold", reflect.TypeOf((*MockHold)(nil).UpdateHold), ctx, id, h)
+}
+
+// MockLedger is a mock of Ledger interface.
+type MockLedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerMockRecorder
+}
+
+// MockLedgerMockRecorder is the mock recorder for MockLedger.
+type MockLedgerMockRecorder struct {
+	mock *MockLedger
+}
+
+// NewMockLedger creates a new mock instance.
+func NewMockLedger(ctrl *gomock.Controller) *MockLedger {
+	mock := &MockLedger{ctrl: ctrl}
+	mock.recorder = &MockLedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
+	return m.recorder
+}
+
+// CreateEntry mocks base method.
+func (m *MockLedger) CreateEntry(ctx context.Context, e entities.LedgerEntry) (entities.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, e)
+	ret0, _ := ret[0].(entities.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockLedgerMockRecorder) CreateEntry(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockLedger)(nil).CreateEntry), ctx, e)
+}
+
+// GetBalance mocks base method.
+func (m *MockLedger) GetBalance(ctx context.Context, memberID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, memberID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockLedgerMockRecorder) GetBalance(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockLedger)(nil).GetBalance), ctx, memberID)
+}
+
+// GetEntries mocks base method.
+func (m *MockLedger) GetEntries(ctx context.Context, memberID int) ([]entities.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", ctx, memberID)
+	ret0, _ := ret[0].([]entities.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockLedgerMockRecorder) GetEntries(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockLedger)(nil).GetEntries), ctx, memberID)
+}
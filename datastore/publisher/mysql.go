@@ -0,0 +1,161 @@
+package publisher
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Publisher
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanPublisher reads a publisher selected with the columns of datastore.GetPublisher, it is used by all
+// the publisher stores
+func scanPublisher(row datastore.Scanner) (entities.Publisher, error) {
+	var p entities.Publisher
+
+	err := row.Scan(&p.ID, &p.Name, &p.Website, &p.Version)
+
+	return p, err
+}
+
+// GetPublishers function is to perform DB Queries to get the list of publishers
+func (s Storer) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	return getPublishers(ctx, datastore.Conn(ctx, s.db), datastore.GetPublisher)
+}
+
+// getPublishers reads the publishers selected by query ordered by id
+func getPublishers(ctx context.Context, db datastore.DBTX, query string, args ...interface{}) ([]entities.Publisher,
+	error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	publishers := make([]entities.Publisher, 0)
+
+	for rows.Next() {
+		p, err := scanPublisher(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		publishers = append(publishers, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return publishers, nil
+}
+
+// GetPublisherByID function is to perform DB Queries to get a publisher instance using its ID
+func (s Storer) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	return getPublisher(ctx, datastore.Conn(ctx, s.db), datastore.GetByIDPublisher, id)
+}
+
+// GetPublishersByIDs function is to perform a single DB Query to get all the publishers having any of the ids,
+// ids which do not exist are skipped
+func (s Storer) GetPublishersByIDs(ctx context.Context, ids []int) ([]entities.Publisher, error) {
+	return getPublishersByIDs(ctx, datastore.Conn(ctx, s.db), ids)
+}
+
+func getPublishersByIDs(ctx context.Context, db datastore.DBTX, ids []int) ([]entities.Publisher, error) {
+	if len(ids) == 0 {
+		return []entities.Publisher{}, nil
+	}
+
+	query, args := datastore.PublishersByIDsQuery(ids)
+
+	return getPublishers(ctx, db, query, args...)
+}
+
+// GetPublisherByName function is to perform DB Queries to get a publisher instance using its name, the names
+// are compared ignoring the case
+func (s Storer) GetPublisherByName(ctx context.Context, name string) (entities.Publisher, error) {
+	return getPublisher(ctx, datastore.Conn(ctx, s.db), datastore.GetByNamePublisher, name)
+}
+
+// getPublisher reads the single publisher selected by query, the id of the not found error is set only when key
+// is an id
+func getPublisher(ctx context.Context, db datastore.DBTX, query string, key interface{}) (entities.Publisher, error) {
+	p, err := scanPublisher(db.QueryRowContext(ctx, query, key))
+	if err == sql.ErrNoRows {
+		id, _ := key.(int)
+
+		return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher", ID: id}
+	}
+
+	if err != nil {
+		return entities.Publisher{}, errors.DB{Err: err}
+	}
+
+	return p, nil
+}
+
+// CreatePublisher function is to perform DB execution to add a new publisher instance in database
+func (s Storer) CreatePublisher(ctx context.Context, p entities.Publisher) (entities.Publisher, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertPublisher, p.Name, p.Website)
+	if err != nil {
+		return entities.Publisher{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Publisher{}, errors.DB{Err: err}
+	}
+
+	p.ID = int(id)
+	p.Version = 1
+
+	return p, nil
+}
+
+// UpdatePublisher function is to perform required DB Queries to replace a publisher instance in database,
+// the publisher is returned with its new version
+func (s Storer) UpdatePublisher(ctx context.Context, id int, p entities.Publisher) (entities.Publisher, error) {
+	return updatePublisher(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdatePublisher, id, p)
+}
+
+func updatePublisher(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	p entities.Publisher) (entities.Publisher, error) {
+	version, err := d.Update(ctx, db, "Publisher", "Publishers", id, query, p.Name, p.Website, id, p.Version)
+	if err != nil {
+		return entities.Publisher{}, err
+	}
+
+	p.ID = id
+	p.Version = version
+
+	return p, nil
+}
+
+// DeletePublisher function is to perform required DB Queries to remove a publisher instance from database,
+// a publisher of any book can not be removed
+func (s Storer) DeletePublisher(ctx context.Context, id int) error {
+	return deletePublisher(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func deletePublisher(ctx context.Context, db datastore.DBTX, id int) error {
+	res, err := db.ExecContext(ctx, datastore.DeletePublisher, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Publisher", ID: id}
+	}
+
+	return nil
+}
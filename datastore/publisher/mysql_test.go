@@ -0,0 +1,298 @@
+package publisher
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var columns = []string{"id", "name", "website", "version"}
+
+func publisher() entities.Publisher {
+	return entities.Publisher{Name: "Penguin", Website: "https://www.penguin.co.in"}
+}
+
+func TestStorer_CreatePublisher(t *testing.T) {
+	created := publisher()
+	created.ID, created.Version = 4, 1
+
+	testcases := []struct {
+		desc   string
+		dbErr  error
+		expRes entities.Publisher
+		expErr error
+	}{
+		{desc: "created", expRes: created},
+		{desc: "name is taken", dbErr: fmt.Errorf("duplicate entry"), expErr: errors.DB{Err: fmt.Errorf("duplicate entry")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		p := publisher()
+
+		mock.ExpectExec(datastore.InsertPublisher).
+			WithArgs(p.Name, p.Website).
+			WillReturnResult(sqlmock.NewResult(4, 1)).
+			WillReturnError(tc.dbErr)
+
+		res, err := New(db).CreatePublisher(context.Background(), p)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetPublishers(t *testing.T) {
+	stored := publisher()
+	stored.ID, stored.Version = 1, 2
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes []entities.Publisher
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.Name, stored.Website, 2),
+			expRes: []entities.Publisher{stored}},
+		{desc: "empty", rows: sqlmock.NewRows(columns), expRes: []entities.Publisher{}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetPublisher)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetPublishers(context.Background())
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetPublisherByID(t *testing.T) {
+	stored := publisher()
+	stored.ID, stored.Version = 1, 3
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes entities.Publisher
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.Name, stored.Website, 3), expRes: stored},
+		{desc: "not found", rows: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Publisher", ID: 1}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetByIDPublisher).WithArgs(1)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetPublisherByID(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_GetPublishersByIDs(t *testing.T) {
+	stored := publisher()
+	stored.ID, stored.Version = 1, 2
+
+	query, _ := datastore.PublishersByIDsQuery([]int{1, 4})
+
+	testcases := []struct {
+		desc   string
+		ids    []int
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes []entities.Publisher
+		expErr error
+	}{
+		{desc: "found", ids: []int{1, 4}, rows: sqlmock.NewRows(columns).AddRow(1, stored.Name, stored.Website, 2),
+			expRes: []entities.Publisher{stored}},
+		{desc: "no ids", expRes: []entities.Publisher{}},
+		{desc: "query error", ids: []int{1, 4}, dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		if tc.ids != nil {
+			q := mock.ExpectQuery(query).WithArgs(1, 4)
+			if tc.dbErr != nil {
+				q.WillReturnError(tc.dbErr)
+			} else {
+				q.WillReturnRows(tc.rows)
+			}
+		}
+
+		res, err := New(db).GetPublishersByIDs(context.Background(), tc.ids)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("[TEST%d]Failed. %v", i+1, err)
+		}
+	}
+}
+
+func TestStorer_GetPublisherByName(t *testing.T) {
+	stored := publisher()
+	stored.ID, stored.Version = 1, 3
+
+	testcases := []struct {
+		desc   string
+		rows   *sqlmock.Rows
+		dbErr  error
+		expRes entities.Publisher
+		expErr error
+	}{
+		{desc: "found", rows: sqlmock.NewRows(columns).AddRow(1, stored.Name, stored.Website, 3), expRes: stored},
+		{desc: "not found", rows: sqlmock.NewRows(columns), expErr: errors.EntityNotFound{Entity: "Publisher"}},
+		{desc: "query error", dbErr: fmt.Errorf("connection refused"),
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		query := mock.ExpectQuery(datastore.GetByNamePublisher).WithArgs(stored.Name)
+		if tc.dbErr != nil {
+			query.WillReturnError(tc.dbErr)
+		} else {
+			query.WillReturnRows(tc.rows)
+		}
+
+		res, err := New(db).GetPublisherByName(context.Background(), stored.Name)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_UpdatePublisher(t *testing.T) {
+	updated := publisher()
+	updated.ID, updated.Version = 1, 1
+
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		// stored is the version of the row when it is not updated, there is no row when it is 0
+		stored int
+		expRes entities.Publisher
+		expErr error
+	}{
+		{desc: "updated", rowsAffected: 1, expRes: updated},
+		{desc: "modified since read", stored: 2, expErr: errors.PreconditionFailed{Entity: "Publisher", ID: 1}},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Publisher", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+		p := publisher()
+
+		mock.ExpectExec(datastore.UpdatePublisher).
+			WithArgs(p.Name, p.Website, 1, p.Version).
+			WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+
+		if tc.rowsAffected == 0 {
+			rows := sqlmock.NewRows([]string{"version"})
+			if tc.stored != 0 {
+				rows.AddRow(tc.stored)
+			}
+
+			mock.ExpectQuery("select version from Publishers where id=?").WithArgs(1).WillReturnRows(rows)
+		}
+
+		res, err := New(db).UpdatePublisher(context.Background(), 1, p)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, res, tc.expRes)
+		}
+	}
+}
+
+func TestStorer_DeletePublisher(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		rowsAffected int64
+		dbErr        error
+		expErr       error
+	}{
+		{desc: "deleted", rowsAffected: 1},
+		{desc: "not found", expErr: errors.EntityNotFound{Entity: "Publisher", ID: 1}},
+		{desc: "publisher of books", dbErr: fmt.Errorf("foreign key constraint fails"),
+			expErr: errors.DB{Err: fmt.Errorf("foreign key constraint fails")}},
+	}
+
+	for i, tc := range testcases {
+		db, mock := NewMock()
+
+		mock.ExpectExec(datastore.DeletePublisher).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected)).
+			WillReturnError(tc.dbErr)
+
+		err := New(db).DeletePublisher(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Got %v\tExpected %v\n", i+1, err, tc.expErr)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package publisher
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Publisher, it differs from Storer only in reading
+// the generated id of a new publisher
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetPublishers function is to perform DB Queries to get the list of publishers
+func (s SQLiteStorer) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	return getPublishers(ctx, datastore.Conn(ctx, s.db), datastore.GetPublisher)
+}
+
+// GetPublisherByID function is to perform DB Queries to get a publisher instance using its ID
+func (s SQLiteStorer) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	return getPublisher(ctx, datastore.Conn(ctx, s.db), datastore.GetByIDPublisher, id)
+}
+
+// GetPublishersByIDs function is to perform a single DB Query to get all the publishers having any of the ids,
+// ids which do not exist are skipped
+func (s SQLiteStorer) GetPublishersByIDs(ctx context.Context, ids []int) ([]entities.Publisher, error) {
+	return getPublishersByIDs(ctx, datastore.Conn(ctx, s.db), ids)
+}
+
+// GetPublisherByName function is to perform DB Queries to get a publisher instance using its name, the names
+// are compared ignoring the case
+func (s SQLiteStorer) GetPublisherByName(ctx context.Context, name string) (entities.Publisher, error) {
+	return getPublisher(ctx, datastore.Conn(ctx, s.db), datastore.GetByNamePublisherSQLite, name)
+}
+
+// CreatePublisher function is to perform DB execution to add a new publisher instance in database
+func (s SQLiteStorer) CreatePublisher(ctx context.Context, p entities.Publisher) (entities.Publisher, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertPublisherSQLite, p.Name, p.Website).
+		Scan(&p.ID, &p.Version)
+	if err != nil {
+		return entities.Publisher{}, errors.DB{Err: err}
+	}
+
+	return p, nil
+}
+
+// UpdatePublisher function is to perform required DB Queries to replace a publisher instance in database,
+// the publisher is returned with its new version
+func (s SQLiteStorer) UpdatePublisher(ctx context.Context, id int, p entities.Publisher) (entities.Publisher, error) {
+	return updatePublisher(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdatePublisherSQLite, id, p)
+}
+
+// DeletePublisher function is to perform required DB Queries to remove a publisher instance from database,
+// a publisher of any book can not be removed
+func (s SQLiteStorer) DeletePublisher(ctx context.Context, id int) error {
+	return deletePublisher(ctx, datastore.Conn(ctx, s.db), id)
+}
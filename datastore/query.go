@@ -1,15 +1,109 @@
 package datastore
 
 const (
-	GetAuthor     = "select id,first_name,last_name,dob,pen_name from Authors;"
-	GetByIDAuthor = "select id,first_name,last_name,dob,pen_name from Authors where id=?"
+	GetAuthor     = "select id,first_name,last_name,dob,pen_name,version from Authors;"
+	GetByIDAuthor = "select id,first_name,last_name,dob,pen_name,version from Authors where id=?"
 	InsertAuthor  = "INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES (?,?,?,?);"
-	UpdateAuthor  = "UPDATE Authors SET first_name = ? ,last_name = ? ,dob = ? ,pen_name = ?  WHERE id =?"
+	UpdateAuthor  = "UPDATE Authors SET first_name = ? ,last_name = ? ,dob = ? ,pen_name = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
 	DeleteAuthor  = "delete from Authors where id=?;"
 
-	GetBook     = "select id,title,publication,publication_date,author_id from Books;"
-	GetByIDBook = "select id,title,publication,publication_date,author_id from Books where id=?"
-	InsertBook  = "INSERT INTO Books (title, publication, publication_date, author_id) VALUES (?,?,?,?);"
-	UpdateBook  = "UPDATE Books SET title = ? ,publication = ? ,publication_date = ?,author_id=?  WHERE id =?"
-	DeleteBook  = "delete from Books where id=?;"
+	GetBook       = "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books;"
+	GetByIDBook   = "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books where id=?"
+	GetByISBNBook = "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version from Books where isbn=?"
+	InsertBook    = "INSERT INTO Books (title, publisher_id, publication_date, author_id, isbn, title_key) VALUES (?,?,?,?,NULLIF(?, ''),?);"
+	UpdateBook    = "UPDATE Books SET title = ? ,publisher_id = ? ,publication_date = ?,author_id=? ,isbn = NULLIF(?, '') ,title_key = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+	DeleteBook    = "delete from Books where id=?;"
+
+	InsertContributor  = "INSERT INTO BookAuthors (book_id, author_id, role, position) VALUES (?,?,?,?);"
+	DeleteContributors = "delete from BookAuthors where book_id=?;"
+
+	GetPublisher       = "select id,name,website,version from Publishers order by id;"
+	GetByIDPublisher   = "select id,name,website,version from Publishers where id=?"
+	GetByNamePublisher = "select id,name,website,version from Publishers where name=?"
+	InsertPublisher    = "INSERT INTO Publishers (name, website) VALUES (?,?);"
+	UpdatePublisher    = "UPDATE Publishers SET name = ? ,website = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+	DeletePublisher    = "delete from Publishers where id=?;"
+
+	// the parent of a genre is NULL for the top genres, it is read and written as 0
+	GetGenre         = "select id,name,kind,COALESCE(parent_id, 0),version from Genres order by id;"
+	GetByIDGenre     = "select id,name,kind,COALESCE(parent_id, 0),version from Genres where id=?"
+	InsertGenre      = "INSERT INTO Genres (name, kind, parent_id) VALUES (?,?,NULLIF(?, 0));"
+	UpdateGenre      = "UPDATE Genres SET name = ? ,kind = ? ,parent_id = NULLIF(?, 0) ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+	DeleteGenre      = "delete from Genres where id=?;"
+	InsertBookGenre  = "INSERT INTO BookGenres (book_id, genre_id) VALUES (?,?);"
+	DeleteBookGenres = "delete from BookGenres where book_id=?;"
+
+	GetSeries        = "select id,name,version from Series order by id;"
+	GetByIDSeries    = "select id,name,version from Series where id=?"
+	InsertSeries     = "INSERT INTO Series (name) VALUES (?);"
+	UpdateSeries     = "UPDATE Series SET name = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+	DeleteSeries     = "delete from Series where id=?;"
+	GetVolumes       = "select volume,book_id from SeriesBooks where series_id=?;"
+	InsertSeriesBook = "INSERT INTO SeriesBooks (book_id, series_id, volume) VALUES (?,?,?);"
+	DeleteSeriesBook = "delete from SeriesBooks where book_id=?;"
+	InsertEdition    = "INSERT INTO Editions (book_id, work_id, kind, language) VALUES (?,?,?,?);"
+	DeleteEdition    = "delete from Editions where book_id=?;"
+
+	GetMember        = "select id,first_name,last_name,email,phone,address,membership_type,expires_on,status,version from Members;"
+	GetByIDMember    = "select id,first_name,last_name,email,phone,address,membership_type,expires_on,status,version from Members where id=?"
+	GetByEmailMember = "select id,first_name,last_name,email,phone,address,membership_type,expires_on,status,version from Members where email=?"
+	InsertMember     = "INSERT INTO Members (first_name, last_name, email, phone, address, membership_type, expires_on, status) VALUES (?,?,?,?,?,?,?,?);"
+	UpdateMember     = "UPDATE Members SET first_name = ? ,last_name = ? ,email = ? ,phone = ? ,address = ? ,membership_type = ? ,expires_on = ? ,status = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+	DeleteMember     = "delete from Members where id=?;"
+
+	GetCopies        = "select id,book_id,barcode,copy_condition,shelf_location,acquired_on,status,version from Copies where book_id=? order by id;"
+	GetByIDCopy      = "select id,book_id,barcode,copy_condition,shelf_location,acquired_on,status,version from Copies where id=?"
+	GetByBarcodeCopy = "select id,book_id,barcode,copy_condition,shelf_location,acquired_on,status,version from Copies where barcode=?"
+	InsertCopy       = "INSERT INTO Copies (book_id, barcode, copy_condition, shelf_location, acquired_on, status) VALUES (?,?,?,?,?,?);"
+	UpdateCopy       = "UPDATE Copies SET book_id = ? ,barcode = ? ,copy_condition = ? ,shelf_location = ? ,acquired_on = ? ,status = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+	DeleteCopy       = "delete from Copies where id=?;"
+	// UpdateCopyStatus changes the status only when the copy still has the expected status
+	UpdateCopyStatus = "UPDATE Copies SET status = ? ,version = version + 1  WHERE id = ? AND status = ?"
+
+	GetByIDLoan    = "select id,copy_id,member_id,book_id,checked_out_on,due_on,returned_on,renewals,version from Loans where id=?"
+	GetActiveLoans = "select id,copy_id,member_id,book_id,checked_out_on,due_on,returned_on,renewals,version from Loans where member_id=? and returned_on IS NULL order by id;"
+	GetLoansByBook = "select id,copy_id,member_id,book_id,checked_out_on,due_on,returned_on,renewals,version from Loans where book_id=? order by id;"
+	GetMemberLoans = "select id,copy_id,member_id,book_id,checked_out_on,due_on,returned_on,renewals,version from Loans where member_id=? order by id;"
+	InsertLoan     = "INSERT INTO Loans (copy_id, member_id, book_id, checked_out_on, due_on, returned_on, renewals) VALUES (?,?,?,?,?,?,?);"
+	UpdateLoan     = "UPDATE Loans SET due_on = ? ,returned_on = ? ,renewals = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+
+	// the copy of a hold is NULL until a copy is assigned, it is read and written as 0
+	GetByIDHold      = "select id,book_id,member_id,COALESCE(copy_id, 0),status,placed_on,ready_on,expires_on,version from Holds where id=?"
+	GetHoldsByBook   = "select id,book_id,member_id,COALESCE(copy_id, 0),status,placed_on,ready_on,expires_on,version from Holds where book_id=? and status in ('waiting','ready') order by id;"
+	GetHoldsByMember = "select id,book_id,member_id,COALESCE(copy_id, 0),status,placed_on,ready_on,expires_on,version from Holds where member_id=? and status in ('waiting','ready') order by id;"
+	GetHoldsByStatus = "select id,book_id,member_id,COALESCE(copy_id, 0),status,placed_on,ready_on,expires_on,version from Holds where status=? order by id;"
+	InsertHold       = "INSERT INTO Holds (book_id, member_id, copy_id, status, placed_on, ready_on, expires_on) VALUES (?,?,NULLIF(?, 0),?,?,?,?);"
+	UpdateHold       = "UPDATE Holds SET copy_id = NULLIF(?, 0) ,status = ? ,ready_on = ? ,expires_on = ? ,version = LAST_INSERT_ID(version + 1)  WHERE id = ? AND version = ?"
+
+	// the loan of an entry is NULL for the payments and the waivers, it is read and written as 0
+	GetLedgerEntries = "select id,member_id,COALESCE(loan_id, 0),entry_type,amount,note,created_on from LedgerEntries where member_id=? order by id;"
+	GetBalance       = "select COALESCE(SUM(CASE WHEN entry_type='fine' THEN amount ELSE -amount END), 0) from LedgerEntries where member_id=?"
+	InsertLedger     = "INSERT INTO LedgerEntries (member_id, loan_id, entry_type, amount, note, created_on) VALUES (?,NULLIF(?, 0),?,?,?,?);"
+
+	// sqlite stores read the generated id and the version back using RETURNING instead of LastInsertId
+	InsertAuthorSQLite    = "INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES (?,?,?,?) RETURNING id, version;"
+	InsertBookSQLite      = "INSERT INTO Books (title, publisher_id, publication_date, author_id, isbn, title_key) VALUES (?,?,?,?,NULLIF(?, ''),?) RETURNING id, version;"
+	InsertCopySQLite      = "INSERT INTO Copies (book_id, barcode, copy_condition, shelf_location, acquired_on, status) VALUES (?,?,?,?,?,?) RETURNING id, version;"
+	InsertGenreSQLite     = "INSERT INTO Genres (name, kind, parent_id) VALUES (?,?,NULLIF(?, 0)) RETURNING id, version;"
+	InsertHoldSQLite      = "INSERT INTO Holds (book_id, member_id, copy_id, status, placed_on, ready_on, expires_on) VALUES (?,?,NULLIF(?, 0),?,?,?,?) RETURNING id, version;"
+	InsertLedgerSQLite    = "INSERT INTO LedgerEntries (member_id, loan_id, entry_type, amount, note, created_on) VALUES (?,NULLIF(?, 0),?,?,?,?) RETURNING id;"
+	InsertLoanSQLite      = "INSERT INTO Loans (copy_id, member_id, book_id, checked_out_on, due_on, returned_on, renewals) VALUES (?,?,?,?,?,?,?) RETURNING id, version;"
+	InsertMemberSQLite    = "INSERT INTO Members (first_name, last_name, email, phone, address, membership_type, expires_on, status) VALUES (?,?,?,?,?,?,?,?) RETURNING id, version;"
+	InsertPublisherSQLite = "INSERT INTO Publishers (name, website) VALUES (?,?) RETURNING id, version;"
+	InsertSeriesSQLite    = "INSERT INTO Series (name) VALUES (?) RETURNING id, version;"
+
+	// sqlite stores read the new version of an updated row back using RETURNING instead of LAST_INSERT_ID
+	UpdateAuthorSQLite    = "UPDATE Authors SET first_name = ? ,last_name = ? ,dob = ? ,pen_name = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateBookSQLite      = "UPDATE Books SET title = ? ,publisher_id = ? ,publication_date = ?,author_id=? ,isbn = NULLIF(?, '') ,title_key = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdatePublisherSQLite = "UPDATE Publishers SET name = ? ,website = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateGenreSQLite     = "UPDATE Genres SET name = ? ,kind = ? ,parent_id = NULLIF(?, 0) ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateSeriesSQLite    = "UPDATE Series SET name = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateMemberSQLite    = "UPDATE Members SET first_name = ? ,last_name = ? ,email = ? ,phone = ? ,address = ? ,membership_type = ? ,expires_on = ? ,status = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateCopySQLite      = "UPDATE Copies SET book_id = ? ,barcode = ? ,copy_condition = ? ,shelf_location = ? ,acquired_on = ? ,status = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateLoanSQLite      = "UPDATE Loans SET due_on = ? ,returned_on = ? ,renewals = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+	UpdateHoldSQLite      = "UPDATE Holds SET copy_id = NULLIF(?, 0) ,status = ? ,ready_on = ? ,expires_on = ? ,version = version + 1  WHERE id = ? AND version = ? RETURNING version;"
+
+	// sqlite compares the names ignoring the case the same as the collation of the mysql tables, the unique index of
+	// the names has the same collation
+	GetByNamePublisherSQLite = "select id,name,website,version from Publishers where name=? COLLATE NOCASE"
 )
@@ -0,0 +1,192 @@
+package datastore
+
+import (
+	"ThreeLayer/entities"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Weights of the words of a book by where they are found
+const (
+	TitleWeight     = 3
+	AuthorWeight    = 2
+	PublisherWeight = 1
+)
+
+const (
+	// selectSearch ranks the books by the relevance of their title, the names of their contributors and the name of
+	// their publisher, it needs the FULLTEXT indexes of the migrations and is used only with MySQL
+	selectSearch = "select b.*, MATCH(b.title) AGAINST (? IN BOOLEAN MODE) * 3" +
+		" + COALESCE((select MAX(MATCH(a.first_name,a.last_name,a.pen_name) AGAINST (? IN BOOLEAN MODE))" +
+		" from BookAuthors ba join Authors a on a.id = ba.author_id where ba.book_id = b.id), 0) * 2" +
+		" + COALESCE((select MATCH(p.name) AGAINST (? IN BOOLEAN MODE) from Publishers p" +
+		" where p.id = b.publisher_id), 0) as score from Books b"
+
+	searchBooks = "select id,title,publisher_id,publication_date,author_id,COALESCE(isbn, ''),version,score from (" +
+		selectSearch + ") ranked where score > 0 order by score desc, id limit ? offset ?;"
+	countSearch = "select count(*) from (" + selectSearch + ") ranked where score > 0;"
+
+	// matchSearch selects the books whose title, contributors or publisher match the FTS4 tables kept by the triggers
+	// of the sqlite migrations, it is used only with SQLite
+	matchSearch = selectBooks + " where id in (select docid from BooksSearch where BooksSearch match ?)" +
+		" or id in (select book_id from BookAuthors where author_id in" +
+		" (select docid from AuthorsSearch where AuthorsSearch match ?))" +
+		" or publisher_id in (select docid from PublishersSearch where PublishersSearch match ?) order by id;"
+
+	// the names of the matching books indexed by a SearchIndex
+	selectAuthorNames    = "select id,first_name,last_name,pen_name from Authors where id in (%s);"
+	selectPublisherNames = "select id,name from Publishers where id in (%s);"
+)
+
+// SearchBooksQuery returns the MySQL full-text query for a page of the books matching the search along with the query
+// counting all of them. Every term matches as a prefix, the count query uses all the args except the last two which
+// are the limit and offset.
+func SearchBooksQuery(query entities.SearchQuery) (list, count string, args []interface{}) {
+	terms := make([]string, len(query.Terms))
+	for i, term := range query.Terms {
+		terms[i] = term + "*"
+	}
+
+	against := strings.Join(terms, " ")
+
+	return searchBooks, countSearch, []interface{}{against, against, against, query.Limit, query.Offset}
+}
+
+// SearchMatchesQuery returns the SQLite full-text query for all the books matching any of the terms of the search
+// along with its args, every term matches as a prefix. The books are ranked by a SearchIndex of the matches
+func SearchMatchesQuery(query entities.SearchQuery) (string, []interface{}) {
+	// the terms are words of letters and digits, a quoted term ending with a star matches as a prefix
+	terms := make([]string, len(query.Terms))
+	for i, term := range query.Terms {
+		terms[i] = `"` + term + `*"`
+	}
+
+	match := strings.Join(terms, " OR ")
+
+	return matchSearch, []interface{}{match, match, match}
+}
+
+// AuthorNamesQuery returns the query for the names of the authors having any of the ids along with its args, ids must
+// not be empty
+func AuthorNamesQuery(ids []int) (string, []interface{}) {
+	placeholders, args := inArgs(ids)
+
+	return fmt.Sprintf(selectAuthorNames, placeholders), args
+}
+
+// PublisherNamesQuery returns the query for the names of the publishers having any of the ids along with its args,
+// ids must not be empty
+func PublisherNamesQuery(ids []int) (string, []interface{}) {
+	placeholders, args := inArgs(ids)
+
+	return fmt.Sprintf(selectPublisherNames, placeholders), args
+}
+
+// SearchIndex is the portable full-text index ranking the books of the memory store and the matches of the SQLite
+// full-text tables, the words are weighted the same way as in the MySQL query though the scores are computed
+// differently
+type SearchIndex struct {
+	books map[int]entities.Book
+	// words holds the weights of every word by the id of the book
+	words map[string]map[int]float64
+}
+
+// NewSearchIndex indexes the words of the titles of the books, of the names of their contributors and of the names of
+// their publishers, contributors are by the id of the book
+func NewSearchIndex(books []entities.Book, contributors map[int][]entities.Contributor, authors []entities.Author,
+	publishers []entities.Publisher) SearchIndex {
+	index := SearchIndex{books: make(map[int]entities.Book, len(books)), words: make(map[string]map[int]float64)}
+
+	authorsByID := make(map[int]entities.Author, len(authors))
+	for i := range authors {
+		authorsByID[authors[i].ID] = authors[i]
+	}
+
+	publishersByID := make(map[int]entities.Publisher, len(publishers))
+	for i := range publishers {
+		publishersByID[publishers[i].ID] = publishers[i]
+	}
+
+	for i := range books {
+		book := books[i]
+		index.books[book.ID] = book
+		index.add(book.ID, book.Title, TitleWeight)
+
+		// an author credited in several roles is indexed once
+		seen := make(map[int]bool)
+
+		for _, c := range contributors[book.ID] {
+			if seen[c.Author.ID] {
+				continue
+			}
+
+			seen[c.Author.ID] = true
+			author := authorsByID[c.Author.ID]
+			index.add(book.ID, author.FirstName+" "+author.LastName+" "+author.PenName, AuthorWeight)
+		}
+
+		index.add(book.ID, publishersByID[book.Publisher.ID].Name, PublisherWeight)
+	}
+
+	return index
+}
+
+// add indexes the words of text for the book with given id
+func (x SearchIndex) add(bookID int, text string, weight float64) {
+	for _, word := range entities.Words(text) {
+		if x.words[word] == nil {
+			x.words[word] = make(map[int]float64)
+		}
+
+		x.words[word][bookID] += weight
+	}
+}
+
+// Search returns a page of the books having a word starting with any of the terms, ordered by score and then by id.
+// A word which is a term scores its weight and a word which only starts with a term half of it
+func (x SearchIndex) Search(query entities.SearchQuery) entities.SearchPage {
+	scores := make(map[int]float64)
+
+	for _, term := range query.Terms {
+		for word, weights := range x.words {
+			if !strings.HasPrefix(word, term) {
+				continue
+			}
+
+			for id, weight := range weights {
+				if word == term {
+					scores[id] += weight
+				} else {
+					scores[id] += weight / 2
+				}
+			}
+		}
+	}
+
+	results := make([]entities.SearchResult, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, entities.SearchResult{Book: x.books[id], Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+
+		return results[i].Book.ID < results[j].Book.ID
+	})
+
+	page := entities.SearchPage{Results: make([]entities.SearchResult, 0), Total: len(results)}
+
+	if query.Offset < len(results) {
+		end := len(results)
+		if query.Limit > 0 && query.Offset+query.Limit < end {
+			end = query.Offset + query.Limit
+		}
+
+		page.Results = append(page.Results, results[query.Offset:end]...)
+	}
+
+	return page
+}
@@ -0,0 +1,84 @@
+package datastore
+
+import (
+	"ThreeLayer/entities"
+	"reflect"
+	"testing"
+)
+
+func TestSearchIndex_Search(t *testing.T) {
+	books := []entities.Book{
+		{ID: 1, Title: "Harry Potter", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 2}},
+		{ID: 2, Title: "Potted Plants", Author: entities.Author{ID: 2}, Publisher: entities.Publisher{ID: 1}},
+		{ID: 3, Title: "Rowling, a Biography", Author: entities.Author{ID: 2}, Publisher: entities.Publisher{ID: 1}},
+	}
+
+	contributors := map[int][]entities.Contributor{
+		1: {{Author: entities.Author{ID: 1}, Role: entities.RoleAuthor},
+			{Author: entities.Author{ID: 1}, Role: entities.RoleIllustrator}},
+		2: {{Author: entities.Author{ID: 2}, Role: entities.RoleAuthor}},
+		3: {{Author: entities.Author{ID: 2}, Role: entities.RoleAuthor}},
+	}
+
+	authors := []entities.Author{{ID: 1, FirstName: "Joanne", LastName: "Rowling", PenName: "JK"},
+		{ID: 2, FirstName: "MG", LastName: "Verma", PenName: "Verma"}}
+	publishers := []entities.Publisher{{ID: 1, Name: "Arihanth"}, {ID: 2, Name: "Scholastic"}}
+
+	index := NewSearchIndex(books, contributors, authors, publishers)
+
+	testcases := []struct {
+		desc       string
+		query      entities.SearchQuery
+		expResults []entities.SearchResult
+		expTotal   int
+	}{
+		{"a word of the title ranks above a name", entities.SearchQuery{Terms: []string{"rowling"}},
+			[]entities.SearchResult{{Book: books[2], Score: TitleWeight}, {Book: books[0], Score: AuthorWeight}}, 2},
+		{"a prefix scores half", entities.SearchQuery{Terms: []string{"pot"}},
+			[]entities.SearchResult{{Book: books[0], Score: 1.5}, {Book: books[1], Score: 1.5}}, 2},
+		{"the scores of the terms add up", entities.SearchQuery{Terms: []string{"potter", "scholastic"}},
+			[]entities.SearchResult{{Book: books[0], Score: TitleWeight + PublisherWeight}}, 1},
+		{"repeated words add up", entities.SearchQuery{Terms: []string{"verma"}},
+			[]entities.SearchResult{{Book: books[1], Score: 2 * AuthorWeight}, {Book: books[2], Score: 2 * AuthorWeight}},
+			2},
+		{"page", entities.SearchQuery{Terms: []string{"verma"}, Limit: 1, Offset: 1},
+			[]entities.SearchResult{{Book: books[2], Score: 2 * AuthorWeight}}, 2},
+		{"no match", entities.SearchQuery{Terms: []string{"penguin"}}, []entities.SearchResult{}, 0},
+	}
+
+	for i, tc := range testcases {
+		page := index.Search(tc.query)
+
+		if !reflect.DeepEqual(page.Results, tc.expResults) || page.Total != tc.expTotal {
+			t.Errorf("[TEST%d]Failed. %s Expected %v of %d Got %v of %d", i, tc.desc, tc.expResults, tc.expTotal,
+				page.Results, page.Total)
+		}
+	}
+}
+
+func TestSearchBooksQuery(t *testing.T) {
+	list, count, args := SearchBooksQuery(entities.SearchQuery{Terms: []string{"harry", "pot"}, Limit: 5, Offset: 10})
+
+	if list != searchBooks || count != countSearch {
+		t.Errorf("Failed. Expected the full-text queries Got %v, %v", list, count)
+	}
+
+	expArgs := []interface{}{"harry* pot*", "harry* pot*", "harry* pot*", 5, 10}
+	if !reflect.DeepEqual(args, expArgs) {
+		t.Errorf("Failed. Expected %v Got %v", expArgs, args)
+	}
+}
+
+func TestSearchMatchesQuery(t *testing.T) {
+	query, args := SearchMatchesQuery(entities.SearchQuery{Terms: []string{"harry", "pot"}, Limit: 5, Offset: 10})
+
+	if query != matchSearch {
+		t.Errorf("Failed. Expected the full-text query Got %v", query)
+	}
+
+	// the page is taken by the SearchIndex of the matches
+	expArgs := []interface{}{`"harry*" OR "pot*"`, `"harry*" OR "pot*"`, `"harry*" OR "pot*"`}
+	if !reflect.DeepEqual(args, expArgs) {
+		t.Errorf("Failed. Expected %v Got %v", expArgs, args)
+	}
+}
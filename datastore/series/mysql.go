@@ -0,0 +1,164 @@
+package series
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// Storer is the MySQL implementation of datastore.Series
+type Storer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Storer {
+	return Storer{db: db}
+}
+
+// scanSeries reads a series selected with the columns of datastore.GetSeries, it is used by all the series stores
+func scanSeries(row datastore.Scanner) (entities.Series, error) {
+	var s entities.Series
+
+	err := row.Scan(&s.ID, &s.Name, &s.Version)
+
+	return s, err
+}
+
+// GetSeries function is to perform DB Queries to get the list of series
+func (s Storer) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	return getSeries(ctx, datastore.Conn(ctx, s.db))
+}
+
+func getSeries(ctx context.Context, db datastore.DBTX) ([]entities.Series, error) {
+	rows, err := db.QueryContext(ctx, datastore.GetSeries)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	series := make([]entities.Series, 0)
+
+	for rows.Next() {
+		s, err := scanSeries(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		series = append(series, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return series, nil
+}
+
+// GetSeriesByID function is to perform DB Queries to get a series instance using its ID
+func (s Storer) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	return getSeriesByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func getSeriesByID(ctx context.Context, db datastore.DBTX, id int) (entities.Series, error) {
+	s, err := scanSeries(db.QueryRowContext(ctx, datastore.GetByIDSeries, id))
+	if err == sql.ErrNoRows {
+		return entities.Series{}, errors.EntityNotFound{Entity: "Series", ID: id}
+	}
+
+	if err != nil {
+		return entities.Series{}, errors.DB{Err: err}
+	}
+
+	return s, nil
+}
+
+// CreateSeries function is to perform DB execution to add a new series instance in database
+func (s Storer) CreateSeries(ctx context.Context, series entities.Series) (entities.Series, error) {
+	res, err := datastore.Conn(ctx, s.db).ExecContext(ctx, datastore.InsertSeries, series.Name)
+	if err != nil {
+		return entities.Series{}, errors.DB{Err: err}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return entities.Series{}, errors.DB{Err: err}
+	}
+
+	series.ID = int(id)
+	series.Version = 1
+
+	return series, nil
+}
+
+// UpdateSeries function is to perform required DB Queries to replace a series instance in database,
+// the series is returned with its new version
+func (s Storer) UpdateSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error) {
+	return updateSeries(ctx, datastore.Conn(ctx, s.db), datastore.MySQL, datastore.UpdateSeries, id, series)
+}
+
+func updateSeries(ctx context.Context, db datastore.DBTX, d datastore.Dialect, query string, id int,
+	series entities.Series) (entities.Series, error) {
+	version, err := d.Update(ctx, db, "Series", "Series", id, query, series.Name, id, series.Version)
+	if err != nil {
+		return entities.Series{}, err
+	}
+
+	series.ID = id
+	series.Version = version
+
+	return series, nil
+}
+
+// DeleteSeries function is to perform required DB Queries to remove a series instance from database,
+// a series having volumes can not be removed
+func (s Storer) DeleteSeries(ctx context.Context, id int) error {
+	return deleteSeries(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func deleteSeries(ctx context.Context, db datastore.DBTX, id int) error {
+	res, err := db.ExecContext(ctx, datastore.DeleteSeries, id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return errors.EntityNotFound{Entity: "Series", ID: id}
+	}
+
+	return nil
+}
+
+// GetVolumes function is to perform DB Queries to get the ids of the books of a series by their volume numbers
+func (s Storer) GetVolumes(ctx context.Context, id int) (map[int]int, error) {
+	return getVolumes(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+func getVolumes(ctx context.Context, db datastore.DBTX, id int) (map[int]int, error) {
+	rows, err := db.QueryContext(ctx, datastore.GetVolumes, id)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	volumes := make(map[int]int)
+
+	for rows.Next() {
+		var volume, bookID int
+
+		if err = rows.Scan(&volume, &bookID); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		volumes[volume] = bookID
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return volumes, nil
+}
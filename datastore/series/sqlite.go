@@ -0,0 +1,57 @@
+package series
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+)
+
+// SQLiteStorer is the SQLite implementation of datastore.Series, it differs from Storer only in reading
+// the generated id of a new series
+type SQLiteStorer struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) SQLiteStorer {
+	return SQLiteStorer{db: db}
+}
+
+// GetSeries function is to perform DB Queries to get the list of series
+func (s SQLiteStorer) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	return getSeries(ctx, datastore.Conn(ctx, s.db))
+}
+
+// GetSeriesByID function is to perform DB Queries to get a series instance using its ID
+func (s SQLiteStorer) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	return getSeriesByID(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+// CreateSeries function is to perform DB execution to add a new series instance in database
+func (s SQLiteStorer) CreateSeries(ctx context.Context, series entities.Series) (entities.Series, error) {
+	err := datastore.Conn(ctx, s.db).QueryRowContext(ctx, datastore.InsertSeriesSQLite, series.Name).
+		Scan(&series.ID, &series.Version)
+	if err != nil {
+		return entities.Series{}, errors.DB{Err: err}
+	}
+
+	return series, nil
+}
+
+// UpdateSeries function is to perform required DB Queries to replace a series instance in database,
+// the series is returned with its new version
+func (s SQLiteStorer) UpdateSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error) {
+	return updateSeries(ctx, datastore.Conn(ctx, s.db), datastore.SQLite, datastore.UpdateSeriesSQLite, id, series)
+}
+
+// DeleteSeries function is to perform required DB Queries to remove a series instance from database,
+// a series having volumes can not be removed
+func (s SQLiteStorer) DeleteSeries(ctx context.Context, id int) error {
+	return deleteSeries(ctx, datastore.Conn(ctx, s.db), id)
+}
+
+// GetVolumes function is to perform DB Queries to get the ids of the books of a series by their volume numbers
+func (s SQLiteStorer) GetVolumes(ctx context.Context, id int) (map[int]int, error) {
+	return getVolumes(ctx, datastore.Conn(ctx, s.db), id)
+}
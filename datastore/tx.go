@@ -0,0 +1,145 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+
+	"ThreeLayer/errors"
+)
+
+const (
+	// maxAttempts is the number of times a unit of work is run when the database aborts it for a concurrent one
+	maxAttempts = 3
+	// retryDelay is the wait before the second attempt, it grows with every attempt
+	retryDelay = 20 * time.Millisecond
+)
+
+// Transactor runs several store calls as a single unit of work, the stores take part in the
+// transaction when they are called with the context passed to fn
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DBTX is the part of *sql.DB and *sql.Tx used by the sql stores
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Scanner is a *sql.Row or *sql.Rows, so that a row is read the same way by QueryRowContext and QueryContext
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type txKey struct{}
+
+// SQLTransactor is the Transactor of the mysql and sqlite stores
+type SQLTransactor struct {
+	db *sql.DB
+}
+
+func NewSQLTransactor(db *sql.DB) SQLTransactor {
+	return SQLTransactor{db: db}
+}
+
+// WithinTx begins a transaction and commits it when fn returns nil, it is rolled back when fn fails or panics.
+// Calls made when the context already has a transaction join it, so that the outermost call decides the outcome.
+// A transaction aborted for a concurrent one is run again, errors.Unavailable is returned once every attempt failed
+func (t SQLTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := t.run(ctx, fn)
+		if !retryable(err) {
+			return err
+		}
+
+		if attempt == maxAttempts {
+			return errors.Unavailable{Err: err}
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * retryDelay):
+		}
+	}
+}
+
+// run runs fn in a single transaction
+func (t SQLTransactor) run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	// serializable makes the reads done for the checks in fn lock the rows, mysql then rejects the
+	// transaction instead of letting two of them pass the same check
+	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = fmt.Errorf("commit transaction: %w", err)
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
+
+// retryable tells whether the database aborted the transaction for a concurrent one, mysql does on a deadlock or a
+// lock wait timeout and sqlite when the database is busy or locked
+func retryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if stdErrors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1213 || mysqlErr.Number == 1205
+	}
+
+	var sqliteErr sqlite3.Error
+	if stdErrors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	return false
+}
+
+// Duplicate tells whether a unique index of the database rejected the row, mysql answers with a duplicate entry
+// and sqlite with a unique constraint error
+func Duplicate(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if stdErrors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+
+	var sqliteErr sqlite3.Error
+	if stdErrors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+
+	return false
+}
+
+// Conn returns the transaction of the context when there is one and db otherwise
+func Conn(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
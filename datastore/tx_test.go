@@ -0,0 +1,125 @@
+package datastore
+
+import (
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+	"testing"
+)
+
+func TestSQLTransactor_WithinTx(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		fnErr  error
+		expErr error
+	}{
+		{"commit when fn succeeds", nil, nil},
+		{"rollback when fn fails", fmt.Errorf("delete failed"), fmt.Errorf("delete failed")},
+	}
+
+	for i, v := range testcases {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("delete from book where id=?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if v.fnErr == nil {
+			mock.ExpectCommit()
+		} else {
+			mock.ExpectRollback()
+		}
+
+		err = NewSQLTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+			if _, ok := Conn(ctx, db).(*sql.Tx); !ok {
+				t.Errorf("[TEST%d]Failed. Expected the transaction in the context", i)
+			}
+
+			// a nested unit of work joins the running transaction
+			return NewSQLTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+				if _, err := Conn(ctx, db).ExecContext(ctx, "delete from book where id=?", 1); err != nil {
+					return err
+				}
+
+				return v.fnErr
+			})
+		})
+
+		if fmt.Sprint(err) != fmt.Sprint(v.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
+		}
+
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("[TEST%d]Failed. %v", i, err)
+		}
+	}
+}
+
+func TestSQLTransactor_WithinTx_Retry(t *testing.T) {
+	deadlock := errors.DB{Err: &mysql.MySQLError{Number: 1213, Message: "Deadlock found"}}
+
+	testcases := []struct {
+		desc     string
+		errs     []error
+		attempts int
+		expErr   error
+	}{
+		{"deadlock is retried", []error{deadlock, nil}, 2, nil},
+		{"busy sqlite is retried", []error{errors.DB{Err: sqlite3.Error{Code: sqlite3.ErrBusy}}, nil}, 2, nil},
+		{"unavailable after the last attempt", []error{deadlock, deadlock, deadlock}, 3,
+			errors.Unavailable{Err: deadlock}},
+		{"other errors are not retried", []error{errors.DB{Err: &mysql.MySQLError{Number: 1062}}}, 1,
+			errors.DB{Err: &mysql.MySQLError{Number: 1062}}},
+	}
+
+	for i, v := range testcases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		for _, fnErr := range v.errs {
+			mock.ExpectBegin()
+
+			if fnErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+		}
+
+		attempts := 0
+
+		err = NewSQLTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+			attempts++
+
+			return v.errs[attempts-1]
+		})
+
+		if fmt.Sprint(err) != fmt.Sprint(v.expErr) || attempts != v.attempts {
+			t.Errorf("[TEST%d]Failed. Expected %v after %d attempts\tGot %v after %d", i, v.expErr, v.attempts, err,
+				attempts)
+		}
+
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("[TEST%d]Failed. %v", i, err)
+		}
+	}
+}
+
+func TestConn(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	if conn := Conn(context.Background(), db); conn != db {
+		t.Errorf("Failed. Expected the db outside of a transaction Got %v", conn)
+	}
+}
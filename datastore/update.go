@@ -0,0 +1,150 @@
+package datastore
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Dialect tells how the new version of a row is read back after an update guarded by the version of the row,
+// mysql sets it with LAST_INSERT_ID so that it is in the result of the update and sqlite returns it
+type Dialect int
+
+const (
+	MySQL Dialect = iota
+	SQLite
+)
+
+// Update runs the query updating the row with id of table, the version the row must still have is the last of args.
+// The new version is returned, EntityNotFound when there is no row with id and PreconditionFailed when the row has
+// another version
+func (d Dialect) Update(ctx context.Context, db DBTX, entity, table string, id int, query string,
+	args ...interface{}) (int, error) {
+	if d == SQLite {
+		var version int
+
+		err := db.QueryRowContext(ctx, query, args...).Scan(&version)
+		if err == sql.ErrNoRows {
+			return 0, notUpdated(ctx, db, entity, table, id)
+		}
+
+		if err != nil {
+			return 0, errors.DB{Err: err}
+		}
+
+		return version, nil
+	}
+
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.DB{Err: err}
+	}
+
+	if r, _ := res.RowsAffected(); r == 0 {
+		return 0, notUpdated(ctx, db, entity, table, id)
+	}
+
+	version, err := res.LastInsertId()
+	if err != nil {
+		return 0, errors.DB{Err: err}
+	}
+
+	return int(version), nil
+}
+
+// notUpdated tells why no row was updated, either there is no row with id or it has another version
+func notUpdated(ctx context.Context, db DBTX, entity, table string, id int) error {
+	var version int
+
+	err := db.QueryRowContext(ctx, "select version from "+table+" where id=?", id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return errors.EntityNotFound{Entity: entity, ID: id}
+	}
+
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return errors.PreconditionFailed{Entity: entity, ID: id}
+}
+
+// UpdateBookFieldsQuery returns the query of the dialect updating only the given fields of the book with given id
+// when it still has the version of book
+func UpdateBookFieldsQuery(d Dialect, id int, book entities.Book,
+	fields []string) (string, []interface{}, error) {
+	columns := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, field := range fields {
+		switch field {
+		case entities.BookTitle:
+			columns, args = append(columns, "title", "title_key"), append(args, book.Title, entities.TitleKey(book.Title))
+		case entities.BookPublisher:
+			columns, args = append(columns, "publisher_id"), append(args, book.Publisher.ID)
+		case entities.BookPublishedDate:
+			columns, args = append(columns, "publication_date"), append(args, book.PublishedDate)
+		case entities.BookISBN:
+			// a book without an isbn keeps NULL, so that the unique index does not count it
+			var isbn interface{}
+			if book.ISBN != "" {
+				isbn = book.ISBN
+			}
+
+			columns, args = append(columns, "isbn"), append(args, isbn)
+		case entities.BookContributors:
+			// the contributors are kept in BookAuthors by the stores, the book keeps the first of them
+			columns, args = append(columns, "author_id"), append(args, book.Author.ID)
+		case entities.BookGenres, entities.BookSeries, entities.BookEdition:
+			// the genres, the series and the edition are kept in tables of their own by the stores, only the version
+			// of the book changes
+		default:
+			return "", nil, fmt.Errorf("book field %q can not be updated", field)
+		}
+	}
+
+	return d.updateQuery("Books", columns), append(args, id, book.Version), nil
+}
+
+// UpdateAuthorFieldsQuery returns the query of the dialect updating only the given fields of the author with given
+// id when it still has the version of author
+func UpdateAuthorFieldsQuery(d Dialect, id int, author entities.Author,
+	fields []string) (string, []interface{}, error) {
+	columns := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, field := range fields {
+		switch field {
+		case entities.AuthorFirstName:
+			columns, args = append(columns, "first_name"), append(args, author.FirstName)
+		case entities.AuthorLastName:
+			columns, args = append(columns, "last_name"), append(args, author.LastName)
+		case entities.AuthorDob:
+			columns, args = append(columns, "dob"), append(args, author.Dob)
+		case entities.AuthorPenName:
+			columns, args = append(columns, "pen_name"), append(args, author.PenName)
+		default:
+			return "", nil, fmt.Errorf("author field %q can not be updated", field)
+		}
+	}
+
+	return d.updateQuery("Authors", columns), append(args, id, author.Version), nil
+}
+
+// updateQuery sets the columns and increments the version of the row, only the version is incremented
+// when there are no columns
+func (d Dialect) updateQuery(table string, columns []string) string {
+	set := ""
+	if len(columns) > 0 {
+		set = strings.Join(columns, " = ?, ") + " = ?, "
+	}
+
+	if d == SQLite {
+		return "UPDATE " + table + " SET " + set + "version = version + 1 WHERE id = ? AND version = ? " +
+			"RETURNING version"
+	}
+
+	return "UPDATE " + table + " SET " + set + "version = LAST_INSERT_ID(version + 1) WHERE id = ? AND version = ?"
+}
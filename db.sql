@@ -0,0 +1,2 @@
+-- creates the database used by the server, the tables are created with: go run . migrate up
+CREATE DATABASE IF NOT EXISTS test;
@@ -0,0 +1,80 @@
+package author
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceAuthor "ThreeLayer/service/author"
+	"context"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"testing"
+)
+
+// TestHandler_EndToEnd runs the author requests one after another against the real service logic
+// backed by an in memory datastore
+func TestHandler_EndToEnd(t *testing.T) {
+	db := memory.New()
+	bookStore := memory.NewBook(db)
+	handler := New(serviceAuthor.New(memory.NewAuthor(db), bookStore, memory.NewLoan(db), db))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/author", handler.GetAuthor).Methods(http.MethodGet)
+	r.HandleFunc("/author", handler.PostAuthor).Methods(http.MethodPost)
+	r.HandleFunc("/author/{id}", handler.GetAuthorByID).Methods(http.MethodGet)
+	r.HandleFunc("/author/{id}", handler.PutAuthor).Methods(http.MethodPut)
+	r.HandleFunc("/author/{id}", handler.PatchAuthor).Methods(http.MethodPatch)
+	r.HandleFunc("/author/{id}", handler.DeleteAuthor).Methods(http.MethodDelete)
+
+	author := entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"}
+	created := entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}
+	book := entities.Book{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add author", Method: http.MethodPost, Target: "/author", ReqBody: author,
+			ExpStatus: http.StatusCreated, ExpRes: created},
+		{Desc: "author exists already", Method: http.MethodPost, Target: "/author", ReqBody: author,
+			ExpStatus: http.StatusConflict},
+		{Desc: "invalid author", Method: http.MethodPost, Target: "/author",
+			ReqBody: entities.Author{LastName: "Verma"}, ExpStatus: http.StatusBadRequest},
+		{Desc: "get authors", Method: http.MethodGet, Target: "/author", ExpStatus: http.StatusOK,
+			ExpRes: []entities.AuthorDetails{{Author: created}}},
+		{Desc: "patch pen name", Method: http.MethodPatch, Target: "/author/1",
+			ReqBody: json.RawMessage(`{"pen_name":"HCV"}`), ExpStatus: http.StatusOK, ExpRes: entities.Author{ID: 1,
+				FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "HCV"}},
+		{Desc: "patch back pen name", Method: http.MethodPatch, Target: "/author/1",
+			ReqBody:   json.RawMessage(`[{"op":"replace","path":"/pen_name","value":"Verma"}]`),
+			ExpStatus: http.StatusOK, ExpRes: created},
+		{Desc: "patch removing last name", Method: http.MethodPatch, Target: "/author/1",
+			ReqBody: json.RawMessage(`{"last_name":null}`), ExpStatus: http.StatusBadRequest},
+		{Desc: "get author with books", Method: http.MethodGet, Target: "/author/1?includeBooks=true",
+			ExpStatus: http.StatusOK, ExpRes: entities.AuthorDetails{Author: created, Books: []entities.Book{book}}},
+		{Desc: "born after the book was published", Method: http.MethodPatch, Target: "/author/1",
+			ReqBody: json.RawMessage(`{"dob":"2001-01-01"}`), ExpStatus: http.StatusBadRequest},
+		{Desc: "date of birth as a year", Method: http.MethodPatch, Target: "/author/1",
+			ReqBody: json.RawMessage(`{"dob":"1999"}`), ExpStatus: http.StatusBadRequest},
+		{Desc: "delete author with books", Method: http.MethodDelete, Target: "/author/1",
+			ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted author", Method: http.MethodGet, Target: "/author/1", ExpStatus: http.StatusNotFound},
+		{Desc: "get books of deleted author", Method: http.MethodGet, Target: "/author", ExpStatus: http.StatusOK,
+			ExpRes: []entities.AuthorDetails{}},
+	}
+
+	// the book of the author is added before the author is read with the books
+	withBooks := 7
+	deliverytest.Run(t, r, testcases[:withBooks])
+
+	if _, err := bookStore.CreateBook(context.Background(), book); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	deliverytest.Run(t, r, testcases[withBooks:])
+
+	books, _ := bookStore.GetAllBook(context.Background())
+	if len(books) != 0 {
+		t.Errorf("Failed. Expected books of the deleted author to be removed, Got %v", books)
+	}
+}
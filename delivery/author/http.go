@@ -6,9 +6,7 @@ import (
 	"ThreeLayer/errors"
 	"ThreeLayer/service"
 	"context"
-	"encoding/json"
 	"github.com/gorilla/mux"
-	"io"
 	"net/http"
 	"strconv"
 )
@@ -22,6 +20,36 @@ func New(author service.Author) Handler {
 	return Handler{service: author}
 }
 
+// GetAuthor function is to perform Handler Requests to get all the author instances from the database
+func (a Handler) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	includeBooks := r.URL.Query().Get("includeBooks")
+
+	ctx := context.WithValue(r.Context(), entities.IncludeBooks, includeBooks == "true")
+
+	authors, err := a.service.GetAuthor(ctx)
+	delivery.SetStatusCode(w, r.Method, authors, err)
+}
+
+// GetAuthorByID function is to perform Handler Requests to get an author instance using its ID from the database
+func (a Handler) GetAuthorByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	includeBooks := r.URL.Query().Get("includeBooks")
+
+	ctx := context.WithValue(r.Context(), entities.IncludeBooks, includeBooks == "true")
+
+	author, err := a.service.GetAuthorByID(ctx, id)
+	if err == nil && delivery.CheckETag(w, r, author.Version) {
+		return
+	}
+
+	delivery.SetStatusCode(w, r.Method, author, err)
+}
+
 // PutAuthor function is to perform Handler Requests to make changes to an existing author instance in the database
 func (a Handler) PutAuthor(w http.ResponseWriter, r *http.Request) {
 
@@ -31,22 +59,49 @@ func (a Handler) PutAuthor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	author, err := getAuthor(r)
+	author, err := delivery.ReadBody[entities.Author](r)
 	if err != nil {
 		delivery.SetStatusCode(w, r.Method, author, err)
 		return
 	}
 
-	author, err = a.service.PutAuthor(context.Background(), id, author)
+	author, err = a.service.PutAuthor(r.Context(), id, author)
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(author.Version))
+	}
+
+	delivery.SetStatusCode(w, r.Method, author, err)
+}
+
+// PatchAuthor function is to perform Handler Requests to change only some of the details of an existing author,
+// the body is a JSON Merge Patch or a JSON Patch document
+func (a Handler) PatchAuthor(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	patch, err := delivery.GetPatch(r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	author, err := a.service.PatchAuthor(r.Context(), id, patch)
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(author.Version))
+	}
 
 	delivery.SetStatusCode(w, r.Method, author, err)
 }
 
 // PostAuthor function is to perform Handler Requests add new author instance in the database
 func (a Handler) PostAuthor(w http.ResponseWriter, r *http.Request) {
-	author, err := getAuthor(r)
+	author, err := delivery.ReadBody[entities.Author](r)
 	if err != nil {
 		delivery.SetStatusCode(w, r.Method, author, err)
+		return
 	}
 
 	author, err = a.service.PostAuthor(r.Context(), author)
@@ -62,21 +117,6 @@ func (a Handler) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.service.DeleteAuthor(context.Background(), id)
+	err = a.service.DeleteAuthor(r.Context(), id)
 	delivery.SetStatusCode(w, r.Method, nil, err)
-}
-func getAuthor(r *http.Request) (entities.Author, error) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		return entities.Author{}, errors.InValidDetails{Details: "body"}
-	}
-
-	var author entities.Author
-
-	err = json.Unmarshal(body, &author)
-	if err != nil {
-		return entities.Author{}, errors.InValidDetails{Details: "body"}
-	}
-
-	return author, nil
-}
+}
\ No newline at end of file
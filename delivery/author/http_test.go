@@ -6,7 +6,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/golang/mock/gomock"
+	"reflect"
 	"strconv"
 
 	"ThreeLayer/errors"
@@ -35,20 +37,20 @@ func TestHandlerAuthor_Post(t *testing.T) {
 		expError  error
 	}{
 		{"Valid details",
-			entities.Author{FirstName: "HC", LastName: "Verma", Dob: "2/11/1989",
+			entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1989, 11, 2),
 				PenName: "Verma"},
-			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: "2/11/1989",
+			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1989, 11, 2),
 				PenName: "Verma"},
 			http.StatusCreated, nil},
 		{"InValid details",
-			entities.Author{FirstName: "", LastName: "Verma", Dob: "2/11/1989",
+			entities.Author{FirstName: "", LastName: "Verma", Dob: entities.NewDate(1989, 11, 2),
 				PenName: "Verma"},
 			entities.Author{},
 			http.StatusBadRequest, errors.InValidDetails{Details: "FirstName"}},
 	}
 
 	for i, v := range testcases {
-		mockService.EXPECT().PostAuthor(context.TODO(), v.reqBody).Return(v.expRes, v.expError)
+		mockService.EXPECT().PostAuthor(context.Background(), v.reqBody).Return(v.expRes, v.expError)
 
 		body, _ := json.Marshal(v.reqBody)
 		req := httptest.NewRequest(http.MethodPost, "/author", bytes.NewReader(body))
@@ -74,6 +76,128 @@ func TestHandlerAuthor_Post(t *testing.T) {
 	}
 }
 
+// TestAuthorHandler_GetAuthor function contains test cases to check the function which performs Handler Requests
+// to get all the author instances from the database
+func TestAuthorHandler_GetAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockAuthor(ctrl)
+	mock := New(mockService)
+
+	testcases := []struct {
+		desc          string
+		includeBooks  string
+		expRes        []entities.AuthorDetails
+		expStatusCode int
+		expError      error
+	}{
+		{"get all authors", "",
+			[]entities.AuthorDetails{{Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma",
+				Dob: entities.NewDate(1989, 11, 2), PenName: "Verma"}}},
+			http.StatusOK, nil},
+		{"get all authors with books", "true",
+			[]entities.AuthorDetails{{Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma",
+				Dob: entities.NewDate(1989, 11, 2), PenName: "Verma"}, Books: []entities.Book{{ID: 1, Title: "Rahul",
+				Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+				PublishedDate: entities.NewDate(2000, 7, 22)}}}},
+			http.StatusOK, nil},
+		{"database error", "", nil, http.StatusInternalServerError, errors.DB{Err: fmt.Errorf("db error")}},
+	}
+
+	for i, v := range testcases {
+		ctx := context.WithValue(context.Background(), entities.IncludeBooks, v.includeBooks == "true")
+		mockService.EXPECT().GetAuthor(ctx).Return(v.expRes, v.expError)
+
+		req := httptest.NewRequest(http.MethodGet, "/author?includeBooks="+v.includeBooks, nil)
+		w := httptest.NewRecorder()
+
+		mock.GetAuthor(w, req)
+
+		if w.Code != v.expStatusCode {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expStatusCode, w.Code)
+		}
+
+		if v.expError != nil {
+			continue
+		}
+
+		var resAuthors []entities.AuthorDetails
+
+		err := json.NewDecoder(w.Body).Decode(&resAuthors)
+		if err != nil {
+			log.Print(err)
+		}
+
+		if !reflect.DeepEqual(resAuthors, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expRes, resAuthors)
+		}
+	}
+}
+
+// TestAuthorHandler_GetAuthorByID function contains test cases to check the function which performs Handler Requests
+// to get an author instance using its ID from the database
+func TestAuthorHandler_GetAuthorByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockAuthor(ctrl)
+	mock := New(mockService)
+
+	testcases := []struct {
+		desc          string
+		reqID         string
+		includeBooks  string
+		expRes        entities.AuthorDetails
+		expStatusCode int
+		expError      error
+	}{
+		{"get author", "1", "",
+			entities.AuthorDetails{Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma",
+				Dob: entities.NewDate(1989, 11, 2), PenName: "Verma"}},
+			http.StatusOK, nil},
+		{"get author with books", "1", "true",
+			entities.AuthorDetails{Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma",
+				Dob: entities.NewDate(1989, 11, 2), PenName: "Verma"}, Books: []entities.Book{{ID: 1, Title: "Rahul",
+				Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+				PublishedDate: entities.NewDate(2000, 7, 22)}}},
+			http.StatusOK, nil},
+		{"author does not exist", "100", "", entities.AuthorDetails{},
+			http.StatusNotFound, errors.EntityNotFound{Entity: "Author", ID: 100}},
+	}
+
+	for i, v := range testcases {
+		req := httptest.NewRequest(http.MethodGet, "/author/{id}?includeBooks="+v.includeBooks, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": v.reqID})
+		w := httptest.NewRecorder()
+
+		id, _ := strconv.Atoi(v.reqID)
+		ctx := context.WithValue(req.Context(), entities.IncludeBooks, v.includeBooks == "true")
+		mockService.EXPECT().GetAuthorByID(ctx, id).Return(v.expRes, v.expError)
+
+		mock.GetAuthorByID(w, req)
+
+		if w.Code != v.expStatusCode {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expStatusCode, w.Code)
+		}
+
+		if v.expError != nil {
+			continue
+		}
+
+		var resAuthor entities.AuthorDetails
+
+		err := json.NewDecoder(w.Body).Decode(&resAuthor)
+		if err != nil {
+			log.Print(err)
+		}
+
+		if !reflect.DeepEqual(resAuthor, v.expRes) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expRes, resAuthor)
+		}
+	}
+}
+
 // TestAuthorHandler_putAuthor function contains test cases to check the function which performs Handler Requests
 // to make changes to an existing author instance in the database
 func TestAuthorHandler_PutAuthor(t *testing.T) {
@@ -92,27 +216,30 @@ func TestAuthorHandler_PutAuthor(t *testing.T) {
 		expError      error
 	}{
 		{"success case update firstname.", "1",
-			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: "22/07/2000",
+			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22),
 				PenName: "ABC"},
-			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: "22/07/2000",
+			entities.Author{ID: 1, FirstName: "Rahul", LastName: "Saini", Dob: entities.NewDate(2000, 7, 22),
 				PenName: "ABC"},
 			http.StatusOK, nil},
 		{"success case id not present.", "1000",
-			entities.Author{ID: 1000, FirstName: "Ram", LastName: "lal", Dob: "22/07/2000", PenName: "ABC"},
+			entities.Author{ID: 1000, FirstName: "Ram", LastName: "lal", Dob: entities.NewDate(2000, 7, 22),
+				PenName: "ABC"},
 			entities.Author{},
 			http.StatusNotFound, errors.EntityNotFound{Entity: "Author", ID: 1000}},
 		//{"invalid id", "id",
-		//	entities.Author{ID: 1000, FirstName: "Ram", LastName: "lal", Dob: "22/07/2000", PenName: "ABC"},
+		//	entities.Author{ID: 1000, FirstName: "Ram", LastName: "lal", Dob: entities.NewDate(2000, 7, 22),
+		//		PenName: "ABC"},
 		//	entities.Author{},
 		//	http.StatusBadRequest, errors.EntityNotFound{"Author", 1000}},
 		{"exist Already", "2",
-			entities.Author{ID: 2, FirstName: "Ram", LastName: "lal", Dob: "22/07/2000", PenName: "ABC"},
+			entities.Author{ID: 2, FirstName: "Ram", LastName: "lal", Dob: entities.NewDate(2000, 7, 22),
+				PenName: "ABC"},
 			entities.Author{},
 			http.StatusConflict, errors.ExistAlready{Entity: "Author"}},
 	}
 
 	for i, v := range testcases {
-		mockService.EXPECT().PutAuthor(context.Background(), v.reqData.ID, v.reqData).Return(v.expData, v.expError)
+		mockService.EXPECT().PutAuthor(gomock.Any(), v.reqData.ID, v.reqData).Return(v.expData, v.expError)
 
 		body, _ := json.Marshal(v.reqData)
 		req := httptest.NewRequest(http.MethodPut, "/author/id", bytes.NewReader(body))
@@ -163,7 +290,7 @@ func TestAuthorHandler_DeleteAuthor(t *testing.T) {
 			log.Print(err)
 		}
 
-		mockService.EXPECT().DeleteAuthor(context.Background(), id).Return(v.expError)
+		mockService.EXPECT().DeleteAuthor(gomock.Any(), id).Return(v.expError)
 
 		req := httptest.NewRequest(http.MethodDelete, "/author/{id}", nil)
 		req = mux.SetURLVars(req, map[string]string{"id": v.reqID})
@@ -0,0 +1,350 @@
+package books
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceBook "ThreeLayer/service/books"
+	"context"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"testing"
+)
+
+// newRouter returns the book routes backed by the real service and an in memory datastore having two authors, the
+// genre Fiction with its child Mystery and the series Dune. The If-Match header is required for the changes when
+// requireIfMatch is set
+func newRouter(t *testing.T, requireIfMatch bool) *mux.Router {
+	db := memory.New()
+	authorStore := memory.NewAuthor(db)
+
+	for _, firstName := range []string{"HC", "MG"} {
+		_, err := authorStore.CreateAuthor(context.Background(), entities.Author{FirstName: firstName, LastName: "Verma",
+			Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"})
+		if err != nil {
+			t.Fatalf("expected error to be nil got %v", err)
+		}
+	}
+
+	genreStore := memory.NewGenre(db)
+
+	for _, g := range []entities.Genre{{Name: "Fiction", Kind: entities.KindGenre},
+		{Name: "Mystery", Kind: entities.KindGenre, ParentID: 1}} {
+		if _, err := genreStore.CreateGenre(context.Background(), g); err != nil {
+			t.Fatalf("expected error to be nil got %v", err)
+		}
+	}
+
+	seriesStore := memory.NewSeries(db)
+
+	if _, err := seriesStore.CreateSeries(context.Background(), entities.Series{Name: "Dune"}); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	// a book of the same title by the same author is a duplicate
+	policy := serviceBook.Policy{DuplicateKeys: []string{entities.DuplicateByTitle, entities.DuplicateByAuthor}}
+
+	handler := New(serviceBook.New(memory.NewBook(db), authorStore, memory.NewPublisher(db), genreStore,
+		seriesStore, memory.NewCopy(db), memory.NewLoan(db), db, policy))
+
+	ifMatch := delivery.IfMatch(requireIfMatch)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/book", handler.GetBook).Methods(http.MethodGet)
+	r.HandleFunc("/book", handler.PostBook).Methods(http.MethodPost)
+	r.HandleFunc("/book/{id}", handler.GetBookByID).Methods(http.MethodGet)
+	r.HandleFunc("/book/isbn/{isbn}", handler.GetBookByISBN).Methods(http.MethodGet)
+	r.HandleFunc("/search", handler.SearchBooks).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}", ifMatch(handler.PutBook)).Methods(http.MethodPut)
+	r.HandleFunc("/book/{id}", ifMatch(handler.PatchBook)).Methods(http.MethodPatch)
+	r.HandleFunc("/book/{id}", ifMatch(handler.DeleteBook)).Methods(http.MethodDelete)
+	r.HandleFunc("/book/{id}/editions", handler.GetEditions).Methods(http.MethodGet)
+	r.HandleFunc("/series/{id}/books", handler.GetSeriesBooks).Methods(http.MethodGet)
+
+	return r
+}
+
+// TestBookHandler_EndToEnd runs the book requests one after another against the real service logic
+func TestBookHandler_EndToEnd(t *testing.T) {
+	r := newRouter(t, false)
+
+	author := entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}
+	other := entities.Author{ID: 2, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}
+	credits := []entities.Contributor{{Author: author, Role: entities.RoleAuthor}}
+	// the book does not have any copies
+	none := &entities.Availability{}
+	penguin := entities.Publisher{ID: 3, Name: "Penguin"}
+	arihanth := entities.Publisher{ID: 1, Name: "Arihanth"}
+	book := entities.Book{Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+	updated := entities.Book{Title: "Rahul 2", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+		PublishedDate: entities.NewDate(2001, 7, 22)}
+	// the book of the other author is translated by the first one
+	translated := entities.Book{Title: "Go", Contributors: []entities.Contributor{{Author: entities.Author{ID: 2}},
+		{Author: entities.Author{ID: 1}, Role: entities.RoleTranslator}}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+	translatedRes := entities.Book{ID: 2, Title: "Go", Author: other, Contributors: []entities.Contributor{
+		{Author: other, Role: entities.RoleAuthor}, {Author: author, Role: entities.RoleTranslator}}, Publisher: penguin,
+		PublishedDate: entities.NewDate(2000, 7, 22), Availability: none}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add book", Method: http.MethodPost, Target: "/book", ReqBody: book, ExpStatus: http.StatusCreated,
+			ExpRes: entities.Book{ID: 1, Title: "Rahul", Author: author, Contributors: credits, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: none}},
+		{Desc: "same title by the same author", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: " rahul", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2001, 7, 22)},
+			ExpStatus: http.StatusConflict,
+			ExpRes: delivery.ErrorBody{Error: delivery.ErrorResponse{Code: delivery.CodeAlreadyExists,
+				Message: "entity Book with id 1 already exists", Entity: "Book", ID: 1}}},
+		{Desc: "published date as a year", Method: http.MethodPost, Target: "/book",
+			ReqBody: json.RawMessage(`{"title":"Go","author":{"id":1},` +
+				`"publisher":{"id":3},"published_date":"2020"}`), ExpStatus: http.StatusBadRequest},
+		{Desc: "published before the author was born", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Go", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(1999, 1, 1)},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "publisher does not exist", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 7}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "get books with author", Method: http.MethodGet, Target: "/book?includeAuthor=true",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Book{{ID: 1, Title: "Rahul", Author: author,
+				Contributors: credits, Publisher: penguin, PublishedDate: entities.NewDate(2000, 7, 22),
+				Availability: none}}},
+		{Desc: "get books by title", Method: http.MethodGet, Target: "/book?title=Other", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Book{}},
+		{Desc: "get books by publisher", Method: http.MethodGet, Target: "/book?publisherId=2",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Book{}},
+		{Desc: "get book", Method: http.MethodGet, Target: "/book/1", ExpStatus: http.StatusOK,
+			ExpRes: entities.Book{ID: 1, Title: "Rahul", Author: author, Contributors: credits, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: none}},
+		{Desc: "update book", Method: http.MethodPut, Target: "/book/1", ReqBody: updated, ExpStatus: http.StatusOK,
+			ExpRes: entities.Book{ID: 1, Title: "Rahul 2", Author: author, Contributors: credits,
+				Publisher: arihanth, PublishedDate: entities.NewDate(2001, 7, 22), Availability: none}},
+		{Desc: "patch title", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"title":"Rahul 3"}`), ExpStatus: http.StatusOK, ExpRes: entities.Book{ID: 1,
+				Title: "Rahul 3", Author: author, Contributors: credits, Publisher: arihanth,
+				PublishedDate: entities.NewDate(2001, 7, 22), Availability: none}},
+		{Desc: "patch isbn-10", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"isbn":"0-306-40615-2"}`), ExpStatus: http.StatusOK,
+			ExpRes: entities.Book{ID: 1, Title: "Rahul 3", Author: author, Contributors: credits,
+				Publisher: arihanth, PublishedDate: entities.NewDate(2001, 7, 22), ISBN: "9780306406157",
+				ISBN10: "0306406152", Availability: none}},
+		{Desc: "get book by isbn", Method: http.MethodGet, Target: "/book/isbn/978-0-306-40615-7",
+			ExpStatus: http.StatusOK, ExpRes: entities.Book{ID: 1, Title: "Rahul 3", Author: author,
+				Contributors: credits, Publisher: arihanth, PublishedDate: entities.NewDate(2001, 7, 22),
+				ISBN: "9780306406157", ISBN10: "0306406152", Availability: none}},
+		{Desc: "isbn-10 of another isbn", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody:   json.RawMessage(`{"isbn":"9780306406157","isbn_10":"0-8044-2957-X"}`),
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "patch isbn-10 alone", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"isbn_10":"0-8044-2957-x"}`), ExpStatus: http.StatusOK,
+			ExpRes: entities.Book{ID: 1, Title: "Rahul 3", Author: author, Contributors: credits,
+				Publisher: arihanth, PublishedDate: entities.NewDate(2001, 7, 22), ISBN: "9780804429573",
+				ISBN10: "080442957X", Availability: none}},
+		{Desc: "patch isbn-13 alone", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"isbn":"978-0-306-40615-7"}`), ExpStatus: http.StatusOK,
+			ExpRes: entities.Book{ID: 1, Title: "Rahul 3", Author: author, Contributors: credits,
+				Publisher: arihanth, PublishedDate: entities.NewDate(2001, 7, 22), ISBN: "9780306406157",
+				ISBN10: "0306406152", Availability: none}},
+		{Desc: "get book by invalid isbn", Method: http.MethodGet, Target: "/book/isbn/0-306-40615-3",
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "isbn of another book", Method: http.MethodPost, Target: "/book", ReqBody: entities.Book{Title: "Go",
+			Author: entities.Author{ID: 2}, Publisher: entities.Publisher{ID: 3},
+			PublishedDate: entities.NewDate(2000, 7, 22), ISBN: "0306406152"}, ExpStatus: http.StatusConflict,
+			ExpRes: delivery.ErrorBody{Error: delivery.ErrorResponse{Code: delivery.CodeAlreadyExists,
+				Message: "entity Book with id 1 already exists", Entity: "Book", ID: 1}}},
+		{Desc: "patch to missing publisher", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"publisher":{"id":7}}`), ExpStatus: http.StatusBadRequest},
+		{Desc: "patch of missing book", Method: http.MethodPatch, Target: "/book/5",
+			ReqBody: json.RawMessage(`{"title":"Rahul 3"}`), ExpStatus: http.StatusNotFound},
+		{Desc: "add translated book", Method: http.MethodPost, Target: "/book", ReqBody: translated,
+			ExpStatus: http.StatusCreated, ExpRes: translatedRes},
+		{Desc: "contributor with unknown role", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Go",
+				Contributors: []entities.Contributor{{Author: entities.Author{ID: 2}, Role: "reviewer"}},
+				Publisher:    entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "another book of the author", Method: http.MethodPost, Target: "/book", ReqBody: book,
+			ExpStatus: http.StatusCreated, ExpRes: entities.Book{ID: 3, Title: "Rahul", Author: author,
+				Contributors: credits, Publisher: penguin, PublishedDate: entities.NewDate(2000, 7, 22),
+				Availability: none}},
+		{Desc: "search by a prefix of the publisher", Method: http.MethodGet, Target: "/search?q=Pengu",
+			ExpStatus: http.StatusOK, ExpRes: []entities.SearchResult{{Book: translatedRes, Score: 0.5},
+				{Book: entities.Book{ID: 3, Title: "Rahul", Author: author, Contributors: credits, Publisher: penguin,
+					PublishedDate: entities.NewDate(2000, 7, 22), Availability: none}, Score: 0.5}}},
+		{Desc: "search without words", Method: http.MethodGet, Target: "/search?q=%20",
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "delete book", Method: http.MethodDelete, Target: "/book/1", ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted book", Method: http.MethodGet, Target: "/book/1", ExpStatus: http.StatusNotFound},
+		{Desc: "get deleted book by isbn", Method: http.MethodGet, Target: "/book/isbn/9780306406157",
+			ExpStatus: http.StatusNotFound},
+		{Desc: "get books of the translator", Method: http.MethodGet, Target: "/book?authorId=1&includeAuthor=true",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Book{translatedRes, {ID: 3, Title: "Rahul", Author: author,
+				Contributors: credits, Publisher: penguin, PublishedDate: entities.NewDate(2000, 7, 22),
+				Availability: none}}},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
+
+// TestBookHandler_Genres checks that the books of a genre include the books of its descendants and that the facets
+// count the books of every genre, publisher and decade
+func TestBookHandler_Genres(t *testing.T) {
+	r := newRouter(t, false)
+
+	mystery := entities.Genre{ID: 2, Name: "Mystery", Kind: entities.KindGenre, ParentID: 1}
+	author := entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}
+	credits := []entities.Contributor{{Author: author, Role: entities.RoleAuthor}}
+	// the books do not have any copies
+	none := &entities.Availability{}
+	tagged := entities.Book{ID: 1, Title: "Rahul", Author: author, Contributors: credits,
+		Publisher: entities.Publisher{ID: 3, Name: "Penguin"}, PublishedDate: entities.NewDate(2000, 7, 22),
+		Genres: []entities.Genre{mystery}, Availability: none}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add mystery", Method: http.MethodPost, Target: "/book", ReqBody: entities.Book{Title: "Rahul",
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+			PublishedDate: entities.NewDate(2000, 7, 22), Genres: []entities.Genre{{ID: 2}}},
+			ExpStatus: http.StatusCreated, ExpRes: tagged},
+		{Desc: "add untagged book", Method: http.MethodPost, Target: "/book", ReqBody: entities.Book{Title: "Go",
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(2012, 3, 28)}, ExpStatus: http.StatusCreated},
+		{Desc: "genre does not exist", Method: http.MethodPost, Target: "/book", ReqBody: entities.Book{Title: "C",
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(1978, 2, 22), Genres: []entities.Genre{{ID: 7}}},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "books of the parent genre", Method: http.MethodGet, Target: "/book?genre=1&includeAuthor=true",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Book{tagged}},
+		{Desc: "books of an unknown genre", Method: http.MethodGet, Target: "/book?genre=7",
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "facets", Method: http.MethodGet, Target: "/book?facets=true&limit=1&includeAuthor=true",
+			ExpStatus: http.StatusOK, ExpRes: facetedPage{Books: []entities.Book{tagged},
+				Facets: &entities.BookFacets{Genres: []entities.Facet{{ID: 1, Name: "Fiction", Count: 1}, {ID: 2,
+					Name: "Mystery", Count: 1}}, Publishers: []entities.Facet{{ID: 1, Name: "Arihanth", Count: 1}, {ID: 3,
+					Name: "Penguin", Count: 1}}, Decades: []entities.DecadeFacet{{Decade: 2000, Count: 1}, {Decade: 2010,
+					Count: 1}}}}},
+		{Desc: "untag book", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"genres":null}`), ExpStatus: http.StatusOK, ExpRes: entities.Book{ID: 1,
+				Title: "Rahul", Author: author, Contributors: credits, Publisher: entities.Publisher{ID: 3,
+					Name: "Penguin"}, PublishedDate: entities.NewDate(2000, 7, 22), Availability: none}},
+		{Desc: "no books of the genre", Method: http.MethodGet, Target: "/book?genre=2", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Book{}},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
+
+// TestBookHandler_SeriesAndEditions checks that the volumes of a series are listed in order and the editions of a work
+// are linked to its original
+func TestBookHandler_SeriesAndEditions(t *testing.T) {
+	r := newRouter(t, false)
+
+	author := entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}
+	credits := []entities.Contributor{{Author: author, Role: entities.RoleAuthor}}
+	penguin := entities.Publisher{ID: 3, Name: "Penguin"}
+	// the books do not have any copies
+	none := &entities.Availability{}
+
+	second := entities.Book{ID: 1, Title: "Dune Messiah", Author: author, Contributors: credits, Publisher: penguin,
+		PublishedDate: entities.NewDate(2010, 1, 1), Series: &entities.SeriesVolume{ID: 1, Name: "Dune", Volume: 2},
+		Availability: none}
+	first := entities.Book{ID: 2, Title: "Dune", Author: author, Contributors: credits, Publisher: penguin,
+		PublishedDate: entities.NewDate(2005, 1, 1), Series: &entities.SeriesVolume{ID: 1, Name: "Dune", Volume: 1},
+		Availability: none}
+	translation := entities.Book{ID: 3, Title: "Le Messie de Dune", Author: author, Contributors: credits,
+		Publisher: penguin, PublishedDate: entities.NewDate(2012, 1, 1), Edition: &entities.Edition{WorkID: 1,
+			Kind: entities.KindTranslation, Language: "fr"}, Availability: none}
+	edition := entities.Book{ID: 4, Title: "Dune Messiah", Author: author, Contributors: credits,
+		Publisher: entities.Publisher{ID: 1, Name: "Arihanth"}, PublishedDate: entities.NewDate(2015, 1, 1),
+		Edition: &entities.Edition{WorkID: 1, Kind: entities.KindEdition}, Availability: none}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add second volume", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Dune Messiah", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2010, 1, 1),
+				Series: &entities.SeriesVolume{ID: 1, Volume: 2}}, ExpStatus: http.StatusCreated, ExpRes: second},
+		{Desc: "add first volume", Method: http.MethodPost, Target: "/book", ReqBody: entities.Book{Title: "Dune",
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+			PublishedDate: entities.NewDate(2005, 1, 1), Series: &entities.SeriesVolume{ID: 1, Volume: 1}},
+			ExpStatus: http.StatusCreated, ExpRes: first},
+		{Desc: "volume is taken", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Children of Dune", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2011, 1, 1),
+				Series: &entities.SeriesVolume{ID: 1, Volume: 1}}, ExpStatus: http.StatusConflict},
+		{Desc: "series does not exist", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Children of Dune", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2011, 1, 1),
+				Series: &entities.SeriesVolume{ID: 7, Volume: 3}}, ExpStatus: http.StatusBadRequest},
+		{Desc: "volumes in order", Method: http.MethodGet, Target: "/series/1/books?includeAuthor=true",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Book{first, second}},
+		{Desc: "volumes of an unknown series", Method: http.MethodGet, Target: "/series/7/books",
+			ExpStatus: http.StatusNotFound},
+		{Desc: "add translation", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Le Messie de Dune", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2012, 1, 1),
+				Edition: &entities.Edition{WorkID: 1, Kind: "Translation", Language: "fr"}},
+			ExpStatus: http.StatusCreated, ExpRes: translation},
+		{Desc: "edition of the translation is linked to the original", Method: http.MethodPost, Target: "/book",
+			ReqBody: entities.Book{Title: "Dune Messiah", Author: entities.Author{ID: 1},
+				Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2015, 1, 1),
+				Edition: &entities.Edition{WorkID: 3}}, ExpStatus: http.StatusCreated, ExpRes: edition},
+		{Desc: "editions of the work", Method: http.MethodGet, Target: "/book/3/editions?includeAuthor=true",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Book{second, translation, edition}},
+		{Desc: "editions of an unknown book", Method: http.MethodGet, Target: "/book/9/editions",
+			ExpStatus: http.StatusNotFound},
+		{Desc: "original having editions", Method: http.MethodPatch, Target: "/book/1",
+			ReqBody: json.RawMessage(`{"edition":{"work_id":2}}`), ExpStatus: http.StatusConflict},
+		{Desc: "edition of itself", Method: http.MethodPatch, Target: "/book/3",
+			ReqBody: json.RawMessage(`{"edition":{"work_id":3}}`), ExpStatus: http.StatusBadRequest},
+		{Desc: "volume number is not in the title", Method: http.MethodGet,
+			Target: "/book?title=Dune%20Messiah&includeAuthor=true", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Book{second, edition}},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
+
+// TestBookHandler_ETag checks that the changes are made only to the version of the book sent in If-Match
+func TestBookHandler_ETag(t *testing.T) {
+	r := newRouter(t, true)
+
+	book := entities.Book{Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add book", Method: http.MethodPost, Target: "/book", ReqBody: book, ExpStatus: http.StatusCreated},
+		{Desc: "get book", Method: http.MethodGet, Target: "/book/1", ExpStatus: http.StatusOK, ExpETag: `"1"`},
+		{Desc: "not modified", Method: http.MethodGet, Target: "/book/1", IfNoneMatch: `"1"`,
+			ExpStatus: http.StatusNotModified, ExpETag: `"1"`},
+		{Desc: "weak tag not modified", Method: http.MethodGet, Target: "/book/1", IfNoneMatch: `"7", W/"1"`,
+			ExpStatus: http.StatusNotModified, ExpETag: `"1"`},
+		{Desc: "update without If-Match", Method: http.MethodPut, Target: "/book/1", ReqBody: book,
+			ExpStatus: http.StatusPreconditionRequired},
+		{Desc: "update of other version", Method: http.MethodPut, Target: "/book/1", IfMatch: `"5"`, ReqBody: book,
+			ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "update", Method: http.MethodPut, Target: "/book/1", IfMatch: `"5", "1"`, ReqBody: book,
+			ExpStatus: http.StatusOK, ExpETag: `"2"`},
+		{Desc: "modified", Method: http.MethodGet, Target: "/book/1", IfNoneMatch: `"1"`, ExpStatus: http.StatusOK,
+			ExpETag: `"2"`},
+		{Desc: "patch of old version", Method: http.MethodPatch, Target: "/book/1", IfMatch: `"1"`,
+			ReqBody: json.RawMessage(`{"title":"Go"}`), ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "patch with weak tag", Method: http.MethodPatch, Target: "/book/1", IfMatch: `W/"2"`,
+			ReqBody: json.RawMessage(`{"title":"Go"}`), ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "patch", Method: http.MethodPatch, Target: "/book/1", IfMatch: `"2"`,
+			ReqBody: json.RawMessage(`{"title":"Go"}`), ExpStatus: http.StatusOK, ExpETag: `"3"`},
+		{Desc: "delete of old version", Method: http.MethodDelete, Target: "/book/1", IfMatch: `"2"`,
+			ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "delete any version", Method: http.MethodDelete, Target: "/book/1", IfMatch: "*",
+			ExpStatus: http.StatusNoContent},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
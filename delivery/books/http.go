@@ -6,9 +6,9 @@ import (
 	"ThreeLayer/errors"
 	"ThreeLayer/service"
 	"context"
-	"encoding/json"
+	"fmt"
 	"github.com/gorilla/mux"
-	"io"
+	"net/url"
 
 	"net/http"
 	"strconv"
@@ -19,22 +19,121 @@ type BookHandler struct {
 	serviceBook service.Book
 }
 
+// facetedPage is the body of a page of books sent along with the facets of all the matching books
+type facetedPage struct {
+	Books  []entities.Book      `json:"books"`
+	Facets *entities.BookFacets `json:"facets"`
+}
+
 //dependency injection
 func New(book service.Book) BookHandler {
 	return BookHandler{serviceBook: book}
 }
 
-// GetBook function is to perform Handler Requests to get a book instance from the database
+// GetBook function is to perform Handler Requests to get a page of the book instances from the database,
+// the total count is sent in the X-Total-Count header and the next and previous pages in the Link header.
+// With facets=true the books are sent in an object along with their counts by genre, publisher and decade
 func (a BookHandler) GetBook(response http.ResponseWriter, request *http.Request) {
-	title := request.URL.Query().Get("title")
+	writePage(response, request, a.serviceBook.GetBook)
+}
+
+// GetSeriesBooks function is to perform Handler Requests to get a page of the volumes of a series using its ID,
+// in the order of their volume numbers by default. The query parameters and the headers are the same as of GetBook
+func (a BookHandler) GetSeriesBooks(response http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		delivery.SetStatusCode(response, request.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	writePage(response, request, func(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+		return a.serviceBook.GetSeriesBooks(ctx, id, filter)
+	})
+}
+
+// GetEditions function is to perform Handler Requests to get a page of the editions and translations of the work
+// of a book using its ID, the original and the book itself included, in the order of their publication by default.
+// The query parameters and the headers are the same as of GetBook
+func (a BookHandler) GetEditions(response http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		delivery.SetStatusCode(response, request.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	writePage(response, request, func(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+		return a.serviceBook.GetEditions(ctx, id, filter)
+	})
+}
+
+// writePage reads the filter of the request, gets the page of the books with it and writes the page along with
+// its headers
+func writePage(response http.ResponseWriter, request *http.Request,
+	get func(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error)) {
+	filter, err := getFilter(request)
+	if err != nil {
+		delivery.SetStatusCode(response, request.Method, nil, err)
+		return
+	}
+
 	includeAuthor := request.URL.Query().Get("includeAuthor")
 
-	ctx := context.WithValue(request.Context(), entities.Title, strings.TrimSpace(title))
+	ctx := context.WithValue(request.Context(), entities.IncludeAuthor, includeAuthor == "true")
+
+	page, err := get(ctx, filter)
+	if err == nil {
+		response.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+
+		if links := pageLinks(request.URL, page.Limit, page.Offset, len(page.Books), page.Total); links != "" {
+			response.Header().Set("Link", links)
+		}
+	}
+
+	if filter.Facets {
+		delivery.SetStatusCode(response, request.Method, facetedPage{Books: page.Books, Facets: page.Facets}, err)
+		return
+	}
+
+	delivery.SetStatusCode(response, request.Method, page.Books, err)
+}
+
+// SearchBooks function is to perform Handler Requests to get a page of the book instances matching the words of the
+// q query parameter, ranked by relevance. The total count and the pages are sent in the same headers as by GetBook
+func (a BookHandler) SearchBooks(response http.ResponseWriter, request *http.Request) {
+	query := entities.SearchQuery{Text: request.URL.Query().Get("q")}
 
-	ctx = context.WithValue(ctx, entities.IncludeAuthor, includeAuthor)
+	ints := []struct {
+		name  string
+		value *int
+	}{
+		{"limit", &query.Limit},
+		{"offset", &query.Offset},
+	}
+
+	for _, v := range ints {
+		if request.URL.Query().Get(v.name) == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(request.URL.Query().Get(v.name))
+		if err != nil {
+			delivery.SetStatusCode(response, request.Method, nil, errors.InValidDetails{Details: v.name})
+			return
+		}
+
+		*v.value = n
+	}
+
+	page, err := a.serviceBook.SearchBooks(request.Context(), query)
+	if err == nil {
+		response.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
 
-	books, err := a.serviceBook.GetBook(ctx)
-	delivery.SetStatusCode(response, request.Method, books, err)
+		if links := pageLinks(request.URL, page.Limit, page.Offset, len(page.Results), page.Total); links != "" {
+			response.Header().Set("Link", links)
+		}
+	}
+
+	delivery.SetStatusCode(response, request.Method, page.Results, err)
 }
 
 // GetBookByID function is to perform Handler Requests to get an author instance using its ID from the database
@@ -45,15 +144,30 @@ func (a BookHandler) GetBookByID(response http.ResponseWriter, request *http.Req
 		return
 	}
 
-	book, err := a.serviceBook.GetBookByID(context.Background(), id)
+	book, err := a.serviceBook.GetBookByID(request.Context(), id)
+	if err == nil && delivery.CheckETag(response, request, book.Version) {
+		return
+	}
+
+	delivery.SetStatusCode(response, request.Method, book, err)
+}
+
+// GetBookByISBN function is to perform Handler Requests to get a book instance using its ISBN-10 or ISBN-13
+func (a BookHandler) GetBookByISBN(response http.ResponseWriter, request *http.Request) {
+	book, err := a.serviceBook.GetBookByISBN(request.Context(), mux.Vars(request)["isbn"])
+	if err == nil && delivery.CheckETag(response, request, book.Version) {
+		return
+	}
+
 	delivery.SetStatusCode(response, request.Method, book, err)
 }
 
 // PostBook function is to perform Handler Requests to add a new book instance to the database
 func (a BookHandler) PostBook(response http.ResponseWriter, request *http.Request) {
-	book, err := getBook(request)
+	book, err := delivery.ReadBody[entities.Book](request)
 	if err != nil {
 		delivery.SetStatusCode(response, request.Method, book, err)
+		return
 	}
 
 	book, err = a.serviceBook.PostBook(request.Context(), book)
@@ -66,13 +180,40 @@ func (a BookHandler) PutBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	book, err := getBook(r)
+	book, err := delivery.ReadBody[entities.Book](r)
 	if err != nil {
 		delivery.SetStatusCode(w, r.Method, book, err)
 		return
 	}
 
-	book, err = a.serviceBook.PutBook(context.Background(), id, book)
+	book, err = a.serviceBook.PutBook(r.Context(), id, book)
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(book.Version))
+	}
+
+	delivery.SetStatusCode(w, r.Method, book, err)
+}
+
+// PatchBook function is to perform Handler Requests to change only some of the details of an existing book,
+// the body is a JSON Merge Patch or a JSON Patch document
+func (a BookHandler) PatchBook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	patch, err := delivery.GetPatch(r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	book, err := a.serviceBook.PatchBook(r.Context(), id, patch)
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(book.Version))
+	}
+
 	delivery.SetStatusCode(w, r.Method, book, err)
 }
 
@@ -84,22 +225,82 @@ func (a BookHandler) DeleteBook(response http.ResponseWriter, request *http.Requ
 		return
 	}
 
-	err = a.serviceBook.DeleteBook(context.Background(), id)
+	err = a.serviceBook.DeleteBook(request.Context(), id)
 	delivery.SetStatusCode(response, request.Method, nil, err)
 }
 
-func getBook(r *http.Request) (entities.Book, error) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		return entities.Book{}, errors.InValidDetails{Details: "body"}
+// getFilter reads the filter, sort order and page of the books from the query parameters
+func getFilter(r *http.Request) (entities.BookFilter, error) {
+	query := r.URL.Query()
+
+	filter := entities.BookFilter{
+		Title:         strings.TrimSpace(query.Get("title")),
+		PublishedFrom: query.Get("publishedFrom"),
+		PublishedTo:   query.Get("publishedTo"),
+		Sort:          query.Get("sort"),
+		Facets:        query.Get("facets") == "true",
 	}
 
-	var book entities.Book
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return entities.BookFilter{}, errors.InValidDetails{Details: "order"}
+	}
 
-	err = json.Unmarshal(body, &book)
-	if err != nil {
-		return entities.Book{}, errors.InValidDetails{Details: "body"}
+	ints := []struct {
+		name  string
+		value *int
+	}{
+		{"authorId", &filter.AuthorID},
+		{"publisherId", &filter.PublisherID},
+		{"genre", &filter.GenreID},
+		{"limit", &filter.Limit},
+		{"offset", &filter.Offset},
+	}
+
+	for _, v := range ints {
+		if query.Get(v.name) == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(query.Get(v.name))
+		if err != nil {
+			return entities.BookFilter{}, errors.InValidDetails{Details: v.name}
+		}
+
+		*v.value = n
+	}
+
+	return filter, nil
+}
+
+// pageLinks returns the Link header value pointing to the next and previous pages of the request, count is the number
+// of the items on the page and total of all the items
+func pageLinks(u *url.URL, limit, offset, count, total int) string {
+	var links []string
+
+	link := func(offset int, rel string) string {
+		query := u.Query()
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(limit))
+
+		return fmt.Sprintf("<%s?%s>; rel=\"%s\"", u.Path, query.Encode(), rel)
+	}
+
+	if offset+count < total {
+		links = append(links, link(offset+count, "next"))
+	}
+
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+
+		links = append(links, link(prev, "prev"))
 	}
 
-	return book, nil
+	return strings.Join(links, ", ")
 }
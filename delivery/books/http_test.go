@@ -15,6 +15,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -36,24 +37,24 @@ func TestBookHandler_GetAll(t *testing.T) {
 		expStatusCode int
 	}{
 		{desc: "get all books", title: "", includeAuthor: "", expRes: []entities.Book{
-			{ID: 1, Title: "Rahul", Publication: "Penguin", PublishedDate: "22/07/2000"},
+			{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)},
 		}, expStatusCode: http.StatusOK, expError: nil},
 		{desc: "get all books with query param", title: "Rahul", includeAuthor: "", expRes: []entities.Book{
-			{ID: 1, Title: "Rahul", Publication: "Penguin", PublishedDate: "22/07/2000"}},
+			{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7,
+				22)}},
 			expStatusCode: http.StatusOK, expError: nil},
 		{desc: "get all books with query param", title: "", includeAuthor: "true",
 			expRes: []entities.Book{
 				{ID: 1, Title: "Rahul",
 					Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma",
-						Dob: "2/12/1999", PenName: "Verma"},
-					Publication: "Penguin", PublishedDate: "22/07/2000"}}, expStatusCode: http.StatusOK, expError: nil},
+						Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"},
+					Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)}}, expStatusCode: http.StatusOK, expError: nil},
 	}
 	for i, tc := range testcases {
-		//ctx := context.Background()
-		//ctx = context.WithValue(ctx, entities.Title, tc.title)
-		//ctx = context.WithValue(ctx, entities.IncludeAuthor, tc.includeAuthor == "true")
+		ctx := context.WithValue(context.Background(), entities.IncludeAuthor, tc.includeAuthor == "true")
 
-		mockService.EXPECT().GetBook(context.TODO()).Return(tc.expRes, tc.expError)
+		mockService.EXPECT().GetBook(ctx, entities.BookFilter{Title: tc.title}).
+			Return(entities.BookPage{Books: tc.expRes, Total: len(tc.expRes), Limit: 20}, tc.expError)
 		req := httptest.NewRequest(http.MethodGet, "/book?title="+tc.title+"&includeAuthor="+tc.includeAuthor,
 			nil)
 		w := httptest.NewRecorder()
@@ -83,6 +84,194 @@ func TestBookHandler_GetAll(t *testing.T) {
 	}
 }
 
+// TestBookHandler_GetPage function contains test cases for the filter, sort and page query parameters of the
+// book list and the headers pointing to the other pages
+func TestBookHandler_GetPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockBook(ctrl)
+	mock := New(mockService)
+	defer ctrl.Finish()
+
+	books := []entities.Book{{ID: 3, Title: "Rahul", Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}}
+
+	testcases := []struct {
+		desc          string
+		query         string
+		expFilter     entities.BookFilter
+		page          entities.BookPage
+		expStatusCode int
+		expTotal      string
+		expLink       string
+	}{
+		{desc: "filters and sort", query: "publisherId=3&authorId=1&publishedFrom=2000-01-01" +
+			"&publishedTo=2001-01-01&sort=published_date&order=desc",
+			expFilter: entities.BookFilter{PublisherID: 3, AuthorID: 1, PublishedFrom: "2000-01-01",
+				PublishedTo: "2001-01-01", Sort: entities.SortByPublishedDate, Desc: true},
+			page:          entities.BookPage{Books: books, Total: 1, Limit: 20},
+			expStatusCode: http.StatusOK, expTotal: "1"},
+		{desc: "first page", query: "limit=1",
+			expFilter:     entities.BookFilter{Limit: 1},
+			page:          entities.BookPage{Books: books, Total: 3, Limit: 1},
+			expStatusCode: http.StatusOK, expTotal: "3",
+			expLink: `</book?limit=1&offset=1>; rel="next"`},
+		{desc: "middle page", query: "limit=1&offset=1&title=Rahul",
+			expFilter:     entities.BookFilter{Title: "Rahul", Limit: 1, Offset: 1},
+			page:          entities.BookPage{Books: books, Total: 3, Limit: 1, Offset: 1},
+			expStatusCode: http.StatusOK, expTotal: "3",
+			expLink: `</book?limit=1&offset=2&title=Rahul>; rel="next", </book?limit=1&offset=0&title=Rahul>; rel="prev"`},
+		{desc: "invalid order", query: "order=up", expStatusCode: http.StatusBadRequest},
+		{desc: "invalid limit", query: "limit=ten", expStatusCode: http.StatusBadRequest},
+		{desc: "genre with facets", query: "genre=2&facets=true",
+			expFilter:     entities.BookFilter{GenreID: 2, Facets: true},
+			page:          entities.BookPage{Books: books, Total: 1, Limit: 20, Facets: &entities.BookFacets{}},
+			expStatusCode: http.StatusOK, expTotal: "1"},
+		{desc: "invalid publisher", query: "publisherId=penguin", expStatusCode: http.StatusBadRequest},
+		{desc: "invalid genre", query: "genre=mystery", expStatusCode: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatusCode == http.StatusOK {
+			ctx := context.WithValue(context.Background(), entities.IncludeAuthor, false)
+			mockService.EXPECT().GetBook(ctx, tc.expFilter).Return(tc.page, nil)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/book?"+tc.query, nil)
+		w := httptest.NewRecorder()
+
+		mock.GetBook(w, req)
+
+		if w.Code != tc.expStatusCode {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatusCode, w.Code)
+		}
+
+		if got := w.Header().Get("X-Total-Count"); got != tc.expTotal {
+			t.Errorf("[TEST%d]Failed. Expected total %v\tGot %v", i, tc.expTotal, got)
+		}
+
+		if got := w.Header().Get("Link"); got != tc.expLink {
+			t.Errorf("[TEST%d]Failed. Expected link %v\tGot %v", i, tc.expLink, got)
+		}
+	}
+}
+
+func TestBookHandler_GetLinkedBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockBook(ctrl)
+	mock := New(mockService)
+	defer ctrl.Finish()
+
+	books := []entities.Book{{ID: 3, Title: "Rahul", Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}}
+
+	testcases := []struct {
+		desc          string
+		target        string
+		id            string
+		handler       http.HandlerFunc
+		err           error
+		expStatusCode int
+		expLink       string
+	}{
+		{desc: "volumes of a series", target: "/series/1/books?limit=1", id: "1", handler: mock.GetSeriesBooks,
+			expStatusCode: http.StatusOK, expLink: `</series/1/books?limit=1&offset=1>; rel="next"`},
+		{desc: "series does not exist", target: "/series/7/books?limit=1", id: "7", handler: mock.GetSeriesBooks,
+			err: errors.EntityNotFound{Entity: "Series", ID: 7}, expStatusCode: http.StatusNotFound},
+		{desc: "invalid series id", target: "/series/abc/books", id: "abc", handler: mock.GetSeriesBooks,
+			expStatusCode: http.StatusBadRequest},
+		{desc: "editions of a book", target: "/book/1/editions?limit=1", id: "1", handler: mock.GetEditions,
+			expStatusCode: http.StatusOK, expLink: `</book/1/editions?limit=1&offset=1>; rel="next"`},
+		{desc: "book does not exist", target: "/book/7/editions?limit=1", id: "7", handler: mock.GetEditions,
+			err: errors.EntityNotFound{Entity: "Book", ID: 7}, expStatusCode: http.StatusNotFound},
+		{desc: "invalid book id", target: "/book/abc/editions", id: "abc", handler: mock.GetEditions,
+			expStatusCode: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		page := entities.BookPage{Books: books, Total: 2, Limit: 1}
+		if tc.err != nil {
+			page = entities.BookPage{}
+		}
+
+		id, _ := strconv.Atoi(tc.id)
+
+		if strings.HasPrefix(tc.target, "/series/") && tc.expStatusCode != http.StatusBadRequest {
+			mockService.EXPECT().GetSeriesBooks(gomock.Any(), id, entities.BookFilter{Limit: 1}).Return(page, tc.err)
+		}
+
+		if strings.HasPrefix(tc.target, "/book/") && tc.expStatusCode != http.StatusBadRequest {
+			mockService.EXPECT().GetEditions(gomock.Any(), id, entities.BookFilter{Limit: 1}).Return(page, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		tc.handler(w, req)
+
+		if w.Code != tc.expStatusCode {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expStatusCode, w.Code)
+		}
+
+		if got := w.Header().Get("Link"); got != tc.expLink {
+			t.Errorf("[TEST%d]Failed. %s Expected link %v\tGot %v", i, tc.desc, tc.expLink, got)
+		}
+	}
+}
+
+func TestBookHandler_SearchBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockBook(ctrl)
+	mock := New(mockService)
+	defer ctrl.Finish()
+
+	results := []entities.SearchResult{{Book: entities.Book{ID: 3, Title: "Rahul", Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}, Score: 3}}
+
+	testcases := []struct {
+		desc          string
+		query         string
+		expQuery      entities.SearchQuery
+		page          entities.SearchPage
+		err           error
+		expStatusCode int
+		expTotal      string
+		expLink       string
+	}{
+		{desc: "first page", query: "q=rahul+verma&limit=1",
+			expQuery:      entities.SearchQuery{Text: "rahul verma", Limit: 1},
+			page:          entities.SearchPage{Results: results, Total: 2, Limit: 1},
+			expStatusCode: http.StatusOK, expTotal: "2",
+			expLink: `</search?limit=1&offset=1&q=rahul+verma>; rel="next"`},
+		{desc: "no words", query: "q=", expQuery: entities.SearchQuery{},
+			err: errors.InValidDetails{Details: "q"}, expStatusCode: http.StatusBadRequest},
+		{desc: "invalid offset", query: "q=rahul&offset=first", expStatusCode: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatusCode == http.StatusOK || tc.err != nil {
+			mockService.EXPECT().SearchBooks(gomock.Any(), tc.expQuery).Return(tc.page, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/search?"+tc.query, nil)
+		w := httptest.NewRecorder()
+
+		mock.SearchBooks(w, req)
+
+		if w.Code != tc.expStatusCode {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatusCode, w.Code)
+		}
+
+		if got := w.Header().Get("X-Total-Count"); got != tc.expTotal {
+			t.Errorf("[TEST%d]Failed. Expected total %v\tGot %v", i, tc.expTotal, got)
+		}
+
+		if got := w.Header().Get("Link"); got != tc.expLink {
+			t.Errorf("[TEST%d]Failed. Expected link %v\tGot %v", i, tc.expLink, got)
+		}
+	}
+}
+
 // TestBookDeliveryGetBookByID function contains test cases for function to perform Handler Requests to get a
 // book instance using its ID from the database
 //error
@@ -100,14 +289,16 @@ func TestBookHandler_GetByID(t *testing.T) {
 		expError      error
 	}{
 		{desc: "get book", req: "1", expRes: entities.Book{ID: 1, Title: "Rahul",
-			Author:      entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"},
-			Publication: "Penguin", PublishedDate: "22/07/2000"}, expStatusCode: http.StatusOK},
+			Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "Verma"},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			expStatusCode: http.StatusOK},
 		{"Id doesn't exist", "1000", entities.Book{}, http.StatusNotFound, errors.EntityNotFound{Entity: "Book", ID: 1000}},
 		//	{"invalid id", "id", entities.Book{}, http.StatusBadRequest, errors.EntityNotFound{"Book", 1000}},
 	}
 	for i, tc := range testcases {
 		id, _ := strconv.Atoi(tc.req)
-		mockService.EXPECT().GetBookByID(context.Background(), id).Return(tc.expRes, tc.expError)
+		mockService.EXPECT().GetBookByID(gomock.Any(), id).Return(tc.expRes, tc.expError)
 		w := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/book/{id}", nil)
 		req = mux.SetURLVars(req, map[string]string{"id": tc.req})
@@ -129,7 +320,53 @@ func TestBookHandler_GetByID(t *testing.T) {
 			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatusCode, w.Code)
 		}
 
-		if resBook != tc.expRes {
+		if !reflect.DeepEqual(resBook, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, resBook)
+		}
+	}
+}
+
+func TestBookHandler_GetByISBN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockBook(ctrl)
+	mock := New(mockService)
+	defer ctrl.Finish()
+
+	testcases := []struct {
+		desc          string
+		isbn          string
+		expRes        entities.Book
+		expStatusCode int
+		expError      error
+	}{
+		{desc: "get book", isbn: "0-306-40615-2", expRes: entities.Book{ID: 1, Title: "Rahul",
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22), ISBN: "9780306406157",
+			ISBN10: "0306406152"}, expStatusCode: http.StatusOK},
+		{desc: "isbn doesn't exist", isbn: "9781861972712", expStatusCode: http.StatusNotFound,
+			expError: errors.EntityNotFound{Entity: "Book"}},
+		{desc: "invalid isbn", isbn: "12345", expStatusCode: http.StatusBadRequest,
+			expError: errors.InValidDetails{Details: "isbn"}},
+	}
+	for i, tc := range testcases {
+		mockService.EXPECT().GetBookByISBN(gomock.Any(), tc.isbn).Return(tc.expRes, tc.expError)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/book/isbn/{isbn}", nil)
+		req = mux.SetURLVars(req, map[string]string{"isbn": tc.isbn})
+
+		mock.GetBookByISBN(w, req)
+
+		resBook := entities.Book{}
+		if tc.expError == nil {
+			if err := json.NewDecoder(w.Result().Body).Decode(&resBook); err != nil {
+				t.Errorf("[TEST%d]Failed. expected error to be nil got %v", i, err)
+			}
+		}
+
+		if w.Result().StatusCode != tc.expStatusCode {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatusCode, w.Code)
+		}
+
+		if !reflect.DeepEqual(resBook, tc.expRes) {
 			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, resBook)
 		}
 	}
@@ -150,21 +387,21 @@ func TestBookHandler_Post(t *testing.T) {
 		expStatus int
 		expError  error
 	}{
-		{"Publication should be Scholastic/Penguin/Arihanth", entities.Book{Title: "Rahul",
-			Author:      entities.Author{ID: 1},
-			Publication: "Rahul", PublishedDate: "22/07/2000"}, entities.Book{},
-			http.StatusBadRequest, errors.InValidDetails{Details: "Publication"}},
+		{"Publisher should exist", entities.Book{Title: "Rahul",
+			Author:    entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 7}, PublishedDate: entities.NewDate(2000, 7, 22)}, entities.Book{},
+			http.StatusBadRequest, errors.InValidDetails{Details: "Publisher ID"}},
 		{"Publication date should be between 1880 and 2022", entities.Book{Title: "",
-			Author:      entities.Author{ID: 1},
-			Publication: "", PublishedDate: "1/1/1600"}, entities.Book{},
+			Author:    entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(1600, 1, 1)}, entities.Book{},
 			http.StatusBadRequest, errors.InValidDetails{Details: "PublishedDate"}},
 		{"Author should exist", entities.Book{Title: "Rahul",
-			Author:      entities.Author{ID: 2},
-			Publication: "Penguin", PublishedDate: "22/07/2000"}, entities.Book{},
+			Author:    entities.Author{ID: 2},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)}, entities.Book{},
 			http.StatusBadRequest, errors.InValidDetails{Details: "Author ID"}},
 		{"Title can't be empty", entities.Book{Title: "",
-			Author:      entities.Author{ID: 1},
-			Publication: "", PublishedDate: ""}, entities.Book{},
+			Author:    entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.Date{}}, entities.Book{},
 			http.StatusBadRequest, errors.InValidDetails{Details: "Title"}},
 	}
 	for i, tc := range testcases {
@@ -214,9 +451,9 @@ func TestBookHandler_Put(t *testing.T) {
 		expError  error
 	}{
 		{desc: "invalid case id not exist", reqID: "1000", reqBody: entities.Book{ID: 1000, Title: "title1", Author: entities.Author{ID: 9},
-			Publication: "Arihanth", PublishedDate: "18/08/2018"}, expStatus: http.StatusNotFound, expError: errors.EntityNotFound{Entity: "Book", ID: 1000}},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2018, 8, 18)}, expStatus: http.StatusNotFound, expError: errors.EntityNotFound{Entity: "Book", ID: 1000}},
 		{"Invalid book name.", "1", entities.Book{ID: 1, Author: entities.Author{ID: 1},
-			Publication: "Oxford", PublishedDate: "21/04/1985"}, http.StatusBadRequest, errors.InValidDetails{Details: "Title"}},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(1985, 4, 21)}, http.StatusBadRequest, errors.InValidDetails{Details: "Title"}},
 		//{"invalid id", "id", entities.Book{}, http.StatusBadRequest, errors.InValidDetails{"id"}},
 	}
 	for i, tc := range testcases {
@@ -224,7 +461,7 @@ func TestBookHandler_Put(t *testing.T) {
 		if err != nil {
 			log.Print(err)
 		}
-		mockService.EXPECT().PutBook(context.Background(), id, tc.reqBody).Return(entities.Book{}, tc.expError)
+		mockService.EXPECT().PutBook(gomock.Any(), id, tc.reqBody).Return(entities.Book{}, tc.expError)
 		w := httptest.NewRecorder()
 		body, _ := json.Marshal(tc.reqBody)
 		req := httptest.NewRequest(http.MethodPut, "/book/{id}", bytes.NewReader(body))
@@ -238,6 +475,50 @@ func TestBookHandler_Put(t *testing.T) {
 	}
 }
 
+func TestBookHandler_PatchBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := service.NewMockBook(ctrl)
+	mock := New(mockService)
+	defer ctrl.Finish()
+
+	book := entities.Book{ID: 1, Title: "Go", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+
+	testcases := []struct {
+		desc          string
+		contentType   string
+		body          string
+		expPatch      *entities.Patch
+		expStatusCode int
+	}{
+		{"merge patch", entities.MergePatch, `{"title":"Go"}`,
+			&entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"title":"Go"}`)}, http.StatusOK},
+		{"json is a merge patch", "application/json; charset=utf-8", `{"title":"Go"}`,
+			&entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"title":"Go"}`)}, http.StatusOK},
+		{"json patch", entities.JSONPatch, `[{"op":"replace","path":"/title","value":"Go"}]`,
+			&entities.Patch{Type: entities.JSONPatch, Doc: []byte(`[{"op":"replace","path":"/title","value":"Go"}]`)},
+			http.StatusOK},
+		{"unsupported content type", "text/plain", `title=Go`, nil, http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expPatch != nil {
+			mockService.EXPECT().PatchBook(gomock.Any(), 1, *tc.expPatch).Return(book, nil)
+		}
+
+		req := httptest.NewRequest(http.MethodPatch, "/book/1", bytes.NewReader([]byte(tc.body)))
+		req.Header.Set("Content-Type", tc.contentType)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+
+		mock.PatchBook(w, req)
+
+		if w.Code != tc.expStatusCode {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatusCode, w.Code)
+		}
+	}
+}
+
 // TestBookHandler_DeleteBook function contains test cases for function to perform Handler Requests to remove a
 // book instance from the database
 //error
@@ -261,7 +542,7 @@ func TestBookHandler_DeleteBook(t *testing.T) {
 		if err != nil {
 			log.Print(err)
 		}
-		mockService.EXPECT().DeleteBook(context.Background(), id).Return(tc.expError)
+		mockService.EXPECT().DeleteBook(gomock.Any(), id).Return(tc.expError)
 
 		w := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodDelete, "/book/{id}", nil)
@@ -0,0 +1,114 @@
+package copies
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery"
+	handlerBook "ThreeLayer/delivery/books"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceBook "ThreeLayer/service/books"
+	serviceCopy "ThreeLayer/service/copies"
+	serviceLoan "ThreeLayer/service/loans"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the copy requests one after another against the real service logic
+// backed by an in memory datastore having a single book, the availability of the book follows the copies
+func TestHandler_EndToEnd(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	author, err := memory.NewAuthor(db).CreateAuthor(ctx, entities.Author{FirstName: "HC", LastName: "Verma",
+		Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	_, err = memory.NewBook(db).CreateBook(ctx, entities.Book{Title: "Rahul", Author: entities.Author{ID: author.ID},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	// the version of the author is sent only in the ETag header
+	author.Version = 0
+
+	handler := New(serviceCopy.New(memory.NewCopy(db), memory.NewBook(db), memory.NewLoan(db), memory.NewHold(db), db,
+		serviceLoan.Policy{PickupDays: 3}))
+	book := handlerBook.New(serviceBook.New(memory.NewBook(db), memory.NewAuthor(db), memory.NewPublisher(db),
+		memory.NewGenre(db), memory.NewSeries(db), memory.NewCopy(db), memory.NewLoan(db), db, serviceBook.Policy{}))
+
+	ifMatch := delivery.IfMatch(true)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/book/{id}", book.GetBookByID).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}", ifMatch(book.DeleteBook)).Methods(http.MethodDelete)
+	r.HandleFunc("/book/{id}/copies", handler.GetCopies).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}/copies", handler.PostCopy).Methods(http.MethodPost)
+	r.HandleFunc("/copy/{id}", handler.GetCopyByID).Methods(http.MethodGet)
+	r.HandleFunc("/copy/{id}", ifMatch(handler.PutCopy)).Methods(http.MethodPut)
+	r.HandleFunc("/copy/{id}", ifMatch(handler.DeleteCopy)).Methods(http.MethodDelete)
+
+	first := entities.Copy{ID: 1, BookID: 1, Barcode: "LIB-0001", Condition: entities.ConditionNew,
+		ShelfLocation: "A-12", AcquiredOn: entities.NewDate(2020, 2, 1), Status: entities.CopyAvailable}
+	second := entities.Copy{ID: 2, BookID: 1, Barcode: "LIB-0002", Condition: entities.ConditionGood,
+		AcquiredOn: entities.NewDate(2021, 4, 3), Status: entities.CopyAvailable}
+	lent := second
+	lent.Status = entities.CopyOnLoan
+	withdrawn := second
+	withdrawn.Status = entities.CopyWithdrawn
+
+	availability := func(total, available, onLoan int) entities.Book {
+		return entities.Book{ID: 1, Title: "Rahul", Author: author, Contributors: []entities.Contributor{{Author: author,
+			Role: entities.RoleAuthor}}, Publisher: entities.Publisher{ID: 3, Name: "Penguin"},
+			PublishedDate: entities.NewDate(2000, 7, 22), Availability: &entities.Availability{Total: total,
+				Available: available,
+				OnLoan:    onLoan}}
+	}
+
+	testcases := []deliverytest.Request{
+		{Desc: "book without copies", Method: http.MethodGet, Target: "/book/1", ExpStatus: http.StatusOK,
+			ExpRes: availability(0, 0, 0)},
+		{Desc: "add copy", Method: http.MethodPost, Target: "/book/1/copies", ReqBody: entities.Copy{
+			Barcode: "LIB-0001", Condition: entities.ConditionNew, ShelfLocation: "A-12",
+			AcquiredOn: entities.NewDate(2020, 2, 1)},
+			ExpStatus: http.StatusCreated, ExpRes: first},
+		{Desc: "add second copy", Method: http.MethodPost, Target: "/book/1/copies", ReqBody: entities.Copy{
+			Barcode: "LIB-0002", AcquiredOn: entities.NewDate(2021, 4, 3)},
+			ExpStatus: http.StatusCreated, ExpRes: second},
+		{Desc: "barcode is taken", Method: http.MethodPost, Target: "/book/1/copies",
+			ReqBody: entities.Copy{Barcode: "LIB-0001"}, ExpStatus: http.StatusConflict},
+		{Desc: "copy of missing book", Method: http.MethodPost, Target: "/book/5/copies",
+			ReqBody: entities.Copy{Barcode: "LIB-0003"}, ExpStatus: http.StatusNotFound},
+		{Desc: "invalid copy", Method: http.MethodPost, Target: "/book/1/copies",
+			ReqBody: entities.Copy{Barcode: "LIB-0003", Status: "sold"}, ExpStatus: http.StatusBadRequest},
+		{Desc: "get copies", Method: http.MethodGet, Target: "/book/1/copies", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Copy{first, second}},
+		{Desc: "copies of missing book", Method: http.MethodGet, Target: "/book/5/copies",
+			ExpStatus: http.StatusNotFound},
+		{Desc: "lend copy by hand", Method: http.MethodPut, Target: "/copy/2", IfMatch: `"1"`, ReqBody: lent,
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "withdraw copy", Method: http.MethodPut, Target: "/copy/2", IfMatch: `"1"`, ReqBody: withdrawn,
+			ExpStatus: http.StatusOK, ExpRes: withdrawn},
+		{Desc: "update without If-Match", Method: http.MethodPut, Target: "/copy/2", ReqBody: withdrawn,
+			ExpStatus: http.StatusPreconditionRequired},
+		{Desc: "get copy", Method: http.MethodGet, Target: "/copy/2", ExpStatus: http.StatusOK, ExpRes: withdrawn},
+		{Desc: "book with copies", Method: http.MethodGet, Target: "/book/1", ExpStatus: http.StatusOK,
+			ExpRes: availability(1, 1, 0)},
+		{Desc: "delete copy", Method: http.MethodDelete, Target: "/copy/1", IfMatch: `"1"`,
+			ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted copy", Method: http.MethodGet, Target: "/copy/1", ExpStatus: http.StatusNotFound},
+		{Desc: "book with withdrawn copy", Method: http.MethodGet, Target: "/book/1", ExpStatus: http.StatusOK,
+			ExpRes: availability(0, 0, 0)},
+		{Desc: "delete book", Method: http.MethodDelete, Target: "/book/1", IfMatch: "*",
+			ExpStatus: http.StatusNoContent},
+		{Desc: "copies are deleted with the book", Method: http.MethodGet, Target: "/copy/2",
+			ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
@@ -0,0 +1,100 @@
+package copies
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	service service.Copy
+}
+
+func New(c service.Copy) Handler {
+	return Handler{service: c}
+}
+
+// GetCopies function is to perform Handler Requests to get the copies of a book from the database
+func (h Handler) GetCopies(w http.ResponseWriter, r *http.Request) {
+	bookID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	copies, err := h.service.GetCopies(r.Context(), bookID)
+	delivery.SetStatusCode(w, r.Method, copies, err)
+}
+
+// GetCopyByID function is to perform Handler Requests to get a copy instance using its ID from the database
+func (h Handler) GetCopyByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	c, err := h.service.GetCopyByID(r.Context(), id)
+	if err == nil && delivery.CheckETag(w, r, c.Version) {
+		return
+	}
+
+	delivery.SetStatusCode(w, r.Method, c, err)
+}
+
+// PostCopy function is to perform Handler Requests to add a new copy of a book to the database
+func (h Handler) PostCopy(w http.ResponseWriter, r *http.Request) {
+	bookID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	c, err := delivery.ReadBody[entities.Copy](r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	c, err = h.service.PostCopy(r.Context(), bookID, c)
+	delivery.SetStatusCode(w, r.Method, c, err)
+}
+
+// PutCopy function is to perform Handler Requests to replace an existing copy instance in the database
+func (h Handler) PutCopy(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	c, err := delivery.ReadBody[entities.Copy](r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	c, err = h.service.PutCopy(r.Context(), id, c)
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(c.Version))
+	}
+
+	delivery.SetStatusCode(w, r.Method, c, err)
+}
+
+// DeleteCopy function is to perform Handler Requests to remove a copy instance from the database
+func (h Handler) DeleteCopy(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	err = h.service.DeleteCopy(r.Context(), id)
+	delivery.SetStatusCode(w, r.Method, nil, err)
+}
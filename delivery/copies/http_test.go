@@ -0,0 +1,198 @@
+package copies
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_GetCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCopy(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		id        string
+		res       []entities.Copy
+		err       error
+		expStatus int
+	}{
+		{desc: "found", id: "1", res: []entities.Copy{{ID: 1, BookID: 1}}, expStatus: http.StatusOK},
+		{desc: "book not found", id: "2", err: errors.EntityNotFound{Entity: "Book", ID: 2},
+			expStatus: http.StatusNotFound},
+		{desc: "database error", id: "3", err: errors.DB{Err: fmt.Errorf("connection refused")},
+			expStatus: http.StatusInternalServerError},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().GetCopies(gomock.Any(), gomock.Any()).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/book/"+tc.id+"/copies", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.GetCopies(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
+
+func TestHandler_GetCopyByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCopy(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc        string
+		id          string
+		ifNoneMatch string
+		res         entities.Copy
+		err         error
+		expStatus   int
+		expETag     string
+	}{
+		{desc: "found", id: "1", res: entities.Copy{ID: 1, Version: 4}, expStatus: http.StatusOK, expETag: `"4"`},
+		{desc: "not modified", id: "1", ifNoneMatch: `"4"`, res: entities.Copy{ID: 1, Version: 4},
+			expStatus: http.StatusNotModified, expETag: `"4"`},
+		{desc: "not found", id: "2", err: errors.EntityNotFound{Entity: "Copy", ID: 2}, expStatus: http.StatusNotFound},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().GetCopyByID(gomock.Any(), gomock.Any()).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/copy/"+tc.id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+		if tc.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+
+		w := httptest.NewRecorder()
+
+		h.GetCopyByID(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
+
+func TestHandler_PostCopy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCopy(ctrl)
+	h := New(mockService)
+
+	c := entities.Copy{Barcode: "LIB-0001", ShelfLocation: "A-12"}
+
+	testcases := []struct {
+		desc      string
+		id        string
+		body      []byte
+		res       entities.Copy
+		err       error
+		expStatus int
+	}{
+		{desc: "created", id: "1", res: entities.Copy{ID: 1, BookID: 1}, expStatus: http.StatusCreated},
+		{desc: "barcode is taken", id: "1", err: errors.ExistAlready{Entity: "Copy"}, expStatus: http.StatusConflict},
+		{desc: "book not found", id: "2", err: errors.EntityNotFound{Entity: "Book", ID: 2},
+			expStatus: http.StatusNotFound},
+		{desc: "invalid body", id: "1", body: []byte(`{"barcode":`), expStatus: http.StatusBadRequest},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		body := tc.body
+		if body == nil {
+			body, _ = json.Marshal(c)
+
+			if tc.expStatus != http.StatusBadRequest {
+				mockService.EXPECT().PostCopy(gomock.Any(), gomock.Any(), c).Return(tc.res, tc.err)
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/book/"+tc.id+"/copies", bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.PostCopy(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
+
+func TestHandler_PutCopy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCopy(ctrl)
+	h := New(mockService)
+
+	c := entities.Copy{Barcode: "LIB-0001", Status: entities.CopyLost}
+
+	testcases := []struct {
+		desc      string
+		body      []byte
+		res       entities.Copy
+		err       error
+		expStatus int
+		expETag   string
+	}{
+		{desc: "updated", res: entities.Copy{ID: 1, Version: 2}, expStatus: http.StatusOK, expETag: `"2"`},
+		{desc: "modified since read", err: errors.PreconditionFailed{Entity: "Copy", ID: 1},
+			expStatus: http.StatusPreconditionFailed},
+		{desc: "invalid body", body: []byte(`{"barcode":`), expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		body := tc.body
+		if body == nil {
+			body, _ = json.Marshal(c)
+
+			mockService.EXPECT().PutCopy(gomock.Any(), 1, c).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/copy/1", bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+
+		h.PutCopy(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
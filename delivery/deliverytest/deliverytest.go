@@ -0,0 +1,83 @@
+// Package deliverytest sends the end to end requests of the handlers, the requests are sent one after another so that
+// every request sees the changes of the requests before it.
+package deliverytest
+
+import (
+	"ThreeLayer/entities"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Request is a request sent to the router along with the response it is expected to get, ExpETag and ExpRes are
+// checked only when they are set
+type Request struct {
+	Desc        string
+	Method      string
+	Target      string
+	IfMatch     string
+	IfNoneMatch string
+	ReqBody     interface{}
+	ExpStatus   int
+	ExpETag     string
+	ExpRes      interface{}
+}
+
+// Run sends the requests to the router in order. The body is sent as json, a json array as a JSON Patch document,
+// and the response body is decoded into the type of ExpRes
+func Run(t *testing.T, r http.Handler, requests []Request) {
+	t.Helper()
+
+	for i, tc := range requests {
+		var body []byte
+		if tc.ReqBody != nil {
+			body, _ = json.Marshal(tc.ReqBody)
+		}
+
+		req := httptest.NewRequest(tc.Method, tc.Target, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		// a json array is a JSON Patch document
+		if bytes.HasPrefix(body, []byte("[")) {
+			req.Header.Set("Content-Type", entities.JSONPatch)
+		}
+
+		if tc.IfMatch != "" {
+			req.Header.Set("If-Match", tc.IfMatch)
+		}
+
+		if tc.IfNoneMatch != "" {
+			req.Header.Set("If-None-Match", tc.IfNoneMatch)
+		}
+
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != tc.ExpStatus {
+			t.Errorf("[TEST%d]Failed. %s Got %v\tExpected %v\n", i+1, tc.Desc, w.Code, tc.ExpStatus)
+		}
+
+		if etag := w.Header().Get("ETag"); tc.ExpETag != "" && etag != tc.ExpETag {
+			t.Errorf("[TEST%d]Failed. %s Got ETag %v\tExpected %v\n", i+1, tc.Desc, etag, tc.ExpETag)
+		}
+
+		if tc.ExpRes == nil {
+			continue
+		}
+
+		res := reflect.New(reflect.TypeOf(tc.ExpRes))
+
+		err := json.NewDecoder(w.Body).Decode(res.Interface())
+		if err != nil {
+			t.Errorf("[TEST%d]Failed. %s expected error to be nil got %v", i+1, tc.Desc, err)
+		}
+
+		if !reflect.DeepEqual(res.Elem().Interface(), tc.ExpRes) {
+			t.Errorf("[TEST%d]Failed. %s Got %v\tExpected %v\n", i+1, tc.Desc, res.Elem().Interface(), tc.ExpRes)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+)
+
+// ETag returns the strong entity tag of the version of an entity
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// IfMatch returns the middleware which sets the versions of the If-Match header in the context of the request,
+// the service rejects the change when none of them is the stored version. "*" matches any version and
+// the request is rejected with 428 when the header is missing and required is set
+func IfMatch(required bool) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("If-Match")
+
+			switch {
+			case header == "" && required:
+				SetStatusCode(w, r.Method, nil, errors.PreconditionRequired{})
+				return
+			case header == "" || strings.TrimSpace(header) == "*":
+				next(w, r)
+				return
+			}
+
+			// tags which are weak or not a version can not match, they are left out so that the
+			// request fails when no valid version is sent
+			versions := make([]int, 0)
+
+			for _, tag := range strings.Split(header, ",") {
+				if v, ok := parseETag(tag); ok {
+					versions = append(versions, v)
+				}
+			}
+
+			next(w, r.WithContext(context.WithValue(r.Context(), entities.IfMatch, versions)))
+		}
+	}
+}
+
+// CheckETag sets the ETag header of the version, true is returned when the If-None-Match header of the request
+// has the same version, then 304 is written and nothing else has to be sent
+func CheckETag(w http.ResponseWriter, r *http.Request, version int) bool {
+	w.Header().Set("ETag", ETag(version))
+
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		// If-None-Match uses the weak comparison
+		v, ok := parseETag(strings.TrimPrefix(tag, "W/"))
+		if tag == "*" || ok && v == version {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseETag returns the version of a strong entity tag
+func parseETag(tag string) (int, bool) {
+	tag = strings.TrimSpace(tag)
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	v, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || v < 0 {
+		return 0, false
+	}
+
+	return v, true
+}
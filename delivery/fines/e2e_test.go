@@ -0,0 +1,105 @@
+package fines
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery/deliverytest"
+	handlerLoan "ThreeLayer/delivery/loans"
+	"ThreeLayer/entities"
+	serviceFines "ThreeLayer/service/fines"
+	serviceLoan "ThreeLayer/service/loans"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the account requests one after another against the real service logic backed by
+// an in memory datastore having a standard member with a loan which is ten days overdue
+func TestHandler_EndToEnd(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	date := func(days int) entities.Date {
+		return entities.Today().AddDays(days)
+	}
+
+	author, err := memory.NewAuthor(db).CreateAuthor(ctx, entities.Author{FirstName: "HC", LastName: "Verma",
+		Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	book, err := memory.NewBook(db).CreateBook(ctx, entities.Book{Title: "Rahul", Author: entities.Author{ID: author.ID},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	for _, c := range []entities.Copy{{Barcode: "LIB-0001", Status: entities.CopyOnLoan},
+		{Barcode: "LIB-0002", Status: entities.CopyAvailable}} {
+		c.BookID, c.Condition = book.ID, entities.ConditionGood
+
+		if _, err = memory.NewCopy(db).CreateCopy(ctx, c); err != nil {
+			t.Fatalf("expected error to be nil got %v", err)
+		}
+	}
+
+	_, err = memory.NewMember(db).CreateMember(ctx, entities.Member{FirstName: "Rahul", LastName: "Saini",
+		Email: "rahul@example.com", MembershipType: entities.MembershipStandard,
+		ExpiresOn: entities.NewDate(2099, 12, 31), Status: entities.MemberActive})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	_, err = memory.NewLoan(db).CreateLoan(ctx, entities.Loan{CopyID: 1, MemberID: 1, BookID: book.ID,
+		CheckedOutOn: date(-24), DueOn: date(-10)})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	policy := serviceFines.Policy{DailyRates: map[string]int{entities.MembershipStandard: 25},
+		Caps: map[string]int{entities.MembershipStandard: 1000}, BlockAbove: 200}
+
+	handler := New(serviceFines.New(memory.NewLedger(db), memory.NewMember(db), memory.NewLoan(db), db, policy))
+	loan := handlerLoan.New(serviceLoan.New(memory.NewLoan(db), memory.NewHold(db), memory.NewCopy(db),
+		memory.NewMember(db), memory.NewBook(db), memory.NewLedger(db), db,
+		serviceLoan.Policy{Days: 14, MaxRenewals: 2, PickupDays: 3, Fines: policy}))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/member/{id}/account", handler.GetAccount).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}/account", handler.PostEntry).Methods(http.MethodPost)
+	r.HandleFunc("/loan", loan.PostLoan).Methods(http.MethodPost)
+	r.HandleFunc("/loan/{id}/return", loan.ReturnLoan).Methods(http.MethodPost)
+
+	fine := entities.LedgerEntry{ID: 1, MemberID: 1, LoanID: 1, Type: entities.LedgerFine, Amount: 250,
+		Note: "10 days overdue", CreatedOn: date(0)}
+	payment := entities.LedgerEntry{ID: 2, MemberID: 1, Type: entities.LedgerPayment, Amount: 100, Note: "cash",
+		CreatedOn: date(0)}
+
+	testcases := []deliverytest.Request{
+		{Desc: "fine accruing", Method: http.MethodGet, Target: "/member/1/account", ExpStatus: http.StatusOK,
+			ExpRes: entities.Account{MemberID: 1, Accruing: 250, Entries: []entities.LedgerEntry{}}},
+		{Desc: "accruing fine above the limit", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 2, MemberID: 1}, ExpStatus: http.StatusConflict},
+		{Desc: "return", Method: http.MethodPost, Target: "/loan/1/return", ExpStatus: http.StatusOK},
+		{Desc: "fine charged", Method: http.MethodGet, Target: "/member/1/account", ExpStatus: http.StatusOK,
+			ExpRes: entities.Account{MemberID: 1, Balance: 250, Entries: []entities.LedgerEntry{fine}}},
+		{Desc: "balance above the limit", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 2, MemberID: 1}, ExpStatus: http.StatusConflict},
+		{Desc: "more than the balance", Method: http.MethodPost, Target: "/member/1/account",
+			ReqBody: entities.LedgerEntry{Type: entities.LedgerPayment, Amount: 300}, ExpStatus: http.StatusConflict},
+		{Desc: "fine posted by staff", Method: http.MethodPost, Target: "/member/1/account",
+			ReqBody: entities.LedgerEntry{Type: entities.LedgerFine, Amount: 100}, ExpStatus: http.StatusBadRequest},
+		{Desc: "payment", Method: http.MethodPost, Target: "/member/1/account",
+			ReqBody:   entities.LedgerEntry{Type: entities.LedgerPayment, Amount: 100, Note: "cash"},
+			ExpStatus: http.StatusCreated, ExpRes: payment},
+		{Desc: "balance paid down", Method: http.MethodGet, Target: "/member/1/account", ExpStatus: http.StatusOK,
+			ExpRes: entities.Account{MemberID: 1, Balance: 150, Entries: []entities.LedgerEntry{fine, payment}}},
+		{Desc: "checkout", Method: http.MethodPost, Target: "/loan", ReqBody: entities.Loan{CopyID: 2, MemberID: 1},
+			ExpStatus: http.StatusCreated},
+		{Desc: "unknown member", Method: http.MethodGet, Target: "/member/9/account", ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
@@ -0,0 +1,50 @@
+package fines
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	service service.Account
+}
+
+func New(account service.Account) Handler {
+	return Handler{service: account}
+}
+
+// GetAccount function is to perform Handler Requests to get the ledger and the balance of a member
+func (h Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	account, err := h.service.GetAccount(r.Context(), id)
+	delivery.SetStatusCode(w, r.Method, account, err)
+}
+
+// PostEntry function is to perform Handler Requests to record a payment or a waiver in the ledger of a member
+func (h Handler) PostEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	entry, err := delivery.ReadBody[entities.LedgerEntry](r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	entry, err = h.service.PostEntry(r.Context(), id, entry)
+	delivery.SetStatusCode(w, r.Method, entry, err)
+}
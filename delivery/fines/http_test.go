@@ -0,0 +1,93 @@
+package fines
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_GetAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockAccount(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		id        string
+		err       error
+		expStatus int
+	}{
+		{desc: "found", id: "1", expStatus: http.StatusOK},
+		{desc: "member not found", id: "2", err: errors.EntityNotFound{Entity: "Member", ID: 2},
+			expStatus: http.StatusNotFound},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().GetAccount(gomock.Any(), gomock.Any()).
+				Return(entities.Account{MemberID: 1, Balance: 250}, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/member/"+tc.id+"/account", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.GetAccount(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
+
+func TestHandler_PostEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockAccount(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		id        string
+		body      string
+		err       error
+		expStatus int
+	}{
+		{desc: "paid", id: "1", body: `{"type":"payment","amount":100}`, expStatus: http.StatusCreated},
+		{desc: "more than the balance", id: "1", body: `{"type":"payment","amount":100}`,
+			err:       errors.Conflict{Entity: "Member", ID: 1, Reason: "has a balance of 50 which is less than the amount"},
+			expStatus: http.StatusConflict},
+		{desc: "invalid type", id: "1", body: `{"type":"payment","amount":100}`,
+			err: errors.InValidDetails{Details: "Type"}, expStatus: http.StatusBadRequest},
+		{desc: "invalid body", id: "1", body: `{"type":`, expStatus: http.StatusBadRequest},
+		{desc: "invalid id", id: "abc", body: `{"type":"payment","amount":100}`, expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.err != nil || tc.expStatus == http.StatusCreated {
+			mockService.EXPECT().PostEntry(gomock.Any(), 1, entities.LedgerEntry{Type: "payment", Amount: 100}).
+				Return(entities.LedgerEntry{ID: 1, MemberID: 1, Type: "payment", Amount: 100}, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/member/"+tc.id+"/account", bytes.NewReader([]byte(tc.body)))
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.PostEntry(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
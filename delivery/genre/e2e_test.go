@@ -0,0 +1,61 @@
+package genre
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceGenre "ThreeLayer/service/genre"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the genre requests one after another against the real service logic
+// backed by an in memory datastore
+func TestHandler_EndToEnd(t *testing.T) {
+	db := memory.New()
+	handler := New(serviceGenre.New(memory.NewGenre(db), db))
+
+	ifMatch := delivery.IfMatch(true)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/genre", handler.GetGenres).Methods(http.MethodGet)
+	r.HandleFunc("/genre", handler.PostGenre).Methods(http.MethodPost)
+	r.HandleFunc("/genre/{id}", handler.GetGenreByID).Methods(http.MethodGet)
+	r.HandleFunc("/genre/{id}", ifMatch(handler.PutGenre)).Methods(http.MethodPut)
+	r.HandleFunc("/genre/{id}", ifMatch(handler.DeleteGenre)).Methods(http.MethodDelete)
+
+	fiction := entities.Genre{ID: 1, Name: "Fiction", Kind: entities.KindGenre}
+	mystery := entities.Genre{ID: 2, Name: "Mystery", Kind: entities.KindGenre, ParentID: 1}
+	history := entities.Genre{ID: 3, Name: "History", Kind: entities.KindSubject}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add genre", Method: http.MethodPost, Target: "/genre", ReqBody: entities.Genre{Name: " Fiction "},
+			ExpStatus: http.StatusCreated, ExpRes: fiction},
+		{Desc: "add child genre", Method: http.MethodPost, Target: "/genre",
+			ReqBody: entities.Genre{Name: "Mystery", Kind: "Genre", ParentID: 1}, ExpStatus: http.StatusCreated,
+			ExpRes: mystery},
+		{Desc: "add subject", Method: http.MethodPost, Target: "/genre",
+			ReqBody: entities.Genre{Name: "History", Kind: entities.KindSubject}, ExpStatus: http.StatusCreated,
+			ExpRes: history},
+		{Desc: "name is taken", Method: http.MethodPost, Target: "/genre", ReqBody: entities.Genre{Name: "mystery"},
+			ExpStatus: http.StatusConflict},
+		{Desc: "parent of another kind", Method: http.MethodPost, Target: "/genre",
+			ReqBody: entities.Genre{Name: "Noir", ParentID: 3}, ExpStatus: http.StatusBadRequest},
+		{Desc: "unknown kind", Method: http.MethodPost, Target: "/genre",
+			ReqBody: entities.Genre{Name: "Noir", Kind: "mood"}, ExpStatus: http.StatusBadRequest},
+		{Desc: "get genres", Method: http.MethodGet, Target: "/genre", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Genre{fiction, mystery, history}},
+		{Desc: "parent under its child", Method: http.MethodPut, Target: "/genre/1", IfMatch: `"1"`,
+			ReqBody: entities.Genre{Name: "Fiction", ParentID: 2}, ExpStatus: http.StatusBadRequest},
+		{Desc: "genre with children", Method: http.MethodDelete, Target: "/genre/1", IfMatch: `"1"`,
+			ExpStatus: http.StatusConflict},
+		{Desc: "delete genre", Method: http.MethodDelete, Target: "/genre/2", IfMatch: `"1"`,
+			ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted genre", Method: http.MethodGet, Target: "/genre/2", ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
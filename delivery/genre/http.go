@@ -0,0 +1,47 @@
+package genre
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/service"
+	"net/http"
+)
+
+type Handler struct {
+	service service.Genre
+}
+
+func New(genre service.Genre) Handler {
+	return Handler{service: genre}
+}
+
+// GetGenres function is to perform Handler Requests to get all the genre instances from the database
+func (h Handler) GetGenres(w http.ResponseWriter, r *http.Request) {
+	genres, err := h.service.GetGenres(r.Context())
+	delivery.SetStatusCode(w, r.Method, genres, err)
+}
+
+// GetGenreByID function is to perform Handler Requests to get a genre instance using its ID from the database
+func (h Handler) GetGenreByID(w http.ResponseWriter, r *http.Request) {
+	delivery.GetByID(w, r, h.service.GetGenreByID, version)
+}
+
+// PostGenre function is to perform Handler Requests to add a new genre instance to the database
+func (h Handler) PostGenre(w http.ResponseWriter, r *http.Request) {
+	delivery.Post(w, r, h.service.PostGenre)
+}
+
+// PutGenre function is to perform Handler Requests to replace an existing genre instance in the database
+func (h Handler) PutGenre(w http.ResponseWriter, r *http.Request) {
+	delivery.Put(w, r, h.service.PutGenre, version)
+}
+
+// DeleteGenre function is to perform Handler Requests to remove a genre instance from the database
+func (h Handler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	delivery.Delete(w, r, h.service.DeleteGenre)
+}
+
+// version returns the version of the genre sent as its ETag
+func version(g entities.Genre) int {
+	return g.Version
+}
@@ -0,0 +1,118 @@
+package genre
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_GetGenreByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockGenre(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc        string
+		id          string
+		ifNoneMatch string
+		res         entities.Genre
+		err         error
+		expStatus   int
+		expETag     string
+	}{
+		{desc: "found", id: "1", res: entities.Genre{ID: 1, Version: 4}, expStatus: http.StatusOK, expETag: `"4"`},
+		{desc: "not modified", id: "1", ifNoneMatch: `"4"`, res: entities.Genre{ID: 1, Version: 4},
+			expStatus: http.StatusNotModified, expETag: `"4"`},
+		{desc: "not found", id: "2", err: errors.EntityNotFound{Entity: "Genre", ID: 2},
+			expStatus: http.StatusNotFound},
+		{desc: "database error", id: "3", err: errors.DB{Err: fmt.Errorf("connection refused")},
+			expStatus: http.StatusInternalServerError},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().GetGenreByID(gomock.Any(), gomock.Any()).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/genre/"+tc.id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+		if tc.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+
+		w := httptest.NewRecorder()
+
+		h.GetGenreByID(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
+
+func TestHandler_PutGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockGenre(ctrl)
+	h := New(mockService)
+
+	genre := entities.Genre{Name: "Mystery", Kind: entities.KindGenre, ParentID: 1}
+
+	testcases := []struct {
+		desc      string
+		body      []byte
+		res       entities.Genre
+		err       error
+		expStatus int
+		expETag   string
+	}{
+		{desc: "updated", res: entities.Genre{ID: 1, Version: 2}, expStatus: http.StatusOK, expETag: `"2"`},
+		{desc: "modified since read", err: errors.PreconditionFailed{Entity: "Genre", ID: 1},
+			expStatus: http.StatusPreconditionFailed},
+		{desc: "name is taken", err: errors.ExistAlready{Entity: "Genre"}, expStatus: http.StatusConflict},
+		{desc: "parent is a descendant", err: errors.InValidDetails{Details: "Parent ID"},
+			expStatus: http.StatusBadRequest},
+		{desc: "invalid body", body: []byte(`{"name":`), expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		body := tc.body
+		if body == nil {
+			body, _ = json.Marshal(genre)
+
+			mockService.EXPECT().PutGenre(gomock.Any(), 1, genre).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/genre/1", bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+
+		h.PutGenre(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"ThreeLayer/errors"
+)
+
+// GetByID writes the entity of the id in the path, version returns the version of the entity sent as its ETag
+func GetByID[T any](w http.ResponseWriter, r *http.Request, get func(context.Context, int) (T, error),
+	version func(T) int) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	entity, err := get(r.Context(), id)
+	if err == nil && CheckETag(w, r, version(entity)) {
+		return
+	}
+
+	SetStatusCode(w, r.Method, entity, err)
+}
+
+// Post adds the entity read from the body of the request
+func Post[T any](w http.ResponseWriter, r *http.Request, post func(context.Context, T) (T, error)) {
+	entity, err := ReadBody[T](r)
+	if err != nil {
+		SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	entity, err = post(r.Context(), entity)
+	SetStatusCode(w, r.Method, entity, err)
+}
+
+// Put replaces the entity of the id in the path by the one read from the body of the request,
+// the new version is sent as the ETag
+func Put[T any](w http.ResponseWriter, r *http.Request, put func(context.Context, int, T) (T, error),
+	version func(T) int) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	entity, err := ReadBody[T](r)
+	if err != nil {
+		SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	entity, err = put(r.Context(), id, entity)
+	if err == nil {
+		w.Header().Set("ETag", ETag(version(entity)))
+	}
+
+	SetStatusCode(w, r.Method, entity, err)
+}
+
+// Delete removes the entity of the id in the path
+func Delete(w http.ResponseWriter, r *http.Request, del func(context.Context, int) error) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	err = del(r.Context(), id)
+	SetStatusCode(w, r.Method, nil, err)
+}
+
+// ReadBody decodes the JSON body of the request
+func ReadBody[T any](r *http.Request) (T, error) {
+	var entity T
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return entity, errors.InValidDetails{Details: "body"}
+	}
+
+	err = json.Unmarshal(body, &entity)
+	if err != nil {
+		var zero T
+		return zero, errors.InValidDetails{Details: "body"}
+	}
+
+	return entity, nil
+}
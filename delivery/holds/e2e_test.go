@@ -0,0 +1,121 @@
+package holds
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery/deliverytest"
+	handlerLoan "ThreeLayer/delivery/loans"
+	"ThreeLayer/entities"
+	serviceLoan "ThreeLayer/service/loans"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the hold requests one after another against the real service logic
+// backed by an in memory datastore having a book with a single copy and three active members
+func TestHandler_EndToEnd(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	author, err := memory.NewAuthor(db).CreateAuthor(ctx, entities.Author{FirstName: "HC", LastName: "Verma",
+		Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	book, err := memory.NewBook(db).CreateBook(ctx, entities.Book{Title: "Rahul", Author: entities.Author{ID: author.ID},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	_, err = memory.NewCopy(db).CreateCopy(ctx, entities.Copy{BookID: book.ID, Barcode: "LIB-0001",
+		Condition: entities.ConditionGood, Status: entities.CopyAvailable})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		_, err = memory.NewMember(db).CreateMember(ctx, entities.Member{FirstName: "Rahul", LastName: "Saini",
+			Email: fmt.Sprintf("member%d@example.com", i), MembershipType: entities.MembershipStandard,
+			ExpiresOn: entities.NewDate(2099, 12, 31), Status: entities.MemberActive})
+		if err != nil {
+			t.Fatalf("expected error to be nil got %v", err)
+		}
+	}
+
+	svc := serviceLoan.New(memory.NewLoan(db), memory.NewHold(db), memory.NewCopy(db), memory.NewMember(db),
+		memory.NewBook(db), memory.NewLedger(db), db, serviceLoan.Policy{Days: 14, MaxRenewals: 2, PickupDays: 3})
+	handler := New(svc)
+	loan := handlerLoan.New(svc)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/hold", handler.PostHold).Methods(http.MethodPost)
+	r.HandleFunc("/hold/{id}", handler.GetHoldByID).Methods(http.MethodGet)
+	r.HandleFunc("/hold/{id}/cancel", handler.CancelHold).Methods(http.MethodPost)
+	r.HandleFunc("/book/{id}/holds", handler.GetBookHolds).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}/holds", handler.GetMemberHolds).Methods(http.MethodGet)
+	r.HandleFunc("/loan", loan.PostLoan).Methods(http.MethodPost)
+	r.HandleFunc("/loan/{id}/return", loan.ReturnLoan).Methods(http.MethodPost)
+
+	date := func(days int) entities.Date {
+		return entities.Today().AddDays(days)
+	}
+
+	first := entities.Hold{ID: 1, BookID: 1, MemberID: 2, Status: entities.HoldWaiting, PlacedOn: date(0)}
+	second := entities.Hold{ID: 2, BookID: 1, MemberID: 3, Status: entities.HoldWaiting, PlacedOn: date(0)}
+
+	firstReady := first
+	firstReady.CopyID, firstReady.Status, firstReady.ReadyOn, firstReady.ExpiresOn = 1, entities.HoldReady, date(0),
+		date(3)
+	firstCancelled := firstReady
+	firstCancelled.Status = entities.HoldCancelled
+
+	secondReady := second
+	secondReady.CopyID, secondReady.Status, secondReady.ReadyOn, secondReady.ExpiresOn = 1, entities.HoldReady,
+		date(0), date(3)
+	secondFulfilled := secondReady
+	secondFulfilled.Status = entities.HoldFulfilled
+
+	testcases := []deliverytest.Request{
+		{Desc: "copy is available", Method: http.MethodPost, Target: "/hold",
+			ReqBody: entities.Hold{BookID: 1, MemberID: 2}, ExpStatus: http.StatusConflict},
+		{Desc: "checkout", Method: http.MethodPost, Target: "/loan", ReqBody: entities.Loan{CopyID: 1, MemberID: 1},
+			ExpStatus: http.StatusCreated},
+		{Desc: "place hold", Method: http.MethodPost, Target: "/hold", ReqBody: entities.Hold{BookID: 1, MemberID: 2},
+			ExpStatus: http.StatusCreated, ExpRes: first},
+		{Desc: "place second hold", Method: http.MethodPost, Target: "/hold",
+			ReqBody: entities.Hold{BookID: 1, MemberID: 3}, ExpStatus: http.StatusCreated, ExpRes: second},
+		{Desc: "member is in the queue", Method: http.MethodPost, Target: "/hold",
+			ReqBody: entities.Hold{BookID: 1, MemberID: 2}, ExpStatus: http.StatusConflict},
+		{Desc: "member has the book", Method: http.MethodPost, Target: "/hold",
+			ReqBody: entities.Hold{BookID: 1, MemberID: 1}, ExpStatus: http.StatusConflict},
+		{Desc: "unknown book", Method: http.MethodPost, Target: "/hold", ReqBody: entities.Hold{BookID: 5, MemberID: 2},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "queue of book", Method: http.MethodGet, Target: "/book/1/holds", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Hold{first, second}},
+		{Desc: "return", Method: http.MethodPost, Target: "/loan/1/return", ExpStatus: http.StatusOK},
+		{Desc: "copy assigned to first hold", Method: http.MethodGet, Target: "/hold/1", ExpStatus: http.StatusOK,
+			ExpRes: firstReady},
+		{Desc: "copy is on hold for another member", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 1, MemberID: 3}, ExpStatus: http.StatusConflict},
+		{Desc: "cancel ready hold", Method: http.MethodPost, Target: "/hold/1/cancel", ExpStatus: http.StatusOK,
+			ExpRes: firstCancelled},
+		{Desc: "copy assigned to next hold", Method: http.MethodGet, Target: "/member/3/holds",
+			ExpStatus: http.StatusOK, ExpRes: []entities.Hold{secondReady}},
+		{Desc: "hold picked up", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 1, MemberID: 3}, ExpStatus: http.StatusCreated},
+		{Desc: "hold is fulfilled", Method: http.MethodGet, Target: "/hold/2", ExpStatus: http.StatusOK,
+			ExpRes: secondFulfilled},
+		{Desc: "empty queue", Method: http.MethodGet, Target: "/book/1/holds", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Hold{}},
+		{Desc: "cancel fulfilled hold", Method: http.MethodPost, Target: "/hold/2/cancel",
+			ExpStatus: http.StatusConflict},
+		{Desc: "unknown hold", Method: http.MethodGet, Target: "/hold/9", ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
@@ -0,0 +1,89 @@
+package holds
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	service service.Hold
+}
+
+func New(hold service.Hold) Handler {
+	return Handler{service: hold}
+}
+
+// GetHoldByID function is to perform Handler Requests to get a hold instance using its ID from the database
+func (h Handler) GetHoldByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	hold, err := h.service.GetHoldByID(r.Context(), id)
+	if err == nil && delivery.CheckETag(w, r, hold.Version) {
+		return
+	}
+
+	delivery.SetStatusCode(w, r.Method, hold, err)
+}
+
+// GetBookHolds function is to perform Handler Requests to get the queue of holds of a book from the database
+func (h Handler) GetBookHolds(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	holds, err := h.service.GetBookHolds(r.Context(), id)
+	delivery.SetStatusCode(w, r.Method, holds, err)
+}
+
+// GetMemberHolds function is to perform Handler Requests to get the queued holds of a member from the database
+func (h Handler) GetMemberHolds(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	holds, err := h.service.GetMemberHolds(r.Context(), id)
+	delivery.SetStatusCode(w, r.Method, holds, err)
+}
+
+// PostHold function is to perform Handler Requests to place a hold on a book for a member
+func (h Handler) PostHold(w http.ResponseWriter, r *http.Request) {
+	hold, err := delivery.ReadBody[entities.Hold](r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	hold, err = h.service.PlaceHold(r.Context(), hold)
+	delivery.SetStatusCode(w, r.Method, hold, err)
+}
+
+// CancelHold function is to perform Handler Requests to take a hold out of the queue of its book,
+// the cancellation is a POST which updates the hold so it is answered with 200 as an update
+func (h Handler) CancelHold(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	hold, err := h.service.CancelHold(r.Context(), id)
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(hold.Version))
+	}
+
+	delivery.SetStatusCode(w, http.MethodPut, hold, err)
+}
@@ -0,0 +1,96 @@
+package holds
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_PostHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockHold(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		body      string
+		err       error
+		expStatus int
+	}{
+		{desc: "placed", body: `{"book_id":1,"member_id":2}`, expStatus: http.StatusCreated},
+		{desc: "copies are available", body: `{"book_id":1,"member_id":2}`,
+			err: errors.Conflict{Entity: "Book", ID: 1, Reason: "has available copies"}, expStatus: http.StatusConflict},
+		{desc: "member is in the queue", body: `{"book_id":1,"member_id":2}`, err: errors.ExistAlready{Entity: "Hold"},
+			expStatus: http.StatusConflict},
+		{desc: "invalid body", body: `{"book_id":`, expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().PlaceHold(gomock.Any(), entities.Hold{BookID: 1, MemberID: 2}).
+				Return(entities.Hold{ID: 1, BookID: 1, MemberID: 2, Status: entities.HoldWaiting}, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/hold", bytes.NewReader([]byte(tc.body)))
+		w := httptest.NewRecorder()
+
+		h.PostHold(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
+
+func TestHandler_CancelHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockHold(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		id        string
+		res       entities.Hold
+		err       error
+		expStatus int
+		expETag   string
+	}{
+		{desc: "cancelled", id: "1", res: entities.Hold{ID: 1, Status: entities.HoldCancelled, Version: 2},
+			expStatus: http.StatusOK, expETag: `"2"`},
+		{desc: "hold is fulfilled", id: "1", err: errors.Conflict{Entity: "Hold", ID: 1, Reason: "is fulfilled"},
+			expStatus: http.StatusConflict},
+		{desc: "not found", id: "2", err: errors.EntityNotFound{Entity: "Hold", ID: 2}, expStatus: http.StatusNotFound},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().CancelHold(gomock.Any(), gomock.Any()).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/hold/"+tc.id+"/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.CancelHold(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package loans
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceLoan "ThreeLayer/service/loans"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the loan requests one after another against the real service logic
+// backed by an in memory datastore having a book with a single copy, an active and a suspended member
+func TestHandler_EndToEnd(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	author, err := memory.NewAuthor(db).CreateAuthor(ctx, entities.Author{FirstName: "HC", LastName: "Verma",
+		Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	book, err := memory.NewBook(db).CreateBook(ctx, entities.Book{Title: "Rahul", Author: entities.Author{ID: author.ID},
+		Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	_, err = memory.NewCopy(db).CreateCopy(ctx, entities.Copy{BookID: book.ID, Barcode: "LIB-0001",
+		Condition: entities.ConditionGood, Status: entities.CopyAvailable})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+
+	for _, status := range []string{entities.MemberActive, entities.MemberSuspended} {
+		_, err = memory.NewMember(db).CreateMember(ctx, entities.Member{FirstName: "Rahul", LastName: "Saini",
+			Email: status + "@example.com", MembershipType: entities.MembershipStandard,
+			ExpiresOn: entities.NewDate(2099, 12, 31), Status: status})
+		if err != nil {
+			t.Fatalf("expected error to be nil got %v", err)
+		}
+	}
+
+	handler := New(serviceLoan.New(memory.NewLoan(db), memory.NewHold(db), memory.NewCopy(db), memory.NewMember(db),
+		memory.NewBook(db), memory.NewLedger(db), db, serviceLoan.Policy{Days: 14, MaxRenewals: 1, PickupDays: 3}))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/loan", handler.PostLoan).Methods(http.MethodPost)
+	r.HandleFunc("/loan/{id}", handler.GetLoanByID).Methods(http.MethodGet)
+	r.HandleFunc("/loan/{id}/return", handler.ReturnLoan).Methods(http.MethodPost)
+	r.HandleFunc("/loan/{id}/renew", handler.RenewLoan).Methods(http.MethodPost)
+	r.HandleFunc("/member/{id}/loans", handler.GetMemberLoans).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}/loans", handler.GetBookLoans).Methods(http.MethodGet)
+
+	date := func(days int) entities.Date {
+		return entities.Today().AddDays(days)
+	}
+
+	lent := entities.Loan{ID: 1, CopyID: 1, MemberID: 1, BookID: 1, CheckedOutOn: date(0), DueOn: date(14)}
+	renewed := lent
+	renewed.DueOn, renewed.Renewals = date(28), 1
+	returned := renewed
+	returned.ReturnedOn = date(0)
+
+	testcases := []deliverytest.Request{
+		{Desc: "suspended member", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 1, MemberID: 2}, ExpStatus: http.StatusConflict},
+		{Desc: "unknown copy", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 5, MemberID: 1}, ExpStatus: http.StatusBadRequest},
+		{Desc: "checkout", Method: http.MethodPost, Target: "/loan", ReqBody: entities.Loan{CopyID: 1, MemberID: 1},
+			ExpStatus: http.StatusCreated, ExpRes: lent},
+		{Desc: "copy is on loan", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 1, MemberID: 1}, ExpStatus: http.StatusConflict},
+		{Desc: "get loan", Method: http.MethodGet, Target: "/loan/1", ExpStatus: http.StatusOK, ExpRes: lent},
+		{Desc: "active loans of member", Method: http.MethodGet, Target: "/member/1/loans", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Loan{lent}},
+		{Desc: "renew", Method: http.MethodPost, Target: "/loan/1/renew", ExpStatus: http.StatusOK, ExpRes: renewed},
+		{Desc: "renewal limit reached", Method: http.MethodPost, Target: "/loan/1/renew",
+			ExpStatus: http.StatusConflict},
+		{Desc: "return", Method: http.MethodPost, Target: "/loan/1/return", ExpStatus: http.StatusOK,
+			ExpRes: returned},
+		{Desc: "returned already", Method: http.MethodPost, Target: "/loan/1/return", ExpStatus: http.StatusConflict},
+		{Desc: "no active loans", Method: http.MethodGet, Target: "/member/1/loans", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Loan{}},
+		{Desc: "loan history of book", Method: http.MethodGet, Target: "/book/1/loans", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Loan{returned}},
+		{Desc: "copy is available again", Method: http.MethodPost, Target: "/loan",
+			ReqBody: entities.Loan{CopyID: 1, MemberID: 1}, ExpStatus: http.StatusCreated},
+		{Desc: "unknown member", Method: http.MethodGet, Target: "/member/9/loans", ExpStatus: http.StatusNotFound},
+		{Desc: "invalid id", Method: http.MethodGet, Target: "/loan/one", ExpStatus: http.StatusBadRequest},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
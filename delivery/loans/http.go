@@ -0,0 +1,106 @@
+package loans
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	service service.Loan
+}
+
+func New(loan service.Loan) Handler {
+	return Handler{service: loan}
+}
+
+// GetLoanByID function is to perform Handler Requests to get a loan instance using its ID from the database
+func (h Handler) GetLoanByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	loan, err := h.service.GetLoanByID(r.Context(), id)
+	if err == nil && delivery.CheckETag(w, r, loan.Version) {
+		return
+	}
+
+	delivery.SetStatusCode(w, r.Method, loan, err)
+}
+
+// GetMemberLoans function is to perform Handler Requests to get the active loans of a member from the database
+func (h Handler) GetMemberLoans(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	loans, err := h.service.GetActiveLoans(r.Context(), id)
+	delivery.SetStatusCode(w, r.Method, loans, err)
+}
+
+// GetBookLoans function is to perform Handler Requests to get the loan history of a book from the database
+func (h Handler) GetBookLoans(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	loans, err := h.service.GetBookLoans(r.Context(), id)
+	delivery.SetStatusCode(w, r.Method, loans, err)
+}
+
+// PostLoan function is to perform Handler Requests to check a copy out to a member
+func (h Handler) PostLoan(w http.ResponseWriter, r *http.Request) {
+	loan, err := delivery.ReadBody[entities.Loan](r)
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, err)
+		return
+	}
+
+	loan, err = h.service.Checkout(r.Context(), loan)
+	delivery.SetStatusCode(w, r.Method, loan, err)
+}
+
+// ReturnLoan function is to perform Handler Requests to record the return of the copy of a loan
+func (h Handler) ReturnLoan(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	loan, err := h.service.Return(r.Context(), id)
+	setLoan(w, loan, err)
+}
+
+// RenewLoan function is to perform Handler Requests to extend the due date of a loan
+func (h Handler) RenewLoan(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.SetStatusCode(w, r.Method, nil, errors.InValidDetails{Details: "id"})
+		return
+	}
+
+	loan, err := h.service.Renew(r.Context(), id)
+	setLoan(w, loan, err)
+}
+
+// setLoan writes the loan changed by an action, the action is a POST which updates the loan
+// so it is answered with 200 as an update and not with 201
+func setLoan(w http.ResponseWriter, loan entities.Loan, err error) {
+	if err == nil {
+		w.Header().Set("ETag", delivery.ETag(loan.Version))
+	}
+
+	delivery.SetStatusCode(w, http.MethodPut, loan, err)
+}
@@ -0,0 +1,126 @@
+package loans
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_PostLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockLoan(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		body      string
+		err       error
+		expStatus int
+	}{
+		{desc: "checked out", body: `{"copy_id":1,"member_id":2}`, expStatus: http.StatusCreated},
+		{desc: "copy is not available", body: `{"copy_id":1,"member_id":2}`,
+			err: errors.Conflict{Entity: "Copy", ID: 1, Reason: "is not available"}, expStatus: http.StatusConflict},
+		{desc: "invalid body", body: `{"copy_id":`, expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().Checkout(gomock.Any(), entities.Loan{CopyID: 1, MemberID: 2}).
+				Return(entities.Loan{ID: 1, CopyID: 1, MemberID: 2}, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/loan", bytes.NewReader([]byte(tc.body)))
+		w := httptest.NewRecorder()
+
+		h.PostLoan(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
+
+func TestHandler_ReturnLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockLoan(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		id        string
+		res       entities.Loan
+		err       error
+		expStatus int
+		expETag   string
+	}{
+		{desc: "returned", id: "1", res: entities.Loan{ID: 1, Version: 2}, expStatus: http.StatusOK, expETag: `"2"`},
+		{desc: "returned already", id: "1", err: errors.Conflict{Entity: "Loan", ID: 1, Reason: "has been returned"},
+			expStatus: http.StatusConflict},
+		{desc: "not found", id: "2", err: errors.EntityNotFound{Entity: "Loan", ID: 2}, expStatus: http.StatusNotFound},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().Return(gomock.Any(), gomock.Any()).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/loan/"+tc.id+"/return", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+		w := httptest.NewRecorder()
+
+		h.ReturnLoan(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
+
+func TestHandler_RenewLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockLoan(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc      string
+		res       entities.Loan
+		err       error
+		expStatus int
+	}{
+		{desc: "renewed", res: entities.Loan{ID: 1, Renewals: 1, Version: 2}, expStatus: http.StatusOK},
+		{desc: "limit reached", err: errors.Conflict{Entity: "Loan", ID: 1, Reason: "has reached the limit of 2 renewals"},
+			expStatus: http.StatusConflict},
+	}
+
+	for i, tc := range testcases {
+		mockService.EXPECT().Renew(gomock.Any(), 1).Return(tc.res, tc.err)
+
+		req := httptest.NewRequest(http.MethodPost, "/loan/1/renew", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+
+		h.RenewLoan(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package member
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceMember "ThreeLayer/service/member"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// expiresOn is the expiry date of the members in the tests
+var expiresOn = entities.NewDate(2030, 12, 31)
+
+// TestHandler_EndToEnd runs the member requests one after another against the real service logic
+// backed by an in memory datastore
+func TestHandler_EndToEnd(t *testing.T) {
+	db := memory.New()
+	handler := New(serviceMember.New(memory.NewMember(db), memory.NewLoan(db), memory.NewHold(db),
+		memory.NewLedger(db), db))
+
+	ifMatch := delivery.IfMatch(true)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/member", handler.GetMembers).Methods(http.MethodGet)
+	r.HandleFunc("/member", handler.PostMember).Methods(http.MethodPost)
+	r.HandleFunc("/member/{id}", handler.GetMemberByID).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}", ifMatch(handler.PutMember)).Methods(http.MethodPut)
+	r.HandleFunc("/member/{id}", ifMatch(handler.DeleteMember)).Methods(http.MethodDelete)
+
+	member := entities.Member{FirstName: "Rahul", LastName: "Saini", Email: "rahul@example.com",
+		MembershipType: entities.MembershipStudent, ExpiresOn: expiresOn}
+	created := entities.Member{ID: 1, FirstName: "Rahul", LastName: "Saini", Email: "rahul@example.com",
+		MembershipType: entities.MembershipStudent, ExpiresOn: expiresOn, Status: entities.MemberActive}
+	suspended := created
+	suspended.Status = entities.MemberSuspended
+
+	testcases := []deliverytest.Request{
+		{Desc: "add member", Method: http.MethodPost, Target: "/member", ReqBody: member,
+			ExpStatus: http.StatusCreated, ExpRes: created},
+		{Desc: "email is taken", Method: http.MethodPost, Target: "/member",
+			ReqBody:   entities.Member{FirstName: "MG", LastName: "Verma", Email: "RAHUL@example.com"},
+			ExpStatus: http.StatusConflict},
+		{Desc: "invalid member", Method: http.MethodPost, Target: "/member", ReqBody: entities.Member{FirstName: "MG"},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "invalid body", Method: http.MethodPost, Target: "/member", ReqBody: json.RawMessage(`"member"`),
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "get members", Method: http.MethodGet, Target: "/member", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Member{created}},
+		{Desc: "get member", Method: http.MethodGet, Target: "/member/1", ExpStatus: http.StatusOK, ExpRes: created},
+		{Desc: "update without If-Match", Method: http.MethodPut, Target: "/member/1", ReqBody: suspended,
+			ExpStatus: http.StatusPreconditionRequired},
+		{Desc: "update of other version", Method: http.MethodPut, Target: "/member/1", IfMatch: `"2"`,
+			ReqBody: suspended, ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "suspend member", Method: http.MethodPut, Target: "/member/1", IfMatch: `"1"`, ReqBody: suspended,
+			ExpStatus: http.StatusOK, ExpRes: suspended},
+		{Desc: "get suspended member", Method: http.MethodGet, Target: "/member/1", ExpStatus: http.StatusOK,
+			ExpRes: suspended},
+		{Desc: "invalid id", Method: http.MethodGet, Target: "/member/one", ExpStatus: http.StatusBadRequest},
+		{Desc: "delete member", Method: http.MethodDelete, Target: "/member/1", IfMatch: `"2"`,
+			ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted member", Method: http.MethodGet, Target: "/member/1", ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
@@ -0,0 +1,47 @@
+package member
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/service"
+	"net/http"
+)
+
+type Handler struct {
+	service service.Member
+}
+
+func New(member service.Member) Handler {
+	return Handler{service: member}
+}
+
+// GetMembers function is to perform Handler Requests to get all the member instances from the database
+func (h Handler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := h.service.GetMembers(r.Context())
+	delivery.SetStatusCode(w, r.Method, members, err)
+}
+
+// GetMemberByID function is to perform Handler Requests to get a member instance using its ID from the database
+func (h Handler) GetMemberByID(w http.ResponseWriter, r *http.Request) {
+	delivery.GetByID(w, r, h.service.GetMemberByID, version)
+}
+
+// PostMember function is to perform Handler Requests to add a new member instance to the database
+func (h Handler) PostMember(w http.ResponseWriter, r *http.Request) {
+	delivery.Post(w, r, h.service.PostMember)
+}
+
+// PutMember function is to perform Handler Requests to replace an existing member instance in the database
+func (h Handler) PutMember(w http.ResponseWriter, r *http.Request) {
+	delivery.Put(w, r, h.service.PutMember, version)
+}
+
+// DeleteMember function is to perform Handler Requests to remove a member instance from the database
+func (h Handler) DeleteMember(w http.ResponseWriter, r *http.Request) {
+	delivery.Delete(w, r, h.service.DeleteMember)
+}
+
+// version returns the version of the member sent as its ETag
+func version(m entities.Member) int {
+	return m.Version
+}
@@ -0,0 +1,32 @@
+package delivery
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// GetPatch reads the patch document of a PATCH request, application/json is read as a JSON Merge Patch
+func GetPatch(r *http.Request) (entities.Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return entities.Patch{}, errors.InValidDetails{Details: "Content-Type"}
+	}
+
+	switch mediaType {
+	case "application/json", entities.MergePatch:
+		mediaType = entities.MergePatch
+	case entities.JSONPatch:
+	default:
+		return entities.Patch{}, errors.InValidDetails{Details: "Content-Type"}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return entities.Patch{}, errors.InValidDetails{Details: "body"}
+	}
+
+	return entities.Patch{Type: mediaType, Doc: body}, nil
+}
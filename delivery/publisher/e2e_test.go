@@ -0,0 +1,72 @@
+package publisher
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	servicePublisher "ThreeLayer/service/publisher"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the publisher requests one after another against the real service logic
+// backed by an in memory datastore
+func TestHandler_EndToEnd(t *testing.T) {
+	db := memory.New()
+	handler := New(servicePublisher.New(memory.NewPublisher(db), memory.NewBook(db), db))
+
+	author, err := memory.NewAuthor(db).CreateAuthor(context.Background(), entities.Author{FirstName: "RD",
+		LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2), PenName: "Sharma"})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating an author", err)
+	}
+
+	_, err = memory.NewBook(db).CreateBook(context.Background(), entities.Book{Title: "Mathematics",
+		Author: entities.Author{ID: author.ID}, Publisher: entities.Publisher{ID: 1},
+		PublishedDate: entities.NewDate(2000, 7, 22)})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a book", err)
+	}
+
+	ifMatch := delivery.IfMatch(true)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/publisher", handler.GetPublishers).Methods(http.MethodGet)
+	r.HandleFunc("/publisher", handler.PostPublisher).Methods(http.MethodPost)
+	r.HandleFunc("/publisher/{id}", handler.GetPublisherByID).Methods(http.MethodGet)
+	r.HandleFunc("/publisher/{id}", ifMatch(handler.PutPublisher)).Methods(http.MethodPut)
+	r.HandleFunc("/publisher/{id}", ifMatch(handler.DeletePublisher)).Methods(http.MethodDelete)
+
+	seeded := []entities.Publisher{{ID: 1, Name: "Arihanth"}, {ID: 2, Name: "Scholastic"}, {ID: 3, Name: "Penguin"}}
+	created := entities.Publisher{ID: 4, Name: "Oxford"}
+	renamed := entities.Publisher{ID: 4, Name: "Oxford University Press", Website: "https://global.oup.com"}
+
+	testcases := []deliverytest.Request{
+		{Desc: "get seeded publishers", Method: http.MethodGet, Target: "/publisher", ExpStatus: http.StatusOK,
+			ExpRes: seeded},
+		{Desc: "add publisher", Method: http.MethodPost, Target: "/publisher",
+			ReqBody: entities.Publisher{Name: " Oxford "}, ExpStatus: http.StatusCreated, ExpRes: created},
+		{Desc: "name is taken", Method: http.MethodPost, Target: "/publisher",
+			ReqBody: entities.Publisher{Name: "penguin"}, ExpStatus: http.StatusConflict},
+		{Desc: "invalid publisher", Method: http.MethodPost, Target: "/publisher",
+			ReqBody: entities.Publisher{Name: "Harper", Website: "harper"}, ExpStatus: http.StatusBadRequest},
+		{Desc: "get publisher", Method: http.MethodGet, Target: "/publisher/4", ExpStatus: http.StatusOK,
+			ExpRes: created},
+		{Desc: "update of other version", Method: http.MethodPut, Target: "/publisher/4", IfMatch: `"2"`,
+			ReqBody: renamed, ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "rename publisher", Method: http.MethodPut, Target: "/publisher/4", IfMatch: `"1"`, ReqBody: renamed,
+			ExpStatus: http.StatusOK, ExpRes: renamed},
+		{Desc: "publisher of a book", Method: http.MethodDelete, Target: "/publisher/1", IfMatch: `"1"`,
+			ExpStatus: http.StatusConflict},
+		{Desc: "delete publisher", Method: http.MethodDelete, Target: "/publisher/4", IfMatch: `"2"`,
+			ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted publisher", Method: http.MethodGet, Target: "/publisher/4",
+			ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
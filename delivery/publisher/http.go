@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/service"
+	"net/http"
+)
+
+type Handler struct {
+	service service.Publisher
+}
+
+func New(publisher service.Publisher) Handler {
+	return Handler{service: publisher}
+}
+
+// GetPublishers function is to perform Handler Requests to get all the publisher instances from the database
+func (h Handler) GetPublishers(w http.ResponseWriter, r *http.Request) {
+	publishers, err := h.service.GetPublishers(r.Context())
+	delivery.SetStatusCode(w, r.Method, publishers, err)
+}
+
+// GetPublisherByID function is to perform Handler Requests to get a publisher instance using its ID from the database
+func (h Handler) GetPublisherByID(w http.ResponseWriter, r *http.Request) {
+	delivery.GetByID(w, r, h.service.GetPublisherByID, version)
+}
+
+// PostPublisher function is to perform Handler Requests to add a new publisher instance to the database
+func (h Handler) PostPublisher(w http.ResponseWriter, r *http.Request) {
+	delivery.Post(w, r, h.service.PostPublisher)
+}
+
+// PutPublisher function is to perform Handler Requests to replace an existing publisher instance in the database
+func (h Handler) PutPublisher(w http.ResponseWriter, r *http.Request) {
+	delivery.Put(w, r, h.service.PutPublisher, version)
+}
+
+// DeletePublisher function is to perform Handler Requests to remove a publisher instance from the database
+func (h Handler) DeletePublisher(w http.ResponseWriter, r *http.Request) {
+	delivery.Delete(w, r, h.service.DeletePublisher)
+}
+
+// version returns the version of the publisher sent as its ETag
+func version(p entities.Publisher) int {
+	return p.Version
+}
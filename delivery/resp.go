@@ -8,19 +8,84 @@ import (
 	"ThreeLayer/errors"
 )
 
-// SetStatusCode writes the status code based on the error type
+// Error codes of the ErrorResponse
+const (
+	CodeAlreadyExists  = "ALREADY_EXISTS"
+	CodeConflict       = "CONFLICT"
+	CodeInvalidDetails = "INVALID_DETAILS"
+	CodeNotFound       = "NOT_FOUND"
+	CodePrecondition   = "PRECONDITION_FAILED"
+	CodeRequired       = "PRECONDITION_REQUIRED"
+	CodeUnavailable    = "UNAVAILABLE"
+	CodeInternal       = "INTERNAL_ERROR"
+)
+
+// ErrorResponse is the body written for every error, Errors has one entry per invalid field
+// when more than one field is invalid
+type ErrorResponse struct {
+	Code      string          `json:"code"`
+	Message   string          `json:"message"`
+	Field     string          `json:"field,omitempty"`
+	Entity    string          `json:"entity,omitempty"`
+	ID        int             `json:"id,omitempty"`
+	RequestID string          `json:"requestId,omitempty"`
+	Errors    []ErrorResponse `json:"errors,omitempty"`
+}
+
+// ErrorBody wraps the ErrorResponse, so that an error can not be read as the entity requested
+type ErrorBody struct {
+	Error ErrorResponse `json:"error"`
+}
+
+// SetStatusCode writes the status code based on the error type, the error is described in the body
 func SetStatusCode(w http.ResponseWriter, method string, data interface{}, err error) {
-	switch err.(type) {
+	if err == nil {
+		writeSuccessResponse(method, w, data)
+		return
+	}
+
+	status, resp := errorResponse(err)
+	resp.RequestID = w.Header().Get(RequestIDHeader)
+
+	if status >= http.StatusInternalServerError {
+		log.Printf("request %s failed: %v", resp.RequestID, err)
+	}
+
+	writeResponseBody(w, status, ErrorBody{Error: resp})
+}
+
+// errorResponse maps the error to the status code and the body, the message of an unknown error
+// is not sent as it can have the details of the database
+func errorResponse(err error) (int, ErrorResponse) {
+	switch e := err.(type) {
 	case errors.ExistAlready:
-		w.WriteHeader(http.StatusConflict)
+		return http.StatusConflict, ErrorResponse{Code: CodeAlreadyExists, Message: e.Error(), Entity: e.Entity, ID: e.ID,
+			Field: e.Field}
+	case errors.Conflict:
+		return http.StatusConflict, ErrorResponse{Code: CodeConflict, Message: e.Error(), Entity: e.Entity, ID: e.ID}
 	case errors.InValidDetails:
-		w.WriteHeader(http.StatusBadRequest)
+		return http.StatusBadRequest, ErrorResponse{Code: CodeInvalidDetails, Message: e.Error(), Field: e.Details}
+	case errors.InValidFields:
+		resp := ErrorResponse{Code: CodeInvalidDetails, Message: e.Error()}
+
+		for i := range e {
+			_, field := errorResponse(e[i])
+			resp.Errors = append(resp.Errors, field)
+		}
+
+		return http.StatusBadRequest, resp
 	case errors.EntityNotFound:
-		w.WriteHeader(http.StatusNotFound)
-	case nil:
-		writeSuccessResponse(method, w, data)
+		return http.StatusNotFound, ErrorResponse{Code: CodeNotFound, Message: e.Error(), Entity: e.Entity, ID: e.ID}
+	case errors.PreconditionFailed:
+		return http.StatusPreconditionFailed, ErrorResponse{Code: CodePrecondition, Message: e.Error(), Entity: e.Entity,
+			ID: e.ID}
+	case errors.PreconditionRequired:
+		return http.StatusPreconditionRequired, ErrorResponse{Code: CodeRequired, Message: e.Error()}
+	case errors.Unavailable:
+		return http.StatusServiceUnavailable, ErrorResponse{Code: CodeUnavailable,
+			Message: "the request ran into concurrent requests, send it again"}
 	default:
-		w.WriteHeader(http.StatusInternalServerError)
+		return http.StatusInternalServerError, ErrorResponse{Code: CodeInternal, Message: "internal server error"}
 	}
 }
 
@@ -31,7 +96,7 @@ func writeSuccessResponse(method string, w http.ResponseWriter, data interface{}
 		writeResponseBody(w, http.StatusCreated, data)
 	case http.MethodGet:
 		writeResponseBody(w, http.StatusOK, data)
-	case http.MethodPut:
+	case http.MethodPut, http.MethodPatch:
 		writeResponseBody(w, http.StatusOK, data)
 	case http.MethodDelete:
 		writeResponseBody(w, http.StatusNoContent, data)
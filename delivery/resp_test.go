@@ -0,0 +1,110 @@
+package delivery
+
+import (
+	"ThreeLayer/errors"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSetStatusCode_Error(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		err       error
+		expStatus int
+		expBody   ErrorResponse
+	}{
+		{"already exists", errors.ExistAlready{Entity: "Book"}, http.StatusConflict,
+			ErrorResponse{Code: CodeAlreadyExists, Message: "entity  Book already exists", Entity: "Book", RequestID: "req-1"}},
+		{"duplicate of a stored book", errors.ExistAlready{Entity: "Book", ID: 2}, http.StatusConflict,
+			ErrorResponse{Code: CodeAlreadyExists, Message: "entity Book with id 2 already exists", Entity: "Book", ID: 2,
+				RequestID: "req-1"}},
+		{"duplicate isbn", errors.ExistAlready{Entity: "Book", Field: "isbn"}, http.StatusConflict,
+			ErrorResponse{Code: CodeAlreadyExists, Message: "entity Book with the same isbn already exists", Entity: "Book",
+				Field: "isbn", RequestID: "req-1"}},
+		{"conflict", errors.Conflict{Entity: "Copy", ID: 4, Reason: "is not available"}, http.StatusConflict,
+			ErrorResponse{Code: CodeConflict, Message: "entity Copy with id 4 is not available", Entity: "Copy", ID: 4,
+				RequestID: "req-1"}},
+		{"invalid detail", errors.InValidDetails{Details: "Title"}, http.StatusBadRequest,
+			ErrorResponse{Code: CodeInvalidDetails, Message: "detail Title is invalid", Field: "Title", RequestID: "req-1"}},
+		{"invalid details", errors.InValidFields{{Details: "Title"}, {Details: "Publication"}}, http.StatusBadRequest,
+			ErrorResponse{Code: CodeInvalidDetails, Message: "details Title, Publication are invalid", RequestID: "req-1",
+				Errors: []ErrorResponse{
+					{Code: CodeInvalidDetails, Message: "detail Title is invalid", Field: "Title"},
+					{Code: CodeInvalidDetails, Message: "detail Publication is invalid", Field: "Publication"},
+				}}},
+		{"not found", errors.EntityNotFound{Entity: "Author", ID: 3}, http.StatusNotFound,
+			ErrorResponse{Code: CodeNotFound, Message: "entity Author with id 3 not found", Entity: "Author", ID: 3,
+				RequestID: "req-1"}},
+		{"precondition failed", errors.PreconditionFailed{Entity: "Book", ID: 2}, http.StatusPreconditionFailed,
+			ErrorResponse{Code: CodePrecondition, Message: "entity Book with id 2 has been modified", Entity: "Book", ID: 2,
+				RequestID: "req-1"}},
+		{"precondition required", errors.PreconditionRequired{}, http.StatusPreconditionRequired,
+			ErrorResponse{Code: CodeRequired, Message: "the If-Match header is required", RequestID: "req-1"}},
+		{"busy database", errors.Unavailable{Err: fmt.Errorf("deadlock found")}, http.StatusServiceUnavailable,
+			ErrorResponse{Code: CodeUnavailable, Message: "the request ran into concurrent requests, send it again",
+				RequestID: "req-1"}},
+		{"database error is not sent", errors.DB{Err: fmt.Errorf("connection refused")}, http.StatusInternalServerError,
+			ErrorResponse{Code: CodeInternal, Message: "internal server error", RequestID: "req-1"}},
+	}
+
+	for i, tc := range testcases {
+		w := httptest.NewRecorder()
+		w.Header().Set(RequestIDHeader, "req-1")
+
+		SetStatusCode(w, http.MethodGet, nil, tc.err)
+
+		var body ErrorBody
+
+		err := json.NewDecoder(w.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("[TEST%d]Failed. Expected error to be nil\tGot %v", i, err)
+		}
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if !reflect.DeepEqual(body.Error, tc.expBody) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expBody, body.Error)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	testcases := []struct {
+		desc    string
+		reqID   string
+		expKept bool
+	}{
+		{"id of the client is kept", "abc-123", true},
+		{"missing id is generated", "", false},
+		{"invalid id is replaced", "bad id\n", false},
+	}
+
+	for i, tc := range testcases {
+		var ctxID string
+
+		h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxID = GetRequestID(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/book", nil)
+		req.Header.Set(RequestIDHeader, tc.reqID)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		id := w.Header().Get(RequestIDHeader)
+		if id == "" || id != ctxID {
+			t.Errorf("[TEST%d]Failed. Expected the same id in the header and context\tGot %q and %q", i, id, ctxID)
+		}
+
+		if (id == tc.reqID) != tc.expKept {
+			t.Errorf("[TEST%d]Failed. Expected id kept %v\tGot %q", i, tc.expKept, id)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package series
+
+import (
+	"ThreeLayer/datastore/memory"
+	"ThreeLayer/delivery"
+	"ThreeLayer/delivery/deliverytest"
+	"ThreeLayer/entities"
+	serviceSeries "ThreeLayer/service/series"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHandler_EndToEnd runs the series requests one after another against the real service logic
+// backed by an in memory datastore
+func TestHandler_EndToEnd(t *testing.T) {
+	db := memory.New()
+	handler := New(serviceSeries.New(memory.NewSeries(db), db))
+
+	ifMatch := delivery.IfMatch(true)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/series", handler.GetSeries).Methods(http.MethodGet)
+	r.HandleFunc("/series", handler.PostSeries).Methods(http.MethodPost)
+	r.HandleFunc("/series/{id}", handler.GetSeriesByID).Methods(http.MethodGet)
+	r.HandleFunc("/series/{id}", ifMatch(handler.PutSeries)).Methods(http.MethodPut)
+	r.HandleFunc("/series/{id}", ifMatch(handler.DeleteSeries)).Methods(http.MethodDelete)
+
+	dune := entities.Series{ID: 1, Name: "Dune"}
+	foundation := entities.Series{ID: 2, Name: "Foundation"}
+
+	testcases := []deliverytest.Request{
+		{Desc: "add series", Method: http.MethodPost, Target: "/series", ReqBody: entities.Series{Name: " Dune "},
+			ExpStatus: http.StatusCreated, ExpRes: dune},
+		{Desc: "add another series", Method: http.MethodPost, Target: "/series",
+			ReqBody: entities.Series{Name: "Foundation"}, ExpStatus: http.StatusCreated, ExpRes: foundation},
+		{Desc: "name is missing", Method: http.MethodPost, Target: "/series", ReqBody: entities.Series{},
+			ExpStatus: http.StatusBadRequest},
+		{Desc: "get series", Method: http.MethodGet, Target: "/series", ExpStatus: http.StatusOK,
+			ExpRes: []entities.Series{dune, foundation}},
+		{Desc: "rename series", Method: http.MethodPut, Target: "/series/1", IfMatch: `"1"`,
+			ReqBody: entities.Series{Name: "Dune Chronicles"}, ExpStatus: http.StatusOK,
+			ExpRes: entities.Series{ID: 1, Name: "Dune Chronicles"}},
+		{Desc: "modified since read", Method: http.MethodPut, Target: "/series/1", IfMatch: `"1"`,
+			ReqBody: entities.Series{Name: "Dune"}, ExpStatus: http.StatusPreconditionFailed},
+		{Desc: "delete series", Method: http.MethodDelete, Target: "/series/2", IfMatch: `"1"`,
+			ExpStatus: http.StatusNoContent},
+		{Desc: "get deleted series", Method: http.MethodGet, Target: "/series/2", ExpStatus: http.StatusNotFound},
+	}
+
+	deliverytest.Run(t, r, testcases)
+}
@@ -0,0 +1,47 @@
+package series
+
+import (
+	"ThreeLayer/delivery"
+	"ThreeLayer/entities"
+	"ThreeLayer/service"
+	"net/http"
+)
+
+type Handler struct {
+	service service.Series
+}
+
+func New(series service.Series) Handler {
+	return Handler{service: series}
+}
+
+// GetSeries function is to perform Handler Requests to get all the series instances from the database
+func (h Handler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := h.service.GetSeries(r.Context())
+	delivery.SetStatusCode(w, r.Method, series, err)
+}
+
+// GetSeriesByID function is to perform Handler Requests to get a series instance using its ID from the database
+func (h Handler) GetSeriesByID(w http.ResponseWriter, r *http.Request) {
+	delivery.GetByID(w, r, h.service.GetSeriesByID, version)
+}
+
+// PostSeries function is to perform Handler Requests to add a new series instance to the database
+func (h Handler) PostSeries(w http.ResponseWriter, r *http.Request) {
+	delivery.Post(w, r, h.service.PostSeries)
+}
+
+// PutSeries function is to perform Handler Requests to replace an existing series instance in the database
+func (h Handler) PutSeries(w http.ResponseWriter, r *http.Request) {
+	delivery.Put(w, r, h.service.PutSeries, version)
+}
+
+// DeleteSeries function is to perform Handler Requests to remove a series instance from the database
+func (h Handler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	delivery.Delete(w, r, h.service.DeleteSeries)
+}
+
+// version returns the version of the series sent as its ETag
+func version(s entities.Series) int {
+	return s.Version
+}
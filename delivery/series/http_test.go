@@ -0,0 +1,117 @@
+package series
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_GetSeriesByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockSeries(ctrl)
+	h := New(mockService)
+
+	testcases := []struct {
+		desc        string
+		id          string
+		ifNoneMatch string
+		res         entities.Series
+		err         error
+		expStatus   int
+		expETag     string
+	}{
+		{desc: "found", id: "1", res: entities.Series{ID: 1, Version: 4}, expStatus: http.StatusOK, expETag: `"4"`},
+		{desc: "not modified", id: "1", ifNoneMatch: `"4"`, res: entities.Series{ID: 1, Version: 4},
+			expStatus: http.StatusNotModified, expETag: `"4"`},
+		{desc: "not found", id: "2", err: errors.EntityNotFound{Entity: "Series", ID: 2},
+			expStatus: http.StatusNotFound},
+		{desc: "database error", id: "3", err: errors.DB{Err: fmt.Errorf("connection refused")},
+			expStatus: http.StatusInternalServerError},
+		{desc: "invalid id", id: "abc", expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		if tc.expStatus != http.StatusBadRequest {
+			mockService.EXPECT().GetSeriesByID(gomock.Any(), gomock.Any()).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/series/"+tc.id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+		if tc.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+
+		w := httptest.NewRecorder()
+
+		h.GetSeriesByID(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
+
+func TestHandler_PutSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockSeries(ctrl)
+	h := New(mockService)
+
+	series := entities.Series{Name: "Dune"}
+
+	testcases := []struct {
+		desc      string
+		body      []byte
+		res       entities.Series
+		err       error
+		expStatus int
+		expETag   string
+	}{
+		{desc: "updated", res: entities.Series{ID: 1, Version: 2}, expStatus: http.StatusOK, expETag: `"2"`},
+		{desc: "modified since read", err: errors.PreconditionFailed{Entity: "Series", ID: 1},
+			expStatus: http.StatusPreconditionFailed},
+		{desc: "name is missing", err: errors.InValidDetails{Details: "Name"}, expStatus: http.StatusBadRequest},
+		{desc: "not found", err: errors.EntityNotFound{Entity: "Series", ID: 1}, expStatus: http.StatusNotFound},
+		{desc: "invalid body", body: []byte(`{"name":`), expStatus: http.StatusBadRequest},
+	}
+
+	for i, tc := range testcases {
+		body := tc.body
+		if body == nil {
+			body, _ = json.Marshal(series)
+
+			mockService.EXPECT().PutSeries(gomock.Any(), 1, series).Return(tc.res, tc.err)
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/series/1", bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+
+		h.PutSeries(w, req)
+
+		if w.Code != tc.expStatus {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expStatus, w.Code)
+		}
+
+		if etag := w.Header().Get("ETag"); etag != tc.expETag {
+			t.Errorf("[TEST%d]Failed. Expected ETag %v\tGot %v", i, tc.expETag, etag)
+		}
+	}
+}
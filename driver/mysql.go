@@ -3,19 +3,26 @@ package driver
 import (
 	"database/sql"
 	"log"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+
+	"ThreeLayer/config"
 )
 
-func ConnectToSQL() (*sql.DB, error) {
-	db, err := sql.Open("mysql",
-		"root:Gurpreet@0848@tcp(localhost:3306)/test")
+// ConnectToSQL opens the MySQL database of the config and sets the size of the connection pool
+func ConnectToSQL(cfg config.Database) (*sql.DB, error) {
+	db, err := sql.Open("mysql", cfg.DSN)
 	if err != nil {
 		log.Println(err)
 
 		return nil, err
 	}
 
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime))
+
 	if err := db.Ping(); err != nil {
 		log.Println(err)
 
@@ -0,0 +1,58 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"ThreeLayer/migrations"
+)
+
+// ConnectToSQLite opens the SQLite database at path and applies the pending migrations,
+// ":memory:" can be used as the path for a throw away database
+func ConnectToSQLite(path string) (*sql.DB, error) {
+	db, err := OpenSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := migrations.New(db, migrations.SQLite)
+	if err != nil {
+		log.Println(err)
+
+		return nil, err
+	}
+
+	if _, err = migrator.Up(context.Background()); err != nil {
+		log.Println(err)
+
+		return nil, err
+	}
+
+	log.Println("Connected")
+
+	return db, nil
+}
+
+// OpenSQLite opens the SQLite database at path without changing its schema
+func OpenSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on")
+	if err != nil {
+		log.Println(err)
+
+		return nil, err
+	}
+
+	// sqlite allows a single writer, and every connection to ":memory:" is a separate database
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		log.Println(err)
+
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -4,6 +4,14 @@ type Author struct {
 	ID        int    `json:"id,omitempty"`
 	FirstName string `json:"first_name,omitempty"`
 	LastName  string `json:"last_name,omitempty"`
-	Dob       string `json:"dob,omitempty"`
+	Dob       Date   `json:"dob,omitempty"`
 	PenName   string `json:"pen_name,omitempty"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// AuthorDetails is an author along with the books written by the author, books are set only when requested
+type AuthorDetails struct {
+	Author
+	Books []Book `json:"books,omitempty"`
 }
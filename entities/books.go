@@ -1,11 +1,108 @@
 package entities
 
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
 type Book struct {
-	ID            int    `json:"id"`
-	Title         string `json:"title"`
-	Author        Author `json:"author,omitempty"`
-	Publication   string `json:"publication"`
-	PublishedDate string `json:"published_date"`
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	// Author is the first of the contributors, a book sent without contributors is credited to the author alone
+	Author        Author        `json:"author,omitempty"`
+	Contributors  []Contributor `json:"contributors,omitempty"`
+	Publisher     Publisher     `json:"publisher,omitempty"`
+	PublishedDate Date          `json:"published_date"`
+	// ISBN is the ISBN-13 of the book without hyphens, an ISBN-10 is converted when saved. It is optional and unique
+	ISBN string `json:"isbn,omitempty"`
+	// ISBN10 is the ISBN-10 of the book, it is not stored and is written from the ISBN when the ISBN-13 has the 978
+	// prefix. A book can be sent with the ISBN-10 in place of the ISBN
+	ISBN10 string `json:"isbn_10,omitempty"`
+	// Genres are the genres and subjects the book is tagged with, only their ids are needed to save the book
+	Genres []Genre `json:"genres,omitempty"`
+	// Series is the series the book is a volume of, if any
+	Series *SeriesVolume `json:"series,omitempty"`
+	// Edition links the book to the original of the work it is an edition or a translation of, the original has none
+	Edition *Edition `json:"edition,omitempty"`
+	// Availability is set only in the responses of the book service, it is not stored with the book
+	Availability *Availability `json:"availability,omitempty"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// MarshalJSON writes the book with the ISBN-10 of its ISBN when it has no ISBN-10 of its own
+func (b Book) MarshalJSON() ([]byte, error) {
+	// book has the fields of Book without its methods, so that it is written the default way
+	type book Book
+
+	out := book(b)
+	if out.ISBN10 == "" {
+		out.ISBN10 = ToISBN10(b.ISBN)
+	}
+
+	return json.Marshal(out)
+}
+
+// ToISBN10 returns the ISBN-10 of an ISBN-13 with the 978 prefix, it is empty for the other ISBN-13 which have none
+func ToISBN10(isbn13 string) string {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+
+	// the nine digits after the prefix are weighted 10 down to 2, the check digit 10 is written as X
+	sum := 0
+
+	for i := 3; i < 12; i++ {
+		if isbn13[i] < '0' || isbn13[i] > '9' {
+			return ""
+		}
+
+		sum += (13 - i) * int(isbn13[i]-'0')
+	}
+
+	if check := (11 - sum%11) % 11; check != 10 {
+		return isbn13[3:12] + strconv.Itoa(check)
+	}
+
+	return isbn13[3:12] + "X"
+}
+
+// Credits returns the contributors of the book, a book without contributors is credited to its author alone
+func (b Book) Credits() []Contributor {
+	if len(b.Contributors) == 0 && b.Author.ID != 0 {
+		return []Contributor{{Author: b.Author, Role: RoleAuthor}}
+	}
+
+	return b.Contributors
+}
+
+// Roles in which an author contributes to a book
+const (
+	RoleAuthor      = "author"
+	RoleEditor      = "editor"
+	RoleTranslator  = "translator"
+	RoleIllustrator = "illustrator"
+)
+
+// Contributor is an author credited on a book in a role, the contributors of a book are in the order of the credits
+type Contributor struct {
+	Author Author `json:"author"`
+	Role   string `json:"role"`
+}
+
+// Details of the books compared by the duplicate detection
+const (
+	DuplicateByTitle         = "title"
+	DuplicateByAuthor        = "author"
+	DuplicateByPublisher     = "publisher"
+	DuplicateByPublishedDate = "published_date"
+)
+
+// TitleKey returns the title in lower case with single spaces between the words, titles differing only in case and
+// spacing have the same key and the stores keep the key of every book to look up its duplicates
+func TitleKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
 }
 
 type ContextKey string
@@ -13,6 +110,50 @@ type ContextKey string
 const (
 	Title         ContextKey = "title"
 	IncludeAuthor ContextKey = "includeAuthor"
+	IncludeBooks  ContextKey = "includeBooks"
 	Id            ContextKey = "id"
 	FirstName     ContextKey = "FirstName"
+	// IfMatch holds the versions of the If-Match header as []int, there is no check when it is not set
+	IfMatch ContextKey = "ifMatch"
+)
+
+const (
+	SortByID            = "id"
+	SortByTitle         = "title"
+	SortByPublishedDate = "published_date"
+	// SortByVolume sorts the books by their volume numbers, the books not in a series come first
+	SortByVolume = "volume"
 )
+
+// BookFilter is the criteria for listing books, fields left empty are not used for filtering.
+// PublishedFrom and PublishedTo are inclusive dates in yyyy-mm-dd format. A book is listed for a GenreID when it is
+// tagged with the genre or any of its descendants, the ids of which the service sets in GenreIDs for the stores.
+// The facets of all the matching books are counted when Facets is set. SeriesID lists the volumes of a series and
+// WorkID the original book of a work along with all its editions. All the matching books are listed when Limit is 0
+type BookFilter struct {
+	Title         string
+	PublisherID   int
+	AuthorID      int
+	GenreID       int
+	GenreIDs      []int
+	SeriesID      int
+	WorkID        int
+	PublishedFrom string
+	PublishedTo   string
+	Sort          string
+	Desc          bool
+	Limit         int
+	Offset        int
+	Facets        bool
+}
+
+// BookPage is a single page of the books matching a BookFilter, Total is the count of all the matching books.
+// Limit and Offset are the page size and position used after applying the defaults. Facets is set only when
+// the filter asks for them
+type BookPage struct {
+	Books  []Book
+	Total  int
+	Limit  int
+	Offset int
+	Facets *BookFacets
+}
@@ -0,0 +1,59 @@
+package entities
+
+// Statuses of a Copy, only an available copy can be lent. A copy on hold is kept for the member
+// of the ready hold it is assigned to
+const (
+	CopyAvailable = "available"
+	CopyOnLoan    = "on_loan"
+	CopyOnHold    = "on_hold"
+	CopyLost      = "lost"
+	CopyWithdrawn = "withdrawn"
+)
+
+// Conditions of a Copy
+const (
+	ConditionNew     = "new"
+	ConditionGood    = "good"
+	ConditionFair    = "fair"
+	ConditionPoor    = "poor"
+	ConditionDamaged = "damaged"
+)
+
+// Copy is a physical copy of a book held by the library
+type Copy struct {
+	ID            int    `json:"id,omitempty"`
+	BookID        int    `json:"book_id,omitempty"`
+	Barcode       string `json:"barcode,omitempty"`
+	Condition     string `json:"condition,omitempty"`
+	ShelfLocation string `json:"shelf_location,omitempty"`
+	AcquiredOn    Date   `json:"acquired_on"`
+	Status        string `json:"status,omitempty"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// Availability counts the copies of a book, lost and withdrawn copies are not part of the total
+type Availability struct {
+	Total     int `json:"total"`
+	Available int `json:"available"`
+	OnLoan    int `json:"on_loan"`
+	OnHold    int `json:"on_hold"`
+}
+
+// Add returns the availability with count more copies having the status
+func (a Availability) Add(status string, count int) Availability {
+	switch status {
+	case CopyAvailable:
+		a.Available += count
+	case CopyOnLoan:
+		a.OnLoan += count
+	case CopyOnHold:
+		a.OnHold += count
+	default:
+		return a
+	}
+
+	a.Total += count
+
+	return a
+}
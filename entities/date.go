@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// DateLayout is the ISO 8601 layout in which the dates are sent and stored
+	DateLayout = "2006-01-02"
+	// LegacyDateLayout is the dd/mm/yyyy layout which is still accepted, day and month may have a single digit
+	LegacyDateLayout = "2/1/2006"
+)
+
+// Date is a calendar day without a time of day, the zero Date is no date and is sent as null
+type Date time.Time
+
+// NewDate returns the date of the day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// ParseDate parses an ISO 8601 date, with or without a time of day, or a legacy dd/mm/yyyy date.
+// An empty string is the zero Date
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+
+	for _, layout := range []string{DateLayout, time.RFC3339, LegacyDateLayout} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return NewDate(t.Date()), nil
+		}
+	}
+
+	return Date{}, fmt.Errorf("invalid date %q, expected yyyy-mm-dd or dd/mm/yyyy", s)
+}
+
+// Today returns the current date
+func Today() Date {
+	return NewDate(time.Now().Date())
+}
+
+// IsZero tells whether the date is not set
+func (d Date) IsZero() bool {
+	return time.Time(d).IsZero()
+}
+
+// Before tells whether the date is a day before other
+func (d Date) Before(other Date) bool {
+	return time.Time(d).Before(time.Time(other))
+}
+
+// After tells whether the date is a day after other
+func (d Date) After(other Date) bool {
+	return time.Time(d).After(time.Time(other))
+}
+
+// AddDays returns the date days later, days can be negative
+func (d Date) AddDays(days int) Date {
+	return Date(time.Time(d).AddDate(0, 0, days))
+}
+
+// DaysSince returns the number of days from other to the date, it is negative when other is later
+func (d Date) DaysSince(other Date) int {
+	return int(time.Time(d).Sub(time.Time(other)).Hours() / 24)
+}
+
+// Year returns the year of the date
+func (d Date) Year() int {
+	return time.Time(d).Year()
+}
+
+// String returns the date as yyyy-mm-dd, it is empty for the zero Date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return time.Time(d).Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
+
+// Scan reads a DATE column, which the drivers return as a time or as yyyy-mm-dd text, NULL is the zero Date
+func (d *Date) Scan(src interface{}) error {
+	var err error
+
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = NewDate(v.Date())
+	case []byte:
+		*d, err = ParseDate(string(v))
+	case string:
+		*d, err = ParseDate(v)
+	default:
+		err = fmt.Errorf("can not scan %T into a date", src)
+	}
+
+	return err
+}
+
+// Value writes the date as yyyy-mm-dd, the zero Date is written as NULL
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+
+	return d.String(), nil
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	testcases := []struct {
+		desc    string
+		input   string
+		expDate Date
+		expErr  bool
+	}{
+		{desc: "iso 8601", input: "2000-07-22", expDate: NewDate(2000, 7, 22)},
+		{desc: "iso 8601 with time", input: "2000-07-22T10:30:00+05:30", expDate: NewDate(2000, 7, 22)},
+		{desc: "legacy", input: "22/07/2000", expDate: NewDate(2000, 7, 22)},
+		{desc: "legacy with single digits", input: "2/7/2000", expDate: NewDate(2000, 7, 2)},
+		{desc: "empty", input: ""},
+		{desc: "year only", input: "2020", expErr: true},
+		{desc: "day out of range", input: "31/02/2000", expErr: true},
+		{desc: "month first", input: "07/22/2000", expErr: true},
+	}
+
+	for i, tc := range testcases {
+		date, err := ParseDate(tc.input)
+		if (err != nil) != tc.expErr {
+			t.Errorf("[TEST%d]Failed. %s Expected error %v Got %v", i, tc.desc, tc.expErr, err)
+		}
+
+		if date != tc.expDate {
+			t.Errorf("[TEST%d]Failed. %s Expected %v Got %v", i, tc.desc, tc.expDate, date)
+		}
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	var book Book
+
+	if err := json.Unmarshal([]byte(`{"published_date":"22/07/2000","author":{"dob":null}}`), &book); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	if book.PublishedDate != NewDate(2000, 7, 22) || !book.Author.Dob.IsZero() {
+		t.Errorf("Failed. Got %v, %v", book.PublishedDate, book.Author.Dob)
+	}
+
+	b, _ := json.Marshal(Author{Dob: NewDate(1999, 12, 2)})
+	if string(b) != `{"dob":"1999-12-02"}` {
+		t.Errorf("Failed. Expected the date as yyyy-mm-dd Got %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"published_date":"2020"}`), &book); err == nil {
+		t.Errorf("Failed. Expected an error for an invalid date")
+	}
+}
+
+func TestDate_Scan(t *testing.T) {
+	testcases := []struct {
+		src     interface{}
+		expDate Date
+		expErr  bool
+	}{
+		{src: time.Date(2000, 7, 22, 0, 0, 0, 0, time.Local), expDate: NewDate(2000, 7, 22)},
+		{src: []byte("2000-07-22"), expDate: NewDate(2000, 7, 22)},
+		{src: "2000-07-22", expDate: NewDate(2000, 7, 22)},
+		{src: nil},
+		{src: 20000722, expErr: true},
+	}
+
+	for i, tc := range testcases {
+		var date Date
+
+		err := date.Scan(tc.src)
+		if (err != nil) != tc.expErr || date != tc.expDate {
+			t.Errorf("[TEST%d]Failed. Expected %v, %v Got %v, %v", i, tc.expDate, tc.expErr, date, err)
+		}
+
+		if v, _ := date.Value(); !tc.expErr && tc.src != nil && v != "2000-07-22" {
+			t.Errorf("[TEST%d]Failed. Expected the date to be written as yyyy-mm-dd Got %v", i, v)
+		}
+	}
+}
+
+func TestDate_Days(t *testing.T) {
+	due := NewDate(2000, 2, 27)
+
+	if renewed := due.AddDays(3); renewed != NewDate(2000, 3, 1) || !renewed.After(due) || renewed.Before(due) {
+		t.Errorf("Failed. Expected the date to be 2000-03-01 Got %v", renewed)
+	}
+
+	if days := NewDate(2000, 3, 1).DaysSince(due); days != 3 {
+		t.Errorf("Failed. Expected 3 days Got %d", days)
+	}
+
+	if days := due.DaysSince(NewDate(2000, 3, 1)); days != -3 {
+		t.Errorf("Failed. Expected -3 days Got %d", days)
+	}
+}
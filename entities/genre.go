@@ -0,0 +1,62 @@
+package entities
+
+// Kinds of the terms of the taxonomy
+const (
+	KindGenre   = "genre"
+	KindSubject = "subject"
+)
+
+// Genre is a genre or a subject of the taxonomy which books are tagged with. A genre can have a parent of the same kind,
+// such as Fiction for Mystery, and the name of a genre is unique among the genres of its kind
+type Genre struct {
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	ParentID int    `json:"parent_id,omitempty"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// Facet is the count of the books having a genre or a publisher, a genre counts the books tagged with it
+// or with any of its descendants
+type Facet struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+// DecadeFacet is the count of the books published in the decade starting in the year Decade
+type DecadeFacet struct {
+	Decade int `json:"decade"`
+	Count  int `json:"count"`
+}
+
+// BookFacets are the counts of all the books matching a BookFilter by genre, publisher and decade of publication
+type BookFacets struct {
+	Genres     []Facet       `json:"genres"`
+	Publishers []Facet       `json:"publishers"`
+	Decades    []DecadeFacet `json:"decades"`
+}
+
+// Descendants returns the id of the genre with given id followed by the ids of all its descendants among the genres,
+// a genre met twice is not followed again
+func Descendants(genres []Genre, id int) []int {
+	children := make(map[int][]int)
+	for i := range genres {
+		children[genres[i].ParentID] = append(children[genres[i].ParentID], genres[i].ID)
+	}
+
+	ids := []int{id}
+	seen := map[int]bool{id: true}
+
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,31 @@
+package entities
+
+// Statuses of a Hold, a waiting hold is in the queue of the book until a copy is assigned to it
+// and the hold is ready to be picked up
+const (
+	HoldWaiting   = "waiting"
+	HoldReady     = "ready"
+	HoldFulfilled = "fulfilled"
+	HoldCancelled = "cancelled"
+	HoldExpired   = "expired"
+)
+
+// Hold is the reservation of a book by a member, the holds of a book are served first in first out.
+// CopyID is the copy assigned to a ready hold, ReadyOn and ExpiresOn are set once the hold is ready
+type Hold struct {
+	ID        int    `json:"id,omitempty"`
+	BookID    int    `json:"book_id,omitempty"`
+	MemberID  int    `json:"member_id,omitempty"`
+	CopyID    int    `json:"copy_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	PlacedOn  Date   `json:"placed_on"`
+	ReadyOn   Date   `json:"ready_on"`
+	ExpiresOn Date   `json:"expires_on"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// Queued tells whether the hold is still in the queue of the book, waiting for a copy or ready to be picked up
+func (h Hold) Queued() bool {
+	return h.Status == HoldWaiting || h.Status == HoldReady
+}
@@ -0,0 +1,38 @@
+package entities
+
+// Types of a LedgerEntry, fines are charged for the overdue loans and are paid or waived by the staff
+const (
+	LedgerFine    = "fine"
+	LedgerPayment = "payment"
+	LedgerWaiver  = "waiver"
+)
+
+// LedgerEntry is a line of the account of a member, Amount is in cents and is never negative.
+// LoanID is the loan a fine was charged for
+type LedgerEntry struct {
+	ID        int    `json:"id,omitempty"`
+	MemberID  int    `json:"member_id,omitempty"`
+	LoanID    int    `json:"loan_id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Amount    int    `json:"amount"`
+	Note      string `json:"note,omitempty"`
+	CreatedOn Date   `json:"created_on"`
+}
+
+// Signed returns the amount the entry adds to the balance, payments and waivers lower it
+func (e LedgerEntry) Signed() int {
+	if e.Type == LedgerFine {
+		return e.Amount
+	}
+
+	return -e.Amount
+}
+
+// Account is the ledger of a member, Balance is owed for the entries and Accruing is the fines of
+// the overdue loans which are not returned yet, both in cents
+type Account struct {
+	MemberID int           `json:"member_id"`
+	Balance  int           `json:"balance"`
+	Accruing int           `json:"accruing"`
+	Entries  []LedgerEntry `json:"entries"`
+}
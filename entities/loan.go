@@ -0,0 +1,20 @@
+package entities
+
+// Loan is the lending of a copy to a member, ReturnedOn is the zero Date while the loan is active
+type Loan struct {
+	ID           int  `json:"id,omitempty"`
+	CopyID       int  `json:"copy_id,omitempty"`
+	MemberID     int  `json:"member_id,omitempty"`
+	BookID       int  `json:"book_id,omitempty"`
+	CheckedOutOn Date `json:"checked_out_on"`
+	DueOn        Date `json:"due_on"`
+	ReturnedOn   Date `json:"returned_on"`
+	Renewals     int  `json:"renewals"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// Active tells whether the copy of the loan has not been returned yet
+func (l Loan) Active() bool {
+	return l.ReturnedOn.IsZero()
+}
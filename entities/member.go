@@ -0,0 +1,30 @@
+package entities
+
+// Membership types of a Member
+const (
+	MembershipStandard = "standard"
+	MembershipStudent  = "student"
+	MembershipPremium  = "premium"
+)
+
+// Statuses of a Member, only an active member can borrow books
+const (
+	MemberActive    = "active"
+	MemberSuspended = "suspended"
+	MemberCancelled = "cancelled"
+)
+
+// Member is a patron of the library, ExpiresOn is the last day of the membership
+type Member struct {
+	ID             int    `json:"id,omitempty"`
+	FirstName      string `json:"first_name,omitempty"`
+	LastName       string `json:"last_name,omitempty"`
+	Email          string `json:"email,omitempty"`
+	Phone          string `json:"phone,omitempty"`
+	Address        string `json:"address,omitempty"`
+	MembershipType string `json:"membership_type,omitempty"`
+	ExpiresOn      Date   `json:"expires_on"`
+	Status         string `json:"status,omitempty"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
@@ -0,0 +1,33 @@
+package entities
+
+// Media types of the patch documents accepted for partial updates
+const (
+	MergePatch = "application/merge-patch+json"
+	JSONPatch  = "application/json-patch+json"
+)
+
+// Patch is a JSON Merge Patch (RFC 7386) or JSON Patch (RFC 6902) document, Type is the media type of Doc
+type Patch struct {
+	Type string
+	Doc  []byte
+}
+
+// Fields of a book which are updated on their own, the names are the json names of the fields
+const (
+	BookTitle         = "title"
+	BookPublisher     = "publisher"
+	BookPublishedDate = "published_date"
+	BookContributors  = "contributors"
+	BookISBN          = "isbn"
+	BookGenres        = "genres"
+	BookSeries        = "series"
+	BookEdition       = "edition"
+)
+
+// Fields of an author which are updated on their own, the names are the json names of the fields
+const (
+	AuthorFirstName = "first_name"
+	AuthorLastName  = "last_name"
+	AuthorDob       = "dob"
+	AuthorPenName   = "pen_name"
+)
@@ -0,0 +1,10 @@
+package entities
+
+// Publisher is a publishing house of the books, the name of a publisher is unique
+type Publisher struct {
+	ID      int    `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Website string `json:"website,omitempty"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
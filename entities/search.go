@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchQuery is a search of the catalog, Text is the query as sent and Terms are its words. A term matches the words
+// of the titles, of the names and pen names of the contributors and of the names of the publishers starting with it
+type SearchQuery struct {
+	Text   string
+	Terms  []string
+	Limit  int
+	Offset int
+}
+
+// SearchResult is a book matching a SearchQuery, a book with a higher Score is a better match
+type SearchResult struct {
+	Book  Book    `json:"book"`
+	Score float64 `json:"score"`
+}
+
+// SearchPage is a single page of the results ranked by score, Total is the count of all the matching books.
+// Limit and Offset are the page size and position used after applying the defaults.
+type SearchPage struct {
+	Results []SearchResult
+	Total   int
+	Limit   int
+	Offset  int
+}
+
+// Words returns the words of s in lower case, the words are split at anything other than a letter or a digit
+func Words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
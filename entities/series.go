@@ -0,0 +1,31 @@
+package entities
+
+// Series is a series of books such as a trilogy, the books of a series are its volumes
+type Series struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Version is incremented on every update, it is sent as the ETag header instead of in the body
+	Version int `json:"-"`
+}
+
+// SeriesVolume places a book in a series, Volume is the number of the book in the series starting at 1 and is unique
+// within the series. Only the id of the series is needed to save the book
+type SeriesVolume struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Volume int    `json:"volume"`
+}
+
+// Kinds of the editions of a work
+const (
+	KindEdition     = "edition"
+	KindTranslation = "translation"
+)
+
+// Edition links a book to the original book of the work it is an edition or a translation of, WorkID is the id of the
+// original which has no Edition of its own. Language is the language of a translation
+type Edition struct {
+	WorkID   int    `json:"work_id"`
+	Kind     string `json:"kind"`
+	Language string `json:"language,omitempty"`
+}
@@ -0,0 +1,15 @@
+package errors
+
+import "fmt"
+
+// Conflict is returned when an action can not be done in the current state of the entity,
+// Reason completes the message such as "is not available"
+type Conflict struct {
+	Entity string
+	ID     int
+	Reason string
+}
+
+func (e Conflict) Error() string {
+	return fmt.Sprintf("entity %s with id %d %s", e.Entity, e.ID, e.Reason)
+}
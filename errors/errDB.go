@@ -7,3 +7,8 @@ type DB struct {
 func (e DB) Error() string {
 	return e.Err.Error()
 }
+
+// Unwrap returns the error of the driver, so that the callers can tell which error the database returned
+func (e DB) Unwrap() error {
+	return e.Err
+}
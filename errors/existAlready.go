@@ -2,10 +2,22 @@ package errors
 
 import "fmt"
 
+// ExistAlready is returned when the entity is a duplicate, ID is the id of the stored entity when it is known and
+// Field is the field it shares with the stored entity when a unique index of the database rejected it
 type ExistAlready struct {
 	Entity string
+	ID     int
+	Field  string
 }
 
 func (e ExistAlready) Error() string {
+	if e.ID != 0 {
+		return fmt.Sprintf("entity %v with id %d already exists", e.Entity, e.ID)
+	}
+
+	if e.Field != "" {
+		return fmt.Sprintf("entity %v with the same %v already exists", e.Entity, e.Field)
+	}
+
 	return fmt.Sprintf("entity  %v already exists", e.Entity)
 }
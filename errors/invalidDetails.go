@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"strings"
 )
 
 type InValidDetails struct {
@@ -11,3 +12,33 @@ type InValidDetails struct {
 func (e InValidDetails) Error() string {
 	return fmt.Sprintf("detail %s is invalid", e.Details)
 }
+
+// InValidFields is returned when more than one detail is invalid, so that all of them are reported at once
+type InValidFields []InValidDetails
+
+func (e InValidFields) Error() string {
+	details := make([]string, 0, len(e))
+	for i := range e {
+		details = append(details, e[i].Details)
+	}
+
+	return fmt.Sprintf("details %s are invalid", strings.Join(details, ", "))
+}
+
+// InValid returns the error for the invalid details, it is nil when there are none,
+// InValidDetails for a single detail and InValidFields otherwise
+func InValid(details ...string) error {
+	switch len(details) {
+	case 0:
+		return nil
+	case 1:
+		return InValidDetails{Details: details[0]}
+	}
+
+	e := make(InValidFields, 0, len(details))
+	for _, d := range details {
+		e = append(e, InValidDetails{Details: d})
+	}
+
+	return e
+}
@@ -0,0 +1,20 @@
+package errors
+
+import "fmt"
+
+// PreconditionFailed is returned when the version sent in If-Match is not the stored version of the entity
+type PreconditionFailed struct {
+	Entity string
+	ID     int
+}
+
+func (e PreconditionFailed) Error() string {
+	return fmt.Sprintf("entity %s with id %d has been modified", e.Entity, e.ID)
+}
+
+// PreconditionRequired is returned when an update is sent without the If-Match header
+type PreconditionRequired struct{}
+
+func (e PreconditionRequired) Error() string {
+	return "the If-Match header is required"
+}
@@ -0,0 +1,15 @@
+package errors
+
+// Unavailable is returned when the database keeps aborting a unit of work because of concurrent ones, such as on
+// a deadlock, the same request can be sent again later
+type Unavailable struct {
+	Err error
+}
+
+func (e Unavailable) Error() string {
+	return "the database is busy: " + e.Err.Error()
+}
+
+func (e Unavailable) Unwrap() error {
+	return e.Err
+}
@@ -1,59 +1,279 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"ThreeLayer/config"
+	"ThreeLayer/datastore"
+	"ThreeLayer/delivery"
 	"ThreeLayer/driver"
 
 	datastoreAuthor "ThreeLayer/datastore/author"
 	datastoreBook "ThreeLayer/datastore/books"
+	datastoreCopy "ThreeLayer/datastore/copies"
+	datastoreGenre "ThreeLayer/datastore/genre"
+	datastoreHold "ThreeLayer/datastore/holds"
+	datastoreLedger "ThreeLayer/datastore/ledger"
+	datastoreLoan "ThreeLayer/datastore/loans"
+	datastoreMember "ThreeLayer/datastore/member"
+	"ThreeLayer/datastore/memory"
+	datastorePublisher "ThreeLayer/datastore/publisher"
+	datastoreSeries "ThreeLayer/datastore/series"
 	handlerAuthor "ThreeLayer/delivery/author"
 	handlerBook "ThreeLayer/delivery/books"
+	handlerCopy "ThreeLayer/delivery/copies"
+	handlerFines "ThreeLayer/delivery/fines"
+	handlerGenre "ThreeLayer/delivery/genre"
+	handlerHold "ThreeLayer/delivery/holds"
+	handlerLoan "ThreeLayer/delivery/loans"
+	handlerMember "ThreeLayer/delivery/member"
+	handlerPublisher "ThreeLayer/delivery/publisher"
+	handlerSeries "ThreeLayer/delivery/series"
 	serviceAuthor "ThreeLayer/service/author"
 	serviceBook "ThreeLayer/service/books"
+	serviceCopy "ThreeLayer/service/copies"
+	serviceFines "ThreeLayer/service/fines"
+	serviceGenre "ThreeLayer/service/genre"
+	serviceLoan "ThreeLayer/service/loans"
+	serviceMember "ThreeLayer/service/member"
+	servicePublisher "ThreeLayer/service/publisher"
+	serviceSeries "ThreeLayer/service/series"
 )
 
 func main() {
 
 	var err error
 
-	db, err := driver.ConnectToSQL()
+	// CONFIG_FILE is the optional JSON config file, environment variables override it
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
 	if err != nil {
-		log.Println("could not connect to sql, Connection Fail, err:", err)
+		log.Println("invalid configuration, err:", err)
+		os.Exit(1)
+	}
+
+	log.Println("configuration:", cfg)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = migrate(cfg.Database, os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+
 		return
 	}
-	bookStore := datastoreBook.New(db)
-	authorStore := datastoreAuthor.New(db)
 
-	svcBook := serviceBook.New(bookStore, authorStore)
-	svcAuthor := serviceAuthor.New(authorStore, bookStore)
+	var (
+		bookStore      datastore.Book
+		authorStore    datastore.Author
+		publisherStore datastore.Publisher
+		genreStore     datastore.Genre
+		seriesStore    datastore.Series
+		memberStore    datastore.Member
+		copyStore      datastore.Copy
+		loanStore      datastore.Loan
+		holdStore      datastore.Hold
+		ledgerStore    datastore.Ledger
+		tx             datastore.Transactor
+	)
+
+	switch cfg.Database.Driver {
+	case config.DriverSQLite:
+		db, err := driver.ConnectToSQLite(cfg.Database.SQLitePath)
+		if err != nil {
+			log.Println("could not connect to sqlite, Connection Fail, err:", err)
+			return
+		}
+		bookStore = datastoreBook.NewSQLite(db)
+		authorStore = datastoreAuthor.NewSQLite(db)
+		publisherStore = datastorePublisher.NewSQLite(db)
+		genreStore = datastoreGenre.NewSQLite(db)
+		seriesStore = datastoreSeries.NewSQLite(db)
+		memberStore = datastoreMember.NewSQLite(db)
+		copyStore = datastoreCopy.NewSQLite(db)
+		loanStore = datastoreLoan.NewSQLite(db)
+		holdStore = datastoreHold.NewSQLite(db)
+		ledgerStore = datastoreLedger.NewSQLite(db)
+		tx = datastore.NewSQLTransactor(db)
+	case config.DriverMemory:
+		db := memory.New()
+		bookStore = memory.NewBook(db)
+		authorStore = memory.NewAuthor(db)
+		publisherStore = memory.NewPublisher(db)
+		genreStore = memory.NewGenre(db)
+		seriesStore = memory.NewSeries(db)
+		memberStore = memory.NewMember(db)
+		copyStore = memory.NewCopy(db)
+		loanStore = memory.NewLoan(db)
+		holdStore = memory.NewHold(db)
+		ledgerStore = memory.NewLedger(db)
+		tx = db
+	default:
+		db, err := driver.ConnectToSQL(cfg.Database)
+		if err != nil {
+			log.Println("could not connect to sql, Connection Fail, err:", err)
+			return
+		}
+		bookStore = datastoreBook.New(db)
+		authorStore = datastoreAuthor.New(db)
+		publisherStore = datastorePublisher.New(db)
+		genreStore = datastoreGenre.New(db)
+		seriesStore = datastoreSeries.New(db)
+		memberStore = datastoreMember.New(db)
+		copyStore = datastoreCopy.New(db)
+		loanStore = datastoreLoan.New(db)
+		holdStore = datastoreHold.New(db)
+		ledgerStore = datastoreLedger.New(db)
+		tx = datastore.NewSQLTransactor(db)
+	}
+
+	svcBook := serviceBook.New(bookStore, authorStore, publisherStore, genreStore, seriesStore, copyStore, loanStore, tx,
+		serviceBook.Policy{DuplicateKeys: cfg.Books.DuplicateKeys})
+	svcAuthor := serviceAuthor.New(authorStore, bookStore, loanStore, tx)
+	svcPublisher := servicePublisher.New(publisherStore, bookStore, tx)
+	svcGenre := serviceGenre.New(genreStore, tx)
+	svcSeries := serviceSeries.New(seriesStore, tx)
+	svcMember := serviceMember.New(memberStore, loanStore, holdStore, ledgerStore, tx)
+	finesPolicy := serviceFines.Policy{DailyRates: cfg.Fines.DailyRates, Caps: cfg.Fines.Caps,
+		BlockAbove: cfg.Fines.BlockAbove}
+	loansPolicy := serviceLoan.Policy{Days: cfg.Loans.Days, MaxRenewals: cfg.Loans.MaxRenewals,
+		PickupDays: cfg.Loans.HoldPickupDays, Fines: finesPolicy}
+	svcCopy := serviceCopy.New(copyStore, bookStore, loanStore, holdStore, tx, loansPolicy)
+	svcLoan := serviceLoan.New(loanStore, holdStore, copyStore, memberStore, bookStore, ledgerStore, tx, loansPolicy)
+	svcFines := serviceFines.New(ledgerStore, memberStore, loanStore, tx, finesPolicy)
 
 	book := handlerBook.New(svcBook)
 	author := handlerAuthor.New(svcAuthor)
+	publisher := handlerPublisher.New(svcPublisher)
+	genre := handlerGenre.New(svcGenre)
+	series := handlerSeries.New(svcSeries)
+	member := handlerMember.New(svcMember)
+	bookCopy := handlerCopy.New(svcCopy)
+	loan := handlerLoan.New(svcLoan)
+	hold := handlerHold.New(svcLoan)
+	account := handlerFines.New(svcFines)
+
+	go expireHolds(svcLoan)
+
+	// the changes are made only to the version of the entity sent in If-Match
+	ifMatch := delivery.IfMatch(cfg.Server.RequireIfMatch)
 
 	r := mux.NewRouter()
 	r.HandleFunc("/book", book.GetBook).Methods(http.MethodGet)
 	r.HandleFunc("/book", book.PostBook).Methods(http.MethodPost)
 	r.HandleFunc("/book/{id}", book.GetBookByID).Methods(http.MethodGet)
-	r.HandleFunc("/book/{id}", book.PutBook).Methods(http.MethodPut)
-	r.HandleFunc("/book/{id}", book.DeleteBook).Methods(http.MethodDelete)
+	r.HandleFunc("/book/isbn/{isbn}", book.GetBookByISBN).Methods(http.MethodGet)
+	r.HandleFunc("/search", book.SearchBooks).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}", ifMatch(book.PutBook)).Methods(http.MethodPut)
+	r.HandleFunc("/book/{id}", ifMatch(book.PatchBook)).Methods(http.MethodPatch)
+	r.HandleFunc("/book/{id}", ifMatch(book.DeleteBook)).Methods(http.MethodDelete)
+	r.HandleFunc("/book/{id}/editions", book.GetEditions).Methods(http.MethodGet)
 
+	r.HandleFunc("/book/{id}/copies", bookCopy.GetCopies).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}/copies", bookCopy.PostCopy).Methods(http.MethodPost)
+	r.HandleFunc("/copy/{id}", bookCopy.GetCopyByID).Methods(http.MethodGet)
+	r.HandleFunc("/copy/{id}", ifMatch(bookCopy.PutCopy)).Methods(http.MethodPut)
+	r.HandleFunc("/copy/{id}", ifMatch(bookCopy.DeleteCopy)).Methods(http.MethodDelete)
+	r.HandleFunc("/book/{id}/loans", loan.GetBookLoans).Methods(http.MethodGet)
+	r.HandleFunc("/book/{id}/holds", hold.GetBookHolds).Methods(http.MethodGet)
+
+	r.HandleFunc("/author", author.GetAuthor).Methods(http.MethodGet)
 	r.HandleFunc("/author", author.PostAuthor).Methods(http.MethodPost)
-	r.HandleFunc("/author/{id}", author.PutAuthor).Methods(http.MethodPut)
-	r.HandleFunc("/author/{id}", author.DeleteAuthor).Methods(http.MethodDelete)
+	r.HandleFunc("/author/{id}", author.GetAuthorByID).Methods(http.MethodGet)
+	r.HandleFunc("/author/{id}", ifMatch(author.PutAuthor)).Methods(http.MethodPut)
+	r.HandleFunc("/author/{id}", ifMatch(author.PatchAuthor)).Methods(http.MethodPatch)
+	r.HandleFunc("/author/{id}", ifMatch(author.DeleteAuthor)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/publisher", publisher.GetPublishers).Methods(http.MethodGet)
+	r.HandleFunc("/publisher", publisher.PostPublisher).Methods(http.MethodPost)
+	r.HandleFunc("/publisher/{id}", publisher.GetPublisherByID).Methods(http.MethodGet)
+	r.HandleFunc("/publisher/{id}", ifMatch(publisher.PutPublisher)).Methods(http.MethodPut)
+	r.HandleFunc("/publisher/{id}", ifMatch(publisher.DeletePublisher)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/genre", genre.GetGenres).Methods(http.MethodGet)
+	r.HandleFunc("/genre", genre.PostGenre).Methods(http.MethodPost)
+	r.HandleFunc("/genre/{id}", genre.GetGenreByID).Methods(http.MethodGet)
+	r.HandleFunc("/genre/{id}", ifMatch(genre.PutGenre)).Methods(http.MethodPut)
+	r.HandleFunc("/genre/{id}", ifMatch(genre.DeleteGenre)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/series", series.GetSeries).Methods(http.MethodGet)
+	r.HandleFunc("/series", series.PostSeries).Methods(http.MethodPost)
+	r.HandleFunc("/series/{id}", series.GetSeriesByID).Methods(http.MethodGet)
+	r.HandleFunc("/series/{id}", ifMatch(series.PutSeries)).Methods(http.MethodPut)
+	r.HandleFunc("/series/{id}", ifMatch(series.DeleteSeries)).Methods(http.MethodDelete)
+	r.HandleFunc("/series/{id}/books", book.GetSeriesBooks).Methods(http.MethodGet)
+
+	r.HandleFunc("/member", member.GetMembers).Methods(http.MethodGet)
+	r.HandleFunc("/member", member.PostMember).Methods(http.MethodPost)
+	r.HandleFunc("/member/{id}", member.GetMemberByID).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}", ifMatch(member.PutMember)).Methods(http.MethodPut)
+	r.HandleFunc("/member/{id}", ifMatch(member.DeleteMember)).Methods(http.MethodDelete)
+	r.HandleFunc("/member/{id}/loans", loan.GetMemberLoans).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}/holds", hold.GetMemberHolds).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}/account", account.GetAccount).Methods(http.MethodGet)
+	r.HandleFunc("/member/{id}/account", account.PostEntry).Methods(http.MethodPost)
+
+	r.HandleFunc("/loan", loan.PostLoan).Methods(http.MethodPost)
+	r.HandleFunc("/loan/{id}", loan.GetLoanByID).Methods(http.MethodGet)
+	r.HandleFunc("/loan/{id}/return", loan.ReturnLoan).Methods(http.MethodPost)
+	r.HandleFunc("/loan/{id}/renew", loan.RenewLoan).Methods(http.MethodPost)
+
+	r.HandleFunc("/hold", hold.PostHold).Methods(http.MethodPost)
+	r.HandleFunc("/hold/{id}", hold.GetHoldByID).Methods(http.MethodGet)
+	r.HandleFunc("/hold/{id}/cancel", hold.CancelHold).Methods(http.MethodPost)
+
+	r.Use(delivery.RequestID)
+
+	if cfg.LogLevel == "debug" {
+		r.Use(logRequests)
+	}
 
 	server := http.Server{
-		Addr:    ":8000",
-		Handler: r,
+		Addr:         cfg.Server.Addr,
+		Handler:      r,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout),
 	}
 
-	fmt.Println("server stared At localhost:8000")
+	fmt.Println("server stared At", cfg.Server.Addr)
 	err = server.ListenAndServe()
 	if err != nil {
 		fmt.Println(err)
 	}
 }
+
+// expireHolds closes the holds which were not picked up in time, once on start and then every hour
+func expireHolds(svc serviceLoan.Service) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		n, err := svc.ExpireHolds(context.Background())
+		if err != nil {
+			log.Println("could not expire the holds, err:", err)
+			continue
+		}
+
+		if n > 0 {
+			log.Println("expired holds:", n)
+		}
+	}
+}
+
+// logRequests logs every request, it is used with the debug log level
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		next.ServeHTTP(w, r)
+
+		log.Println(delivery.GetRequestID(r.Context()), r.Method, r.URL.String(), time.Since(start))
+	})
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"ThreeLayer/config"
+	"ThreeLayer/driver"
+	"ThreeLayer/migrations"
+)
+
+const migrateUsage = "usage: migrate up|down|status"
+
+// migrate runs the migrate subcommand against the database of the config
+func migrate(cfg config.Database, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	var (
+		db      *sql.DB
+		dialect migrations.Dialect
+		err     error
+	)
+
+	switch cfg.Driver {
+	case config.DriverSQLite:
+		db, err = driver.OpenSQLite(cfg.SQLitePath)
+		dialect = migrations.SQLite
+	case config.DriverMemory:
+		return errors.New("the memory datastore has no schema to migrate")
+	default:
+		db, err = driver.ConnectToSQL(cfg)
+		dialect = migrations.MySQL
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	migrator, err := migrations.New(db, dialect)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		fmt.Printf("applied %d migration(s)\n", count)
+
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !reverted {
+			fmt.Println("no migration to revert")
+		}
+
+		return nil
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range status {
+			state := "pending"
+			if s.Applied {
+				state = "applied at " + s.AppliedAt
+			}
+
+			fmt.Printf("%04d %-30s %s\n", s.Version, s.Name, state)
+		}
+
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,349 @@
+// Package migrations holds the versioned schema of the library and applies it to a database, the applied versions
+// are tracked in the schema_migrations table. Every version is the same change of the schema in every dialect, a
+// dialect which needs no statements for a version has a migration of comments only.
+//
+// MySQL commits every DDL statement on its own, so a mysql migration is either a single DDL statement or DML
+// statements only, which are applied in one transaction with the tracking row. A failed migration is then never
+// half applied and is retried as a whole.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect is the sql dialect of the migration files, every dialect has its own directory
+type Dialect string
+
+const (
+	MySQL  Dialect = "mysql"
+	SQLite Dialect = "sqlite"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql
+var files embed.FS
+
+const (
+	createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations(
+version int NOT NULL,
+name varchar(255) NOT NULL,
+applied_at varchar(64) NOT NULL,
+PRIMARY KEY (version)
+);`
+	getAppliedMigrations = "select version,applied_at from schema_migrations;"
+	insertMigration      = "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?,?,?);"
+	deleteMigration      = "delete from schema_migrations where version=?;"
+)
+
+// Migration is a single version of the schema with the statements to apply and revert it. Check is an optional
+// query selecting the rows which the migration can not convert, the migration is not applied while it selects any
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	Check   string
+}
+
+// Status tells whether a migration is applied, AppliedAt is empty for a pending migration
+type Status struct {
+	Version   int    `json:"version"`
+	Name      string `json:"name"`
+	Applied   bool   `json:"applied"`
+	AppliedAt string `json:"applied_at,omitempty"`
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New returns a Migrator for the migrations of the dialect
+func New(db *sql.DB, dialect Dialect) (Migrator, error) {
+	migrations, err := load(dialect)
+	if err != nil {
+		return Migrator{}, err
+	}
+
+	return Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies all the pending migrations in order of version and returns the number of applied migrations
+func (m Migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err = m.check(ctx, migration.Check); err != nil {
+			return count, fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		err = m.run(ctx, migration.Up, insertMigration, migration.Version, migration.Name,
+			time.Now().UTC().Format(time.RFC3339))
+		if err != nil {
+			return count, fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// Down reverts the latest applied migration, false is returned when no migration is applied
+func (m Migrator) Down(ctx context.Context) (bool, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err = m.run(ctx, migration.Down, deleteMigration, migration.Version)
+		if err != nil {
+			return false, fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Status returns every known migration in order of version
+func (m Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]Status, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		status = append(status, Status{Version: migration.Version, Name: migration.Name, Applied: ok,
+			AppliedAt: appliedAt})
+	}
+
+	return status, nil
+}
+
+// applied creates the tracking table if needed and returns the time of every applied version
+func (m Migrator) applied(ctx context.Context) (map[int]string, error) {
+	if _, err := m.db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, getAppliedMigrations)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	applied := make(map[int]string)
+
+	for rows.Next() {
+		var (
+			version   int
+			appliedAt string
+		)
+
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// check runs the check queries of a migration and fails listing the rows they select, the rows are written as
+// their columns separated by spaces
+func (m Migrator) check(ctx context.Context, queries string) error {
+	var invalid []string
+
+	for _, query := range split(queries) {
+		rows, err := m.db.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		found, err := readRows(rows)
+		if err != nil {
+			return err
+		}
+
+		invalid = append(invalid, found...)
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("%d rows have to be fixed first: %s", len(invalid), strings.Join(invalid, "; "))
+	}
+
+	return nil
+}
+
+func readRows(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
+
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(values))
+		for i := range values {
+			row[i] = fmt.Sprintf("%q", values[i].String)
+		}
+
+		found = append(found, strings.Join(row, " "))
+	}
+
+	return found, rows.Err()
+}
+
+// run executes the statements of a migration and records it in the tracking table in one transaction,
+// mysql commits a DDL statement implicitly so there a migration of a DDL statement has no other statement
+func (m Migrator) run(ctx context.Context, statements, track string, args ...interface{}) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range split(statements) {
+		if _, err = tx.ExecContext(ctx, statement); err != nil {
+			_ = tx.Rollback()
+
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, track, args...); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// load reads the migrations of the dialect, files are named <version>_<name>.<up|down|check>.sql
+func load(dialect Dialect) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, string(dialect))
+	if err != nil {
+		return nil, fmt.Errorf("unknown dialect %q", dialect)
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		kind := ""
+
+		for _, suffix := range []string{".up.sql", ".down.sql", ".check.sql"} {
+			if strings.HasSuffix(entry.Name(), suffix) {
+				kind = suffix
+			}
+		}
+
+		base := strings.TrimSuffix(entry.Name(), kind)
+
+		parts := strings.SplitN(base, "_", 2)
+		if kind == "" || len(parts) != 2 {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := files.ReadFile(path.Join(string(dialect), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = migration
+		}
+
+		switch kind {
+		case ".up.sql":
+			migration.Up = string(content)
+		case ".down.sql":
+			migration.Down = string(content)
+		default:
+			migration.Check = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// split returns the statements of a migration file, statements end with a semicolon at the end of a line.
+// The lines starting with -- are comments and are left out
+func split(statements string) []string {
+	var (
+		result []string
+		lines  []string
+	)
+
+	for _, line := range strings.Split(statements, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";\n") {
+		statement = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+		if statement != "" {
+			result = append(result, statement)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,524 @@
+package migrations
+
+import (
+	"ThreeLayer/entities"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newSQLite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sqlite database", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// before returns the migrations preceding the named one
+func before(t *testing.T, migrations []Migration, name string) []Migration {
+	for i, migration := range migrations {
+		if migration.Name == name {
+			return migrations[:i]
+		}
+	}
+
+	t.Fatalf("unknown migration %s", name)
+
+	return nil
+}
+
+// through returns the migrations up to the named one included
+func through(t *testing.T, migrations []Migration, name string) []Migration {
+	return migrations[:len(before(t, migrations, name))+1]
+}
+
+// revert reverts the latest count migrations
+func revert(ctx context.Context, t *testing.T, m Migrator, count int) {
+	for i := 0; i < count; i++ {
+		if _, err := m.Down(ctx); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	for _, dialect := range []Dialect{MySQL, SQLite} {
+		migrations, err := load(dialect)
+		if err != nil {
+			t.Fatalf("[%s]Failed. expected error to be nil got %v", dialect, err)
+		}
+
+		for i, migration := range migrations {
+			if migration.Version != i+1 || migration.Up == "" || migration.Down == "" {
+				t.Errorf("[%s]Failed. Expected version %d with up and down statements Got %v", dialect, i+1, migration)
+			}
+		}
+	}
+
+	mysql, _ := load(MySQL)
+	sqlite, _ := load(SQLite)
+
+	// a version has to be the same schema whichever the dialect is
+	if len(mysql) != len(sqlite) {
+		t.Fatalf("Failed. Expected the dialects to have the same versions Got %d and %d", len(mysql), len(sqlite))
+	}
+
+	for i := range mysql {
+		if mysql[i].Version != sqlite[i].Version || mysql[i].Name != sqlite[i].Name {
+			t.Errorf("Failed. Expected the same migration Got %04d_%s and %04d_%s", mysql[i].Version, mysql[i].Name,
+				sqlite[i].Version, sqlite[i].Name)
+		}
+	}
+
+	// mysql commits a DDL statement on its own, it can not be applied with other statements
+	for _, migration := range mysql {
+		for _, statements := range []string{migration.Up, migration.Down} {
+			ddl := 0
+
+			for _, statement := range split(statements) {
+				switch strings.ToUpper(strings.Fields(statement)[0]) {
+				case "CREATE", "ALTER", "DROP", "RENAME", "TRUNCATE":
+					ddl++
+				}
+			}
+
+			if ddl > 0 && len(split(statements)) > 1 {
+				t.Errorf("Failed. Expected %04d_%s to be a single DDL statement or DML statements only",
+					migration.Version, migration.Name)
+			}
+		}
+	}
+
+	if _, err := load("oracle"); err == nil {
+		t.Errorf("Failed. Expected an error for an unknown dialect")
+	}
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	count, err := m.Up(ctx)
+	if err != nil || count != len(m.migrations) {
+		t.Errorf("[TEST1]Failed. Expected %d migrations to be applied Got %d, %v", len(m.migrations), count, err)
+	}
+
+	count, err = m.Up(ctx)
+	if err != nil || count != 0 {
+		t.Errorf("[TEST2]Failed. Expected no pending migrations Got %d, %v", count, err)
+	}
+
+	// the foreign key must be enforced
+	_, err = db.Exec("INSERT INTO Books (title, publisher_id, publication_date, author_id) VALUES ('a',1,'c',99)")
+	if err == nil {
+		t.Errorf("[TEST3]Failed. Expected a book without author to be rejected")
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("[TEST4]Failed. expected error to be nil got %v", err)
+	}
+
+	for _, s := range status {
+		if !s.Applied || s.AppliedAt == "" {
+			t.Errorf("[TEST4]Failed. Expected %v to be applied", s)
+		}
+	}
+
+	for range m.migrations {
+		reverted, err := m.Down(ctx)
+		if err != nil || !reverted {
+			t.Errorf("[TEST5]Failed. Expected a migration to be reverted Got %v, %v", reverted, err)
+		}
+	}
+
+	reverted, err := m.Down(ctx)
+	if err != nil || reverted {
+		t.Errorf("[TEST6]Failed. Expected no migration to revert Got %v, %v", reverted, err)
+	}
+
+	if _, err = db.Exec("select id from Authors"); err == nil {
+		t.Errorf("[TEST7]Failed. Expected the Authors table to be dropped")
+	}
+
+	status, _ = m.Status(ctx)
+	for _, s := range status {
+		if s.Applied {
+			t.Errorf("[TEST8]Failed. Expected %v to be pending", s)
+		}
+	}
+}
+
+// TestMigrator_Publishers checks that the publications of the existing books become publishers and back
+func TestMigrator_Publishers(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	all := m.migrations
+	m.migrations = before(t, all, "create_publishers")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES ('a','b','c','d')",
+		"INSERT INTO Books (title, publication, publication_date, author_id) VALUES ('a','Penguin','c',1)",
+		"INSERT INTO Books (title, publication, publication_date, author_id) VALUES ('b','Oxford','c',1)"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	m.migrations = through(t, all, "drop_books_publication")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("[TEST1]Failed. expected error to be nil got %v", err)
+	}
+
+	var penguin, oxford int
+
+	err = db.QueryRow("SELECT publisher_id FROM Books WHERE title = 'a'").Scan(&penguin)
+	if err != nil || penguin != 3 {
+		t.Errorf("[TEST2]Failed. Expected the book to refer to publisher 3 Got %v, %v", penguin, err)
+	}
+
+	err = db.QueryRow("SELECT b.publisher_id FROM Books b JOIN Publishers p ON p.id = b.publisher_id " +
+		"WHERE p.name = 'Oxford'").Scan(&oxford)
+	if err != nil || oxford != 4 {
+		t.Errorf("[TEST3]Failed. Expected the other publication to be added as publisher 4 Got %v, %v", oxford, err)
+	}
+
+	revert(ctx, t, m, len(m.migrations)-len(before(t, all, "create_publishers")))
+
+	var publication string
+
+	err = db.QueryRow("SELECT publication FROM Books WHERE title = 'b'").Scan(&publication)
+	if err != nil || publication != "Oxford" {
+		t.Errorf("[TEST4]Failed. Expected the publication to be restored Got %v, %v", publication, err)
+	}
+}
+
+// TestMigrator_BookAuthors checks that the authors of the existing books are credited as their only contributors
+func TestMigrator_BookAuthors(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	all := m.migrations
+	m.migrations = before(t, all, "create_book_authors")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES ('a','b','c','d')",
+		"INSERT INTO Books (title, publisher_id, publication_date, author_id) VALUES ('a',3,'c',1)"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	m.migrations = through(t, all, "fill_book_authors")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("[TEST1]Failed. expected error to be nil got %v", err)
+	}
+
+	var (
+		authorID, position int
+		role               string
+	)
+
+	err = db.QueryRow("SELECT author_id, role, position FROM BookAuthors WHERE book_id = 1").Scan(&authorID, &role,
+		&position)
+	if err != nil || authorID != 1 || role != "author" || position != 1 {
+		t.Errorf("[TEST2]Failed. Expected the author to be credited Got %v, %v, %v, %v", authorID, role, position, err)
+	}
+
+	revert(ctx, t, m, 2)
+
+	if _, err = db.Exec("SELECT 1 FROM BookAuthors"); err == nil {
+		t.Errorf("[TEST3]Failed. Expected the table to be dropped")
+	}
+}
+
+// TestMigrator_BookISBN checks that the existing books are left without an isbn and that an isbn can not be repeated
+func TestMigrator_BookISBN(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	all := m.migrations
+	m.migrations = before(t, all, "add_books_isbn")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES ('a','b','c','d')",
+		"INSERT INTO Books (title, publisher_id, publication_date, author_id) VALUES ('a',3,'c',1)"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	m.migrations = through(t, all, "add_books_isbn")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("[TEST1]Failed. expected error to be nil got %v", err)
+	}
+
+	var isbn sql.NullString
+
+	err = db.QueryRow("SELECT isbn FROM Books WHERE id = 1").Scan(&isbn)
+	if err != nil || isbn.Valid {
+		t.Errorf("[TEST2]Failed. Expected the book to be without an isbn Got %v, %v", isbn, err)
+	}
+
+	for i, isbn := range []string{"NULL", "'9780306406157'"} {
+		_, err = db.Exec("INSERT INTO Books (title, publisher_id, publication_date, author_id, isbn) VALUES " +
+			"('b',3,'c',1," + isbn + ")")
+		if err != nil {
+			t.Errorf("[TEST3]Failed. Expected book %d to be added Got %v", i, err)
+		}
+	}
+
+	_, err = db.Exec("INSERT INTO Books (title, publisher_id, publication_date, author_id, isbn) VALUES " +
+		"('c',3,'c',1,'9780306406157')")
+	if err == nil {
+		t.Errorf("[TEST4]Failed. Expected a repeated isbn to be rejected")
+	}
+
+	if _, err = m.Down(ctx); err != nil {
+		t.Fatalf("[TEST5]Failed. expected error to be nil got %v", err)
+	}
+
+	if _, err = db.Exec("SELECT isbn FROM Books"); err == nil {
+		t.Errorf("[TEST6]Failed. Expected the column to be dropped")
+	}
+}
+
+// TestMigrator_DateColumns checks that the dd/mm/yyyy dates become yyyy-mm-dd dates and back, the migration is not
+// applied while any date can not be converted
+func TestMigrator_DateColumns(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	all := m.migrations
+	m.migrations = before(t, all, "allow_null_publication_date")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES ('a','b','2/12/1999','d')",
+		"INSERT INTO Books (title, publisher_id, publication_date, author_id) VALUES ('a',3,'22/07/2000',1)",
+		"INSERT INTO Books (title, publisher_id, publication_date, author_id) VALUES ('b',3,'2020',1)",
+		"INSERT INTO Members (first_name, last_name, email, membership_type, expires_on, status) " +
+			"VALUES ('a','b','c','standard','31/12/2030','active')",
+		"INSERT INTO Copies (book_id, barcode, copy_condition, acquired_on, status) VALUES (1,'a','good','01/02/2020','on_loan')",
+		"INSERT INTO Loans (copy_id, member_id, book_id, checked_out_on, due_on) VALUES (1,1,1,'01/03/2022','15/03/2022')",
+		"INSERT INTO Holds (book_id, member_id, status, placed_on) VALUES (1,1,'waiting','02/03/2022')",
+		"INSERT INTO LedgerEntries (member_id, entry_type, amount, created_on) VALUES (1,'fine',50,'20/3/2022')"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	m.migrations = through(t, all, "use_date_created_on")
+
+	_, err = m.Up(ctx)
+	if err == nil || !strings.Contains(err.Error(), `"Books" "publication_date" "2" "2020"`) {
+		t.Fatalf("[TEST1]Failed. Expected the invalid date to be reported Got %v", err)
+	}
+
+	if _, err = db.Exec("UPDATE Books SET publication_date = '' WHERE id = 2"); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("[TEST2]Failed. expected error to be nil got %v", err)
+	}
+
+	// the driver reads the DATE columns as times, the concatenation keeps the stored text
+	var dates [9]sql.NullString
+
+	err = db.QueryRow("SELECT (SELECT dob || '' FROM Authors WHERE id = 1), "+
+		"(SELECT publication_date || '' FROM Books WHERE id = 1), (SELECT publication_date || '' FROM Books WHERE id = 2), "+
+		"(SELECT expires_on || '' FROM Members), (SELECT acquired_on || '' FROM Copies), "+
+		"(SELECT due_on || '' FROM Loans), (SELECT returned_on || '' FROM Loans), (SELECT ready_on || '' FROM Holds), "+
+		"(SELECT created_on || '' FROM LedgerEntries)").Scan(&dates[0], &dates[1], &dates[2], &dates[3], &dates[4],
+		&dates[5], &dates[6], &dates[7], &dates[8])
+
+	exp := [9]sql.NullString{{String: "1999-12-02", Valid: true}, {String: "2000-07-22", Valid: true}, {},
+		{String: "2030-12-31", Valid: true}, {String: "2020-02-01", Valid: true}, {String: "2022-03-15", Valid: true}, {},
+		{}, {String: "2022-03-20", Valid: true}}
+	if err != nil || dates != exp {
+		t.Errorf("[TEST3]Failed. Expected the dates to be converted %v Got %v, %v", exp, dates, err)
+	}
+
+	var active int
+
+	err = db.QueryRow("SELECT COUNT(*) FROM Loans WHERE member_id = 1 AND returned_on IS NULL").Scan(&active)
+	if err != nil || active != 1 {
+		t.Errorf("[TEST4]Failed. Expected the loan to be active Got %v, %v", active, err)
+	}
+
+	revert(ctx, t, m, len(m.migrations)-len(before(t, all, "allow_null_publication_date")))
+
+	err = db.QueryRow("SELECT (SELECT dob FROM Authors WHERE id = 1), (SELECT publication_date FROM Books WHERE id = 1), "+
+		"(SELECT publication_date FROM Books WHERE id = 2), (SELECT expires_on FROM Members), "+
+		"(SELECT acquired_on FROM Copies), (SELECT due_on FROM Loans), (SELECT returned_on FROM Loans), "+
+		"(SELECT ready_on FROM Holds), (SELECT created_on FROM LedgerEntries)").Scan(&dates[0], &dates[1], &dates[2],
+		&dates[3], &dates[4], &dates[5], &dates[6], &dates[7], &dates[8])
+
+	exp = [9]sql.NullString{{String: "02/12/1999", Valid: true}, {String: "22/07/2000", Valid: true},
+		{String: "", Valid: true}, {String: "31/12/2030", Valid: true}, {String: "01/02/2020", Valid: true},
+		{String: "15/03/2022", Valid: true}, {String: "", Valid: true}, {String: "", Valid: true},
+		{String: "20/03/2022", Valid: true}}
+	if err != nil || dates != exp {
+		t.Errorf("[TEST5]Failed. Expected the dates to be restored %v Got %v, %v", exp, dates, err)
+	}
+}
+
+// TestMigrator_LoanHistory checks that the loans can not be deleted with their copy, member or book
+func TestMigrator_LoanHistory(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Authors (first_name, last_name, pen_name) VALUES ('a','b','d')",
+		"INSERT INTO Books (title, publisher_id, author_id) VALUES ('a',3,1)",
+		"INSERT INTO Members (first_name, last_name, email, membership_type, status) " +
+			"VALUES ('a','b','c','standard','active')",
+		"INSERT INTO Copies (book_id, barcode, copy_condition, status) VALUES (1,'a','good','available')",
+		"INSERT INTO Loans (copy_id, member_id, book_id, checked_out_on, due_on, returned_on) " +
+			"VALUES (1,1,1,'2022-03-01','2022-03-15','2022-03-20')"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	for i, query := range []string{"DELETE FROM Copies WHERE id = 1", "DELETE FROM Members WHERE id = 1",
+		"DELETE FROM Books WHERE id = 1"} {
+		if _, err = db.Exec(query); err == nil {
+			t.Errorf("[TEST%d]Failed. Expected the delete to be rejected while the loan exists", i+1)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	statements := split("CREATE INDEX a ON b (c);\nDROP INDEX d;\n\n")
+	if len(statements) != 2 || statements[0] != "CREATE INDEX a ON b (c)" || statements[1] != "DROP INDEX d" {
+		t.Errorf("Failed. Got %q", statements)
+	}
+
+	if statements = split("-- nothing to do;\n  -- at all\n"); len(statements) != 0 {
+		t.Errorf("Failed. Expected the comments to be left out Got %q", statements)
+	}
+}
+
+// TestMigrator_LedgerHistory checks that the ledger entries can not be deleted with their member
+func TestMigrator_LedgerHistory(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Members (first_name, last_name, email, membership_type, status) " +
+		"VALUES ('a','b','c','standard','active')",
+		"INSERT INTO LedgerEntries (member_id, entry_type, amount, note, created_on) " +
+			"VALUES (1,'payment',50,'cash','2022-03-20')"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	if _, err = db.Exec("DELETE FROM Members WHERE id = 1"); err == nil {
+		t.Errorf("Failed. Expected the delete to be rejected while the member has entries")
+	}
+}
+
+func TestMigrator_TitleKey(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	all := m.migrations
+	m.migrations = before(t, all, "fill_books_title_key")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Failed. expected error to be nil got %v", err)
+	}
+
+	for _, query := range []string{"INSERT INTO Authors (first_name, last_name, dob, pen_name) VALUES ('a','b','2/12/1999','d')",
+		"INSERT INTO Books (title, publisher_id, publication_date, author_id) " +
+			"VALUES ('  The    Hobbit' || char(9) || 'Again ',3,'22/07/2000',1)"} {
+		if _, err = db.Exec(query); err != nil {
+			t.Fatalf("Failed. expected error to be nil got %v", err)
+		}
+	}
+
+	m.migrations = through(t, all, "fill_books_title_key")
+
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("[TEST1]Failed. expected error to be nil got %v", err)
+	}
+
+	var title, key string
+
+	err = db.QueryRow("SELECT title, title_key FROM Books WHERE id = 1").Scan(&title, &key)
+	if err != nil || key != entities.TitleKey(title) {
+		t.Errorf("[TEST2]Failed. Expected the key %q Got %q, %v", entities.TitleKey(title), key, err)
+	}
+}
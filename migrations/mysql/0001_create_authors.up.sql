@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS Authors(
+id int NOT NULL AUTO_INCREMENT,
+first_name varchar(255) NOT NULL,
+last_name varchar(255) NOT NULL,
+dob varchar(255) NOT NULL,
+pen_name varchar(255) NOT NULL,
+PRIMARY KEY (id)
+);
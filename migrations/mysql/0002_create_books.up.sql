@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS Books(
+id int NOT NULL AUTO_INCREMENT,
+title varchar(255) NOT NULL,
+publication varchar(255) NOT NULL,
+publication_date varchar(255) NOT NULL,
+author_id int NOT NULL,
+PRIMARY KEY (id),
+CONSTRAINT fk_books_author FOREIGN KEY (author_id) REFERENCES Authors(id)
+);
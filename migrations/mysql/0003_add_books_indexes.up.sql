@@ -0,0 +1 @@
+ALTER TABLE Books ADD INDEX idx_books_title (title), ADD INDEX idx_books_publication (publication);
@@ -0,0 +1 @@
+CREATE INDEX idx_authors_name ON Authors (first_name, last_name);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS Copies(
+id int NOT NULL AUTO_INCREMENT,
+book_id int NOT NULL,
+barcode varchar(64) NOT NULL,
+copy_condition varchar(32) NOT NULL,
+shelf_location varchar(255) NOT NULL DEFAULT '',
+acquired_on varchar(255) NOT NULL,
+status varchar(32) NOT NULL,
+version int NOT NULL DEFAULT 1,
+PRIMARY KEY (id),
+UNIQUE KEY idx_copies_barcode (barcode),
+KEY idx_copies_book (book_id, status),
+CONSTRAINT fk_copies_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE
+);
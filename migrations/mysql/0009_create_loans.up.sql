@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS Loans(
+id int NOT NULL AUTO_INCREMENT,
+copy_id int NOT NULL,
+member_id int NOT NULL,
+book_id int NOT NULL,
+checked_out_on varchar(255) NOT NULL,
+due_on varchar(255) NOT NULL,
+returned_on varchar(255) NOT NULL DEFAULT '',
+renewals int NOT NULL DEFAULT 0,
+version int NOT NULL DEFAULT 1,
+PRIMARY KEY (id),
+KEY idx_loans_member (member_id, returned_on),
+KEY idx_loans_book (book_id),
+CONSTRAINT fk_loans_copy FOREIGN KEY (copy_id) REFERENCES Copies(id) ON DELETE RESTRICT,
+CONSTRAINT fk_loans_member FOREIGN KEY (member_id) REFERENCES Members(id) ON DELETE RESTRICT,
+CONSTRAINT fk_loans_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE RESTRICT
+);
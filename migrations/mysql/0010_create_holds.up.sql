@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS Holds(
+id int NOT NULL AUTO_INCREMENT,
+book_id int NOT NULL,
+member_id int NOT NULL,
+copy_id int NULL,
+status varchar(32) NOT NULL,
+placed_on varchar(255) NOT NULL,
+ready_on varchar(255) NOT NULL DEFAULT '',
+expires_on varchar(255) NOT NULL DEFAULT '',
+version int NOT NULL DEFAULT 1,
+PRIMARY KEY (id),
+KEY idx_holds_book (book_id, status),
+KEY idx_holds_member (member_id, status),
+CONSTRAINT fk_holds_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_holds_member FOREIGN KEY (member_id) REFERENCES Members(id) ON DELETE CASCADE,
+CONSTRAINT fk_holds_copy FOREIGN KEY (copy_id) REFERENCES Copies(id) ON DELETE SET NULL
+);
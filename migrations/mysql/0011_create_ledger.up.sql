@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS LedgerEntries(
+id int NOT NULL AUTO_INCREMENT,
+member_id int NOT NULL,
+loan_id int NULL,
+entry_type varchar(32) NOT NULL,
+amount int NOT NULL,
+note varchar(255) NOT NULL DEFAULT '',
+created_on varchar(255) NOT NULL,
+PRIMARY KEY (id),
+KEY idx_ledger_member (member_id),
+CONSTRAINT fk_ledger_member FOREIGN KEY (member_id) REFERENCES Members(id) ON DELETE RESTRICT,
+CONSTRAINT fk_ledger_loan FOREIGN KEY (loan_id) REFERENCES Loans(id) ON DELETE SET NULL
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS Publishers(
+id int NOT NULL AUTO_INCREMENT,
+name varchar(255) NOT NULL,
+website varchar(255) NOT NULL DEFAULT '',
+version int NOT NULL DEFAULT 1,
+PRIMARY KEY (id),
+UNIQUE KEY idx_publishers_name (name)
+);
@@ -0,0 +1,2 @@
+INSERT INTO Publishers (name) VALUES ('Arihanth'), ('Scholastic'), ('Penguin');
+INSERT INTO Publishers (name) SELECT DISTINCT publication FROM Books WHERE publication NOT IN (SELECT name FROM Publishers);
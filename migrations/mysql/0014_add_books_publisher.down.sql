@@ -0,0 +1 @@
+ALTER TABLE Books DROP COLUMN publisher_id;
@@ -0,0 +1 @@
+ALTER TABLE Books ADD COLUMN publisher_id int NULL;
@@ -0,0 +1 @@
+UPDATE Books SET publication = (SELECT name FROM Publishers WHERE Publishers.id = Books.publisher_id), publisher_id = NULL;
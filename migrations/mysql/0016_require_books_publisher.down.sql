@@ -0,0 +1 @@
+ALTER TABLE Books DROP FOREIGN KEY fk_books_publisher, MODIFY publisher_id int NULL;
@@ -0,0 +1 @@
+ALTER TABLE Books MODIFY publisher_id int NOT NULL, ADD CONSTRAINT fk_books_publisher FOREIGN KEY (publisher_id) REFERENCES Publishers(id);
@@ -0,0 +1 @@
+ALTER TABLE Books ADD COLUMN publication varchar(255) NOT NULL DEFAULT '', ADD INDEX idx_books_publication (publication);
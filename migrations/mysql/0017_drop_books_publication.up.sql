@@ -0,0 +1 @@
+ALTER TABLE Books DROP INDEX idx_books_publication, DROP COLUMN publication;
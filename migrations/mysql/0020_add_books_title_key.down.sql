@@ -0,0 +1 @@
+ALTER TABLE Books DROP INDEX idx_books_title_key, DROP COLUMN title_key;
@@ -0,0 +1 @@
+ALTER TABLE Books ADD COLUMN title_key varchar(255) NOT NULL DEFAULT '', ADD INDEX idx_books_title_key (title_key, author_id, publisher_id);
@@ -0,0 +1,2 @@
+-- the key is the title in lower case with single spaces between the words, the same as entities.TitleKey
+UPDATE Books SET title_key = LOWER(TRIM(REGEXP_REPLACE(title, '[[:space:]]+', ' ')));
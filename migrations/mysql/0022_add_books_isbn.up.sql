@@ -0,0 +1 @@
+ALTER TABLE Books ADD COLUMN isbn varchar(13) NULL, ADD UNIQUE INDEX idx_books_isbn (isbn);
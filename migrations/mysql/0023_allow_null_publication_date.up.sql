@@ -0,0 +1 @@
+ALTER TABLE Books MODIFY publication_date varchar(255) NULL;
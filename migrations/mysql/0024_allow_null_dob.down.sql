@@ -0,0 +1 @@
+ALTER TABLE Authors MODIFY dob varchar(255) NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE Loans MODIFY returned_on varchar(255) NULL DEFAULT NULL;
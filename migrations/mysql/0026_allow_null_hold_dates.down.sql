@@ -0,0 +1 @@
+ALTER TABLE Holds MODIFY ready_on varchar(255) NOT NULL DEFAULT '', MODIFY expires_on varchar(255) NOT NULL DEFAULT '';
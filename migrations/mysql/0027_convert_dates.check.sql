@@ -0,0 +1,18 @@
+-- the dates which are not dd/mm/yyyy would be lost by the conversion, an empty date is converted to NULL
+-- only for the columns which can be NULL
+SELECT tbl, col, id, value FROM (
+SELECT 'Books' AS tbl, 'publication_date' AS col, id, publication_date AS value, 1 AS nullable FROM Books
+UNION ALL SELECT 'Authors', 'dob', id, dob, 1 FROM Authors
+UNION ALL SELECT 'Members', 'expires_on', id, expires_on, 0 FROM Members
+UNION ALL SELECT 'Copies', 'acquired_on', id, acquired_on, 0 FROM Copies
+UNION ALL SELECT 'Loans', 'checked_out_on', id, checked_out_on, 0 FROM Loans
+UNION ALL SELECT 'Loans', 'due_on', id, due_on, 0 FROM Loans
+UNION ALL SELECT 'Loans', 'returned_on', id, returned_on, 1 FROM Loans
+UNION ALL SELECT 'Holds', 'placed_on', id, placed_on, 0 FROM Holds
+UNION ALL SELECT 'Holds', 'ready_on', id, ready_on, 1 FROM Holds
+UNION ALL SELECT 'Holds', 'expires_on', id, expires_on, 1 FROM Holds
+UNION ALL SELECT 'LedgerEntries', 'created_on', id, created_on, 0 FROM LedgerEntries
+) AS dates
+WHERE NOT (value = '' AND nullable = 1)
+AND (value NOT REGEXP '^[0-9]{1,2}/[0-9]{1,2}/[0-9]{4}$' OR STR_TO_DATE(value, '%d/%m/%Y') IS NULL)
+ORDER BY tbl, col, id;
@@ -0,0 +1,7 @@
+UPDATE LedgerEntries SET created_on = DATE_FORMAT(created_on,'%d/%m/%Y');
+UPDATE Holds SET placed_on = DATE_FORMAT(placed_on,'%d/%m/%Y'), ready_on = COALESCE(DATE_FORMAT(ready_on,'%d/%m/%Y'), ''), expires_on = COALESCE(DATE_FORMAT(expires_on,'%d/%m/%Y'), '');
+UPDATE Loans SET checked_out_on = DATE_FORMAT(checked_out_on,'%d/%m/%Y'), due_on = DATE_FORMAT(due_on,'%d/%m/%Y'), returned_on = COALESCE(DATE_FORMAT(returned_on,'%d/%m/%Y'), '');
+UPDATE Copies SET acquired_on = DATE_FORMAT(acquired_on,'%d/%m/%Y');
+UPDATE Members SET expires_on = DATE_FORMAT(expires_on,'%d/%m/%Y');
+UPDATE Authors SET dob = COALESCE(DATE_FORMAT(dob,'%d/%m/%Y'), '');
+UPDATE Books SET publication_date = COALESCE(DATE_FORMAT(publication_date,'%d/%m/%Y'), '');
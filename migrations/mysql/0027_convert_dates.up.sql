@@ -0,0 +1,7 @@
+UPDATE Books SET publication_date = CASE WHEN publication_date = '' THEN NULL ELSE DATE_FORMAT(STR_TO_DATE(publication_date,'%d/%m/%Y'),'%Y-%m-%d') END;
+UPDATE Authors SET dob = CASE WHEN dob = '' THEN NULL ELSE DATE_FORMAT(STR_TO_DATE(dob,'%d/%m/%Y'),'%Y-%m-%d') END;
+UPDATE Members SET expires_on = DATE_FORMAT(STR_TO_DATE(expires_on,'%d/%m/%Y'),'%Y-%m-%d');
+UPDATE Copies SET acquired_on = DATE_FORMAT(STR_TO_DATE(acquired_on,'%d/%m/%Y'),'%Y-%m-%d');
+UPDATE Loans SET checked_out_on = DATE_FORMAT(STR_TO_DATE(checked_out_on,'%d/%m/%Y'),'%Y-%m-%d'), due_on = DATE_FORMAT(STR_TO_DATE(due_on,'%d/%m/%Y'),'%Y-%m-%d'), returned_on = CASE WHEN returned_on = '' THEN NULL ELSE DATE_FORMAT(STR_TO_DATE(returned_on,'%d/%m/%Y'),'%Y-%m-%d') END;
+UPDATE Holds SET placed_on = DATE_FORMAT(STR_TO_DATE(placed_on,'%d/%m/%Y'),'%Y-%m-%d'), ready_on = CASE WHEN ready_on = '' THEN NULL ELSE DATE_FORMAT(STR_TO_DATE(ready_on,'%d/%m/%Y'),'%Y-%m-%d') END, expires_on = CASE WHEN expires_on = '' THEN NULL ELSE DATE_FORMAT(STR_TO_DATE(expires_on,'%d/%m/%Y'),'%Y-%m-%d') END;
+UPDATE LedgerEntries SET created_on = DATE_FORMAT(STR_TO_DATE(created_on,'%d/%m/%Y'),'%Y-%m-%d');
@@ -0,0 +1 @@
+ALTER TABLE Books DROP INDEX idx_books_publication_date, MODIFY publication_date varchar(255) NULL;
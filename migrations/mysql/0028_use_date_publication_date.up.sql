@@ -0,0 +1 @@
+ALTER TABLE Books MODIFY publication_date DATE NULL, ADD INDEX idx_books_publication_date (publication_date);
@@ -0,0 +1 @@
+ALTER TABLE Authors MODIFY dob DATE NULL;
@@ -0,0 +1 @@
+ALTER TABLE Members MODIFY expires_on varchar(255) NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE Members MODIFY expires_on DATE NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE Copies MODIFY acquired_on varchar(255) NOT NULL;
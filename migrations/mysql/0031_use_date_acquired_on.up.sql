@@ -0,0 +1 @@
+ALTER TABLE Copies MODIFY acquired_on DATE NOT NULL;
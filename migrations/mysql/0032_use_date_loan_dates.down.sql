@@ -0,0 +1 @@
+ALTER TABLE Loans MODIFY checked_out_on varchar(255) NOT NULL, MODIFY due_on varchar(255) NOT NULL, MODIFY returned_on varchar(255) NULL DEFAULT NULL;
@@ -0,0 +1 @@
+ALTER TABLE Loans MODIFY checked_out_on DATE NOT NULL, MODIFY due_on DATE NOT NULL, MODIFY returned_on DATE NULL DEFAULT NULL;
@@ -0,0 +1 @@
+ALTER TABLE Holds MODIFY placed_on varchar(255) NOT NULL, MODIFY ready_on varchar(255) NULL DEFAULT NULL, MODIFY expires_on varchar(255) NULL DEFAULT NULL;
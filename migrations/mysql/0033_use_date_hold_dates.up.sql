@@ -0,0 +1 @@
+ALTER TABLE Holds MODIFY placed_on DATE NOT NULL, MODIFY ready_on DATE NULL DEFAULT NULL, MODIFY expires_on DATE NULL DEFAULT NULL;
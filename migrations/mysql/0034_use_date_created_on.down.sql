@@ -0,0 +1 @@
+ALTER TABLE LedgerEntries MODIFY created_on varchar(255) NOT NULL;
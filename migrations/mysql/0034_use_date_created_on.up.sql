@@ -0,0 +1 @@
+ALTER TABLE LedgerEntries MODIFY created_on DATE NOT NULL;
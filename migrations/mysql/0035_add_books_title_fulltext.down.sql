@@ -0,0 +1 @@
+DROP INDEX ft_books_title ON Books;
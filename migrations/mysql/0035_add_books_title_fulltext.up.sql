@@ -0,0 +1 @@
+CREATE FULLTEXT INDEX ft_books_title ON Books (title);
@@ -0,0 +1 @@
+DROP INDEX ft_authors_names ON Authors;
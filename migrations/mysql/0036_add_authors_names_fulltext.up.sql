@@ -0,0 +1 @@
+CREATE FULLTEXT INDEX ft_authors_names ON Authors (first_name, last_name, pen_name);
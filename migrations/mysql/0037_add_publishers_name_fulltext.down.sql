@@ -0,0 +1 @@
+DROP INDEX ft_publishers_name ON Publishers;
@@ -0,0 +1 @@
+CREATE FULLTEXT INDEX ft_publishers_name ON Publishers (name);
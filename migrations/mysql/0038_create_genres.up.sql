@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS Genres(
+id int NOT NULL AUTO_INCREMENT,
+name varchar(255) NOT NULL,
+kind varchar(32) NOT NULL,
+parent_id int NULL,
+version int NOT NULL DEFAULT 1,
+PRIMARY KEY (id),
+UNIQUE KEY idx_genres_kind_name (kind, name),
+CONSTRAINT fk_genres_parent FOREIGN KEY (parent_id) REFERENCES Genres(id)
+);
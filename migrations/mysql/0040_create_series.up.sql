@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS Series(
+id int NOT NULL AUTO_INCREMENT,
+name varchar(255) NOT NULL,
+version int NOT NULL DEFAULT 1,
+PRIMARY KEY (id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS SeriesBooks(
+book_id int NOT NULL,
+series_id int NOT NULL,
+volume int NOT NULL,
+PRIMARY KEY (book_id),
+UNIQUE KEY idx_series_books_volume (series_id, volume),
+CONSTRAINT fk_series_books_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_series_books_series FOREIGN KEY (series_id) REFERENCES Series(id)
+);
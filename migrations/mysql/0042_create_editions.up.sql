@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS Editions(
+book_id int NOT NULL,
+work_id int NOT NULL,
+kind varchar(32) NOT NULL,
+language varchar(32) NOT NULL DEFAULT '',
+PRIMARY KEY (book_id),
+KEY idx_editions_work (work_id),
+CONSTRAINT fk_editions_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_editions_work FOREIGN KEY (work_id) REFERENCES Books(id) ON DELETE CASCADE
+);
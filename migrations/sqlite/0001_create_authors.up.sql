@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS Authors(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+first_name varchar(255) NOT NULL,
+last_name varchar(255) NOT NULL,
+dob varchar(255) NOT NULL,
+pen_name varchar(255) NOT NULL
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS Books(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+title varchar(255) NOT NULL,
+publication varchar(255) NOT NULL,
+publication_date varchar(255) NOT NULL,
+author_id int NOT NULL,
+CONSTRAINT fk_books_author FOREIGN KEY (author_id) REFERENCES Authors(id)
+);
+CREATE INDEX IF NOT EXISTS idx_books_author ON Books (author_id);
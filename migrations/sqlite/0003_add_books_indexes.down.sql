@@ -0,0 +1,2 @@
+DROP INDEX idx_books_publication;
+DROP INDEX idx_books_title;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_books_title ON Books (title);
+CREATE INDEX idx_books_publication ON Books (publication);
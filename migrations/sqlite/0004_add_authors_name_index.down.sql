@@ -0,0 +1 @@
+DROP INDEX idx_authors_name;
@@ -0,0 +1 @@
+ALTER TABLE Authors DROP COLUMN version;
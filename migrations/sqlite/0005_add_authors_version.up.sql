@@ -0,0 +1 @@
+ALTER TABLE Authors ADD COLUMN version int NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+ALTER TABLE Books ADD COLUMN version int NOT NULL DEFAULT 1;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Members(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+first_name varchar(255) NOT NULL,
+last_name varchar(255) NOT NULL,
+email varchar(255) NOT NULL,
+phone varchar(32) NOT NULL DEFAULT '',
+address varchar(255) NOT NULL DEFAULT '',
+membership_type varchar(32) NOT NULL,
+expires_on varchar(255) NOT NULL,
+status varchar(32) NOT NULL,
+version int NOT NULL DEFAULT 1
+);
+CREATE UNIQUE INDEX idx_members_email ON Members (email);
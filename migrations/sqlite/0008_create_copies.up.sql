@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Copies(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+book_id int NOT NULL,
+barcode varchar(64) NOT NULL,
+copy_condition varchar(32) NOT NULL,
+shelf_location varchar(255) NOT NULL DEFAULT '',
+acquired_on varchar(255) NOT NULL,
+status varchar(32) NOT NULL,
+version int NOT NULL DEFAULT 1,
+CONSTRAINT fk_copies_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX idx_copies_barcode ON Copies (barcode);
+CREATE INDEX idx_copies_book ON Copies (book_id, status);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS Holds(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+book_id int NOT NULL,
+member_id int NOT NULL,
+copy_id int NULL,
+status varchar(32) NOT NULL,
+placed_on varchar(255) NOT NULL,
+ready_on varchar(255) NOT NULL DEFAULT '',
+expires_on varchar(255) NOT NULL DEFAULT '',
+version int NOT NULL DEFAULT 1,
+CONSTRAINT fk_holds_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_holds_member FOREIGN KEY (member_id) REFERENCES Members(id) ON DELETE CASCADE,
+CONSTRAINT fk_holds_copy FOREIGN KEY (copy_id) REFERENCES Copies(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_holds_book ON Holds (book_id, status);
+CREATE INDEX idx_holds_member ON Holds (member_id, status);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS LedgerEntries(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+member_id int NOT NULL,
+loan_id int NULL,
+entry_type varchar(32) NOT NULL,
+amount int NOT NULL,
+note varchar(255) NOT NULL DEFAULT '',
+created_on varchar(255) NOT NULL,
+CONSTRAINT fk_ledger_member FOREIGN KEY (member_id) REFERENCES Members(id) ON DELETE RESTRICT,
+CONSTRAINT fk_ledger_loan FOREIGN KEY (loan_id) REFERENCES Loans(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_ledger_member ON LedgerEntries (member_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS Publishers(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+name varchar(255) NOT NULL,
+website varchar(255) NOT NULL DEFAULT '',
+version int NOT NULL DEFAULT 1
+);
+CREATE UNIQUE INDEX idx_publishers_name ON Publishers (name COLLATE NOCASE);
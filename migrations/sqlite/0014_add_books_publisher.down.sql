@@ -0,0 +1,2 @@
+DROP INDEX idx_books_publisher;
+ALTER TABLE Books DROP COLUMN publisher_id;
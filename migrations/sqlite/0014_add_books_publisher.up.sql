@@ -0,0 +1,2 @@
+ALTER TABLE Books ADD COLUMN publisher_id int REFERENCES Publishers(id);
+CREATE INDEX idx_books_publisher ON Books (publisher_id);
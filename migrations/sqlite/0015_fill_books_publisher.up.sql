@@ -0,0 +1 @@
+UPDATE Books SET publisher_id = (SELECT id FROM Publishers WHERE Publishers.name = Books.publication);
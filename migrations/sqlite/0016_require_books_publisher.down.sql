@@ -0,0 +1 @@
+-- nothing to revert, see 0016_require_books_publisher.up.sql
@@ -0,0 +1,2 @@
+-- sqlite can not make an added column NOT NULL, the reference to the publisher is declared with the column,
+-- the version is kept so that every version is the same schema in both dialects
@@ -0,0 +1,2 @@
+ALTER TABLE Books ADD COLUMN publication varchar(255) NOT NULL DEFAULT '';
+CREATE INDEX idx_books_publication ON Books (publication);
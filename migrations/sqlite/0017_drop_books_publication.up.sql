@@ -0,0 +1,2 @@
+DROP INDEX idx_books_publication;
+ALTER TABLE Books DROP COLUMN publication;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS BookAuthors(
+book_id int NOT NULL,
+author_id int NOT NULL,
+role varchar(32) NOT NULL,
+position int NOT NULL,
+PRIMARY KEY (book_id, author_id, role),
+CONSTRAINT fk_book_authors_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_book_authors_author FOREIGN KEY (author_id) REFERENCES Authors(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_book_authors_author ON BookAuthors (author_id);
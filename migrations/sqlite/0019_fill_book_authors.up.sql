@@ -0,0 +1 @@
+INSERT INTO BookAuthors (book_id, author_id, role, position) SELECT id, author_id, 'author', 1 FROM Books;
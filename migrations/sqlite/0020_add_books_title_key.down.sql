@@ -0,0 +1,2 @@
+DROP INDEX idx_books_title_key;
+ALTER TABLE Books DROP COLUMN title_key;
@@ -0,0 +1,2 @@
+ALTER TABLE Books ADD COLUMN title_key varchar(255) NOT NULL DEFAULT '';
+CREATE INDEX idx_books_title_key ON Books (title_key, author_id, publisher_id);
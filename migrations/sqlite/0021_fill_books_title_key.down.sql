@@ -0,0 +1 @@
+UPDATE Books SET title_key = '';
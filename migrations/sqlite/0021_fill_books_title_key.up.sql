@@ -0,0 +1,7 @@
+-- the key is the title in lower case with single spaces between the words, the same as entities.TitleKey. sqlite has
+-- no regular expressions, so tabs and line breaks are turned into spaces and the runs of spaces are halved until
+-- titles with up to 32 spaces in a row have single spaces. LOWER of sqlite only changes the ASCII letters, the
+-- keys of the other titles are set again when the books are saved
+UPDATE Books SET title_key = REPLACE(REPLACE(REPLACE(title, char(9), ' '), char(10), ' '), char(13), ' ');
+UPDATE Books SET title_key = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(title_key, '  ', ' '), '  ', ' '), '  ', ' '), '  ', ' '), '  ', ' ');
+UPDATE Books SET title_key = LOWER(TRIM(title_key));
@@ -0,0 +1,2 @@
+DROP INDEX idx_books_isbn;
+ALTER TABLE Books DROP COLUMN isbn;
@@ -0,0 +1,2 @@
+ALTER TABLE Books ADD COLUMN isbn varchar(13) NULL;
+CREATE UNIQUE INDEX idx_books_isbn ON Books (isbn);
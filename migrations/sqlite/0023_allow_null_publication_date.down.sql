@@ -0,0 +1 @@
+-- nothing to revert, see 0023_allow_null_publication_date.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0024_allow_null_dob.up.sql
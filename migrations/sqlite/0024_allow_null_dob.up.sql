@@ -0,0 +1,2 @@
+-- sqlite stores NULL in any column without altering it, the dates are converted by the convert_dates version,
+-- the version is kept so that every version is the same schema in both dialects
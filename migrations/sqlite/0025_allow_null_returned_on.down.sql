@@ -0,0 +1 @@
+-- nothing to revert, see 0025_allow_null_returned_on.up.sql
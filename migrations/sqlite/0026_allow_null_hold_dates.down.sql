@@ -0,0 +1 @@
+-- nothing to revert, see 0026_allow_null_hold_dates.up.sql
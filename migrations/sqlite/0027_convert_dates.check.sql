@@ -0,0 +1,23 @@
+-- the dates which are not dd/mm/yyyy would be lost by the conversion, an empty date is converted to NULL
+-- only for the columns which can be NULL
+SELECT tbl, col, id, value FROM (
+SELECT tbl, col, id, value, nullable, d, m, y, printf('%04d-%02d-%02d', y, m, d) AS iso FROM (
+SELECT tbl, col, id, value, nullable, CAST(substr(value, 1, instr(value, '/') - 1) AS INTEGER) AS d,
+CAST(substr(rest, 1, instr(rest, '/') - 1) AS INTEGER) AS m, CAST(substr(rest, instr(rest, '/') + 1) AS INTEGER) AS y
+FROM (SELECT tbl, col, id, value, nullable, substr(value, instr(value, '/') + 1) AS rest FROM (
+SELECT 'Books' AS tbl, 'publication_date' AS col, id, publication_date AS value, 1 AS nullable FROM Books
+UNION ALL SELECT 'Authors', 'dob', id, dob, 1 FROM Authors
+UNION ALL SELECT 'Members', 'expires_on', id, expires_on, 0 FROM Members
+UNION ALL SELECT 'Copies', 'acquired_on', id, acquired_on, 0 FROM Copies
+UNION ALL SELECT 'Loans', 'checked_out_on', id, checked_out_on, 0 FROM Loans
+UNION ALL SELECT 'Loans', 'due_on', id, due_on, 0 FROM Loans
+UNION ALL SELECT 'Loans', 'returned_on', id, returned_on, 1 FROM Loans
+UNION ALL SELECT 'Holds', 'placed_on', id, placed_on, 0 FROM Holds
+UNION ALL SELECT 'Holds', 'ready_on', id, ready_on, 1 FROM Holds
+UNION ALL SELECT 'Holds', 'expires_on', id, expires_on, 1 FROM Holds
+UNION ALL SELECT 'LedgerEntries', 'created_on', id, created_on, 0 FROM LedgerEntries
+))))
+WHERE NOT (value = '' AND nullable = 1)
+AND (value NOT IN (printf('%d/%d/%d', d, m, y), printf('%02d/%d/%d', d, m, y), printf('%d/%02d/%d', d, m, y),
+printf('%02d/%02d/%d', d, m, y)) OR y < 1000 OR date(iso, '+0 days') IS NOT iso)
+ORDER BY tbl, col, id;
@@ -0,0 +1,43 @@
+DROP INDEX idx_books_publication_date;
+DROP INDEX idx_loans_member;
+ALTER TABLE Books ADD COLUMN publication_date_text varchar(255) NOT NULL DEFAULT '';
+UPDATE Books SET publication_date_text = COALESCE(strftime('%d/%m/%Y', publication_date), '');
+ALTER TABLE Books DROP COLUMN publication_date;
+ALTER TABLE Books RENAME COLUMN publication_date_text TO publication_date;
+ALTER TABLE Authors ADD COLUMN dob_text varchar(255) NOT NULL DEFAULT '';
+UPDATE Authors SET dob_text = COALESCE(strftime('%d/%m/%Y', dob), '');
+ALTER TABLE Authors DROP COLUMN dob;
+ALTER TABLE Authors RENAME COLUMN dob_text TO dob;
+ALTER TABLE Members ADD COLUMN expires_on_text varchar(255) NOT NULL DEFAULT '';
+UPDATE Members SET expires_on_text = COALESCE(strftime('%d/%m/%Y', expires_on), '');
+ALTER TABLE Members DROP COLUMN expires_on;
+ALTER TABLE Members RENAME COLUMN expires_on_text TO expires_on;
+ALTER TABLE Copies ADD COLUMN acquired_on_text varchar(255) NOT NULL DEFAULT '';
+UPDATE Copies SET acquired_on_text = COALESCE(strftime('%d/%m/%Y', acquired_on), '');
+ALTER TABLE Copies DROP COLUMN acquired_on;
+ALTER TABLE Copies RENAME COLUMN acquired_on_text TO acquired_on;
+ALTER TABLE Loans ADD COLUMN checked_out_on_text varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE Loans ADD COLUMN due_on_text varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE Loans ADD COLUMN returned_on_text varchar(255) NOT NULL DEFAULT '';
+UPDATE Loans SET checked_out_on_text = COALESCE(strftime('%d/%m/%Y', checked_out_on), ''), due_on_text = COALESCE(strftime('%d/%m/%Y', due_on), ''), returned_on_text = COALESCE(strftime('%d/%m/%Y', returned_on), '');
+ALTER TABLE Loans DROP COLUMN checked_out_on;
+ALTER TABLE Loans RENAME COLUMN checked_out_on_text TO checked_out_on;
+ALTER TABLE Loans DROP COLUMN due_on;
+ALTER TABLE Loans RENAME COLUMN due_on_text TO due_on;
+ALTER TABLE Loans DROP COLUMN returned_on;
+ALTER TABLE Loans RENAME COLUMN returned_on_text TO returned_on;
+ALTER TABLE Holds ADD COLUMN placed_on_text varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE Holds ADD COLUMN ready_on_text varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE Holds ADD COLUMN expires_on_text varchar(255) NOT NULL DEFAULT '';
+UPDATE Holds SET placed_on_text = COALESCE(strftime('%d/%m/%Y', placed_on), ''), ready_on_text = COALESCE(strftime('%d/%m/%Y', ready_on), ''), expires_on_text = COALESCE(strftime('%d/%m/%Y', expires_on), '');
+ALTER TABLE Holds DROP COLUMN placed_on;
+ALTER TABLE Holds RENAME COLUMN placed_on_text TO placed_on;
+ALTER TABLE Holds DROP COLUMN ready_on;
+ALTER TABLE Holds RENAME COLUMN ready_on_text TO ready_on;
+ALTER TABLE Holds DROP COLUMN expires_on;
+ALTER TABLE Holds RENAME COLUMN expires_on_text TO expires_on;
+ALTER TABLE LedgerEntries ADD COLUMN created_on_text varchar(255) NOT NULL DEFAULT '';
+UPDATE LedgerEntries SET created_on_text = COALESCE(strftime('%d/%m/%Y', created_on), '');
+ALTER TABLE LedgerEntries DROP COLUMN created_on;
+ALTER TABLE LedgerEntries RENAME COLUMN created_on_text TO created_on;
+CREATE INDEX idx_loans_member ON Loans (member_id, returned_on);
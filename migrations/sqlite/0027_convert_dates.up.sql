@@ -0,0 +1,66 @@
+CREATE TEMP TABLE LegacyDates(
+tbl varchar(32) NOT NULL,
+col varchar(32) NOT NULL,
+id int NOT NULL,
+iso DATE,
+PRIMARY KEY (tbl, col, id)
+);
+INSERT INTO LegacyDates (tbl, col, id, iso)
+SELECT tbl, col, id, CASE WHEN value = '' THEN NULL ELSE printf('%04d-%02d-%02d', CAST(substr(rest, instr(rest, '/') + 1) AS INTEGER), CAST(substr(rest, 1, instr(rest, '/') - 1) AS INTEGER), CAST(substr(value, 1, instr(value, '/') - 1) AS INTEGER)) END
+FROM (SELECT tbl, col, id, value, substr(value, instr(value, '/') + 1) AS rest FROM (
+SELECT 'Books' AS tbl, 'publication_date' AS col, id, publication_date AS value FROM Books
+UNION ALL SELECT 'Authors', 'dob', id, dob FROM Authors
+UNION ALL SELECT 'Members', 'expires_on', id, expires_on FROM Members
+UNION ALL SELECT 'Copies', 'acquired_on', id, acquired_on FROM Copies
+UNION ALL SELECT 'Loans', 'checked_out_on', id, checked_out_on FROM Loans
+UNION ALL SELECT 'Loans', 'due_on', id, due_on FROM Loans
+UNION ALL SELECT 'Loans', 'returned_on', id, returned_on FROM Loans
+UNION ALL SELECT 'Holds', 'placed_on', id, placed_on FROM Holds
+UNION ALL SELECT 'Holds', 'ready_on', id, ready_on FROM Holds
+UNION ALL SELECT 'Holds', 'expires_on', id, expires_on FROM Holds
+UNION ALL SELECT 'LedgerEntries', 'created_on', id, created_on FROM LedgerEntries
+));
+DROP INDEX idx_loans_member;
+ALTER TABLE Books ADD COLUMN publication_date_iso DATE;
+UPDATE Books SET publication_date_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Books' AND col = 'publication_date' AND id = Books.id);
+ALTER TABLE Books DROP COLUMN publication_date;
+ALTER TABLE Books RENAME COLUMN publication_date_iso TO publication_date;
+CREATE INDEX idx_books_publication_date ON Books (publication_date);
+ALTER TABLE Authors ADD COLUMN dob_iso DATE;
+UPDATE Authors SET dob_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Authors' AND col = 'dob' AND id = Authors.id);
+ALTER TABLE Authors DROP COLUMN dob;
+ALTER TABLE Authors RENAME COLUMN dob_iso TO dob;
+ALTER TABLE Members ADD COLUMN expires_on_iso DATE;
+UPDATE Members SET expires_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Members' AND col = 'expires_on' AND id = Members.id);
+ALTER TABLE Members DROP COLUMN expires_on;
+ALTER TABLE Members RENAME COLUMN expires_on_iso TO expires_on;
+ALTER TABLE Copies ADD COLUMN acquired_on_iso DATE;
+UPDATE Copies SET acquired_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Copies' AND col = 'acquired_on' AND id = Copies.id);
+ALTER TABLE Copies DROP COLUMN acquired_on;
+ALTER TABLE Copies RENAME COLUMN acquired_on_iso TO acquired_on;
+ALTER TABLE Loans ADD COLUMN checked_out_on_iso DATE;
+ALTER TABLE Loans ADD COLUMN due_on_iso DATE;
+ALTER TABLE Loans ADD COLUMN returned_on_iso DATE;
+UPDATE Loans SET checked_out_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Loans' AND col = 'checked_out_on' AND id = Loans.id), due_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Loans' AND col = 'due_on' AND id = Loans.id), returned_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Loans' AND col = 'returned_on' AND id = Loans.id);
+ALTER TABLE Loans DROP COLUMN checked_out_on;
+ALTER TABLE Loans RENAME COLUMN checked_out_on_iso TO checked_out_on;
+ALTER TABLE Loans DROP COLUMN due_on;
+ALTER TABLE Loans RENAME COLUMN due_on_iso TO due_on;
+ALTER TABLE Loans DROP COLUMN returned_on;
+ALTER TABLE Loans RENAME COLUMN returned_on_iso TO returned_on;
+ALTER TABLE Holds ADD COLUMN placed_on_iso DATE;
+ALTER TABLE Holds ADD COLUMN ready_on_iso DATE;
+ALTER TABLE Holds ADD COLUMN expires_on_iso DATE;
+UPDATE Holds SET placed_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Holds' AND col = 'placed_on' AND id = Holds.id), ready_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Holds' AND col = 'ready_on' AND id = Holds.id), expires_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'Holds' AND col = 'expires_on' AND id = Holds.id);
+ALTER TABLE Holds DROP COLUMN placed_on;
+ALTER TABLE Holds RENAME COLUMN placed_on_iso TO placed_on;
+ALTER TABLE Holds DROP COLUMN ready_on;
+ALTER TABLE Holds RENAME COLUMN ready_on_iso TO ready_on;
+ALTER TABLE Holds DROP COLUMN expires_on;
+ALTER TABLE Holds RENAME COLUMN expires_on_iso TO expires_on;
+ALTER TABLE LedgerEntries ADD COLUMN created_on_iso DATE;
+UPDATE LedgerEntries SET created_on_iso = (SELECT iso FROM LegacyDates WHERE tbl = 'LedgerEntries' AND col = 'created_on' AND id = LedgerEntries.id);
+ALTER TABLE LedgerEntries DROP COLUMN created_on;
+ALTER TABLE LedgerEntries RENAME COLUMN created_on_iso TO created_on;
+CREATE INDEX idx_loans_member ON Loans (member_id, returned_on);
+DROP TABLE LegacyDates;
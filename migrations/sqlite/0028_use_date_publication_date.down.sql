@@ -0,0 +1 @@
+-- nothing to revert, see 0028_use_date_publication_date.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0029_use_date_dob.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0030_use_date_expires_on.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0031_use_date_acquired_on.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0032_use_date_loan_dates.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0033_use_date_hold_dates.up.sql
@@ -0,0 +1 @@
+-- nothing to revert, see 0034_use_date_created_on.up.sql
@@ -0,0 +1,4 @@
+DROP TRIGGER books_search_delete;
+DROP TRIGGER books_search_update;
+DROP TRIGGER books_search_insert;
+DROP TABLE BooksSearch;
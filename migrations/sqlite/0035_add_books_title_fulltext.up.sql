@@ -0,0 +1,11 @@
+-- sqlite has no FULLTEXT index, the titles are kept in an FTS4 table by triggers instead, the docid of a row is the id
+-- of its book. FTS4 is built into the driver while FTS5 needs a build tag. The statements of a trigger are on a single
+-- line, as a semicolon at the end of a line ends a statement of the migration
+CREATE VIRTUAL TABLE BooksSearch USING fts4(title, tokenize=unicode61 "remove_diacritics=0");
+INSERT INTO BooksSearch (docid, title) SELECT id, title FROM Books;
+CREATE TRIGGER books_search_insert AFTER INSERT ON Books
+BEGIN INSERT INTO BooksSearch (docid, title) VALUES (NEW.id, NEW.title); END;
+CREATE TRIGGER books_search_update AFTER UPDATE OF title ON Books
+BEGIN UPDATE BooksSearch SET title = NEW.title WHERE docid = NEW.id; END;
+CREATE TRIGGER books_search_delete AFTER DELETE ON Books
+BEGIN DELETE FROM BooksSearch WHERE docid = OLD.id; END;
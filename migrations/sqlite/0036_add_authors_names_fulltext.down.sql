@@ -0,0 +1,4 @@
+DROP TRIGGER authors_search_delete;
+DROP TRIGGER authors_search_update;
+DROP TRIGGER authors_search_insert;
+DROP TABLE AuthorsSearch;
@@ -0,0 +1,9 @@
+-- the names of the authors are kept in an FTS4 table the same way as the titles in 0035_add_books_title_fulltext
+CREATE VIRTUAL TABLE AuthorsSearch USING fts4(first_name, last_name, pen_name, tokenize=unicode61 "remove_diacritics=0");
+INSERT INTO AuthorsSearch (docid, first_name, last_name, pen_name) SELECT id, first_name, last_name, pen_name FROM Authors;
+CREATE TRIGGER authors_search_insert AFTER INSERT ON Authors
+BEGIN INSERT INTO AuthorsSearch (docid, first_name, last_name, pen_name) VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.pen_name); END;
+CREATE TRIGGER authors_search_update AFTER UPDATE OF first_name, last_name, pen_name ON Authors
+BEGIN UPDATE AuthorsSearch SET first_name = NEW.first_name, last_name = NEW.last_name, pen_name = NEW.pen_name WHERE docid = NEW.id; END;
+CREATE TRIGGER authors_search_delete AFTER DELETE ON Authors
+BEGIN DELETE FROM AuthorsSearch WHERE docid = OLD.id; END;
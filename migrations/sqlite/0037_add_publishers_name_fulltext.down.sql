@@ -0,0 +1,4 @@
+DROP TRIGGER publishers_search_delete;
+DROP TRIGGER publishers_search_update;
+DROP TRIGGER publishers_search_insert;
+DROP TABLE PublishersSearch;
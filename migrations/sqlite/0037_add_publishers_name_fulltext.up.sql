@@ -0,0 +1,9 @@
+-- the names of the publishers are kept in an FTS4 table the same way as the titles in 0035_add_books_title_fulltext
+CREATE VIRTUAL TABLE PublishersSearch USING fts4(name, tokenize=unicode61 "remove_diacritics=0");
+INSERT INTO PublishersSearch (docid, name) SELECT id, name FROM Publishers;
+CREATE TRIGGER publishers_search_insert AFTER INSERT ON Publishers
+BEGIN INSERT INTO PublishersSearch (docid, name) VALUES (NEW.id, NEW.name); END;
+CREATE TRIGGER publishers_search_update AFTER UPDATE OF name ON Publishers
+BEGIN UPDATE PublishersSearch SET name = NEW.name WHERE docid = NEW.id; END;
+CREATE TRIGGER publishers_search_delete AFTER DELETE ON Publishers
+BEGIN DELETE FROM PublishersSearch WHERE docid = OLD.id; END;
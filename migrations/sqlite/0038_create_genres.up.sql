@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS Genres(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+name varchar(255) NOT NULL,
+kind varchar(32) NOT NULL,
+parent_id int NULL REFERENCES Genres(id),
+version int NOT NULL DEFAULT 1
+);
+CREATE UNIQUE INDEX idx_genres_kind_name ON Genres (kind, name);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS BookGenres(
+book_id int NOT NULL,
+genre_id int NOT NULL,
+PRIMARY KEY (book_id, genre_id),
+CONSTRAINT fk_book_genres_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_book_genres_genre FOREIGN KEY (genre_id) REFERENCES Genres(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_book_genres_genre ON BookGenres (genre_id);
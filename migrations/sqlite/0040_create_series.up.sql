@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS Series(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+name varchar(255) NOT NULL,
+version int NOT NULL DEFAULT 1
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS SeriesBooks(
+book_id int NOT NULL PRIMARY KEY,
+series_id int NOT NULL,
+volume int NOT NULL,
+CONSTRAINT fk_series_books_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_series_books_series FOREIGN KEY (series_id) REFERENCES Series(id)
+);
+CREATE UNIQUE INDEX idx_series_books_volume ON SeriesBooks (series_id, volume);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS Editions(
+book_id int NOT NULL PRIMARY KEY,
+work_id int NOT NULL,
+kind varchar(32) NOT NULL,
+language varchar(32) NOT NULL DEFAULT '',
+CONSTRAINT fk_editions_book FOREIGN KEY (book_id) REFERENCES Books(id) ON DELETE CASCADE,
+CONSTRAINT fk_editions_work FOREIGN KEY (work_id) REFERENCES Books(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_editions_work ON Editions (work_id);
@@ -5,17 +5,81 @@ import (
 	_ "ThreeLayer/datastore/author"
 	"ThreeLayer/entities"
 	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"ThreeLayer/service/patch"
 	"context"
+	"time"
 )
 
 type authorService struct {
 	authorstore datastore.Author
 	bookstore   datastore.Book
+	loanstore   datastore.Loan
+	tx          datastore.Transactor
 }
 
 //dependency injection factory function
-func New(author datastore.Author, book datastore.Book) authorService {
-	return authorService{authorstore: author, bookstore: book}
+func New(author datastore.Author, book datastore.Book, loan datastore.Loan, tx datastore.Transactor) authorService {
+	return authorService{authorstore: author, bookstore: book, loanstore: loan, tx: tx}
+}
+
+// GetAuthor returns all the authors, books crediting every author in any role are included when includeBooks is set in the context
+func (s authorService) GetAuthor(ctx context.Context) ([]entities.AuthorDetails, error) {
+	authors, err := s.authorstore.GetAuthor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]entities.AuthorDetails, 0, len(authors))
+	for i := range authors {
+		details = append(details, entities.AuthorDetails{Author: authors[i]})
+	}
+
+	includeBooks, _ := ctx.Value(entities.IncludeBooks).(bool)
+	if !includeBooks {
+		return details, nil
+	}
+
+	books, contributors, err := s.getBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// grouping the books by every author credited on them so that all the books are fetched in a single call
+	booksByAuthor := make(map[int][]entities.Book)
+	for i := range books {
+		for _, id := range creditedIDs(books[i], contributors[books[i].ID]) {
+			booksByAuthor[id] = append(booksByAuthor[id], books[i])
+		}
+	}
+
+	for i := range details {
+		details[i].Books = booksByAuthor[details[i].ID]
+	}
+
+	return details, nil
+}
+
+// GetAuthorByID returns the author with given id, books crediting the author in any role are included when includeBooks is set in the context
+func (s authorService) GetAuthorByID(ctx context.Context, id int) (entities.AuthorDetails, error) {
+	author, err := s.authorstore.GetAuthorByID(ctx, id)
+	if err != nil {
+		return entities.AuthorDetails{}, err
+	}
+
+	details := entities.AuthorDetails{Author: author}
+
+	includeBooks, _ := ctx.Value(entities.IncludeBooks).(bool)
+	if !includeBooks {
+		return details, nil
+	}
+
+	details.Books, err = s.authorBooks(ctx, id)
+	if err != nil {
+		return entities.AuthorDetails{}, err
+	}
+
+	return details, nil
 }
 
 func (s authorService) PostAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
@@ -36,71 +100,261 @@ func (s authorService) PostAuthor(ctx context.Context, author entities.Author) (
 
 	return s.authorstore.CreateAuthor(ctx, author)
 }
+
+// PutAuthor replaces the author with given id, the version of the author is checked against the If-Match versions
+// in the context
 func (s authorService) PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error) {
-	_, err := s.authorstore.GetAuthorByID(ctx, id)
+	if err := checkDetails(author); err != nil {
+		return entities.Author{}, err
+	}
+
+	var updated entities.Author
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.authorstore.GetAuthorByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Author", id, stored.Version); err != nil {
+			return err
+		}
+
+		if author.Dob != stored.Dob {
+			if err = s.checkBorn(ctx, id, author.Dob); err != nil {
+				return err
+			}
+		}
+
+		author.Version = stored.Version
+
+		updated, err = s.authorstore.PutAuthor(ctx, id, author)
+
+		return err
+	})
 	if err != nil {
 		return entities.Author{}, err
 	}
 
-	return s.authorstore.PutAuthor(ctx, id, author)
+	return updated, nil
 }
-func (s authorService) DeleteAuthor(ctx context.Context, id int) error {
-	_, err := s.authorstore.GetAuthorByID(ctx, id)
-	if err != nil {
+
+// PatchAuthor applies the patch to the author with given id, the patched author is validated as a whole and
+// only the changed fields are updated
+func (s authorService) PatchAuthor(ctx context.Context, id int, p entities.Patch) (entities.Author, error) {
+	var patched entities.Author
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		author, err := s.authorstore.GetAuthorByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Author", id, author.Version); err != nil {
+			return err
+		}
+
+		patched = author
+		if err = patch.Apply(&patched, p); err != nil {
+			return err
+		}
+
+		patched.ID, patched.Version = id, author.Version
+
+		if err = checkDetails(patched); err != nil {
+			return err
+		}
+
+		fields := changedFields(author, patched)
+		if len(fields) == 0 {
+			return nil
+		}
+
+		if patched.Dob != author.Dob {
+			if err = s.checkBorn(ctx, id, patched.Dob); err != nil {
+				return err
+			}
+		}
+
+		patched.Version, err = s.authorstore.UpdateAuthorFields(ctx, id, patched, fields)
+
 		return err
-	}
-	books, err := s.bookstore.GetAllBook(ctx)
+	})
 	if err != nil {
-		return err
+		return entities.Author{}, err
 	}
 
-	books = matchBook(books, func(book entities.Book) bool {
-		return book.Author.ID == id
-	})
+	return patched, nil
+}
 
-	for i := range books {
-		err = s.bookstore.DeleteBook(ctx, (books[i].ID))
+// DeleteAuthor removes the author together with all the books of the author, nothing is removed when any of the deletes fails.
+// An author whose books have been lent is kept along with the loans, and so is an author credited on the books of
+// other authors as the credits would be removed with the author
+func (s authorService) DeleteAuthor(ctx context.Context, id int) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		author, err := s.authorstore.GetAuthorByID(ctx, id)
 		if err != nil {
 			return err
 		}
 
-	}
-	return s.authorstore.DeleteAuthor(ctx, id)
+		if err = service.CheckVersion(ctx, "Author", id, author.Version); err != nil {
+			return err
+		}
+
+		books, err := s.authorBooks(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for i := range books {
+			if books[i].Author.ID != id {
+				return errors.Conflict{Entity: "Author", ID: id, Reason: "is credited on books of other authors"}
+			}
+		}
+
+		for i := range books {
+			loans, err := s.loanstore.GetLoansByBook(ctx, books[i].ID)
+			if err != nil {
+				return err
+			}
+
+			if err = service.CheckLoans("Author", id, loans); err != nil {
+				return err
+			}
+		}
+
+		for i := range books {
+			err = s.bookstore.DeleteBook(ctx, books[i].ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.authorstore.DeleteAuthor(ctx, id)
+	})
 }
 
 //<--------------functions----------------->
 
+// getBooks returns all the books along with their contributors by book id
+func (s authorService) getBooks(ctx context.Context) ([]entities.Book, map[int][]entities.Contributor, error) {
+	books, err := s.bookstore.GetAllBook(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	contributors, err := s.bookstore.GetContributors(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return books, contributors, nil
+}
+
+// authorBooks returns the books crediting the author with given id in any role
+func (s authorService) authorBooks(ctx context.Context, id int) ([]entities.Book, error) {
+	page, err := s.bookstore.GetBooks(ctx, entities.BookFilter{AuthorID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Books, nil
+}
+
+// checkBorn returns InValidDetails when the author with given id, born on dob, is credited on a book published
+// on or before dob
+func (s authorService) checkBorn(ctx context.Context, id int, dob entities.Date) error {
+	books, err := s.authorBooks(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		if !dob.Before(books[i].PublishedDate) {
+			return errors.InValidDetails{Details: "Dob"}
+		}
+	}
+
+	return nil
+}
+
+// creditedIDs returns the unique ids of the author and the contributors of the book
+func creditedIDs(book entities.Book, contributors []entities.Contributor) []int {
+	ids := []int{book.Author.ID}
+
+	for _, c := range contributors {
+		credited := false
+
+		for _, id := range ids {
+			credited = credited || id == c.Author.ID
+		}
+
+		if !credited {
+			ids = append(ids, c.Author.ID)
+		}
+	}
+
+	return ids
+}
+
 // checking duplicacy
 func checkDuplicate(a1, a2 entities.Author) bool {
 	return a1.FirstName == a2.FirstName && a1.LastName == a2.LastName && a1.Dob == a2.Dob && a1.PenName == a2.PenName
 }
 
-// CheckDetails function is to check all the validations for an author
-func checkDetails(author entities.Author) error {
-	switch {
-	case author.FirstName == "":
-		return errors.InValidDetails{Details: "FirstName"}
-	case author.LastName == "":
-		return errors.InValidDetails{Details: "LastName"}
-	case author.PenName == "":
-		return errors.InValidDetails{Details: "PenName"}
-	case author.Dob == "":
-		return errors.InValidDetails{Details: "Dob"}
-	default:
-		return nil
+// changedFields returns the fields of the author which differ after the patch
+func changedFields(old, patched entities.Author) []string {
+	var fields []string
+
+	if old.FirstName != patched.FirstName {
+		fields = append(fields, entities.AuthorFirstName)
+	}
+
+	if old.LastName != patched.LastName {
+		fields = append(fields, entities.AuthorLastName)
+	}
+
+	if old.Dob != patched.Dob {
+		fields = append(fields, entities.AuthorDob)
 	}
+
+	if old.PenName != patched.PenName {
+		fields = append(fields, entities.AuthorPenName)
+	}
+
+	return fields
 }
 
-//match function is used of matching the all same book with same author id
-func matchBook(books []entities.Book, fn func(book entities.Book) bool) []entities.Book {
-	count := 0
+// CheckDetails function is to check all the validations for an author, all the invalid details are reported
+func checkDetails(author entities.Author) error {
+	var invalid []string
 
-	for i := range books {
+	if author.FirstName == "" {
+		invalid = append(invalid, "FirstName")
+	}
 
-		if fn(books[i]) {
-			books[count] = books[i]
-			count++
-		}
+	if author.LastName == "" {
+		invalid = append(invalid, "LastName")
 	}
-	return books[:count]
+
+	if author.PenName == "" {
+		invalid = append(invalid, "PenName")
+	}
+
+	// a date of birth is required and can not be in the future
+	if author.Dob.IsZero() || today().Before(author.Dob) {
+		invalid = append(invalid, "Dob")
+	}
+
+	return errors.InValid(invalid...)
+}
+
+// today returns the current date
+func today() entities.Date {
+	return entities.NewDate(time.Now().Date())
 }
@@ -9,28 +9,43 @@ import (
 	"testing"
 )
 
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 type mockAuthorStore struct {
 }
 
 func (m mockAuthorStore) GetAuthor(ctx context.Context) ([]entities.Author, error) {
-	return []entities.Author{{ID: 2, FirstName: "MG", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"}}, nil
+	return []entities.Author{{ID: 2, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}}, nil
 }
 func (m mockAuthorStore) GetAuthorByID(ctx context.Context, id int) (entities.Author, error) {
 	if id == 10 {
 		return entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: id}
 	}
-	return entities.Author{ID: id, FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"}, nil
+	return entities.Author{ID: id, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}, nil
 }
 func (m mockAuthorStore) PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error) {
 	if author.FirstName == "" {
 		return entities.Author{}, errors.InValidDetails{Details: "FirstName"}
 	}
 	if author.FirstName == "HC" {
-		return entities.Author{FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"}, nil
+		return entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+			PenName: "Verma"}, nil
 	}
 	return entities.Author{}, errors.EntityNotFound{Entity: "Author", ID: 100}
 }
 
+func (m mockAuthorStore) UpdateAuthorFields(ctx context.Context, id int, author entities.Author,
+	fields []string) (int, error) {
+	return author.Version + 1, nil
+}
+
 func (m mockAuthorStore) DeleteAuthor(ctx context.Context, id int) error {
 	if id == 1 {
 		return nil
@@ -40,9 +55,22 @@ func (m mockAuthorStore) DeleteAuthor(ctx context.Context, id int) error {
 	}
 	return errors.EntityNotFound{Entity: "Author", ID: id}
 }
+func (m mockAuthorStore) GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error) {
+	authors := make([]entities.Author, 0, len(ids))
+
+	for _, id := range ids {
+		if author, err := m.GetAuthorByID(ctx, id); err == nil {
+			authors = append(authors, author)
+		}
+	}
+
+	return authors, nil
+}
+
 func (m mockAuthorStore) CreateAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
 	if author.FirstName != "" {
-		return entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"}, nil
+		return entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+			PenName: "Verma"}, nil
 	}
 	return entities.Author{}, errors.InValidDetails{Details: "FirstName"}
 }
@@ -55,14 +83,47 @@ type mockBookStore struct {
 
 func (m mockBookStore) GetAllBook(ctx context.Context) ([]entities.Book, error) {
 	return []entities.Book{{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1},
-		Publication: "Rahul", PublishedDate: "11/03/2002"}, {ID: 2, Title: "Rahul", Author: entities.Author{ID: 1},
-		Publication: "Rahul", PublishedDate: "11/03/2002"}}, nil
+		Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2002, 3, 11)}, {ID: 2, Title: "Rahul", Author: entities.Author{ID: 1},
+		Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2002, 3, 11)}}, nil
+}
+
+// GetBooks lists the books crediting the author of the filter in any role
+func (m mockBookStore) GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	books, _ := m.GetAllBook(ctx)
+	contributors, _ := m.GetContributors(ctx, nil)
+
+	page := entities.BookPage{Books: []entities.Book{}}
+
+	for i := range books {
+		for _, c := range contributors[books[i].ID] {
+			if filter.AuthorID == 0 || c.Author.ID == filter.AuthorID {
+				page.Books = append(page.Books, books[i])
+				break
+			}
+		}
+	}
+
+	page.Total = len(page.Books)
+
+	return page, nil
 }
 
 func (m mockBookStore) GetBookByID(ctx context.Context, id int) (entities.Book, error) {
 	return entities.Book{}, nil
 }
 
+func (m mockBookStore) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	return entities.Book{}, nil
+}
+
+func (m mockBookStore) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	return entities.SearchPage{Results: []entities.SearchResult{}}, nil
+}
+
+func (m mockBookStore) GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error) {
+	return []entities.Book{}, nil
+}
+
 func (m mockBookStore) CreateBook(ctx context.Context, book entities.Book) (entities.Book, error) {
 	return entities.Book{}, nil
 }
@@ -72,6 +133,33 @@ func (m mockBookStore) UpdateBook(ctx context.Context, id int, book entities.Boo
 	return entities.Book{}, nil
 }
 
+func (m mockBookStore) UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+	return book.Version + 1, nil
+}
+
+// GetContributors credits the author 2 as the editor of the book 2
+func (m mockBookStore) GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error) {
+	return map[int][]entities.Contributor{1: {{Author: entities.Author{ID: 1}, Role: entities.RoleAuthor}},
+		2: {{Author: entities.Author{ID: 1}, Role: entities.RoleAuthor}, {Author: entities.Author{ID: 2},
+			Role: entities.RoleEditor}}}, nil
+}
+
+func (m mockBookStore) GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error) {
+	return map[int][]entities.Genre{}, nil
+}
+
+func (m mockBookStore) GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error) {
+	return entities.BookFacets{}, nil
+}
+
+func (m mockBookStore) GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	return map[int]entities.SeriesVolume{}, nil
+}
+
+func (m mockBookStore) GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error) {
+	return map[int]entities.Edition{}, nil
+}
+
 func (m mockBookStore) DeleteBook(ctx context.Context, id int) error {
 	if id == 3 {
 		return fmt.Errorf("temp err")
@@ -79,6 +167,45 @@ func (m mockBookStore) DeleteBook(ctx context.Context, id int) error {
 	return nil
 }
 
+//<-------------------LoanStruct----------------------->
+
+// mockLoanStore has only the loans it is given
+type mockLoanStore struct {
+	loans []entities.Loan
+}
+
+func (m mockLoanStore) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	return entities.Loan{}, errors.EntityNotFound{Entity: "Loan", ID: id}
+}
+
+func (m mockLoanStore) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return []entities.Loan{}, nil
+}
+
+func (m mockLoanStore) GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	loans := []entities.Loan{}
+
+	for i := range m.loans {
+		if m.loans[i].BookID == bookID {
+			loans = append(loans, m.loans[i])
+		}
+	}
+
+	return loans, nil
+}
+
+func (m mockLoanStore) GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return []entities.Loan{}, nil
+}
+
+func (m mockLoanStore) CreateLoan(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	return l, nil
+}
+
+func (m mockLoanStore) UpdateLoan(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+	return l, nil
+}
+
 //<------------------------------main functions--------------------------------------->
 func TestServiceAuthor_PostAuthor(t *testing.T) {
 
@@ -90,38 +217,45 @@ func TestServiceAuthor_PostAuthor(t *testing.T) {
 	}{
 		{
 			"Valid details",
-			entities.Author{FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"},
-			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"},
+			entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"},
+			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "Verma"},
 			nil,
 		},
 
 		{
 			"InValid details Firstname",
-			entities.Author{FirstName: "", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"},
+			entities.Author{FirstName: "", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"},
 			entities.Author{},
 			errors.InValidDetails{Details: "FirstName"},
 		},
 		{
 			"InValid details lastname",
-			entities.Author{FirstName: "HC", LastName: "", Dob: "2/12/1999", PenName: "Verma"},
+			entities.Author{FirstName: "HC", LastName: "", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"},
 			entities.Author{},
 			errors.InValidDetails{Details: "LastName"},
 		},
 		{
 			"InValid details dob",
-			entities.Author{FirstName: "HC", LastName: "Verma", Dob: "", PenName: "Verma"},
+			entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.Date{}, PenName: "Verma"},
 			entities.Author{},
 			errors.InValidDetails{Details: "Dob"},
 		},
 		{
 			"InValid details penname",
-			entities.Author{FirstName: "HC", LastName: "Verma", Dob: "2/12/1999", PenName: ""},
+			entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: ""},
 			entities.Author{},
 			errors.InValidDetails{Details: "PenName"},
 		},
+		{
+			"InValid details all reported",
+			entities.Author{FirstName: "", LastName: "", Dob: entities.Date{}, PenName: "Verma"},
+			entities.Author{},
+			errors.InValidFields{{Details: "FirstName"}, {Details: "LastName"}, {Details: "Dob"}},
+		},
 		{
 			"exist Already",
-			entities.Author{FirstName: "MG", LastName: "Verma", Dob: "2/12/1999", PenName: "Verma"},
+			entities.Author{FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"},
 			entities.Author{},
 			errors.ExistAlready{Entity: "Author"},
 		},
@@ -150,7 +284,7 @@ func TestServiceAuthor_PostAuthor(t *testing.T) {
 	//	assert.Equalf(t, v.reqResult, resp, "Actual %v and expected body %v not equal, Test Case %d failed", v.expResult, resp, i)
 
 	for i, v := range testcases {
-		a := New(mockAuthorStore{}, mockBookStore{})
+		a := New(mockAuthorStore{}, mockBookStore{}, mockLoanStore{}, mockTx{})
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, entities.FirstName, v.reqResult.FirstName)
@@ -165,6 +299,73 @@ func TestServiceAuthor_PostAuthor(t *testing.T) {
 	}
 }
 
+func TestServiceAuthor_GetAuthor(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		includeBooks bool
+		expResult    []entities.AuthorDetails
+		expErr       error
+	}{
+		{desc: "get all authors", expResult: []entities.AuthorDetails{{Author: entities.Author{ID: 2,
+			FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"}}}},
+		{desc: "get all authors with books, author credited as an editor", includeBooks: true,
+			expResult: []entities.AuthorDetails{{Author: entities.Author{ID: 2, FirstName: "MG",
+				LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"},
+				Books: []entities.Book{{ID: 2, Title: "Rahul",
+					Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+					PublishedDate: entities.NewDate(2002, 3, 11)}}}}},
+	}
+
+	for i, v := range testcases {
+		a := New(mockAuthorStore{}, mockBookStore{}, mockLoanStore{}, mockTx{})
+
+		ctx := context.WithValue(context.Background(), entities.IncludeBooks, v.includeBooks)
+		res, err := a.GetAuthor(ctx)
+		if !reflect.DeepEqual(v.expErr, err) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
+		}
+
+		if !reflect.DeepEqual(res, v.expResult) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expResult, res)
+		}
+	}
+}
+
+func TestServiceAuthor_GetAuthorByID(t *testing.T) {
+	testcases := []struct {
+		desc         string
+		reqID        int
+		includeBooks bool
+		expResult    entities.AuthorDetails
+		expErr       error
+	}{
+		{desc: "get author", reqID: 1, expResult: entities.AuthorDetails{Author: entities.Author{ID: 1,
+			FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"}}},
+		{desc: "get author with books", reqID: 1, includeBooks: true,
+			expResult: entities.AuthorDetails{Author: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma",
+				Dob: entities.NewDate(1999, 12, 2), PenName: "Verma"}, Books: []entities.Book{{ID: 1, Title: "Rahul",
+				Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+				PublishedDate: entities.NewDate(2002, 3, 11)},
+				{ID: 2, Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+					PublishedDate: entities.NewDate(2002, 3, 11)}}}},
+		{desc: "author does not exist", reqID: 10, expErr: errors.EntityNotFound{Entity: "Author", ID: 10}},
+	}
+
+	for i, v := range testcases {
+		a := New(mockAuthorStore{}, mockBookStore{}, mockLoanStore{}, mockTx{})
+
+		ctx := context.WithValue(context.Background(), entities.IncludeBooks, v.includeBooks)
+		res, err := a.GetAuthorByID(ctx, v.reqID)
+		if !reflect.DeepEqual(v.expErr, err) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
+		}
+
+		if !reflect.DeepEqual(res, v.expResult) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expResult, res)
+		}
+	}
+}
+
 func TestServiceAuthor_PutAuthor(t *testing.T) {
 	testcases := []struct {
 		desc      string
@@ -176,18 +377,24 @@ func TestServiceAuthor_PutAuthor(t *testing.T) {
 		{
 			desc: "id does not exists", reqID: 10,
 			reqResult: entities.Author{ID: 10, FirstName: "Rahul", LastName: "Saini",
-				Dob: "22/07/2000", PenName: "ABC"},
+				Dob: entities.NewDate(2000, 7, 22), PenName: "ABC"},
 			expErr: errors.EntityNotFound{Entity: "Author", ID: 10},
 		},
 		{
 			desc: "InValid details first name", reqID: 1,
-			reqResult: entities.Author{FirstName: "", LastName: "Verma", Dob: "2/12/1999",
+			reqResult: entities.Author{FirstName: "", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
 				PenName: "Verma"},
 			expErr: errors.InValidDetails{Details: "FirstName"},
 		},
+		{
+			desc: "born after a book of the author was published", reqID: 1,
+			reqResult: entities.Author{FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(2002, 3, 11),
+				PenName: "Verma"},
+			expErr: errors.InValidDetails{Details: "Dob"},
+		},
 	}
 	for i, v := range testcases {
-		a := New(mockAuthorStore{}, mockBookStore{})
+		a := New(mockAuthorStore{}, mockBookStore{}, mockLoanStore{}, mockTx{})
 
 		resBook, err := a.PutAuthor(context.Background(), v.reqID, v.reqResult)
 		if !reflect.DeepEqual(v.expErr, err) {
@@ -200,19 +407,114 @@ func TestServiceAuthor_PutAuthor(t *testing.T) {
 	}
 }
 
+// fieldsAuthorStore records the fields updated by UpdateAuthorFields
+type fieldsAuthorStore struct {
+	mockAuthorStore
+	fields *[]string
+}
+
+func (m fieldsAuthorStore) UpdateAuthorFields(ctx context.Context, id int, author entities.Author,
+	fields []string) (int, error) {
+	*m.fields = fields
+	return author.Version + 1, nil
+}
+
+func TestServiceAuthor_PatchAuthor(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		id        int
+		patch     entities.Patch
+		versions  []int
+		expFields []string
+		expRes    entities.Author
+		expErr    error
+	}{
+		{desc: "merge patch of the pen name", id: 1,
+			patch:     entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":"HCV"}`)},
+			expFields: []string{entities.AuthorPenName},
+			expRes: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "HCV",
+				Version: 1}},
+		{desc: "json patch of the names", id: 1, patch: entities.Patch{Type: entities.JSONPatch,
+			Doc: []byte(`[{"op":"replace","path":"/first_name","value":"MG"},{"op":"replace","path":"/last_name","value":"V"}]`)},
+			expFields: []string{entities.AuthorFirstName, entities.AuthorLastName},
+			expRes:    entities.Author{ID: 1, FirstName: "MG", LastName: "V", Dob: entities.NewDate(1999, 12, 2), PenName: "Verma", Version: 1}},
+		{desc: "version matches", id: 1, versions: []int{3, 0},
+			patch:     entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":"HCV"}`)},
+			expFields: []string{entities.AuthorPenName},
+			expRes: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "HCV",
+				Version: 1}},
+		{desc: "version does not match", id: 1, versions: []int{3},
+			patch:  entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":"HCV"}`)},
+			expErr: errors.PreconditionFailed{Entity: "Author", ID: 1}},
+		{desc: "nothing changed", id: 1, patch: entities.Patch{Type: entities.MergePatch, Doc: []byte(`{}`)},
+			expRes: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "Verma"}},
+		{desc: "removed field is validated", id: 1,
+			patch:  entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":null,"dob":""}`)},
+			expErr: errors.InValidFields{{Details: "PenName"}, {Details: "Dob"}}},
+		{desc: "legacy date of birth", id: 1,
+			patch:     entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"dob":"1/1/1980"}`)},
+			expFields: []string{entities.AuthorDob},
+			expRes: entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1980, 1, 1),
+				PenName: "Verma", Version: 1}},
+		{desc: "born after a book of the author was published", id: 1,
+			patch:  entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"dob":"2003-01-01"}`)},
+			expErr: errors.InValidDetails{Details: "Dob"}},
+		{desc: "born in the future", id: 1,
+			patch:  entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"dob":"2999-01-01"}`)},
+			expErr: errors.InValidDetails{Details: "Dob"}},
+		{desc: "author not found", id: 10, patch: entities.Patch{Type: entities.MergePatch, Doc: []byte(`{}`)},
+			expErr: errors.EntityNotFound{Entity: "Author", ID: 10}},
+	}
+
+	for i, tc := range testcases {
+		var fields []string
+
+		a := New(fieldsAuthorStore{fields: &fields}, mockBookStore{}, mockLoanStore{}, mockTx{})
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		res, err := a.PatchAuthor(ctx, tc.id, tc.patch)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		if !reflect.DeepEqual(fields, tc.expFields) {
+			t.Errorf("[TEST%d]Failed. Expected fields %v\tGot %v", i, tc.expFields, fields)
+		}
+	}
+}
+
 func TestServiceAuthor_DeleteAuthor(t *testing.T) {
 	testcases := []struct {
 		desc   string
 		reqID  int
 		expErr error
+		loans  []entities.Loan
 	}{
-		{"Valid Details", 1, nil},
+		{"Valid Details", 1, nil, nil},
 		{desc: "Author does not exists", reqID: 100, expErr: errors.EntityNotFound{Entity: "Author", ID: 100}},
+		{desc: "book is lent", reqID: 1, loans: []entities.Loan{{ID: 1, CopyID: 1, BookID: 2}},
+			expErr: errors.Conflict{Entity: "Author", ID: 1, Reason: "has an active loan"}},
+		{desc: "book was lent", reqID: 1,
+			loans:  []entities.Loan{{ID: 1, CopyID: 1, BookID: 2, ReturnedOn: entities.NewDate(2022, 1, 10)}},
+			expErr: errors.Conflict{Entity: "Author", ID: 1, Reason: "has a loan history"}},
+		{desc: "author is credited on a book of another author", reqID: 2,
+			expErr: errors.Conflict{Entity: "Author", ID: 2, Reason: "is credited on books of other authors"}},
 	}
 
 	for i, v := range testcases {
 
-		a := New(mockAuthorStore{}, mockBookStore{})
+		a := New(mockAuthorStore{}, mockBookStore{}, mockLoanStore{loans: v.loans}, mockTx{})
 		ctx := context.Background()
 		err := a.DeleteAuthor(ctx, v.reqID)
 		if !reflect.DeepEqual(v.expErr, err) {
@@ -1,13 +1,22 @@
 package books
 
 import (
+	"ThreeLayer/datastore"
 	"ThreeLayer/entities"
 	"ThreeLayer/errors"
 	"context"
+	"github.com/golang/mock/gomock"
 	"reflect"
 	"testing"
 )
 
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 //<---------------------AUTHOR STORE--------------------------->
 type mockAuthorStore struct {
 }
@@ -23,7 +32,20 @@ func (m mockAuthorStore) GetAuthorByID(ctx context.Context, id int) (entities.Au
 	if id == 9 {
 		return entities.Author{}, errors.InValidDetails{Details: "Author ID"}
 	}
-	return entities.Author{ID: id, FirstName: "RD", LastName: "Sharma", Dob: "2/11/1989", PenName: "Sharma"}, nil
+	return entities.Author{ID: id, FirstName: "RD", LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2),
+		PenName: "Sharma"}, nil
+}
+
+func (m mockAuthorStore) GetAuthorsByIDs(ctx context.Context, ids []int) ([]entities.Author, error) {
+	authors := make([]entities.Author, 0, len(ids))
+
+	for _, id := range ids {
+		if author, err := m.GetAuthorByID(ctx, id); err == nil {
+			authors = append(authors, author)
+		}
+	}
+
+	return authors, nil
 }
 
 func (m mockAuthorStore) CreateAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
@@ -34,36 +56,184 @@ func (m mockAuthorStore) PutAuthor(ctx context.Context, id int, author entities.
 	return entities.Author{}, nil
 }
 
+func (m mockAuthorStore) UpdateAuthorFields(ctx context.Context, id int, author entities.Author,
+	fields []string) (int, error) {
+	return author.Version + 1, nil
+}
+
 func (m mockAuthorStore) DeleteAuthor(ctx context.Context, id int) error {
 	return nil
 }
+
+//<---------------------PUBLISHER STORE--------------------------->
+type mockPublisherStore struct {
+}
+
+func (m mockPublisherStore) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	return []entities.Publisher{{ID: 1, Name: "Arihanth"}, {ID: 2, Name: "Scholastic"}, {ID: 3, Name: "Penguin"}}, nil
+}
+
+func (m mockPublisherStore) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	publishers, _ := m.GetPublishers(ctx)
+
+	for i := range publishers {
+		if publishers[i].ID == id {
+			return publishers[i], nil
+		}
+	}
+
+	return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher", ID: id}
+}
+
+func (m mockPublisherStore) GetPublishersByIDs(ctx context.Context, ids []int) ([]entities.Publisher, error) {
+	publishers := make([]entities.Publisher, 0, len(ids))
+
+	for _, id := range ids {
+		if p, err := m.GetPublisherByID(ctx, id); err == nil {
+			publishers = append(publishers, p)
+		}
+	}
+
+	return publishers, nil
+}
+
+func (m mockPublisherStore) GetPublisherByName(ctx context.Context, name string) (entities.Publisher, error) {
+	return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher"}
+}
+
+func (m mockPublisherStore) CreatePublisher(ctx context.Context, p entities.Publisher) (entities.Publisher, error) {
+	return entities.Publisher{}, nil
+}
+
+func (m mockPublisherStore) UpdatePublisher(ctx context.Context, id int, p entities.Publisher) (entities.Publisher,
+	error) {
+	return entities.Publisher{}, nil
+}
+
+func (m mockPublisherStore) DeletePublisher(ctx context.Context, id int) error {
+	return nil
+}
+
+//<---------------------GENRE STORE--------------------------->
+// mockGenreStore has the genre Fiction with its child Mystery and the subject History
+type mockGenreStore struct {
+}
+
+func (m mockGenreStore) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	return []entities.Genre{{ID: 1, Name: "Fiction", Kind: entities.KindGenre},
+		{ID: 2, Name: "Mystery", Kind: entities.KindGenre, ParentID: 1},
+		{ID: 3, Name: "History", Kind: entities.KindSubject}}, nil
+}
+
+func (m mockGenreStore) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	return entities.Genre{}, errors.EntityNotFound{Entity: "Genre", ID: id}
+}
+
+func (m mockGenreStore) CreateGenre(ctx context.Context, g entities.Genre) (entities.Genre, error) {
+	return g, nil
+}
+
+func (m mockGenreStore) UpdateGenre(ctx context.Context, id int, g entities.Genre) (entities.Genre, error) {
+	return g, nil
+}
+
+func (m mockGenreStore) DeleteGenre(ctx context.Context, id int) error {
+	return nil
+}
+
+// mockSeriesStore has only the series 1 Dune, the book 2 is its first volume
+type mockSeriesStore struct {
+}
+
+func (m mockSeriesStore) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	return []entities.Series{{ID: 1, Name: "Dune", Version: 1}}, nil
+}
+
+func (m mockSeriesStore) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	if id == 1 {
+		return entities.Series{ID: 1, Name: "Dune", Version: 1}, nil
+	}
+
+	return entities.Series{}, errors.EntityNotFound{Entity: "Series", ID: id}
+}
+
+func (m mockSeriesStore) CreateSeries(ctx context.Context, series entities.Series) (entities.Series, error) {
+	return series, nil
+}
+
+func (m mockSeriesStore) UpdateSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error) {
+	return series, nil
+}
+
+func (m mockSeriesStore) DeleteSeries(ctx context.Context, id int) error {
+	return nil
+}
+
+func (m mockSeriesStore) GetVolumes(ctx context.Context, id int) (map[int]int, error) {
+	if id == 1 {
+		return map[int]int{1: 2}, nil
+	}
+
+	return map[int]int{}, nil
+}
+
 func TestServiceBook_GetBook(t *testing.T) {
 	testcases := []struct {
 		desc          string
-		title         string
+		filter        entities.BookFilter
 		includeAuthor string
-		expResult     []entities.Book
+		expResult     entities.BookPage
 		expErr        error
 	}{
-		{desc: "get all books", expResult: []entities.Book{
-			{ID: 1, Title: "Rahul", Publication: "Penguin", PublishedDate: "22/07/2000", Author: entities.Author{ID: 3}},
-		}},
-
-		{desc: "get all books with query param", title: "Rahul", includeAuthor: "false", expResult: []entities.Book{
-			{ID: 1, Title: "Rahul", Publication: "Penguin", PublishedDate: "22/07/2000", Author: entities.Author{ID: 3}},
-		}},
-		{desc: "get all books with query param", includeAuthor: "true", expResult: []entities.Book{
-			{ID: 1, Title: "Rahul", Author: entities.Author{ID: 3, FirstName: "RD", LastName: "Sharma", Dob: "2/11/1989",
-				PenName: "Sharma"}, Publication: "Penguin", PublishedDate: "22/07/2000"},
-		}},
+		{desc: "get all books", expResult: entities.BookPage{Books: []entities.Book{
+			{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3, Name: "Penguin"}, PublishedDate: entities.NewDate(2000, 7, 22), Author: entities.Author{ID: 3},
+				Contributors: []entities.Contributor{{Author: entities.Author{ID: 3}, Role: entities.RoleAuthor}},
+				Availability: &entities.Availability{}},
+		}, Total: 1, Limit: DefaultPageSize}},
+
+		{desc: "get all books with query param", filter: entities.BookFilter{Title: "Rahul", Sort: entities.SortByTitle,
+			Limit: 10, Offset: 0}, includeAuthor: "false", expResult: entities.BookPage{Books: []entities.Book{
+			{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3, Name: "Penguin"}, PublishedDate: entities.NewDate(2000, 7, 22), Author: entities.Author{ID: 3},
+				Contributors: []entities.Contributor{{Author: entities.Author{ID: 3}, Role: entities.RoleAuthor}},
+				Availability: &entities.Availability{}},
+		}, Total: 1, Limit: 10}},
+		{desc: "get all books with query param", includeAuthor: "true", expResult: entities.BookPage{Books: []entities.Book{
+			{ID: 1, Title: "Rahul", Author: entities.Author{ID: 3, FirstName: "RD", LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2),
+				PenName: "Sharma"}, Contributors: []entities.Contributor{{Author: entities.Author{ID: 3, FirstName: "RD",
+				LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2), PenName: "Sharma"}, Role: entities.RoleAuthor}},
+				Publisher: entities.Publisher{ID: 3, Name: "Penguin"}, PublishedDate: entities.NewDate(2000, 7, 22),
+				Availability: &entities.Availability{}},
+		}, Total: 1, Limit: DefaultPageSize}},
+		{desc: "unknown sort", filter: entities.BookFilter{Sort: "author"}, expErr: errors.InValidDetails{Details: "sort"}},
+		{desc: "limit too large", filter: entities.BookFilter{Limit: MaxPageSize + 1},
+			expErr: errors.InValidDetails{Details: "limit"}},
+		{desc: "negative offset", filter: entities.BookFilter{Offset: -1}, expErr: errors.InValidDetails{Details: "offset"}},
+		{desc: "negative publisher", filter: entities.BookFilter{PublisherID: -1},
+			expErr: errors.InValidDetails{Details: "publisherId"}},
+		{desc: "invalid date", filter: entities.BookFilter{PublishedFrom: "22/07/2000"},
+			expErr: errors.InValidDetails{Details: "publishedFrom"}},
+		{desc: "invalid date", filter: entities.BookFilter{PublishedTo: "2000-13-01"},
+			expErr: errors.InValidDetails{Details: "publishedTo"}},
+		{desc: "facets by count with names", filter: entities.BookFilter{Facets: true},
+			expResult: entities.BookPage{Books: []entities.Book{
+				{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3, Name: "Penguin"},
+					PublishedDate: entities.NewDate(2000, 7, 22), Author: entities.Author{ID: 3},
+					Contributors: []entities.Contributor{{Author: entities.Author{ID: 3}, Role: entities.RoleAuthor}},
+					Availability: &entities.Availability{}},
+			}, Total: 1, Limit: DefaultPageSize, Facets: &entities.BookFacets{
+				Genres: []entities.Facet{{ID: 1, Name: "Fiction", Count: 2}, {ID: 2, Name: "Mystery", Count: 1}},
+				Publishers: []entities.Facet{{ID: 3, Name: "Penguin", Count: 1}},
+				Decades:    []entities.DecadeFacet{{Decade: 2000, Count: 1}}}}},
+		{desc: "genre does not exist", filter: entities.BookFilter{GenreID: 7},
+			expErr: errors.InValidDetails{Details: "genre"}},
+		{desc: "negative genre", filter: entities.BookFilter{GenreID: -1}, expErr: errors.InValidDetails{Details: "genre"}},
 	}
 	for i, v := range testcases {
-		a := New(mockBookStore{}, mockAuthorStore{})
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
 
 		ctx := context.Background()
-		ctx = context.WithValue(ctx, entities.Title, v.title)
 		ctx = context.WithValue(ctx, entities.IncludeAuthor, v.includeAuthor == "true")
-		output, err := a.GetBook(ctx)
+		output, err := a.GetBook(ctx, v.filter)
 
 		if !reflect.DeepEqual(err, v.expErr) {
 			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
@@ -75,6 +245,268 @@ func TestServiceBook_GetBook(t *testing.T) {
 	}
 }
 
+// TestServiceBook_GetBookAuthorsBatch checks that the authors of all the books are fetched in a single call
+func TestServiceBook_GetBookAuthorsBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bookStore := datastore.NewMockBook(ctrl)
+	authorStore := datastore.NewMockAuthor(ctrl)
+
+	books := make([]entities.Book, 0, 10)
+	ids := make([]int, 0, 10)
+	contributors := make(map[int][]entities.Contributor, 10)
+
+	for i := 1; i <= 10; i++ {
+		books = append(books, entities.Book{ID: i, Title: "Rahul", Author: entities.Author{ID: i%3 + 1}})
+		ids = append(ids, i)
+		contributors[i] = []entities.Contributor{{Author: entities.Author{ID: i%3 + 1}, Role: entities.RoleAuthor}}
+	}
+
+	// the author 4 is the illustrator of the last book
+	contributors[10] = append(contributors[10], entities.Contributor{Author: entities.Author{ID: 4},
+		Role: entities.RoleIllustrator})
+
+	authors := []entities.Author{{ID: 1, FirstName: "RD"}, {ID: 2, FirstName: "HC"}, {ID: 4, FirstName: "MG"}}
+
+	bookStore.EXPECT().GetBooks(gomock.Any(), gomock.Any()).
+		Return(entities.BookPage{Books: books, Total: len(books)}, nil)
+	bookStore.EXPECT().GetContributors(gomock.Any(), ids).Return(contributors, nil).Times(1)
+	bookStore.EXPECT().GetBookGenres(gomock.Any(), ids).Return(map[int][]entities.Genre{}, nil)
+	bookStore.EXPECT().GetBookSeries(gomock.Any(), ids).Return(map[int]entities.SeriesVolume{}, nil)
+	bookStore.EXPECT().GetEditions(gomock.Any(), ids).Return(map[int]entities.Edition{}, nil)
+	authorStore.EXPECT().GetAuthorsByIDs(gomock.Any(), []int{2, 3, 1, 4}).Return(authors, nil).Times(1)
+
+	ctx := context.WithValue(context.Background(), entities.IncludeAuthor, true)
+
+	page, err := New(bookStore, authorStore, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{}).GetBook(ctx, entities.BookFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("Failed. Expected error to be nil Got %v", err)
+	}
+
+	// author 3 does not exist and is left empty
+	byID := map[int]entities.Author{1: authors[0], 2: authors[1]}
+
+	for _, book := range page.Books {
+		if expAuthor := byID[book.ID%3+1]; book.Author != expAuthor || book.Contributors[0].Author != expAuthor {
+			t.Errorf("Failed. Expected author %v for book %d Got %v", expAuthor, book.ID, book.Author)
+		}
+	}
+
+	if illustrator := page.Books[9].Contributors[1]; illustrator.Author != authors[2] {
+		t.Errorf("Failed. Expected the illustrator %v Got %v", authors[2], illustrator.Author)
+	}
+}
+
+// TestServiceBook_GetBookByGenre checks that the books of a genre include the books of its descendants
+func TestServiceBook_GetBookByGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bookStore := datastore.NewMockBook(ctrl)
+	genreStore := datastore.NewMockGenre(ctrl)
+
+	genreStore.EXPECT().GetGenres(gomock.Any()).Return([]entities.Genre{{ID: 1, Name: "Fiction"},
+		{ID: 2, Name: "Mystery", ParentID: 1}, {ID: 3, Name: "Noir", ParentID: 2}, {ID: 4, Name: "Poetry"}}, nil)
+	bookStore.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{GenreID: 2, GenreIDs: []int{2, 3}, Sort: entities.SortByID,
+		Limit: DefaultPageSize}).Return(entities.BookPage{Books: []entities.Book{}}, nil)
+
+	_, err := New(bookStore, mockAuthorStore{}, mockPublisherStore{}, genreStore, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{}).
+		GetBook(context.Background(), entities.BookFilter{GenreID: 2})
+	if err != nil {
+		t.Errorf("Failed. Expected error to be nil Got %v", err)
+	}
+}
+
+// TestServiceBook_GetSeriesBooks checks that the volumes of a series are listed in order by default
+func TestServiceBook_GetSeriesBooks(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		id        int
+		filter    entities.BookFilter
+		expFilter entities.BookFilter
+		expErr    error
+	}{
+		{desc: "in the order of the volumes", id: 1, expFilter: entities.BookFilter{SeriesID: 1,
+			Sort: entities.SortByVolume, Limit: DefaultPageSize}},
+		{desc: "in the order of the filter", id: 1, filter: entities.BookFilter{Sort: entities.SortByTitle, Desc: true},
+			expFilter: entities.BookFilter{SeriesID: 1, Sort: entities.SortByTitle, Desc: true,
+				Limit: DefaultPageSize}},
+		{desc: "series does not exist", id: 7, expErr: errors.EntityNotFound{Entity: "Series", ID: 7}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		bookStore := datastore.NewMockBook(ctrl)
+
+		if tc.expErr == nil {
+			bookStore.EXPECT().GetBooks(gomock.Any(), tc.expFilter).
+				Return(entities.BookPage{Books: []entities.Book{}}, nil)
+		}
+
+		_, err := New(bookStore, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{},
+			mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{}).GetSeriesBooks(context.Background(), tc.id, tc.filter)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+// TestServiceBook_GetEditions checks that the editions of the work of an edition are those of its original
+func TestServiceBook_GetEditions(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		id        int
+		expWorkID int
+		expErr    error
+	}{
+		{desc: "editions of an original", id: 2, expWorkID: 2},
+		{desc: "editions of the work of an edition", id: 3, expWorkID: 2},
+		{desc: "book does not exist", id: 9, expErr: errors.EntityNotFound{Entity: "Book", ID: 9}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		bookStore := datastore.NewMockBook(ctrl)
+
+		// the book 3 is a translation of the book 2
+		bookStore.EXPECT().GetBookByID(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id int) (entities.Book, error) {
+				if id > 3 {
+					return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
+				}
+
+				return entities.Book{ID: id}, nil
+			})
+		bookStore.EXPECT().GetEditions(gomock.Any(), []int{tc.id}).Return(map[int]entities.Edition{
+			3: {WorkID: 2, Kind: entities.KindTranslation}}, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			bookStore.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{WorkID: tc.expWorkID,
+				Sort: entities.SortByPublishedDate, Limit: DefaultPageSize}).
+				Return(entities.BookPage{Books: []entities.Book{}}, nil)
+		}
+
+		_, err := New(bookStore, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{},
+			mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{}).GetEditions(context.Background(), tc.id, entities.BookFilter{})
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+// TestServiceBook_GetEdition checks that the editions are linked to the originals of their works
+func TestServiceBook_GetEdition(t *testing.T) {
+	testcases := []struct {
+		desc       string
+		id         int
+		edition    entities.Edition
+		expEdition *entities.Edition
+		expErr     error
+	}{
+		{desc: "edition of an original", id: 4, edition: entities.Edition{WorkID: 2, Kind: entities.KindEdition},
+			expEdition: &entities.Edition{WorkID: 2, Kind: entities.KindEdition}},
+		{desc: "translation of a translation is linked to the original", edition: entities.Edition{WorkID: 3,
+			Kind: entities.KindTranslation, Language: "fr"}, expEdition: &entities.Edition{WorkID: 2,
+			Kind: entities.KindTranslation, Language: "fr"}},
+		{desc: "linked to itself through its translation", id: 2, edition: entities.Edition{WorkID: 3,
+			Kind: entities.KindEdition}, expErr: errors.InValidDetails{Details: "Work ID"}},
+		{desc: "linked to itself", id: 3, edition: entities.Edition{WorkID: 3, Kind: entities.KindEdition},
+			expErr: errors.InValidDetails{Details: "Work ID"}},
+		{desc: "original does not exist", id: 4, edition: entities.Edition{WorkID: 9, Kind: entities.KindEdition},
+			expErr: errors.InValidDetails{Details: "Work ID"}},
+		{desc: "book has editions", id: 5, edition: entities.Edition{WorkID: 2, Kind: entities.KindEdition},
+			expErr: errors.Conflict{Entity: "Book", ID: 5, Reason: "has editions"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		bookStore := datastore.NewMockBook(ctrl)
+
+		// the book 3 is a translation of the book 2 and the book 6 an edition of the book 5
+		bookStore.EXPECT().GetBookByID(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id int) (entities.Book, error) {
+				if id > 6 {
+					return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
+				}
+
+				return entities.Book{ID: id}, nil
+			})
+		bookStore.EXPECT().GetEditions(gomock.Any(), []int{tc.edition.WorkID}).Return(map[int]entities.Edition{
+			3: {WorkID: 2, Kind: entities.KindTranslation}}, nil).AnyTimes()
+		bookStore.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{WorkID: tc.id, Limit: 1}).DoAndReturn(
+			func(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+				if filter.WorkID == 5 {
+					return entities.BookPage{Books: []entities.Book{{ID: 5}}, Total: 2}, nil
+				}
+
+				return entities.BookPage{Books: []entities.Book{{ID: filter.WorkID}}, Total: 1}, nil
+			}).AnyTimes()
+
+		s := New(bookStore, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{},
+			mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
+
+		res, err := s.getEdition(context.Background(), tc.id, &tc.edition)
+		if !reflect.DeepEqual(err, tc.expErr) || !reflect.DeepEqual(res, tc.expEdition) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v, %v\tGot %v, %v", i, tc.desc, tc.expEdition, tc.expErr, res, err)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestServiceBook_SearchBooks(t *testing.T) {
+	author := entities.Author{ID: 3, FirstName: "RD", LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2),
+		PenName: "Sharma"}
+
+	testcases := []struct {
+		desc      string
+		query     entities.SearchQuery
+		expResult entities.SearchPage
+		expErr    error
+	}{
+		{desc: "books with details", query: entities.SearchQuery{Text: "Rahul sharma", Offset: 5},
+			expResult: entities.SearchPage{Results: []entities.SearchResult{{Book: entities.Book{ID: 1, Title: "Rahul",
+				Author: author, Contributors: []entities.Contributor{{Author: author, Role: entities.RoleAuthor}},
+				Publisher:     entities.Publisher{ID: 3, Name: "Penguin"},
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: &entities.Availability{}}, Score: 3}},
+				Total: 1, Limit: DefaultPageSize, Offset: 5}},
+		{desc: "no words", query: entities.SearchQuery{Text: " -- "}, expErr: errors.InValidDetails{Details: "q"}},
+		{desc: "too many words", query: entities.SearchQuery{Text: "a b c d e f g h i j k"},
+			expErr: errors.InValidDetails{Details: "q"}},
+		{desc: "invalid page", query: entities.SearchQuery{Text: "Rahul", Limit: MaxPageSize + 1, Offset: -1},
+			expErr: errors.InValidFields{{Details: "limit"}, {Details: "offset"}}},
+	}
+
+	for i, v := range testcases {
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
+
+		output, err := a.SearchBooks(context.Background(), v.query)
+
+		if !reflect.DeepEqual(err, v.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
+		}
+
+		if !reflect.DeepEqual(output, v.expResult) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expResult, output)
+		}
+	}
+}
+
+// TestCheckSearch checks that the text of a search is split into its words in lower case, each of them once
+func TestCheckSearch(t *testing.T) {
+	query, err := checkSearch(entities.SearchQuery{Text: "Harry  POTTER, harry's"})
+
+	expTerms := []string{"harry", "potter", "s"}
+	if err != nil || !reflect.DeepEqual(query.Terms, expTerms) {
+		t.Errorf("Failed. Expected %v Got %v, %v", expTerms, query.Terms, err)
+	}
+}
+
 func TestServiceBook_GetBookByID(t *testing.T) {
 	testcases := []struct {
 		desc      string
@@ -87,7 +519,7 @@ func TestServiceBook_GetBookByID(t *testing.T) {
 		//{desc: "Book ID doesn't exist", id: 2, expResult: entities.Book{}, expErr: errors.EntityNotFound{Entity: "Book", ID: 2}},
 	}
 	for i, v := range testcases {
-		a := New(mockBookStore{}, mockAuthorStore{})
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
 
 		output, err := a.GetBookByID(context.Background(), v.id)
 		if !reflect.DeepEqual(v.expErr, err) {
@@ -100,7 +532,37 @@ func TestServiceBook_GetBookByID(t *testing.T) {
 	}
 }
 
+func TestServiceBook_GetBookByISBN(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		isbn   string
+		expID  int
+		expErr error
+	}{
+		{desc: "isbn-13", isbn: "9780306406157", expID: 1},
+		{desc: "isbn-10 with hyphens", isbn: "0-306-40615-2", expID: 1},
+		{desc: "isbn doesn't exist", isbn: "978-1-86197-271-2", expErr: errors.EntityNotFound{Entity: "Book"}},
+		{desc: "invalid isbn", isbn: "0-306-40615-3", expErr: errors.InValidDetails{Details: "isbn"}},
+	}
+
+	for i, v := range testcases {
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
+
+		output, err := a.GetBookByISBN(context.Background(), v.isbn)
+		if !reflect.DeepEqual(v.expErr, err) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
+		}
+
+		if output.ID != v.expID {
+			t.Errorf("[TEST%d]Failed. Expected book %v\tGot %v", i, v.expID, output)
+		}
+	}
+}
+
 func TestServiceBook_PostBook(t *testing.T) {
+	// the stored book is Rahul by the author 3
+	policy := Policy{DuplicateKeys: []string{entities.DuplicateByTitle, entities.DuplicateByAuthor}}
+
 	testcases := []struct {
 		desc      string
 		reqResult entities.Book
@@ -108,42 +570,93 @@ func TestServiceBook_PostBook(t *testing.T) {
 		expErr    error
 	}{
 		{desc: "Valid case", reqResult: entities.Book{Title: "Rahul", Author: entities.Author{ID: 1},
-			Publication: "Arihanth", PublishedDate: "22/07/2000"},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22)},
 			expResult: entities.Book{ID: 1, Title: "Rahul",
-				Author: entities.Author{ID: 1}, Publication: "Arihanth",
-				PublishedDate: "22/07/2000"}},
+				Author:    entities.Author{ID: 1, FirstName: "RD", LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2), PenName: "Sharma"},
+				Publisher: entities.Publisher{ID: 1, Name: "Arihanth"}, PublishedDate: entities.NewDate(2000, 7, 22),
+				Availability: &entities.Availability{}}},
 		{desc: "Already Exists", reqResult: entities.Book{Title: "Rahul",
-			Author:      entities.Author{ID: 3},
-			Publication: "Arihanth", PublishedDate: "22/07/2000"},
-			expErr: errors.ExistAlready{Entity: "Book"}},
-		{desc: "Publication should be Scholastic/Penguin/Arihanth", reqResult: entities.Book{Title: "Rahul",
-			Author: entities.Author{ID: 1}, Publication: "Rahul",
-			PublishedDate: "22/07/2000"},
-			expErr: errors.InValidDetails{Details: "Publication"}},
+			Author:    entities.Author{ID: 3},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			expErr: errors.ExistAlready{Entity: "Book", ID: 1}},
+		{desc: "title differs only in case and spacing", reqResult: entities.Book{Title: "  rahul ",
+			Author: entities.Author{ID: 3}, Publisher: entities.Publisher{ID: 2},
+			PublishedDate: entities.NewDate(2001, 1, 1)},
+			expErr: errors.ExistAlready{Entity: "Book", ID: 1}},
+		{desc: "isbn-10 of the stored book", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22), ISBN: "0-306-40615-2"},
+			expErr: errors.ExistAlready{Entity: "Book", ID: 1}},
+		{desc: "invalid isbn", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22),
+			ISBN: "978-0-306-40615-8"},
+			expErr: errors.InValidDetails{Details: "ISBN"}},
+		{desc: "isbn-10 sent alone", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22), ISBN10: "0-306-40615-2"},
+			expErr: errors.ExistAlready{Entity: "Book", ID: 1}},
+		{desc: "isbn-10 of another isbn", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22),
+			ISBN: "978-0-306-40615-7", ISBN10: "0-8044-2957-X"},
+			expErr: errors.InValidDetails{Details: "ISBN10"}},
+		{desc: "Publisher does not exist", reqResult: entities.Book{Title: "Rahul",
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 7},
+			PublishedDate: entities.NewDate(2000, 7, 22)},
+			expErr: errors.InValidDetails{Details: "Publisher ID"}},
 
 		{desc: "Published date should be in between 1880 and 2022", reqResult: entities.Book{Title: "Rahul",
-			Author: entities.Author{ID: 1}, Publication: "",
-			PublishedDate: "1/1/1600"},
-			expErr: errors.InValidDetails{Details: "PublishedDate"}},
+			Author:        entities.Author{ID: 1},
+			PublishedDate: entities.NewDate(1600, 1, 1)},
+			expErr: errors.InValidFields{{Details: "Publisher ID"}, {Details: "PublishedDate"}}},
 
+		{desc: "published in the future", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2999, 1, 1)},
+			expErr: errors.InValidDetails{Details: "PublishedDate"}},
+		{desc: "published before the author was born", reqResult: entities.Book{Title: "Sharma",
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(1989, 11, 2)},
+			expErr: errors.InValidDetails{Details: "PublishedDate"}},
 		{desc: "Author id invalid", reqResult: entities.Book{Title: "Rahul",
 			Author:        entities.Author{ID: 2},
-			Publication:   "Penguin",
-			PublishedDate: "22/07/2000"},
-			expErr: errors.InValidDetails{Details: "Author ID "}},
+			Publisher:     entities.Publisher{ID: 3},
+			PublishedDate: entities.NewDate(2000, 7, 22)},
+			expErr: errors.InValidDetails{Details: "Author ID"}},
 		{desc: "Author id invalid", reqResult: entities.Book{Title: "Rahul",
 			Author:        entities.Author{ID: 0},
-			Publication:   "Penguin",
-			PublishedDate: "22/07/2000"},
+			Publisher:     entities.Publisher{ID: 3},
+			PublishedDate: entities.NewDate(2000, 7, 22)},
 			expErr: errors.InValidDetails{Details: "Author ID"}},
 		{desc: "Title empty", reqResult: entities.Book{Title: "",
 			Author:        entities.Author{ID: 1},
-			Publication:   "",
-			PublishedDate: ""},
-			expErr: errors.InValidDetails{Details: "Title"}},
+			PublishedDate: entities.Date{}},
+			expErr: errors.InValidFields{{Details: "Title"}, {Details: "Publisher ID"}, {Details: "PublishedDate"}}},
+		{desc: "contributor does not exist", reqResult: entities.Book{Title: "Rahul",
+			Contributors: []entities.Contributor{{Author: entities.Author{ID: 1}}, {Author: entities.Author{ID: 2},
+				Role: entities.RoleEditor}}, Publisher: entities.Publisher{ID: 3},
+			PublishedDate: entities.NewDate(2000, 7, 22)},
+			expErr: errors.InValidDetails{Details: "Author ID"}},
+		{desc: "invalid role and contributor credited twice", reqResult: entities.Book{Title: "Rahul",
+			Contributors: []entities.Contributor{{Author: entities.Author{ID: 1}}, {Author: entities.Author{ID: 4},
+				Role: "reviewer"}, {Author: entities.Author{ID: 1}, Role: entities.RoleAuthor}},
+			Publisher: entities.Publisher{ID: 3}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			expErr: errors.InValidFields{{Details: "Role"}, {Details: "Contributors"}}},
+		{desc: "series does not exist", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22),
+			Series: &entities.SeriesVolume{ID: 7, Volume: 1}},
+			expErr: errors.InValidDetails{Details: "Series ID"}},
+		{desc: "volume of another book", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22),
+			Series: &entities.SeriesVolume{ID: 1, Volume: 1}},
+			expErr: errors.Conflict{Entity: "Series", ID: 1, Reason: "has volume 1"}},
+		{desc: "invalid volume and edition", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22),
+			Series: &entities.SeriesVolume{ID: 1}, Edition: &entities.Edition{Kind: "reprint"}},
+			expErr: errors.InValidFields{{Details: "Volume"}, {Details: "Work ID"}, {Details: "Edition Kind"}}},
+		{desc: "original does not exist", reqResult: entities.Book{Title: "Sharma", Author: entities.Author{ID: 1},
+			Publisher: entities.Publisher{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22),
+			Edition: &entities.Edition{WorkID: 9, Kind: " Translation ", Language: "hi"}},
+			expErr: errors.InValidDetails{Details: "Work ID"}},
 	}
 	for i, v := range testcases {
-		a := New(mockBookStore{}, mockAuthorStore{})
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, policy)
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, entities.Title, v.reqResult.Title)
@@ -154,12 +667,40 @@ func TestServiceBook_PostBook(t *testing.T) {
 			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expErr, err)
 		}
 
-		if res != v.expResult {
+		if !reflect.DeepEqual(res, v.expResult) {
 			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, v.expResult, res)
 		}
 	}
 }
 
+func TestNormalizeISBN(t *testing.T) {
+	testcases := []struct {
+		isbn      string
+		expISBN   string
+		expValid  bool
+		expISBN10 string
+	}{
+		{isbn: "978-0-306-40615-7", expISBN: "9780306406157", expValid: true, expISBN10: "0306406152"},
+		{isbn: "0-306-40615-2", expISBN: "9780306406157", expValid: true, expISBN10: "0306406152"},
+		{isbn: "0 8044 2957 x", expISBN: "9780804429573", expValid: true, expISBN10: "080442957X"},
+		{isbn: "979-1-0000000-1-5", expISBN: "9791000000015", expValid: true},
+		{isbn: "0-306-40615-3", expISBN: "0306406153"},
+		{isbn: "978-0-306-40615-8", expISBN: "9780306406158", expISBN10: "0306406152"},
+		{isbn: "97803064061A7", expISBN: "97803064061A7"},
+	}
+
+	for i, tc := range testcases {
+		isbn := normalizeISBN(tc.isbn)
+		if isbn != tc.expISBN || validISBN13(isbn) != tc.expValid {
+			t.Errorf("[TEST%d]Failed. Expected %v, %v\tGot %v, %v", i, tc.expISBN, tc.expValid, isbn, validISBN13(isbn))
+		}
+
+		if isbn10 := entities.ToISBN10(isbn); isbn10 != tc.expISBN10 {
+			t.Errorf("[TEST%d]Failed. Expected ISBN-10 %v\tGot %v", i, tc.expISBN10, isbn10)
+		}
+	}
+}
+
 func TestServiceBook_PutBook(t *testing.T) {
 	testcases := []struct {
 		desc      string
@@ -170,17 +711,19 @@ func TestServiceBook_PutBook(t *testing.T) {
 	}{
 
 		{desc: "invalid case id not exist", reqID: 999, reqResult: entities.Book{ID: 999, Title: "title1",
-			Author: entities.Author{ID: 9}, Publication: "Arihanth", PublishedDate: "18/08/2018"},
+			Author: entities.Author{ID: 9}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(2018, 8, 18)},
 			expErr: errors.EntityNotFound{Entity: "Book", ID: 999}},
 		{desc: "Invalid name.", reqID: 1, reqResult: entities.Book{ID: 1,
-			Author: entities.Author{ID: 1}, Publication: "Oxford", PublishedDate: "22/07/2000"},
-			expErr: errors.InValidDetails{Details: "Title"}},
+			Author: entities.Author{ID: 1}, PublishedDate: entities.NewDate(2000, 7, 22)},
+			expErr: errors.InValidFields{{Details: "Title"}, {Details: "Publisher ID"}}},
 		{desc: "invalid case id not found", reqID: 1, reqResult: entities.Book{ID: 1, Title: "title1",
-			Author: entities.Author{ID: 9}, Publication: "Arihanth", PublishedDate: "22/07/2000"},
+			Author: entities.Author{ID: 9}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(2000, 7, 22)},
 			expErr: errors.EntityNotFound{Entity: "Book", ID: 1}},
 	}
 	for i, v := range testcases {
-		a := New(mockBookStore{}, mockAuthorStore{})
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
 
 		resBook, err := a.PutBook(context.Background(), v.reqID, v.reqResult)
 		if !reflect.DeepEqual(v.expErr, err) {
@@ -205,7 +748,7 @@ func TestServiceBook_DeleteBook(t *testing.T) {
 		},
 	}
 	for i, v := range testcases {
-		a := New(mockBookStore{}, mockAuthorStore{})
+		a := New(mockBookStore{}, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
 		ctx := context.Background()
 		err := a.DeleteBook(ctx, v.reqID)
 
@@ -217,26 +760,351 @@ func TestServiceBook_DeleteBook(t *testing.T) {
 
 }
 
+func TestServiceBook_DeleteBookLoans(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		loans  []entities.Loan
+		expErr error
+	}{
+		{desc: "copy is lent", loans: []entities.Loan{{ID: 1, CopyID: 1, BookID: 1}},
+			expErr: errors.Conflict{Entity: "Book", ID: 1, Reason: "has an active loan"}},
+		{desc: "copy was lent", loans: []entities.Loan{{ID: 1, CopyID: 1, BookID: 1,
+			ReturnedOn: entities.NewDate(2022, 1, 10)}}, expErr: errors.Conflict{Entity: "Book", ID: 1,
+			Reason: "has a loan history"}},
+		{desc: "another book was lent", loans: []entities.Loan{{ID: 1, CopyID: 2, BookID: 2}}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		bookStore := datastore.NewMockBook(ctrl)
+
+		bookStore.EXPECT().GetBookByID(gomock.Any(), 1).Return(entities.Book{ID: 1, Version: 1}, nil)
+		bookStore.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{WorkID: 1, Limit: 1}).
+			Return(entities.BookPage{Books: []entities.Book{{ID: 1}}, Total: 1}, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			bookStore.EXPECT().DeleteBook(gomock.Any(), 1).Return(nil)
+		}
+
+		err := New(bookStore, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{},
+			mockCopyStore{}, mockLoanStore{loans: tc.loans}, mockTx{}, Policy{}).DeleteBook(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestServiceBook_DeleteBookEditions(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		total  int
+		expErr error
+	}{
+		{desc: "book without editions", total: 1},
+		{desc: "editions point at the book", total: 3,
+			expErr: errors.Conflict{Entity: "Book", ID: 1, Reason: "has editions"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		bookStore := datastore.NewMockBook(ctrl)
+
+		bookStore.EXPECT().GetBookByID(gomock.Any(), 1).Return(entities.Book{ID: 1, Version: 1}, nil)
+		bookStore.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{WorkID: 1, Limit: 1}).
+			Return(entities.BookPage{Books: []entities.Book{{ID: 1}}, Total: tc.total}, nil)
+
+		if tc.expErr == nil {
+			bookStore.EXPECT().DeleteBook(gomock.Any(), 1).Return(nil)
+		}
+
+		err := New(bookStore, mockAuthorStore{}, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{},
+			mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{}).DeleteBook(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestServiceBook_PatchBook(t *testing.T) {
+	initial := entities.Book{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+	author := entities.Author{ID: 1, FirstName: "RD", LastName: "Sharma", Dob: entities.NewDate(1989, 11, 2),
+		PenName: "Sharma"}
+	translator := entities.Author{ID: 4, FirstName: "MG", LastName: "Verma", Dob: entities.NewDate(2000, 7, 13),
+		PenName: "Verma"}
+	availability := &entities.Availability{Total: 2, Available: 1, OnLoan: 1}
+	penguin := entities.Publisher{ID: 3, Name: "Penguin"}
+	credits := []entities.Contributor{{Author: author, Role: entities.RoleAuthor}}
+
+	testcases := []struct {
+		desc      string
+		patch     entities.Patch
+		expFields []string
+		expRes    entities.Book
+		expErr    error
+	}{
+		{desc: "merge patch of the title", patch: entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"title":"Go"}`)},
+			expFields: []string{entities.BookTitle},
+			expRes: entities.Book{ID: 1, Version: 1, Title: "Go", Author: author, Contributors: credits, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: availability}},
+		{desc: "json patch of publisher and date", patch: entities.Patch{Type: entities.JSONPatch,
+			Doc: []byte(`[{"op":"replace","path":"/publisher","value":{"id":2}},` +
+				`{"op":"replace","path":"/published_date","value":"01/01/2001"}]`)},
+			expFields: []string{entities.BookPublisher, entities.BookPublishedDate},
+			expRes: entities.Book{ID: 1, Version: 1, Title: "Rahul", Author: author, Contributors: credits,
+				Publisher: entities.Publisher{ID: 2, Name: "Scholastic"}, PublishedDate: entities.NewDate(2001, 1, 1),
+				Availability: availability}},
+		{desc: "nothing changed", patch: entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"id":5}`)},
+			expRes: entities.Book{ID: 1, Title: "Rahul", Author: author, Contributors: credits, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: availability}},
+		{desc: "json patch adding a translator", patch: entities.Patch{Type: entities.JSONPatch,
+			Doc: []byte(`[{"op":"add","path":"/contributors/-","value":{"author":{"id":4},"role":"translator"}}]`)},
+			expFields: []string{entities.BookContributors},
+			expRes: entities.Book{ID: 1, Version: 1, Title: "Rahul", Author: author, Contributors: append(credits,
+				entities.Contributor{Author: translator, Role: entities.RoleTranslator}), Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: availability}},
+		{desc: "merge patch of the author credits the new author alone", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"author":{"id":4}}`)}, expFields: []string{entities.BookContributors},
+			expRes: entities.Book{ID: 1, Version: 1, Title: "Rahul", Author: translator, Contributors: []entities.Contributor{
+				{Author: translator, Role: entities.RoleAuthor}}, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22),
+				Availability:  availability}},
+		{desc: "contributor does not exist", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"contributors":[{"author":{"id":1}},{"author":{"id":7},"role":"editor"}]}`)},
+			expErr: errors.InValidDetails{Details: "Author ID"}},
+		{desc: "merged book is validated", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"title":null,"publisher":null}`)},
+			expErr: errors.InValidFields{{Details: "Title"}, {Details: "Publisher ID"}}},
+		{desc: "publisher does not exist", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"publisher":{"id":7}}`)}, expErr: errors.InValidDetails{Details: "Publisher ID"}},
+		{desc: "merge patch of the genres", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"genres":[{"id":3},{"id":2}]}`)}, expFields: []string{entities.BookGenres},
+			expRes: entities.Book{ID: 1, Version: 1, Title: "Rahul", Author: author, Contributors: credits, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Genres: []entities.Genre{
+					{ID: 2, Name: "Mystery", Kind: entities.KindGenre, ParentID: 1},
+					{ID: 3, Name: "History", Kind: entities.KindSubject}}, Availability: availability}},
+		{desc: "genre does not exist", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"genres":[{"id":7}]}`)}, expErr: errors.InValidDetails{Details: "Genre ID"}},
+		{desc: "merge patch of the series", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"series":{"id":1,"volume":2}}`)}, expFields: []string{entities.BookSeries},
+			expRes: entities.Book{ID: 1, Version: 1, Title: "Rahul", Author: author, Contributors: credits, Publisher: penguin,
+				PublishedDate: entities.NewDate(2000, 7, 22), Series: &entities.SeriesVolume{ID: 1, Name: "Dune",
+					Volume: 2}, Availability: availability}},
+		{desc: "volume of another book", patch: entities.Patch{Type: entities.MergePatch,
+			Doc: []byte(`{"series":{"id":1,"volume":1}}`)},
+			expErr: errors.Conflict{Entity: "Series", ID: 1, Reason: "has volume 1"}},
+		{desc: "invalid patch", patch: entities.Patch{Type: entities.JSONPatch, Doc: []byte(`{"title":"Go"}`)},
+			expErr: errors.InValidDetails{Details: "patch"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		bookStore := datastore.NewMockBook(ctrl)
+		authorStore := datastore.NewMockAuthor(ctrl)
+		copyStore := datastore.NewMockCopy(ctrl)
+
+		// the book read after the update has the patched fields
+		stored := initial
+		bookStore.EXPECT().GetBookByID(gomock.Any(), 1).DoAndReturn(func(ctx context.Context, id int) (entities.Book, error) {
+			return stored, nil
+		}).AnyTimes()
+		bookStore.EXPECT().GetContributors(gomock.Any(), []int{1}).DoAndReturn(
+			func(ctx context.Context, ids []int) (map[int][]entities.Contributor, error) {
+				contributors := make([]entities.Contributor, 0)
+				for _, c := range stored.Credits() {
+					contributors = append(contributors, entities.Contributor{Author: entities.Author{ID: c.Author.ID},
+						Role: c.Role})
+				}
+
+				return map[int][]entities.Contributor{1: contributors}, nil
+			}).AnyTimes()
+		bookStore.EXPECT().GetBookGenres(gomock.Any(), []int{1}).DoAndReturn(
+			func(ctx context.Context, ids []int) (map[int][]entities.Genre, error) {
+				return map[int][]entities.Genre{1: stored.Genres}, nil
+			}).AnyTimes()
+		bookStore.EXPECT().GetBookSeries(gomock.Any(), []int{1}).DoAndReturn(
+			func(ctx context.Context, ids []int) (map[int]entities.SeriesVolume, error) {
+				if stored.Series == nil {
+					return map[int]entities.SeriesVolume{}, nil
+				}
+
+				return map[int]entities.SeriesVolume{1: *stored.Series}, nil
+			}).AnyTimes()
+		bookStore.EXPECT().GetEditions(gomock.Any(), []int{1}).Return(map[int]entities.Edition{}, nil).AnyTimes()
+		authorStore.EXPECT().GetAuthorsByIDs(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, ids []int) ([]entities.Author, error) {
+				var authors []entities.Author
+				for _, a := range []entities.Author{author, translator} {
+					for _, id := range ids {
+						if a.ID == id {
+							authors = append(authors, a)
+						}
+					}
+				}
+
+				return authors, nil
+			}).AnyTimes()
+		copyStore.EXPECT().GetAvailability(gomock.Any(), []int{1}).
+			Return(map[int]entities.Availability{1: *availability}, nil).AnyTimes()
+
+		if tc.expFields != nil {
+			bookStore.EXPECT().UpdateBookFields(gomock.Any(), 1, gomock.Any(), tc.expFields).
+				DoAndReturn(func(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+					book.Version++
+					stored = book
+
+					return book.Version, nil
+				})
+		}
+
+		res, err := New(bookStore, authorStore, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, copyStore, mockLoanStore{}, mockTx{}, Policy{}).PatchBook(context.Background(), 1, tc.patch)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if !reflect.DeepEqual(res, tc.expRes) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestServiceBook_IfMatch(t *testing.T) {
+	stored := entities.Book{ID: 1, Title: "Rahul", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22), Version: 2}
+	update := entities.Book{Title: "Go", Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22)}
+
+	testcases := []struct {
+		desc     string
+		versions []int
+		expErr   error
+	}{
+		{desc: "If-Match not sent"},
+		{desc: "version matches", versions: []int{1, 2}},
+		{desc: "version does not match", versions: []int{1}, expErr: errors.PreconditionFailed{Entity: "Book", ID: 1}},
+		{desc: "no valid version", versions: []int{}, expErr: errors.PreconditionFailed{Entity: "Book", ID: 1}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		bookStore := datastore.NewMockBook(ctrl)
+		authorStore := datastore.NewMockAuthor(ctrl)
+
+		bookStore.EXPECT().GetBookByID(gomock.Any(), 1).Return(stored, nil).Times(2)
+
+		expRes := entities.Book{}
+
+		if tc.expErr == nil {
+			// the book sent with only the author is credited to the author alone
+			credited := update
+			credited.Contributors = []entities.Contributor{{Author: entities.Author{ID: 1}, Role: entities.RoleAuthor}}
+			credited.Version = stored.Version
+
+			authorStore.EXPECT().GetAuthorsByIDs(gomock.Any(), []int{1}).Return([]entities.Author{{ID: 1}}, nil)
+			bookStore.EXPECT().UpdateBook(gomock.Any(), 1, credited).Return(entities.Book{ID: 1, Title: "Go",
+				Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 3},
+				PublishedDate: entities.NewDate(2000, 7, 22), Version: 3}, nil)
+			bookStore.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{WorkID: 1, Limit: 1}).
+				Return(entities.BookPage{Books: []entities.Book{{ID: 1}}, Total: 1}, nil)
+			bookStore.EXPECT().DeleteBook(gomock.Any(), 1).Return(nil)
+
+			expRes = entities.Book{ID: 1, Title: "Go", Author: entities.Author{ID: 1},
+				Publisher:     entities.Publisher{ID: 3, Name: "Penguin"},
+				PublishedDate: entities.NewDate(2000, 7, 22), Availability: &entities.Availability{}, Version: 3}
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		s := New(bookStore, authorStore, mockPublisherStore{}, mockGenreStore{}, mockSeriesStore{}, mockCopyStore{}, mockLoanStore{}, mockTx{}, Policy{})
+
+		res, err := s.PutBook(ctx, 1, update)
+		if !reflect.DeepEqual(err, tc.expErr) || !reflect.DeepEqual(res, expRes) {
+			t.Errorf("[TEST%d]Failed. Expected %v, %v\tGot %v, %v", i, expRes, tc.expErr, res, err)
+		}
+
+		err = s.DeleteBook(ctx, 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
+
 //<--------------------BookSTORE-------------------------->
 type mockBookStore struct {
 }
 
 func (m mockBookStore) GetAllBook(ctx context.Context) ([]entities.Book, error) {
-	return []entities.Book{{ID: 1, Title: "Rahul", Publication: "Penguin", PublishedDate: "22/07/2000",
-		Author: entities.Author{ID: 3}}}, nil
+	return []entities.Book{{ID: 1, Title: "Rahul", Publisher: entities.Publisher{ID: 3},
+		PublishedDate: entities.NewDate(2000, 7, 22),
+		Author:        entities.Author{ID: 3}}}, nil
+}
+
+func (m mockBookStore) GetBooks(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	books, _ := m.GetAllBook(ctx)
+
+	return entities.BookPage{Books: books, Total: len(books)}, nil
 }
 
 func (m mockBookStore) GetBookByID(ctx context.Context, id int) (entities.Book, error) {
 	return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
 }
 
+// GetBookByISBN has only the book 1 with the isbn 9780306406157
+func (m mockBookStore) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	if isbn == "9780306406157" {
+		books, _ := m.GetAllBook(ctx)
+		books[0].ISBN = isbn
+
+		return books[0], nil
+	}
+
+	return entities.Book{}, errors.EntityNotFound{Entity: "Book"}
+}
+
+// GetDuplicates compares the titles by their keys and the authors of the books
+func (m mockBookStore) GetDuplicates(ctx context.Context, book entities.Book, keys []string) ([]entities.Book, error) {
+	books, _ := m.GetAllBook(ctx)
+	duplicates := []entities.Book{}
+
+	for i := range books {
+		if entities.TitleKey(books[i].Title) == entities.TitleKey(book.Title) && books[i].Author.ID == book.Author.ID {
+			duplicates = append(duplicates, books[i])
+		}
+	}
+
+	return duplicates, nil
+}
+
+// SearchBooks finds the book 1 for any of the terms
+func (m mockBookStore) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	books, _ := m.GetAllBook(ctx)
+
+	return entities.SearchPage{Results: []entities.SearchResult{{Book: books[0], Score: 3}}, Total: 1}, nil
+}
+
 func (m mockBookStore) CreateBook(ctx context.Context, book entities.Book) (entities.Book, error) {
-	if book.Publication == "Rahul" || book.Title == "" {
+	if book.Title == "" {
 		return entities.Book{}, errors.InValidDetails{Details: "Title"}
 	}
-	if book.Publication == "Arihanth" {
+	if book.Publisher.ID == 1 {
 		return entities.Book{ID: 1, Title: "Rahul",
-			Author: entities.Author{ID: 1}, Publication: "Arihanth", PublishedDate: "22/07/2000"}, nil
+			Author: entities.Author{ID: 1}, Publisher: entities.Publisher{ID: 1},
+			PublishedDate: entities.NewDate(2000, 7, 22)}, nil
 	}
 	return entities.Book{}, errors.InValidDetails{Details: "Author ID"}
 }
@@ -252,6 +1120,35 @@ func (m mockBookStore) UpdateBook(ctx context.Context, id int, book entities.Boo
 	return entities.Book{}, errors.InValidDetails{Details: "Title"}
 }
 
+func (m mockBookStore) UpdateBookFields(ctx context.Context, id int, book entities.Book, fields []string) (int, error) {
+	return book.Version + 1, nil
+}
+
+func (m mockBookStore) GetContributors(ctx context.Context, bookIDs []int) (map[int][]entities.Contributor, error) {
+	return map[int][]entities.Contributor{1: {{Author: entities.Author{ID: 3}, Role: entities.RoleAuthor}}}, nil
+}
+
+// GetBookGenres has no genres for any book
+func (m mockBookStore) GetBookGenres(ctx context.Context, bookIDs []int) (map[int][]entities.Genre, error) {
+	return map[int][]entities.Genre{}, nil
+}
+
+// GetBookSeries has no series for any book
+func (m mockBookStore) GetBookSeries(ctx context.Context, bookIDs []int) (map[int]entities.SeriesVolume, error) {
+	return map[int]entities.SeriesVolume{}, nil
+}
+
+// GetEditions has no editions for any book
+func (m mockBookStore) GetEditions(ctx context.Context, bookIDs []int) (map[int]entities.Edition, error) {
+	return map[int]entities.Edition{}, nil
+}
+
+// GetBookFacets counts the book 1 under the genres 2 and 1, its publisher 3 and its decade
+func (m mockBookStore) GetBookFacets(ctx context.Context, filter entities.BookFilter) (entities.BookFacets, error) {
+	return entities.BookFacets{Genres: []entities.Facet{{ID: 2, Count: 1}, {ID: 1, Count: 2}},
+		Publishers: []entities.Facet{{ID: 3, Count: 1}}, Decades: []entities.DecadeFacet{{Decade: 2000, Count: 1}}}, nil
+}
+
 func (m mockBookStore) DeleteBook(ctx context.Context, id int) error {
 	if id == 1 {
 		return nil
@@ -259,3 +1156,78 @@ func (m mockBookStore) DeleteBook(ctx context.Context, id int) error {
 
 	return errors.EntityNotFound{Entity: "Book", ID: 100}
 }
+
+//<--------------------CopySTORE-------------------------->
+// mockCopyStore has no copies of any book
+type mockCopyStore struct {
+}
+
+func (m mockCopyStore) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	return []entities.Copy{}, nil
+}
+
+func (m mockCopyStore) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	return entities.Copy{}, errors.EntityNotFound{Entity: "Copy", ID: id}
+}
+
+func (m mockCopyStore) GetCopyByBarcode(ctx context.Context, barcode string) (entities.Copy, error) {
+	return entities.Copy{}, errors.EntityNotFound{Entity: "Copy"}
+}
+
+func (m mockCopyStore) GetAvailability(ctx context.Context, bookIDs []int) (map[int]entities.Availability, error) {
+	return map[int]entities.Availability{}, nil
+}
+
+func (m mockCopyStore) CreateCopy(ctx context.Context, c entities.Copy) (entities.Copy, error) {
+	return c, nil
+}
+
+func (m mockCopyStore) UpdateCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+	return c, nil
+}
+
+func (m mockCopyStore) UpdateCopyStatus(ctx context.Context, id int, from, to string) error {
+	return nil
+}
+
+func (m mockCopyStore) DeleteCopy(ctx context.Context, id int) error {
+	return nil
+}
+
+//<--------------------LoanSTORE-------------------------->
+// mockLoanStore has only the loans it is given
+type mockLoanStore struct {
+	loans []entities.Loan
+}
+
+func (m mockLoanStore) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	return entities.Loan{}, errors.EntityNotFound{Entity: "Loan", ID: id}
+}
+
+func (m mockLoanStore) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return []entities.Loan{}, nil
+}
+
+func (m mockLoanStore) GetLoansByBook(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	loans := []entities.Loan{}
+
+	for i := range m.loans {
+		if m.loans[i].BookID == bookID {
+			loans = append(loans, m.loans[i])
+		}
+	}
+
+	return loans, nil
+}
+
+func (m mockLoanStore) GetMemberLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	return []entities.Loan{}, nil
+}
+
+func (m mockLoanStore) CreateLoan(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	return l, nil
+}
+
+func (m mockLoanStore) UpdateLoan(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+	return l, nil
+}
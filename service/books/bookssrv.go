@@ -4,78 +4,247 @@ import (
 	"ThreeLayer/datastore"
 	"ThreeLayer/entities"
 	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"ThreeLayer/service/patch"
 	"context"
+	"fmt"
 	"log"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 )
 
+// Policy is the cataloguing policy, a book having the same details as a stored book for all the DuplicateKeys
+// is a duplicate. Titles are compared regardless of case and spacing, duplicates are not checked without keys
+type Policy struct {
+	DuplicateKeys []string
+}
+
 type Service struct {
-	book   datastore.Book
-	author datastore.Author
+	book      datastore.Book
+	author    datastore.Author
+	publisher datastore.Publisher
+	genre     datastore.Genre
+	series    datastore.Series
+	copy      datastore.Copy
+	loan      datastore.Loan
+	tx        datastore.Transactor
+	policy    Policy
 }
 
-func New(b datastore.Book, a datastore.Author) Service {
-	return Service{book: b, author: a}
+func New(b datastore.Book, a datastore.Author, p datastore.Publisher, g datastore.Genre, sr datastore.Series,
+	c datastore.Copy, l datastore.Loan, tx datastore.Transactor, policy Policy) Service {
+	return Service{book: b, author: a, publisher: p, genre: g, series: sr, copy: c, loan: l, tx: tx, policy: policy}
 }
 
 const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
 	LowestPubYear = 1880
-	Publisher1    = "Arihanth"
-	Publisher2    = "Scholastic"
-	Publisher3    = "Penguin"
+
+	// MaxSearchTerms is the most words a search can have
+	MaxSearchTerms = 10
 )
 
 func (s Service) PostBook(ctx context.Context, book entities.Book) (entities.Book, error) {
+	book = normalize(book)
 
 	err := checkDetails(book)
 	if err != nil {
 		return entities.Book{}, err
 	}
 
-	_, err = s.author.GetAuthorByID(ctx, book.Author.ID)
+	var created entities.Book
+
+	// the checks and the insert are done in one transaction so that a concurrent request can not add a book in between
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		authors, err := s.getAuthors(ctx, book.Contributors)
+		if err != nil {
+			return err
+		}
+
+		if err = checkBorn(book, authors); err != nil {
+			return err
+		}
+
+		publisher, err := s.getPublisher(ctx, book.Publisher.ID)
+		if err != nil {
+			return err
+		}
+
+		genres, err := s.getGenres(ctx, book.Genres)
+		if err != nil {
+			return err
+		}
+
+		if book.Series, err = s.getSeries(ctx, 0, book.Series); err != nil {
+			return err
+		}
+
+		if book.Edition, err = s.getEdition(ctx, 0, book.Edition); err != nil {
+			return err
+		}
+
+		if err = s.checkDuplicate(ctx, 0, book); err != nil {
+			return err
+		}
+
+		created, err = s.book.CreateBook(ctx, book)
+		created.Publisher = publisher
+		created.Genres = genres
+		setAuthors(&created, authors)
+
+		return err
+	})
 	if err != nil {
-		return entities.Book{}, errors.InValidDetails{Details: "Author ID "}
+		return entities.Book{}, err
 	}
 
-	books, err := s.GetBook(ctx)
+	// a new book does not have any copies yet
+	created.Availability = &entities.Availability{}
+
+	return created, nil
+}
+
+// GetBook returns a page of the books matching the filter, details of the authors and the contributors of the books
+// are included when includeAuthor is set in the context. The books of a genre include the books of its descendants
+// and the facets of all the matching books are included when the filter asks for them
+func (s Service) GetBook(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
+	filter, err := checkFilter(filter)
 	if err != nil {
-		return entities.Book{}, err
+		return entities.BookPage{}, err
 	}
-	for i := range books {
-		if book.Author.ID == books[i].Author.ID {
-			return entities.Book{}, errors.ExistAlready{Entity: "Book"}
+
+	if filter.GenreID != 0 {
+		if filter.GenreIDs, err = s.genreTree(ctx, filter.GenreID); err != nil {
+			return entities.BookPage{}, err
+		}
+	}
+
+	page, err := s.book.GetBooks(ctx, filter)
+	if err != nil {
+		return entities.BookPage{}, err
+	}
+
+	page.Limit, page.Offset = filter.Limit, filter.Offset
+
+	if filter.Facets {
+		if page.Facets, err = s.getFacets(ctx, filter); err != nil {
+			return entities.BookPage{}, err
+		}
+	}
+
+	if err = s.includeContributors(ctx, page.Books); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	includeAuthor, _ := ctx.Value(entities.IncludeAuthor).(bool)
+
+	if includeAuthor {
+		if err = s.includeAuthors(ctx, page.Books); err != nil {
+			return entities.BookPage{}, err
 		}
 	}
 
-	return s.book.CreateBook(ctx, book)
+	if err = s.includePublishers(ctx, page.Books); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	if err = s.includeGenres(ctx, page.Books); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	if err = s.includeSeries(ctx, page.Books); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	if err = s.includeEditions(ctx, page.Books); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	if err = s.includeAvailability(ctx, page.Books); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	return page, nil
+}
+
+// GetSeriesBooks returns a page of the volumes of the series with given id matching the filter, in the order of their
+// volume numbers unless the filter has a sort order
+func (s Service) GetSeriesBooks(ctx context.Context, id int, filter entities.BookFilter) (entities.BookPage, error) {
+	if _, err := s.series.GetSeriesByID(ctx, id); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	filter.SeriesID = id
+
+	if filter.Sort == "" {
+		filter.Sort = entities.SortByVolume
+	}
+
+	return s.GetBook(ctx, filter)
 }
 
-func (s Service) GetBook(ctx context.Context) ([]entities.Book, error) {
-	books, err := s.book.GetAllBook(ctx)
+// GetEditions returns a page of the books of the work of the book with given id matching the filter, the original
+// along with all its editions and translations including the book itself. They are in the order of their
+// publication unless the filter has a sort order
+func (s Service) GetEditions(ctx context.Context, id int, filter entities.BookFilter) (entities.BookPage, error) {
+	if _, err := s.book.GetBookByID(ctx, id); err != nil {
+		return entities.BookPage{}, err
+	}
+
+	editions, err := s.book.GetEditions(ctx, []int{id})
 	if err != nil {
-		return nil, err
+		return entities.BookPage{}, err
 	}
-	title := ctx.Value(entities.Title)
-	includeAuthor, _ := ctx.Value(entities.IncludeAuthor).(bool)
-	if title != "" {
-		books = matchDetails(books, func(book entities.Book) bool {
-			return book.Title == title
-		})
+
+	filter.WorkID = id
+	if edition, ok := editions[id]; ok {
+		filter.WorkID = edition.WorkID
 	}
 
-	if includeAuthor {
-		for i := range books {
-			auth, err := s.author.GetAuthorByID(ctx, books[i].Author.ID)
-			if err != nil {
-				log.Print(err)
-			}
-			books[i].Author = auth
+	if filter.Sort == "" {
+		filter.Sort = entities.SortByPublishedDate
+	}
+
+	return s.GetBook(ctx, filter)
+}
+
+// SearchBooks returns a page of the books matching any of the words of the text of the query ranked by relevance,
+// the details of the contributors, the publishers, the genres, the series and the editions of the books are included
+func (s Service) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	query, err := checkSearch(query)
+	if err != nil {
+		return entities.SearchPage{}, err
+	}
+
+	page, err := s.book.SearchBooks(ctx, query)
+	if err != nil {
+		return entities.SearchPage{}, err
+	}
+
+	page.Limit, page.Offset = query.Limit, query.Offset
+
+	books := make([]entities.Book, len(page.Results))
+	for i := range page.Results {
+		books[i] = page.Results[i].Book
+	}
+
+	include := []func(ctx context.Context, books []entities.Book) error{s.includeContributors, s.includeAuthors,
+		s.includePublishers, s.includeGenres, s.includeSeries, s.includeEditions, s.includeAvailability}
+
+	for _, fn := range include {
+		if err = fn(ctx, books); err != nil {
+			return entities.SearchPage{}, err
 		}
 	}
 
-	return books, nil
+	for i := range books {
+		page.Results[i].Book = books[i]
+	}
+
+	return page, nil
 }
 
 func (s Service) GetBookByID(ctx context.Context, id int) (entities.Book, error) {
@@ -83,79 +252,1216 @@ func (s Service) GetBookByID(ctx context.Context, id int) (entities.Book, error)
 	if err != nil {
 		return entities.Book{}, err
 	}
-	auth, err := s.author.GetAuthorByID(ctx, book.Author.ID)
+
+	return s.includeDetails(ctx, book)
+}
+
+// GetBookByISBN returns the book with the isbn, which is normalized the same way as the isbn of a saved book
+func (s Service) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	isbn = normalizeISBN(isbn)
+	if !validISBN13(isbn) {
+		return entities.Book{}, errors.InValidDetails{Details: "isbn"}
+	}
+
+	book, err := s.book.GetBookByISBN(ctx, isbn)
+	if err != nil {
+		return entities.Book{}, err
+	}
+
+	return s.includeDetails(ctx, book)
+}
+
+// includeDetails sets the contributors, the details of the authors and the publisher, the genres, the series,
+// the edition and the availability on a single stored book
+func (s Service) includeDetails(ctx context.Context, book entities.Book) (entities.Book, error) {
+	books := []entities.Book{book}
+	if err := s.includeContributors(ctx, books); err != nil {
+		return entities.Book{}, err
+	}
+
+	authors, err := s.author.GetAuthorsByIDs(ctx, authorIDs(books))
+	if err != nil {
+		return entities.Book{}, err
+	}
+
+	byID := make(map[int]entities.Author, len(authors))
+	for i := range authors {
+		byID[authors[i].ID] = authors[i]
+	}
+
+	if _, ok := byID[book.Author.ID]; !ok {
+		return entities.Book{}, errors.EntityNotFound{Entity: "Author", ID: book.Author.ID}
+	}
+
+	book = books[0]
+	setAuthors(&book, byID)
+
+	book.Publisher, err = s.publisher.GetPublisherByID(ctx, book.Publisher.ID)
 	if err != nil {
 		return entities.Book{}, err
 	}
-	book.Author = auth
-	return book, nil
 
+	books[0] = book
+	if err = s.includeGenres(ctx, books); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = s.includeSeries(ctx, books); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = s.includeEditions(ctx, books); err != nil {
+		return entities.Book{}, err
+	}
+
+	if err = s.includeAvailability(ctx, books); err != nil {
+		return entities.Book{}, err
+	}
+
+	return books[0], nil
 }
 
+// PutBook replaces the book with given id, the version of the book is checked against the If-Match versions in the context
 func (s Service) PutBook(ctx context.Context, id int, book entities.Book) (entities.Book, error) {
+	book = normalize(book)
+
 	err := checkDetails(book)
 	if err != nil {
 		return entities.Book{}, err
 	}
-	_, err = s.author.GetAuthorByID(ctx, book.Author.ID)
+
+	var updated entities.Book
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.book.GetBookByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Book", id, stored.Version); err != nil {
+			return err
+		}
+
+		authors, err := s.getAuthors(ctx, book.Contributors)
+		if err != nil {
+			return err
+		}
+
+		if err = checkBorn(book, authors); err != nil {
+			return err
+		}
+
+		publisher, err := s.getPublisher(ctx, book.Publisher.ID)
+		if err != nil {
+			return err
+		}
+
+		genres, err := s.getGenres(ctx, book.Genres)
+		if err != nil {
+			return err
+		}
+
+		if book.Series, err = s.getSeries(ctx, id, book.Series); err != nil {
+			return err
+		}
+
+		if book.Edition, err = s.getEdition(ctx, id, book.Edition); err != nil {
+			return err
+		}
+
+		if err = s.checkDuplicate(ctx, id, book); err != nil {
+			return err
+		}
+
+		book.Version = stored.Version
+
+		updated, err = s.book.UpdateBook(ctx, id, book)
+		if err != nil {
+			return err
+		}
+
+		updated.Publisher = publisher
+		updated.Genres = genres
+		setAuthors(&updated, authors)
+
+		books := []entities.Book{updated}
+		err = s.includeAvailability(ctx, books)
+		updated = books[0]
+
+		return err
+	})
 	if err != nil {
-		return entities.Book{}, errors.InValidDetails{Details: "Author ID"}
+		return entities.Book{}, err
+	}
+
+	return updated, nil
+}
+
+// patchesISBN reports whether the patch sets the ISBN and the ISBN-10, it is found by applying the patch to a book
+// holding placeholders in both. A patch which can not be applied that way is taken to set both
+func patchesISBN(p entities.Patch) (isbn, isbn10 bool) {
+	const placeholder = "-"
+
+	probe := entities.Book{ISBN: placeholder, ISBN10: placeholder}
+	if err := patch.Apply(&probe, p); err != nil {
+		return true, true
 	}
-	_, err = s.book.GetBookByID(ctx, id)
+
+	return probe.ISBN != placeholder, probe.ISBN10 != placeholder
+}
+
+// PatchBook applies the patch to the book with given id, the patched book is validated as a whole and
+// only the changed fields are updated
+func (s Service) PatchBook(ctx context.Context, id int, p entities.Patch) (entities.Book, error) {
+	var patched entities.Book
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		book, err := s.book.GetBookByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Book", id, book.Version); err != nil {
+			return err
+		}
+
+		books := []entities.Book{book}
+		if err = s.includeContributors(ctx, books); err != nil {
+			return err
+		}
+
+		if err = s.includeGenres(ctx, books); err != nil {
+			return err
+		}
+
+		if err = s.includeSeries(ctx, books); err != nil {
+			return err
+		}
+
+		if err = s.includeEditions(ctx, books); err != nil {
+			return err
+		}
+
+		book = books[0]
+
+		merged := book
+		if err = patch.Apply(&merged, p); err != nil {
+			return err
+		}
+
+		merged.ID, merged.Version = id, book.Version
+
+		// the ISBN-10 written from the ISBN is left out, a patch of the ISBN-10 alone replaces the ISBN
+		isbn, isbn10 := patchesISBN(p)
+		switch {
+		case !isbn10:
+			merged.ISBN10 = ""
+		case !isbn:
+			merged.ISBN = ""
+		}
+
+		// a patch of only the author credits the book to the new author alone
+		if merged.Author.ID != book.Author.ID && sameContributors(book.Contributors, merged.Contributors) {
+			merged.Contributors = nil
+		}
+
+		merged = normalize(merged)
+
+		if err = checkDetails(merged); err != nil {
+			return err
+		}
+
+		if !sameContributors(book.Contributors, merged.Contributors) || merged.PublishedDate != book.PublishedDate {
+			authors, err := s.getAuthors(ctx, merged.Contributors)
+			if err != nil {
+				return err
+			}
+
+			if err = checkBorn(merged, authors); err != nil {
+				return err
+			}
+		}
+
+		if merged.Publisher.ID != book.Publisher.ID {
+			if _, err = s.getPublisher(ctx, merged.Publisher.ID); err != nil {
+				return err
+			}
+		}
+
+		if !sameGenres(book.Genres, merged.Genres) {
+			if merged.Genres, err = s.getGenres(ctx, merged.Genres); err != nil {
+				return err
+			}
+		}
+
+		if !sameSeries(book.Series, merged.Series) {
+			if merged.Series, err = s.getSeries(ctx, id, merged.Series); err != nil {
+				return err
+			}
+		}
+
+		if !sameEdition(book.Edition, merged.Edition) {
+			if merged.Edition, err = s.getEdition(ctx, id, merged.Edition); err != nil {
+				return err
+			}
+		}
+
+		if fields := changedFields(book, merged); len(fields) > 0 {
+			if err = s.checkDuplicate(ctx, id, merged); err != nil {
+				return err
+			}
+
+			if _, err = s.book.UpdateBookFields(ctx, id, merged, fields); err != nil {
+				return err
+			}
+		}
+
+		patched, err = s.GetBookByID(ctx, id)
+
+		return err
+	})
 	if err != nil {
 		return entities.Book{}, err
 	}
-	return s.book.UpdateBook(ctx, id, book)
+
+	return patched, nil
 }
 
+// DeleteBook removes the book with given id, a book whose copies have been lent is kept along with the loans and
+// so is the original of any editions, as the editions would be removed with it. The version of the book is checked against the If-Match versions in the context
 func (s Service) DeleteBook(ctx context.Context, id int) error {
-	_, err := s.book.GetBookByID(ctx, id)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		book, err := s.book.GetBookByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Book", id, book.Version); err != nil {
+			return err
+		}
+
+		loans, err := s.loan.GetLoansByBook(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckLoans("Book", id, loans); err != nil {
+			return err
+		}
+
+		// the book itself is counted along with its editions
+		page, err := s.book.GetBooks(ctx, entities.BookFilter{WorkID: id, Limit: 1})
+		if err != nil {
+			return err
+		}
+
+		if page.Total > 1 {
+			return errors.Conflict{Entity: "Book", ID: id, Reason: "has editions"}
+		}
+
+		return s.book.DeleteBook(ctx, id)
+	})
+}
+
+//<-------------functions----------->
+// includeAuthors sets the details of the author and the contributors on every book, the authors of all the books
+// are fetched in a single call
+func (s Service) includeAuthors(ctx context.Context, books []entities.Book) error {
+	authors, err := s.author.GetAuthorsByIDs(ctx, authorIDs(books))
 	if err != nil {
 		return err
 	}
-	return s.book.DeleteBook(ctx, id)
-}
 
-//<-------------functions----------->
-func matchDetails(books []entities.Book, fn func(book entities.Book) bool) []entities.Book {
-	count := 0
+	byID := make(map[int]entities.Author, len(authors))
+	for i := range authors {
+		byID[authors[i].ID] = authors[i]
+	}
+
 	for i := range books {
-		if fn(books[i]) {
-			books[count] = books[i]
-			count++
+		if _, ok := byID[books[i].Author.ID]; !ok {
+			log.Print(errors.EntityNotFound{Entity: "Author", ID: books[i].Author.ID})
 		}
+
+		setAuthors(&books[i], byID)
 	}
-	return books[:count]
+
+	return nil
 }
-func publicationCheck(p string) bool {
-	return !(p == Publisher1 || p == Publisher2 || p == Publisher3)
+
+// includeContributors sets the contributors on every book with only the ids of the authors, the contributors of all
+// the books are fetched in a single call
+func (s Service) includeContributors(ctx context.Context, books []entities.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	contributors, err := s.book.GetContributors(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		books[i].Contributors = contributors[books[i].ID]
+	}
+
+	return nil
 }
 
-func publishedDateCheck(date string) bool {
-	p := strings.Split(date, "/")
+// getAuthors returns the authors of the contributors by id, the error is InValidDetails when any of them does not exist
+func (s Service) getAuthors(ctx context.Context, contributors []entities.Contributor) (map[int]entities.Author, error) {
+	ids := authorIDs([]entities.Book{{Contributors: contributors}})
 
-	year, err := strconv.Atoi(p[2])
+	authors, err := s.author.GetAuthorsByIDs(ctx, ids)
 	if err != nil {
-		return false
+		return nil, err
 	}
 
-	if year > time.Now().Year() || year < LowestPubYear {
-		return false
+	if len(authors) != len(ids) {
+		return nil, errors.InValidDetails{Details: "Author ID"}
 	}
 
-	return true
+	byID := make(map[int]entities.Author, len(authors))
+	for i := range authors {
+		byID[authors[i].ID] = authors[i]
+	}
+
+	return byID, nil
 }
-func checkDetails(book entities.Book) error {
-	switch {
-	case book.Title == "":
-		return errors.InValidDetails{Details: "Title"}
-	case publicationCheck(book.Publication):
-		return errors.InValidDetails{Details: "Publication"}
-	case !publishedDateCheck(book.PublishedDate):
-		return errors.InValidDetails{Details: "PublishedDate"}
-	case book.Author.ID <= 0:
-		return errors.InValidDetails{Details: "Author ID"}
-	default:
-		return nil
+
+// authorIDs returns the unique ids of the authors and the contributors of the books
+func authorIDs(books []entities.Book) []int {
+	var ids []int
+
+	seen := make(map[int]bool)
+
+	add := func(id int) {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
 	}
+
+	for i := range books {
+		add(books[i].Author.ID)
+
+		for _, c := range books[i].Contributors {
+			add(c.Author.ID)
+		}
+	}
+
+	return ids
+}
+
+// setAuthors sets the details of the author and the contributors of the book from the authors by id
+func setAuthors(book *entities.Book, authors map[int]entities.Author) {
+	book.Author = authors[book.Author.ID]
+
+	contributors := make([]entities.Contributor, len(book.Contributors))
+	for i, c := range book.Contributors {
+		contributors[i] = entities.Contributor{Author: authors[c.Author.ID], Role: c.Role}
+	}
+
+	if len(contributors) > 0 {
+		book.Contributors = contributors
+	}
+}
+
+// includePublishers sets the details of the publisher on every book, the publishers of all the books are fetched in
+// a single call
+func (s Service) includePublishers(ctx context.Context, books []entities.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(books))
+
+	seen := make(map[int]bool, len(books))
+	for i := range books {
+		if id := books[i].Publisher.ID; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	publishers, err := s.publisher.GetPublishersByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int]entities.Publisher, len(publishers))
+	for i := range publishers {
+		byID[publishers[i].ID] = publishers[i]
+	}
+
+	for i := range books {
+		books[i].Publisher = byID[books[i].Publisher.ID]
+	}
+
+	return nil
+}
+
+// includeGenres sets the genres on every book, the genres of all the books are fetched in a single call
+func (s Service) includeGenres(ctx context.Context, books []entities.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	genres, err := s.book.GetBookGenres(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		books[i].Genres = genres[books[i].ID]
+	}
+
+	return nil
+}
+
+// getGenres returns the details of the genres of a book being saved ordered by id, the error is InValidDetails when
+// any of them does not exist
+func (s Service) getGenres(ctx context.Context, genres []entities.Genre) ([]entities.Genre, error) {
+	if len(genres) == 0 {
+		return nil, nil
+	}
+
+	stored, err := s.genre.GetGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]entities.Genre, len(stored))
+	for i := range stored {
+		byID[stored[i].ID] = stored[i]
+	}
+
+	details := make([]entities.Genre, len(genres))
+
+	for i := range genres {
+		g, ok := byID[genres[i].ID]
+		if !ok {
+			return nil, errors.InValidDetails{Details: "Genre ID"}
+		}
+
+		details[i] = g
+	}
+
+	sort.Slice(details, func(i, j int) bool { return details[i].ID < details[j].ID })
+
+	return details, nil
+}
+
+// genreTree returns the id of the genre of a filter followed by the ids of its descendants, the error is
+// InValidDetails when the genre does not exist
+func (s Service) genreTree(ctx context.Context, id int) ([]int, error) {
+	genres, err := s.genre.GetGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range genres {
+		if genres[i].ID == id {
+			return entities.Descendants(genres, id), nil
+		}
+	}
+
+	return nil, errors.InValidDetails{Details: "genre"}
+}
+
+// includeSeries sets the series on every book which is a volume of one, the series of all the books are fetched
+// in a single call
+func (s Service) includeSeries(ctx context.Context, books []entities.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	series, err := s.book.GetBookSeries(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		if v, ok := series[books[i].ID]; ok {
+			books[i].Series = &v
+		}
+	}
+
+	return nil
+}
+
+// includeEditions sets the edition link on every book which is an edition or a translation of another, the links
+// of all the books are fetched in a single call
+func (s Service) includeEditions(ctx context.Context, books []entities.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	editions, err := s.book.GetEditions(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		if e, ok := editions[books[i].ID]; ok {
+			books[i].Edition = &e
+		}
+	}
+
+	return nil
+}
+
+// getSeries returns the series of the book with given id being saved along with the name of the series, the id is 0
+// for a new book. The error is InValidDetails when the series does not exist and Conflict when another book is
+// the volume
+func (s Service) getSeries(ctx context.Context, id int, series *entities.SeriesVolume) (*entities.SeriesVolume, error) {
+	if series == nil {
+		return nil, nil
+	}
+
+	stored, err := s.series.GetSeriesByID(ctx, series.ID)
+	if _, ok := err.(errors.EntityNotFound); ok {
+		return nil, errors.InValidDetails{Details: "Series ID"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := s.series.GetVolumes(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookID, ok := volumes[series.Volume]; ok && bookID != id {
+		return nil, errors.Conflict{Entity: "Series", ID: series.ID,
+			Reason: fmt.Sprintf("has volume %d", series.Volume)}
+	}
+
+	return &entities.SeriesVolume{ID: stored.ID, Name: stored.Name, Volume: series.Volume}, nil
+}
+
+// getEdition returns the edition link of the book with given id being saved, the id is 0 for a new book. A link to
+// an edition is made to the original of its work instead so that all the editions of a work link to the same book.
+// The error is InValidDetails when the original does not exist or is the book itself and Conflict when the book
+// has editions of its own
+func (s Service) getEdition(ctx context.Context, id int, edition *entities.Edition) (*entities.Edition, error) {
+	if edition == nil {
+		return nil, nil
+	}
+
+	link := *edition
+
+	if _, err := s.book.GetBookByID(ctx, link.WorkID); err != nil {
+		if _, ok := err.(errors.EntityNotFound); ok {
+			return nil, errors.InValidDetails{Details: "Work ID"}
+		}
+
+		return nil, err
+	}
+
+	editions, err := s.book.GetEditions(ctx, []int{link.WorkID})
+	if err != nil {
+		return nil, err
+	}
+
+	if original, ok := editions[link.WorkID]; ok {
+		link.WorkID = original.WorkID
+	}
+
+	if link.WorkID == id || edition.WorkID == id {
+		return nil, errors.InValidDetails{Details: "Work ID"}
+	}
+
+	if id != 0 {
+		// the book itself is counted along with its editions
+		page, err := s.book.GetBooks(ctx, entities.BookFilter{WorkID: id, Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+
+		if page.Total > 1 {
+			return nil, errors.Conflict{Entity: "Book", ID: id, Reason: "has editions"}
+		}
+	}
+
+	return &link, nil
+}
+
+// getFacets returns the facets of all the books matching the filter with the names of the genres and the publishers,
+// the genres and the publishers having the most books come first and the decades are in order
+func (s Service) getFacets(ctx context.Context, filter entities.BookFilter) (*entities.BookFacets, error) {
+	facets, err := s.book.GetBookFacets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	genres, err := s.genre.GetGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(facets.Publishers))
+	for i := range facets.Publishers {
+		ids[i] = facets.Publishers[i].ID
+	}
+
+	publishers, err := s.publisher.GetPublishersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(genres))
+	for i := range genres {
+		names[genres[i].ID] = genres[i].Name
+	}
+
+	for i := range facets.Genres {
+		facets.Genres[i].Name = names[facets.Genres[i].ID]
+	}
+
+	names = make(map[int]string, len(publishers))
+	for i := range publishers {
+		names[publishers[i].ID] = publishers[i].Name
+	}
+
+	for i := range facets.Publishers {
+		facets.Publishers[i].Name = names[facets.Publishers[i].ID]
+	}
+
+	for _, f := range [][]entities.Facet{facets.Genres, facets.Publishers} {
+		sort.Slice(f, func(i, j int) bool {
+			if f[i].Count != f[j].Count {
+				return f[i].Count > f[j].Count
+			}
+
+			return f[i].ID < f[j].ID
+		})
+	}
+
+	sort.Slice(facets.Decades, func(i, j int) bool { return facets.Decades[i].Decade < facets.Decades[j].Decade })
+
+	return &facets, nil
+}
+
+// checkDuplicate returns ExistAlready with the id of the stored book when a book other than the one with given id
+// has the isbn of the book or is a duplicate of the book by the policy. The books of the work of an edition are not
+// its duplicates as they share its title and authors
+func (s Service) checkDuplicate(ctx context.Context, id int, book entities.Book) error {
+	if book.ISBN != "" {
+		other, err := s.book.GetBookByISBN(ctx, book.ISBN)
+		if _, ok := err.(errors.EntityNotFound); !ok && err != nil {
+			return err
+		}
+
+		if err == nil && other.ID != id {
+			return errors.ExistAlready{Entity: "Book", ID: other.ID}
+		}
+	}
+
+	if len(s.policy.DuplicateKeys) == 0 {
+		return nil
+	}
+
+	books, err := s.book.GetDuplicates(ctx, book, s.policy.DuplicateKeys)
+	if err != nil {
+		return err
+	}
+
+	work, err := s.workOf(ctx, books, book.Edition)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		if books[i].ID != id && !work[books[i].ID] {
+			return errors.ExistAlready{Entity: "Book", ID: books[i].ID}
+		}
+	}
+
+	return nil
+}
+
+// workOf returns the ids of the books which are the original or the editions of the work of the edition among
+// the books, there are none without an edition
+func (s Service) workOf(ctx context.Context, books []entities.Book, edition *entities.Edition) (map[int]bool, error) {
+	work := make(map[int]bool)
+	if edition == nil {
+		return work, nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	editions, err := s.book.GetEditions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	work[edition.WorkID] = true
+
+	for id, e := range editions {
+		if e.WorkID == edition.WorkID {
+			work[id] = true
+		}
+	}
+
+	return work, nil
+}
+
+// getPublisher returns the publisher of a book being saved, the error is InValidDetails when the publisher
+// does not exist
+func (s Service) getPublisher(ctx context.Context, id int) (entities.Publisher, error) {
+	publisher, err := s.publisher.GetPublisherByID(ctx, id)
+	if _, ok := err.(errors.EntityNotFound); ok {
+		return entities.Publisher{}, errors.InValidDetails{Details: "Publisher ID"}
+	}
+
+	return publisher, err
+}
+
+// includeAvailability sets the counts of the copies on every book, the counts of all the books are fetched
+// in a single call
+func (s Service) includeAvailability(ctx context.Context, books []entities.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i := range books {
+		ids[i] = books[i].ID
+	}
+
+	availability, err := s.copy.GetAvailability(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		a := availability[books[i].ID]
+		books[i].Availability = &a
+	}
+
+	return nil
+}
+
+// changedFields returns the fields of the book which differ after the patch
+func changedFields(old, patched entities.Book) []string {
+	var fields []string
+
+	if old.Title != patched.Title {
+		fields = append(fields, entities.BookTitle)
+	}
+
+	if old.Publisher.ID != patched.Publisher.ID {
+		fields = append(fields, entities.BookPublisher)
+	}
+
+	if old.PublishedDate != patched.PublishedDate {
+		fields = append(fields, entities.BookPublishedDate)
+	}
+
+	if old.ISBN != patched.ISBN {
+		fields = append(fields, entities.BookISBN)
+	}
+
+	if !sameContributors(old.Contributors, patched.Contributors) {
+		fields = append(fields, entities.BookContributors)
+	}
+
+	if !sameGenres(old.Genres, patched.Genres) {
+		fields = append(fields, entities.BookGenres)
+	}
+
+	if !sameSeries(old.Series, patched.Series) {
+		fields = append(fields, entities.BookSeries)
+	}
+
+	if !sameEdition(old.Edition, patched.Edition) {
+		fields = append(fields, entities.BookEdition)
+	}
+
+	return fields
+}
+
+// sameSeries tells whether both place the book as the same volume of the same series or neither is set
+func sameSeries(a, b *entities.SeriesVolume) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.ID == b.ID && a.Volume == b.Volume
+}
+
+// sameEdition tells whether both link the book the same way to the same work or neither is set
+func sameEdition(a, b *entities.Edition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// sameGenres tells whether both have the same genres regardless of their order
+func sameGenres(a, b []entities.Genre) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	ids := make(map[int]bool, len(a))
+	for i := range a {
+		ids[a[i].ID] = true
+	}
+
+	for i := range b {
+		if !ids[b[i].ID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameContributors tells whether both have the same authors in the same roles and order
+func sameContributors(a, b []entities.Contributor) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Author.ID != b[i].Author.ID || a[i].Role != b[i].Role {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalize sets the book in the form it is validated and stored in
+func normalize(book entities.Book) entities.Book {
+	book = normalizeContributors(book)
+	book.ISBN = normalizeISBN(book.ISBN)
+
+	// an ISBN-10 sent in place of the ISBN is saved as its ISBN-13, it is kept only when it is invalid or is not the
+	// ISBN-10 of the ISBN so that checkDetails reports it
+	if book.ISBN10 = stripISBN(book.ISBN10); validISBN10(book.ISBN10) {
+		if book.ISBN == "" {
+			book.ISBN = normalizeISBN(book.ISBN10)
+		}
+
+		if book.ISBN == normalizeISBN(book.ISBN10) {
+			book.ISBN10 = ""
+		}
+	}
+
+	// an edition sent without a kind is an edition rather than a translation
+	if book.Edition != nil {
+		edition := *book.Edition
+		edition.Kind = strings.ToLower(strings.TrimSpace(edition.Kind))
+		edition.Language = strings.TrimSpace(edition.Language)
+
+		if edition.Kind == "" {
+			edition.Kind = entities.KindEdition
+		}
+
+		book.Edition = &edition
+	}
+
+	return book
+}
+
+// normalizeContributors credits a book sent without contributors to its author alone and makes the first of the
+// contributors the author otherwise, contributors sent without a role are authors
+func normalizeContributors(book entities.Book) entities.Book {
+	if len(book.Contributors) == 0 {
+		book.Contributors = book.Credits()
+		return book
+	}
+
+	contributors := make([]entities.Contributor, len(book.Contributors))
+	for i, c := range book.Contributors {
+		if c.Role == "" {
+			c.Role = entities.RoleAuthor
+		}
+
+		contributors[i] = c
+	}
+
+	book.Contributors = contributors
+	book.Author = contributors[0].Author
+
+	return book
+}
+
+// checkFilter validates the filter and sets the default page size and sort order, all the invalid params are reported
+func checkFilter(filter entities.BookFilter) (entities.BookFilter, error) {
+	var invalid []string
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = entities.SortByID
+	case entities.SortByID, entities.SortByTitle, entities.SortByPublishedDate, entities.SortByVolume:
+	default:
+		invalid = append(invalid, "sort")
+	}
+
+	invalid = append(invalid, checkPage(&filter.Limit, filter.Offset)...)
+
+	if filter.AuthorID < 0 {
+		invalid = append(invalid, "authorId")
+	}
+
+	if filter.PublisherID < 0 {
+		invalid = append(invalid, "publisherId")
+	}
+
+	if filter.GenreID < 0 {
+		invalid = append(invalid, "genre")
+	}
+
+	if _, err := time.Parse("2006-01-02", filter.PublishedFrom); filter.PublishedFrom != "" && err != nil {
+		invalid = append(invalid, "publishedFrom")
+	}
+
+	if _, err := time.Parse("2006-01-02", filter.PublishedTo); filter.PublishedTo != "" && err != nil {
+		invalid = append(invalid, "publishedTo")
+	}
+
+	if err := errors.InValid(invalid...); err != nil {
+		return entities.BookFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// checkSearch splits the text of the query into its terms and sets the default page size, all the invalid params
+// are reported
+func checkSearch(query entities.SearchQuery) (entities.SearchQuery, error) {
+	var invalid []string
+
+	// a repeated word is searched once
+	query.Terms = nil
+	seen := make(map[string]bool)
+
+	for _, word := range entities.Words(query.Text) {
+		if !seen[word] {
+			seen[word] = true
+			query.Terms = append(query.Terms, word)
+		}
+	}
+
+	if len(query.Terms) == 0 || len(query.Terms) > MaxSearchTerms {
+		invalid = append(invalid, "q")
+	}
+
+	invalid = append(invalid, checkPage(&query.Limit, query.Offset)...)
+
+	if err := errors.InValid(invalid...); err != nil {
+		return entities.SearchQuery{}, err
+	}
+
+	return query, nil
+}
+
+// checkPage sets the default page size when limit is not set and returns the invalid params of the page
+func checkPage(limit *int, offset int) []string {
+	var invalid []string
+
+	switch {
+	case *limit == 0:
+		*limit = DefaultPageSize
+	case *limit < 0 || *limit > MaxPageSize:
+		invalid = append(invalid, "limit")
+	}
+
+	if offset < 0 {
+		invalid = append(invalid, "offset")
+	}
+
+	return invalid
+}
+
+// publishedDateCheck tells whether the book can be published on the date, which must be set, not be before
+// LowestPubYear and not be in the future
+func publishedDateCheck(date entities.Date) bool {
+	return !date.IsZero() && date.Year() >= LowestPubYear && !today().Before(date)
+}
+
+// today returns the current date
+func today() entities.Date {
+	return entities.NewDate(time.Now().Date())
+}
+
+// checkBorn returns InValidDetails when any of the contributors of the book was not born before it was published,
+// authors are the contributors by id
+func checkBorn(book entities.Book, authors map[int]entities.Author) error {
+	for _, c := range book.Credits() {
+		if dob := authors[c.Author.ID].Dob; !dob.IsZero() && !dob.Before(book.PublishedDate) {
+			return errors.InValidDetails{Details: "PublishedDate"}
+		}
+	}
+
+	return nil
+}
+
+// checkDetails validates the book, all the invalid details are reported
+func checkDetails(book entities.Book) error {
+	var invalid []string
+
+	if book.Title == "" {
+		invalid = append(invalid, "Title")
+	}
+
+	if book.Publisher.ID <= 0 {
+		invalid = append(invalid, "Publisher ID")
+	}
+
+	if !publishedDateCheck(book.PublishedDate) {
+		invalid = append(invalid, "PublishedDate")
+	}
+
+	if book.Author.ID <= 0 {
+		invalid = append(invalid, "Author ID")
+	}
+
+	// the isbn is optional, it is checked after it is normalized to an ISBN-13
+	if book.ISBN != "" && !validISBN13(book.ISBN) {
+		invalid = append(invalid, "ISBN")
+	}
+
+	if book.ISBN10 != "" {
+		invalid = append(invalid, "ISBN10")
+	}
+
+	invalid = append(invalid, checkContributors(book.Contributors)...)
+	invalid = append(invalid, checkGenres(book.Genres)...)
+
+	return errors.InValid(append(invalid, checkLinks(book)...)...)
+}
+
+// checkLinks returns the invalid details of the series and the edition of the book, volumes are numbered from 1
+func checkLinks(book entities.Book) []string {
+	var invalid []string
+
+	if book.Series != nil && book.Series.ID <= 0 {
+		invalid = append(invalid, "Series ID")
+	}
+
+	if book.Series != nil && book.Series.Volume < 1 {
+		invalid = append(invalid, "Volume")
+	}
+
+	if book.Edition != nil && book.Edition.WorkID <= 0 {
+		invalid = append(invalid, "Work ID")
+	}
+
+	if book.Edition != nil {
+		switch book.Edition.Kind {
+		case entities.KindEdition, entities.KindTranslation:
+		default:
+			invalid = append(invalid, "Edition Kind")
+		}
+	}
+
+	return invalid
+}
+
+// checkGenres returns the invalid details of the genres, a book can be tagged with a genre only once
+func checkGenres(genres []entities.Genre) []string {
+	var invalidID, repeated bool
+
+	seen := make(map[int]bool, len(genres))
+
+	for i := range genres {
+		if genres[i].ID <= 0 {
+			invalidID = true
+		}
+
+		if seen[genres[i].ID] {
+			repeated = true
+		}
+
+		seen[genres[i].ID] = true
+	}
+
+	var invalid []string
+
+	if invalidID {
+		invalid = append(invalid, "Genre ID")
+	}
+
+	if repeated {
+		invalid = append(invalid, "Genres")
+	}
+
+	return invalid
+}
+
+// checkContributors returns the invalid details of the contributors, an author can be credited only once in a role
+func checkContributors(contributors []entities.Contributor) []string {
+	type credit struct {
+		authorID int
+		role     string
+	}
+
+	var invalidID, invalidRole, repeated bool
+
+	seen := make(map[credit]bool, len(contributors))
+
+	for _, c := range contributors {
+		if c.Author.ID <= 0 {
+			invalidID = true
+		}
+
+		switch c.Role {
+		case entities.RoleAuthor, entities.RoleEditor, entities.RoleTranslator, entities.RoleIllustrator:
+		default:
+			invalidRole = true
+		}
+
+		key := credit{authorID: c.Author.ID, role: c.Role}
+		if seen[key] {
+			repeated = true
+		}
+
+		seen[key] = true
+	}
+
+	var invalid []string
+
+	// an invalid id of the first contributor is already reported as the id of the author
+	if invalidID && (len(contributors) == 0 || contributors[0].Author.ID > 0) {
+		invalid = append(invalid, "Author ID")
+	}
+
+	if invalidRole {
+		invalid = append(invalid, "Role")
+	}
+
+	if repeated {
+		invalid = append(invalid, "Contributors")
+	}
+
+	return invalid
 }
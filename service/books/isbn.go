@@ -0,0 +1,81 @@
+package books
+
+import "strings"
+
+// normalizeISBN strips the hyphens and spaces of the isbn and converts a valid ISBN-10 to its ISBN-13,
+// an invalid isbn is returned without the separators so that checkDetails reports it
+func normalizeISBN(isbn string) string {
+	isbn = stripISBN(isbn)
+
+	if !validISBN10(isbn) {
+		return isbn
+	}
+
+	// an ISBN-10 becomes the ISBN-13 with the 978 prefix and a recomputed check digit
+	isbn13 := "978" + isbn[:9]
+
+	return isbn13 + string(rune('0'+isbn13CheckDigit(isbn13)))
+}
+
+// stripISBN returns the isbn without the hyphens and spaces, the check digit X of an ISBN-10 is upper cased
+func stripISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+// validISBN10 tells whether the isbn is 10 digits, or 9 digits and an X, with a valid check digit
+func validISBN10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+
+	sum := 0
+
+	for i, r := range isbn {
+		var digit int
+
+		switch {
+		case r >= '0' && r <= '9':
+			digit = int(r - '0')
+		case r == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+
+		sum += (10 - i) * digit
+	}
+
+	return sum%11 == 0
+}
+
+// validISBN13 tells whether the isbn is 13 digits with a valid check digit
+func validISBN13(isbn string) bool {
+	if len(isbn) != 13 {
+		return false
+	}
+
+	for _, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return int(isbn[12]-'0') == isbn13CheckDigit(isbn[:12])
+}
+
+// isbn13CheckDigit returns the check digit of the first 12 digits of an ISBN-13, the digits are weighted 1 and 3
+// alternately
+func isbn13CheckDigit(digits string) int {
+	sum := 0
+
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+
+		sum += weight * int(digits[i]-'0')
+	}
+
+	return (10 - sum%10) % 10
+}
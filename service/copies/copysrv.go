@@ -0,0 +1,360 @@
+// Package copies manages the physical copies of the books held by the library
+package copies
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"ThreeLayer/service/loans"
+	"context"
+	stdErrors "errors"
+	"strings"
+)
+
+type Service struct {
+	copy   datastore.Copy
+	book   datastore.Book
+	loan   datastore.Loan
+	hold   datastore.Hold
+	tx     datastore.Transactor
+	policy loans.Policy
+}
+
+func New(c datastore.Copy, b datastore.Book, l datastore.Loan, h datastore.Hold, tx datastore.Transactor,
+	p loans.Policy) Service {
+	return Service{copy: c, book: b, loan: l, hold: h, tx: tx, policy: p}
+}
+
+// GetCopies returns the copies of the book with given id
+func (s Service) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	if _, err := s.book.GetBookByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	return s.copy.GetCopies(ctx, bookID)
+}
+
+// GetCopyByID returns the copy with given id
+func (s Service) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	return s.copy.GetCopyByID(ctx, id)
+}
+
+// PostCopy adds a new copy of the book with given id, a copy is available and in good condition from today
+// unless set otherwise. A new copy can only be lost or withdrawn instead of available, as it is not lent or held
+// yet. An available copy is assigned to the first waiting hold of the book. Two copies can not have the same barcode
+func (s Service) PostCopy(ctx context.Context, bookID int, c entities.Copy) (entities.Copy, error) {
+	c = normalize(c)
+	c.BookID = bookID
+
+	if c.Condition == "" {
+		c.Condition = entities.ConditionGood
+	}
+
+	if c.Status == "" {
+		c.Status = entities.CopyAvailable
+	}
+
+	if c.AcquiredOn.IsZero() {
+		c.AcquiredOn = entities.Today()
+	}
+
+	if err := checkDetails(c); err != nil {
+		return entities.Copy{}, err
+	}
+
+	if !administrative(c.Status) {
+		return entities.Copy{}, errors.InValidDetails{Details: "Status"}
+	}
+
+	var created entities.Copy
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.book.GetBookByID(ctx, bookID); err != nil {
+			return err
+		}
+
+		if err := s.checkDuplicate(ctx, 0, c); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = s.copy.CreateCopy(ctx, c)
+		if err != nil || created.Status != entities.CopyAvailable {
+			return err
+		}
+
+		created, err = s.release(ctx, created)
+
+		return err
+	})
+	if err != nil {
+		return entities.Copy{}, err
+	}
+
+	return created, nil
+}
+
+// PutCopy replaces the copy with given id, the copy stays with its book when the book id is not set and it can
+// only be moved to another book while available. The status is changed by the loans and the holds, it is kept
+// when it is not set and it can only be set to available, lost or withdrawn by hand. A copy made available, or
+// moved while available, is assigned to the first waiting hold of its book. The version of the copy is checked
+// against the If-Match versions in the context
+func (s Service) PutCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+	c = normalize(c)
+
+	if err := checkDetails(c); err != nil {
+		return entities.Copy{}, err
+	}
+
+	var updated entities.Copy
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.copy.GetCopyByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Copy", id, stored.Version); err != nil {
+			return err
+		}
+
+		if c.Status == "" {
+			c.Status = stored.Status
+		}
+
+		if err = s.checkStatus(ctx, stored, c.Status); err != nil {
+			return err
+		}
+
+		if err = s.checkBook(ctx, stored, &c); err != nil {
+			return err
+		}
+
+		if err = s.checkDuplicate(ctx, id, c); err != nil {
+			return err
+		}
+
+		c.Version = stored.Version
+
+		updated, err = s.copy.UpdateCopy(ctx, id, c)
+		if err != nil || updated.Status != entities.CopyAvailable {
+			return err
+		}
+
+		if stored.Status == entities.CopyAvailable && stored.BookID == updated.BookID {
+			return nil
+		}
+
+		updated, err = s.release(ctx, updated)
+
+		return err
+	})
+	if err != nil {
+		return entities.Copy{}, err
+	}
+
+	return updated, nil
+}
+
+// DeleteCopy removes the copy with given id, Conflict is returned when the copy has been lent as its loans are
+// kept, or when a ready hold has the copy. The version of the copy is checked against the If-Match versions in
+// the context
+func (s Service) DeleteCopy(ctx context.Context, id int) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		c, err := s.copy.GetCopyByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Copy", id, c.Version); err != nil {
+			return err
+		}
+
+		loans, err := s.loan.GetLoansByBook(ctx, c.BookID)
+		if err != nil {
+			return err
+		}
+
+		loans = matchLoans(loans, func(l entities.Loan) bool { return l.CopyID == id })
+		if err = service.CheckLoans("Copy", id, loans); err != nil {
+			return err
+		}
+
+		held, err := s.held(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		if held {
+			return errors.Conflict{Entity: "Copy", ID: id, Reason: "has a ready hold"}
+		}
+
+		return s.copy.DeleteCopy(ctx, id)
+	})
+}
+
+// release assigns the available copy to the first waiting hold of its book and returns the copy as it is stored
+func (s Service) release(ctx context.Context, c entities.Copy) (entities.Copy, error) {
+	if err := loans.Release(ctx, s.hold, s.copy, s.policy, c); err != nil {
+		return entities.Copy{}, err
+	}
+
+	return s.copy.GetCopyByID(ctx, c.ID)
+}
+
+// checkBook sets the book of the stored copy when the book id is not set. InValidDetails is returned when the
+// book does not exist and Conflict when a copy which is not available is moved to another book
+func (s Service) checkBook(ctx context.Context, stored entities.Copy, c *entities.Copy) error {
+	if c.BookID == 0 || c.BookID == stored.BookID {
+		c.BookID = stored.BookID
+
+		return nil
+	}
+
+	if stored.Status != entities.CopyAvailable {
+		return errors.Conflict{Entity: "Copy", ID: stored.ID, Reason: "is not available"}
+	}
+
+	_, err := s.book.GetBookByID(ctx, c.BookID)
+
+	var notFound errors.EntityNotFound
+	if stdErrors.As(err, &notFound) {
+		return errors.InValidDetails{Details: "BookID"}
+	}
+
+	return err
+}
+
+// checkDuplicate returns ExistAlready when a copy other than the one with given id has the same barcode
+func (s Service) checkDuplicate(ctx context.Context, id int, c entities.Copy) error {
+	other, err := s.copy.GetCopyByBarcode(ctx, c.Barcode)
+
+	var notFound errors.EntityNotFound
+	if stdErrors.As(err, &notFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if other.ID != id {
+		return errors.ExistAlready{Entity: "Copy"}
+	}
+
+	return nil
+}
+
+// checkStatus returns InValidDetails when the status of the stored copy is changed to on loan or on hold, those
+// are set by the loans and the holds. The copy can be marked lost or withdrawn, or available again, only when
+// no active loan or ready hold has the copy, Conflict is returned otherwise
+func (s Service) checkStatus(ctx context.Context, stored entities.Copy, status string) error {
+	if status == stored.Status {
+		return nil
+	}
+
+	if !administrative(status) {
+		return errors.InValidDetails{Details: "Status"}
+	}
+
+	if !administrative(stored.Status) {
+		return errors.Conflict{Entity: "Copy", ID: stored.ID}
+	}
+
+	loans, err := s.loan.GetLoansByBook(ctx, stored.BookID)
+	if err != nil {
+		return err
+	}
+
+	if len(matchLoans(loans, func(l entities.Loan) bool { return l.CopyID == stored.ID && l.Active() })) > 0 {
+		return errors.Conflict{Entity: "Copy", ID: stored.ID}
+	}
+
+	held, err := s.held(ctx, stored)
+	if err != nil {
+		return err
+	}
+
+	if held {
+		return errors.Conflict{Entity: "Copy", ID: stored.ID}
+	}
+
+	return nil
+}
+
+// held tells whether a ready hold of the book has the copy
+func (s Service) held(ctx context.Context, c entities.Copy) (bool, error) {
+	holds, err := s.hold.GetBookHolds(ctx, c.BookID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, h := range holds {
+		if h.CopyID == c.ID && h.Status == entities.HoldReady {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// matchLoans returns the loans which match
+func matchLoans(loans []entities.Loan, match func(l entities.Loan) bool) []entities.Loan {
+	matched := make([]entities.Loan, 0, len(loans))
+
+	for i := range loans {
+		if match(loans[i]) {
+			matched = append(matched, loans[i])
+		}
+	}
+
+	return matched
+}
+
+// administrative tells whether the status can be set by hand, on loan and on hold are only set by the loans
+// and the holds
+func administrative(status string) bool {
+	return status == entities.CopyAvailable || status == entities.CopyLost || status == entities.CopyWithdrawn
+}
+
+// normalize trims the details of the copy
+func normalize(c entities.Copy) entities.Copy {
+	c.Barcode = strings.TrimSpace(c.Barcode)
+	c.ShelfLocation = strings.TrimSpace(c.ShelfLocation)
+
+	return c
+}
+
+// checkDetails validates the copy, all the invalid details are reported
+func checkDetails(c entities.Copy) error {
+	var invalid []string
+
+	if c.Barcode == "" || len(c.Barcode) > 64 {
+		invalid = append(invalid, "Barcode")
+	}
+
+	switch c.Condition {
+	case entities.ConditionNew, entities.ConditionGood, entities.ConditionFair, entities.ConditionPoor,
+		entities.ConditionDamaged:
+	default:
+		invalid = append(invalid, "Condition")
+	}
+
+	if c.AcquiredOn.IsZero() || c.AcquiredOn.After(entities.Today()) {
+		invalid = append(invalid, "AcquiredOn")
+	}
+
+	// the status of the stored copy is kept when it is not set
+	switch c.Status {
+	case "", entities.CopyAvailable, entities.CopyOnLoan, entities.CopyOnHold, entities.CopyLost, entities.CopyWithdrawn:
+	default:
+		invalid = append(invalid, "Status")
+	}
+
+	if c.BookID < 0 {
+		invalid = append(invalid, "BookID")
+	}
+
+	return errors.InValid(invalid...)
+}
@@ -0,0 +1,344 @@
+package copies
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service/loans"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// acquiredOn is the acquisition date of the copies in the tests
+var acquiredOn = entities.NewDate(2020, 2, 1)
+
+// policy keeps a copy assigned to a hold for 3 days
+var policy = loans.Policy{PickupDays: 3}
+
+// waiting is the hold of member 2 waiting for book 1
+var waiting = entities.Hold{ID: 4, BookID: 1, MemberID: 2, Status: entities.HoldWaiting, PlacedOn: acquiredOn}
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func bookCopy() entities.Copy {
+	return entities.Copy{BookID: 1, Barcode: "LIB-0001", Condition: entities.ConditionFair, ShelfLocation: "A-12",
+		AcquiredOn: acquiredOn, Status: entities.CopyAvailable}
+}
+
+// expectStores sets up book 1 and the copy with barcode LIB-0002 as the only rows
+func expectStores(copyStore *datastore.MockCopy, bookStore *datastore.MockBook) {
+	bookStore.EXPECT().GetBookByID(gomock.Any(), 1).Return(entities.Book{ID: 1}, nil).AnyTimes()
+	bookStore.EXPECT().GetBookByID(gomock.Any(), gomock.Not(1)).DoAndReturn(func(ctx context.Context, id int) (entities.Book, error) {
+		return entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: id}
+	}).AnyTimes()
+
+	copyStore.EXPECT().GetCopyByBarcode(gomock.Any(), "LIB-0002").Return(entities.Copy{ID: 2, BookID: 1,
+		Barcode: "LIB-0002"}, nil).AnyTimes()
+	copyStore.EXPECT().GetCopyByBarcode(gomock.Any(), gomock.Not("LIB-0002")).
+		Return(entities.Copy{}, errors.EntityNotFound{Entity: "Copy"}).AnyTimes()
+}
+
+// expectRelease expects the copy with given id to be assigned to the waiting hold
+func expectRelease(copyStore *datastore.MockCopy, holdStore *datastore.MockHold, id int) {
+	ready := waiting
+	ready.CopyID, ready.Status = id, entities.HoldReady
+	ready.ReadyOn, ready.ExpiresOn = entities.Today(), entities.Today().AddDays(policy.PickupDays)
+
+	copyStore.EXPECT().UpdateCopyStatus(gomock.Any(), id, entities.CopyAvailable, entities.CopyOnHold).Return(nil)
+	holdStore.EXPECT().UpdateHold(gomock.Any(), waiting.ID, ready).Return(ready, nil)
+}
+
+func TestService_GetCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	copyStore := datastore.NewMockCopy(ctrl)
+	bookStore := datastore.NewMockBook(ctrl)
+
+	expectStores(copyStore, bookStore)
+
+	copies := []entities.Copy{{ID: 2, BookID: 1, Barcode: "LIB-0002"}}
+	copyStore.EXPECT().GetCopies(gomock.Any(), 1).Return(copies, nil)
+
+	s := New(copyStore, bookStore, datastore.NewMockLoan(ctrl), datastore.NewMockHold(ctrl), mockTx{}, policy)
+
+	res, err := s.GetCopies(context.Background(), 1)
+	if err != nil || !reflect.DeepEqual(res, copies) {
+		t.Errorf("Failed. Expected %v\tGot %v, %v", copies, res, err)
+	}
+
+	_, err = s.GetCopies(context.Background(), 5)
+	if !reflect.DeepEqual(err, errors.EntityNotFound{Entity: "Book", ID: 5}) {
+		t.Errorf("Failed. Expected the book not to be found Got %v", err)
+	}
+}
+
+func TestService_PostCopy(t *testing.T) {
+	defaults := entities.Copy{BookID: 1, Barcode: "LIB-0001", Condition: entities.ConditionGood,
+		AcquiredOn: entities.Today(), Status: entities.CopyAvailable}
+
+	testcases := []struct {
+		desc   string
+		bookID int
+		req    entities.Copy
+		queue  []entities.Hold
+		expNew entities.Copy
+		expErr error
+	}{
+		{desc: "valid copy", bookID: 1, req: bookCopy(), expNew: bookCopy()},
+		{desc: "assigned to a waiting hold", bookID: 1, req: bookCopy(), queue: []entities.Hold{waiting},
+			expNew: bookCopy()},
+		{desc: "lost on creation", bookID: 1, req: entities.Copy{Barcode: "LIB-0001", Status: entities.CopyLost},
+			queue: []entities.Hold{waiting}, expNew: entities.Copy{BookID: 1, Barcode: "LIB-0001",
+				Condition: entities.ConditionGood, AcquiredOn: entities.Today(), Status: entities.CopyLost}},
+		{desc: "defaults are set", bookID: 1, req: entities.Copy{BookID: 7, Barcode: " LIB-0001 "}, expNew: defaults},
+		{desc: "invalid details", bookID: 1, req: entities.Copy{Condition: "torn",
+			AcquiredOn: entities.Today().AddDays(2), Status: "sold"}, expErr: errors.InValidFields{{Details: "Barcode"}, {Details: "Condition"},
+			{Details: "AcquiredOn"}, {Details: "Status"}}},
+		{desc: "acquired in the future", bookID: 1, req: entities.Copy{Barcode: "LIB-0001",
+			AcquiredOn: entities.Today().AddDays(2)},
+			expErr: errors.InValidDetails{Details: "AcquiredOn"}},
+		{desc: "barcode is taken", bookID: 1, req: entities.Copy{Barcode: "LIB-0002"},
+			expErr: errors.ExistAlready{Entity: "Copy"}},
+		{desc: "lent on creation", bookID: 1, req: entities.Copy{Barcode: "LIB-0001", Status: entities.CopyOnLoan},
+			expErr: errors.InValidDetails{Details: "Status"}},
+		{desc: "book not found", bookID: 5, req: bookCopy(), expErr: errors.EntityNotFound{Entity: "Book", ID: 5}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		copyStore := datastore.NewMockCopy(ctrl)
+		bookStore := datastore.NewMockBook(ctrl)
+
+		expectStores(copyStore, bookStore)
+
+		holdStore := datastore.NewMockHold(ctrl)
+		holdStore.EXPECT().GetBookHolds(gomock.Any(), 1).Return(tc.queue, nil).AnyTimes()
+
+		var expRes entities.Copy
+
+		if tc.expErr == nil {
+			expRes = tc.expNew
+			expRes.ID, expRes.Version = 3, 1
+
+			copyStore.EXPECT().CreateCopy(gomock.Any(), tc.expNew).Return(expRes, nil)
+
+			if expRes.Status == entities.CopyAvailable && tc.queue != nil {
+				expectRelease(copyStore, holdStore, 3)
+
+				expRes.Status, expRes.Version = entities.CopyOnHold, 2
+			}
+
+			copyStore.EXPECT().GetCopyByID(gomock.Any(), 3).Return(expRes, nil).AnyTimes()
+		}
+
+		res, err := New(copyStore, bookStore, datastore.NewMockLoan(ctrl), holdStore, mockTx{}, policy).
+			PostCopy(context.Background(), tc.bookID, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_PutCopy(t *testing.T) {
+	stored := bookCopy()
+	stored.ID, stored.Version = 1, 2
+
+	update := bookCopy()
+	update.BookID = 0
+	update.Status = entities.CopyWithdrawn
+
+	kept := update
+	kept.Status = ""
+
+	returned := entities.Loan{ID: 1, CopyID: 1, BookID: 1, ReturnedOn: acquiredOn}
+	lent := entities.Loan{ID: 2, CopyID: 1, BookID: 1}
+	otherCopy := entities.Hold{ID: 1, BookID: 1, CopyID: 2, Status: entities.HoldReady}
+	held := entities.Hold{ID: 2, BookID: 1, CopyID: 1, Status: entities.HoldReady}
+
+	available := update
+	available.Status = entities.CopyAvailable
+
+	moved := kept
+	moved.BookID = 2
+
+	testcases := []struct {
+		desc     string
+		status   string
+		versions []int
+		loans    []entities.Loan
+		holds    []entities.Hold
+		req      entities.Copy
+		expRes   entities.Copy
+		expErr   error
+	}{
+		{desc: "updated", versions: []int{2}, loans: []entities.Loan{returned}, holds: []entities.Hold{otherCopy},
+			req: update, expRes: entities.Copy{ID: 1, BookID: 1, Barcode: "LIB-0001", Condition: entities.ConditionFair,
+				ShelfLocation: "A-12", AcquiredOn: acquiredOn, Status: entities.CopyWithdrawn, Version: 3}},
+		{desc: "status is kept", req: kept, expRes: entities.Copy{ID: 1, BookID: 1, Barcode: "LIB-0001",
+			Condition: entities.ConditionFair, ShelfLocation: "A-12", AcquiredOn: acquiredOn,
+			Status: entities.CopyAvailable, Version: 3}},
+		{desc: "made available", status: entities.CopyWithdrawn, req: available, expRes: entities.Copy{ID: 1,
+			BookID: 1, Barcode: "LIB-0001", Condition: entities.ConditionFair, ShelfLocation: "A-12",
+			AcquiredOn: acquiredOn, Status: entities.CopyAvailable, Version: 3}},
+		{desc: "found for a waiting hold", status: entities.CopyLost, holds: []entities.Hold{waiting}, req: available,
+			expRes: entities.Copy{ID: 1, BookID: 1, Barcode: "LIB-0001", Condition: entities.ConditionFair,
+				ShelfLocation: "A-12", AcquiredOn: acquiredOn, Status: entities.CopyOnHold, Version: 4}},
+		{desc: "moved to another book", req: moved, expRes: entities.Copy{ID: 1, BookID: 2, Barcode: "LIB-0001",
+			Condition: entities.ConditionFair, ShelfLocation: "A-12", AcquiredOn: acquiredOn,
+			Status: entities.CopyAvailable, Version: 3}},
+		{desc: "moved while lent", status: entities.CopyOnLoan, req: moved,
+			expErr: errors.Conflict{Entity: "Copy", ID: 1, Reason: "is not available"}},
+		{desc: "lent by hand", req: entities.Copy{Barcode: "LIB-0001", Condition: entities.ConditionFair,
+			AcquiredOn: acquiredOn, Status: entities.CopyOnLoan}, expErr: errors.InValidDetails{Details: "Status"}},
+		{desc: "withdrawn while lent", loans: []entities.Loan{returned, lent}, req: update,
+			expErr: errors.Conflict{Entity: "Copy", ID: 1}},
+		{desc: "withdrawn while held", holds: []entities.Hold{otherCopy, held}, req: update,
+			expErr: errors.Conflict{Entity: "Copy", ID: 1}},
+		{desc: "version does not match", versions: []int{1}, req: update,
+			expErr: errors.PreconditionFailed{Entity: "Copy", ID: 1}},
+		{desc: "book not found", req: entities.Copy{BookID: 5, Barcode: "LIB-0001", Condition: entities.ConditionFair,
+			AcquiredOn: acquiredOn, Status: entities.CopyAvailable}, expErr: errors.InValidDetails{Details: "BookID"}},
+		{desc: "barcode of other copy", req: entities.Copy{Barcode: "LIB-0002", Condition: entities.ConditionFair,
+			AcquiredOn: acquiredOn, Status: entities.CopyAvailable}, expErr: errors.ExistAlready{Entity: "Copy"}},
+		{desc: "invalid status", req: entities.Copy{Barcode: "LIB-0001", Condition: entities.ConditionFair,
+			AcquiredOn: acquiredOn, Status: "sold"}, expErr: errors.InValidDetails{Details: "Status"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		copyStore := datastore.NewMockCopy(ctrl)
+		bookStore := datastore.NewMockBook(ctrl)
+
+		bookStore.EXPECT().GetBookByID(gomock.Any(), 2).Return(entities.Book{ID: 2}, nil).AnyTimes()
+		expectStores(copyStore, bookStore)
+
+		s := stored
+		if tc.status != "" {
+			s.Status = tc.status
+		}
+
+		copyStore.EXPECT().GetCopyByID(gomock.Any(), 1).Return(s, nil).MaxTimes(1)
+
+		loanStore := datastore.NewMockLoan(ctrl)
+		loanStore.EXPECT().GetLoansByBook(gomock.Any(), 1).Return(tc.loans, nil).AnyTimes()
+
+		holdStore := datastore.NewMockHold(ctrl)
+		holdStore.EXPECT().GetBookHolds(gomock.Any(), 1).Return(tc.holds, nil).AnyTimes()
+		holdStore.EXPECT().GetBookHolds(gomock.Any(), 2).Return(nil, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			req := tc.req
+			req.Version = s.Version
+
+			if req.BookID == 0 {
+				req.BookID = s.BookID
+			}
+
+			if req.Status == "" {
+				req.Status = s.Status
+			}
+
+			copyStore.EXPECT().UpdateCopy(gomock.Any(), 1, req).DoAndReturn(
+				func(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+					c.ID, c.Version = id, c.Version+1
+					return c, nil
+				})
+
+			if tc.expRes.Status == entities.CopyOnHold {
+				expectRelease(copyStore, holdStore, 1)
+			}
+
+			copyStore.EXPECT().GetCopyByID(gomock.Any(), 1).Return(tc.expRes, nil).AnyTimes()
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		res, err := New(copyStore, bookStore, loanStore, holdStore, mockTx{}, policy).PutCopy(ctx, 1, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_DeleteCopy(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		expErr   error
+	}{
+		{desc: "deleted", id: 1, versions: []int{2}},
+		{desc: "version does not match", id: 1, versions: []int{3}, expErr: errors.PreconditionFailed{Entity: "Copy", ID: 1}},
+		{desc: "not found", id: 5, expErr: errors.EntityNotFound{Entity: "Copy", ID: 5}},
+		{desc: "loan is active", id: 2, versions: []int{2},
+			expErr: errors.Conflict{Entity: "Copy", ID: 2, Reason: "has an active loan"}},
+		{desc: "loans are returned", id: 3, versions: []int{2},
+			expErr: errors.Conflict{Entity: "Copy", ID: 3, Reason: "has a loan history"}},
+		{desc: "hold is ready", id: 4, versions: []int{2},
+			expErr: errors.Conflict{Entity: "Copy", ID: 4, Reason: "has a ready hold"}},
+	}
+
+	loans := []entities.Loan{{ID: 1, CopyID: 2, BookID: 1},
+		{ID: 2, CopyID: 3, BookID: 1, ReturnedOn: entities.NewDate(2022, 1, 10)}}
+	holds := []entities.Hold{waiting, {ID: 5, BookID: 1, MemberID: 3, CopyID: 4, Status: entities.HoldReady}}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+
+		copyStore := datastore.NewMockCopy(ctrl)
+		loanStore := datastore.NewMockLoan(ctrl)
+		holdStore := datastore.NewMockHold(ctrl)
+
+		for id := 1; id <= 4; id++ {
+			copyStore.EXPECT().GetCopyByID(gomock.Any(), id).Return(entities.Copy{ID: id, BookID: 1, Version: 2}, nil).
+				AnyTimes()
+		}
+
+		loanStore.EXPECT().GetLoansByBook(gomock.Any(), 1).Return(loans, nil).AnyTimes()
+		holdStore.EXPECT().GetBookHolds(gomock.Any(), 1).Return(holds, nil).AnyTimes()
+		copyStore.EXPECT().GetCopyByID(gomock.Any(), 5).Return(entities.Copy{},
+			errors.EntityNotFound{Entity: "Copy", ID: 5}).AnyTimes()
+
+		if tc.expErr == nil {
+			copyStore.EXPECT().DeleteCopy(gomock.Any(), tc.id).Return(nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		err := New(copyStore, datastore.NewMockBook(ctrl), loanStore, holdStore, mockTx{}, policy).DeleteCopy(ctx, tc.id)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,132 @@
+package fines
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+)
+
+type Service struct {
+	ledger datastore.Ledger
+	member datastore.Member
+	loan   datastore.Loan
+	tx     datastore.Transactor
+	policy Policy
+}
+
+func New(ledger datastore.Ledger, m datastore.Member, l datastore.Loan, tx datastore.Transactor, p Policy) Service {
+	return Service{ledger: ledger, member: m, loan: l, tx: tx, policy: p}
+}
+
+// GetAccount returns the ledger of the member with given id along with the balance and the fines accruing
+// on the loans which are overdue today
+func (s Service) GetAccount(ctx context.Context, memberID int) (entities.Account, error) {
+	member, err := s.member.GetMemberByID(ctx, memberID)
+	if err != nil {
+		return entities.Account{}, err
+	}
+
+	balance, accruing, entries, err := Owed(ctx, s.ledger, s.loan, s.policy, member)
+	if err != nil {
+		return entities.Account{}, err
+	}
+
+	return entities.Account{MemberID: memberID, Balance: balance, Accruing: accruing, Entries: entries}, nil
+}
+
+// PostEntry records a payment or a waiver of the member with given id today. The amount can not be more
+// than the balance and the loan, when set, must be a loan of the member
+func (s Service) PostEntry(ctx context.Context, memberID int, e entities.LedgerEntry) (entities.LedgerEntry, error) {
+	e.Type = strings.ToLower(strings.TrimSpace(e.Type))
+	e.Note = strings.TrimSpace(e.Note)
+
+	var invalid []string
+
+	if e.Type != entities.LedgerPayment && e.Type != entities.LedgerWaiver {
+		invalid = append(invalid, "Type")
+	}
+
+	if e.Amount <= 0 {
+		invalid = append(invalid, "Amount")
+	}
+
+	if e.LoanID < 0 {
+		invalid = append(invalid, "LoanID")
+	}
+
+	if err := errors.InValid(invalid...); err != nil {
+		return entities.LedgerEntry{}, err
+	}
+
+	var created entities.LedgerEntry
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.member.GetMemberByID(ctx, memberID); err != nil {
+			return err
+		}
+
+		if e.LoanID != 0 {
+			var notFound errors.EntityNotFound
+
+			l, err := s.loan.GetLoanByID(ctx, e.LoanID)
+			if stdErrors.As(err, &notFound) {
+				return errors.InValidDetails{Details: "LoanID"}
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if l.MemberID != memberID {
+				return errors.InValidDetails{Details: "LoanID"}
+			}
+		}
+
+		balance, err := s.ledger.GetBalance(ctx, memberID)
+		if err != nil {
+			return err
+		}
+
+		if e.Amount > balance {
+			return errors.Conflict{Entity: "Member", ID: memberID,
+				Reason: fmt.Sprintf("has a balance of %d which is less than the amount", balance)}
+		}
+
+		e.ID = 0
+		e.MemberID = memberID
+		e.CreatedOn = entities.Today()
+
+		created, err = s.ledger.CreateEntry(ctx, e)
+
+		return err
+	})
+	if err != nil {
+		return entities.LedgerEntry{}, err
+	}
+
+	return created, nil
+}
+
+// Owed returns the balance of the member, the fines accruing today on the overdue loans of the member and
+// the ledger of the member
+func Owed(ctx context.Context, ledger datastore.Ledger, loan datastore.Loan, p Policy,
+	m entities.Member) (balance, accruing int, entries []entities.LedgerEntry, err error) {
+	if balance, err = ledger.GetBalance(ctx, m.ID); err != nil {
+		return 0, 0, nil, err
+	}
+
+	if entries, err = ledger.GetEntries(ctx, m.ID); err != nil {
+		return 0, 0, nil, err
+	}
+
+	loans, err := loan.GetActiveLoans(ctx, m.ID)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return balance, p.Accruing(m.MembershipType, loans, entries, entities.Today()), entries, nil
+}
@@ -0,0 +1,139 @@
+package fines
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// date returns the date days from today
+func date(days int) entities.Date {
+	return entities.Today().AddDays(days)
+}
+
+type stores struct {
+	ledger *datastore.MockLedger
+	member *datastore.MockMember
+	loan   *datastore.MockLoan
+}
+
+// newService returns the service with the mock stores, member 1 is a standard member and member 9 does not exist
+func newService(ctrl *gomock.Controller) (Service, stores) {
+	s := stores{ledger: datastore.NewMockLedger(ctrl), member: datastore.NewMockMember(ctrl),
+		loan: datastore.NewMockLoan(ctrl)}
+
+	s.member.EXPECT().GetMemberByID(gomock.Any(), 1).
+		Return(entities.Member{ID: 1, MembershipType: entities.MembershipStandard}, nil).AnyTimes()
+	s.member.EXPECT().GetMemberByID(gomock.Any(), 9).
+		Return(entities.Member{}, errors.EntityNotFound{Entity: "Member", ID: 9}).AnyTimes()
+
+	return New(s.ledger, s.member, s.loan, mockTx{}, policy), s
+}
+
+func TestService_GetAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, st := newService(ctrl)
+
+	entries := []entities.LedgerEntry{
+		{ID: 1, MemberID: 1, LoanID: 2, Type: entities.LedgerFine, Amount: 300, CreatedOn: date(-5)},
+		{ID: 2, MemberID: 1, Type: entities.LedgerPayment, Amount: 100, CreatedOn: date(-1)},
+	}
+
+	loans := []entities.Loan{{ID: 3, MemberID: 1, DueOn: date(-4)}, {ID: 4, MemberID: 1, DueOn: date(4)}}
+
+	st.ledger.EXPECT().GetBalance(gomock.Any(), 1).Return(200, nil)
+	st.ledger.EXPECT().GetEntries(gomock.Any(), 1).Return(entries, nil)
+	st.loan.EXPECT().GetActiveLoans(gomock.Any(), 1).Return(loans, nil)
+
+	exp := entities.Account{MemberID: 1, Balance: 200, Accruing: 100, Entries: entries}
+
+	if res, err := s.GetAccount(context.Background(), 1); err != nil || !reflect.DeepEqual(res, exp) {
+		t.Errorf("Failed. Expected %v\tGot %v, %v", exp, res, err)
+	}
+
+	if _, err := s.GetAccount(context.Background(), 9); err != (errors.EntityNotFound{Entity: "Member", ID: 9}) {
+		t.Errorf("Failed. Expected the member not to be found Got %v", err)
+	}
+}
+
+func TestService_PostEntry(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		memberID int
+		req      entities.LedgerEntry
+		loan     entities.Loan
+		loanErr  error
+		balance  int
+		expRes   entities.LedgerEntry
+		expErr   error
+	}{
+		{desc: "payment", memberID: 1, req: entities.LedgerEntry{Type: "Payment ", Amount: 200, Note: " cash"},
+			balance: 300, expRes: entities.LedgerEntry{ID: 5, MemberID: 1, Type: entities.LedgerPayment, Amount: 200,
+				Note: "cash", CreatedOn: date(0)}},
+		{desc: "waiver of a fine", memberID: 1, req: entities.LedgerEntry{Type: entities.LedgerWaiver, Amount: 300,
+			LoanID: 2}, loan: entities.Loan{ID: 2, MemberID: 1}, balance: 300, expRes: entities.LedgerEntry{ID: 5,
+			MemberID: 1, LoanID: 2, Type: entities.LedgerWaiver, Amount: 300, CreatedOn: date(0)}},
+		{desc: "fines are charged by the loans", memberID: 1,
+			req:    entities.LedgerEntry{Type: entities.LedgerFine, Amount: 200},
+			expErr: errors.InValidDetails{Details: "Type"}},
+		{desc: "amount is missing", memberID: 1, req: entities.LedgerEntry{Type: entities.LedgerPayment},
+			expErr: errors.InValidDetails{Details: "Amount"}},
+		{desc: "type and amount are invalid", memberID: 1, req: entities.LedgerEntry{Amount: -5},
+			expErr: errors.InValidFields{{Details: "Type"}, {Details: "Amount"}}},
+		{desc: "member not found", memberID: 9, req: entities.LedgerEntry{Type: entities.LedgerPayment, Amount: 200},
+			expErr: errors.EntityNotFound{Entity: "Member", ID: 9}},
+		{desc: "loan of another member", memberID: 1, req: entities.LedgerEntry{Type: entities.LedgerWaiver,
+			Amount: 200, LoanID: 2}, loan: entities.Loan{ID: 2, MemberID: 3},
+			expErr: errors.InValidDetails{Details: "LoanID"}},
+		{desc: "loan not found", memberID: 1, req: entities.LedgerEntry{Type: entities.LedgerWaiver, Amount: 200,
+			LoanID: 4}, loan: entities.Loan{ID: 4}, loanErr: errors.EntityNotFound{Entity: "Loan", ID: 4},
+			expErr: errors.InValidDetails{Details: "LoanID"}},
+		{desc: "loan lookup fails", memberID: 1, req: entities.LedgerEntry{Type: entities.LedgerWaiver, Amount: 200,
+			LoanID: 4}, loan: entities.Loan{ID: 4}, loanErr: errors.DB{Err: fmt.Errorf("connection refused")},
+			expErr: errors.DB{Err: fmt.Errorf("connection refused")}},
+		{desc: "more than the balance", memberID: 1, req: entities.LedgerEntry{Type: entities.LedgerPayment,
+			Amount: 400}, balance: 300, expErr: errors.Conflict{Entity: "Member", ID: 1,
+			Reason: "has a balance of 300 which is less than the amount"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		s, st := newService(ctrl)
+
+		st.loan.EXPECT().GetLoanByID(gomock.Any(), tc.loan.ID).Return(tc.loan, tc.loanErr).AnyTimes()
+		st.ledger.EXPECT().GetBalance(gomock.Any(), 1).Return(tc.balance, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			entry := tc.expRes
+			entry.ID = 0
+
+			st.ledger.EXPECT().CreateEntry(gomock.Any(), entry).Return(tc.expRes, nil)
+		}
+
+		res, err := s.PostEntry(context.Background(), tc.memberID, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
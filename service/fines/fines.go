@@ -0,0 +1,68 @@
+// Package fines charges the members for the overdue loans and keeps their accounts, the fines are recorded
+// in the ledger of the member along with the payments and the waivers of the staff
+package fines
+
+import (
+	"ThreeLayer/entities"
+)
+
+// Policy is the fining policy in cents per membership type, a loan is fined DailyRates for every day it is
+// overdue up to Caps for the loan. A member who owes more than BlockAbove can not borrow
+type Policy struct {
+	DailyRates map[string]int
+	Caps       map[string]int
+	BlockAbove int
+}
+
+// DaysOverdue returns the days from the due date of the loan to the date, zero when the loan is not overdue
+func (p Policy) DaysOverdue(l entities.Loan, on entities.Date) int {
+	if l.DueOn.IsZero() || !on.After(l.DueOn) {
+		return 0
+	}
+
+	return on.DaysSince(l.DueOn)
+}
+
+// Fine returns the fine of the loan on the date for a member of the membership type, up to what is left of
+// the cap after the fines charged for the loan already
+func (p Policy) Fine(membershipType string, l entities.Loan, charged int, on entities.Date) int {
+	fine := p.DaysOverdue(l, on) * p.DailyRates[membershipType]
+
+	if left := p.Caps[membershipType] - charged; fine > left {
+		fine = left
+	}
+
+	if fine < 0 {
+		return 0
+	}
+
+	return fine
+}
+
+// Accruing returns the sum of the fines on the date of the loans which are not returned yet,
+// entries are the ledger of the member
+func (p Policy) Accruing(membershipType string, loans []entities.Loan, entries []entities.LedgerEntry,
+	on entities.Date) int {
+	accruing := 0
+
+	for i := range loans {
+		if loans[i].Active() {
+			accruing += p.Fine(membershipType, loans[i], Charged(entries, loans[i].ID), on)
+		}
+	}
+
+	return accruing
+}
+
+// Charged returns the sum of the fines in entries charged for the loan with given id, waived fines included
+func Charged(entries []entities.LedgerEntry, loanID int) int {
+	charged := 0
+
+	for i := range entries {
+		if entries[i].Type == entities.LedgerFine && entries[i].LoanID == loanID {
+			charged += entries[i].Amount
+		}
+	}
+
+	return charged
+}
@@ -0,0 +1,81 @@
+package fines
+
+import (
+	"ThreeLayer/entities"
+	"testing"
+	"time"
+)
+
+var policy = Policy{
+	DailyRates: map[string]int{entities.MembershipStandard: 25, entities.MembershipStudent: 10},
+	Caps:       map[string]int{entities.MembershipStandard: 1000, entities.MembershipStudent: 500},
+	BlockAbove: 1000,
+}
+
+// on is the date the fines are computed for in the tests
+var on = day(3, 20)
+
+// day returns the date of the month and day in 2022
+func day(month time.Month, d int) entities.Date {
+	return entities.NewDate(2022, month, d)
+}
+
+func TestPolicy_Fine(t *testing.T) {
+	testcases := []struct {
+		desc       string
+		membership string
+		dueOn      entities.Date
+		charged    int
+		expDays    int
+		expFine    int
+	}{
+		{desc: "not due yet", membership: entities.MembershipStandard, dueOn: day(3, 25)},
+		{desc: "due today", membership: entities.MembershipStandard, dueOn: day(3, 20)},
+		{desc: "overdue", membership: entities.MembershipStandard, dueOn: day(3, 10), expDays: 10, expFine: 250},
+		{desc: "rate of the membership", membership: entities.MembershipStudent, dueOn: day(3, 10), expDays: 10,
+			expFine: 100},
+		{desc: "capped", membership: entities.MembershipStudent, dueOn: day(1, 10), expDays: 69, expFine: 500},
+		{desc: "rest of the cap", membership: entities.MembershipStandard, dueOn: day(3, 10), charged: 800,
+			expDays: 10, expFine: 200},
+		{desc: "cap reached", membership: entities.MembershipStandard, dueOn: day(3, 10), charged: 1000,
+			expDays: 10},
+		{desc: "membership without a rate", membership: entities.MembershipPremium, dueOn: day(3, 10), expDays: 10},
+	}
+
+	for i, tc := range testcases {
+		l := entities.Loan{ID: 1, DueOn: tc.dueOn}
+
+		if days := policy.DaysOverdue(l, on); days != tc.expDays {
+			t.Errorf("[TEST%d]Failed. Expected %v days\tGot %v", i, tc.expDays, days)
+		}
+
+		if fine := policy.Fine(tc.membership, l, tc.charged, on); fine != tc.expFine {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expFine, fine)
+		}
+	}
+}
+
+func TestPolicy_Accruing(t *testing.T) {
+	loans := []entities.Loan{
+		{ID: 1, DueOn: day(3, 10)},
+		{ID: 2, DueOn: day(3, 18)},
+		{ID: 3, DueOn: day(3, 1), ReturnedOn: day(3, 15)},
+		{ID: 4, DueOn: day(3, 25)},
+	}
+
+	entries := []entities.LedgerEntry{
+		{LoanID: 1, Type: entities.LedgerFine, Amount: 900},
+		{LoanID: 1, Type: entities.LedgerWaiver, Amount: 900},
+		{LoanID: 3, Type: entities.LedgerFine, Amount: 100},
+		{Type: entities.LedgerPayment, Amount: 100},
+	}
+
+	if charged := Charged(entries, 1); charged != 900 {
+		t.Errorf("Failed. Expected the waived fine to be charged 900 Got %v", charged)
+	}
+
+	// loan 1 is fined the rest of the cap, loan 2 two days and loan 3 is returned
+	if accruing := policy.Accruing(entities.MembershipStandard, loans, entries, on); accruing != 150 {
+		t.Errorf("Failed. Expected 150 Got %v", accruing)
+	}
+}
@@ -0,0 +1,214 @@
+package genre
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"context"
+	"strings"
+)
+
+type Service struct {
+	store datastore.Genre
+	tx    datastore.Transactor
+}
+
+func New(g datastore.Genre, tx datastore.Transactor) Service {
+	return Service{store: g, tx: tx}
+}
+
+// GetGenres returns all the genres and subjects
+func (s Service) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	return s.store.GetGenres(ctx)
+}
+
+// GetGenreByID returns the genre with given id
+func (s Service) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	return s.store.GetGenreByID(ctx, id)
+}
+
+// PostGenre adds a new genre, two genres of a kind can not have the same name
+func (s Service) PostGenre(ctx context.Context, g entities.Genre) (entities.Genre, error) {
+	g = normalize(g)
+
+	if err := checkDetails(g); err != nil {
+		return entities.Genre{}, err
+	}
+
+	var created entities.Genre
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		genres, err := s.store.GetGenres(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = checkTree(genres, 0, g); err != nil {
+			return err
+		}
+
+		created, err = s.store.CreateGenre(ctx, g)
+
+		return err
+	})
+	if err != nil {
+		return entities.Genre{}, err
+	}
+
+	return created, nil
+}
+
+// PutGenre replaces the genre with given id, the version of the genre is checked against the If-Match versions
+// in the context
+func (s Service) PutGenre(ctx context.Context, id int, g entities.Genre) (entities.Genre, error) {
+	g = normalize(g)
+
+	if err := checkDetails(g); err != nil {
+		return entities.Genre{}, err
+	}
+
+	var updated entities.Genre
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.store.GetGenreByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Genre", id, stored.Version); err != nil {
+			return err
+		}
+
+		genres, err := s.store.GetGenres(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = checkTree(genres, id, g); err != nil {
+			return err
+		}
+
+		g.Version = stored.Version
+
+		updated, err = s.store.UpdateGenre(ctx, id, g)
+
+		return err
+	})
+	if err != nil {
+		return entities.Genre{}, err
+	}
+
+	return updated, nil
+}
+
+// DeleteGenre removes the genre with given id and its tags on the books, a genre having children can not be removed.
+// The version of the genre is checked against the If-Match versions in the context
+func (s Service) DeleteGenre(ctx context.Context, id int) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		g, err := s.store.GetGenreByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Genre", id, g.Version); err != nil {
+			return err
+		}
+
+		genres, err := s.store.GetGenres(ctx)
+		if err != nil {
+			return err
+		}
+
+		if hasChildren(genres, id) {
+			return errors.Conflict{Entity: "Genre", ID: id, Reason: "has genres"}
+		}
+
+		return s.store.DeleteGenre(ctx, id)
+	})
+}
+
+// checkTree checks the genre with given id against the other genres, the id is 0 for a new genre. The error is
+// ExistAlready when another genre of the kind has the name and InValidDetails when the parent does not exist, is of
+// another kind or is the genre itself or any of its descendants. The kind of a genre having children can not change
+func checkTree(genres []entities.Genre, id int, g entities.Genre) error {
+	byID := make(map[int]entities.Genre, len(genres))
+
+	for i := range genres {
+		byID[genres[i].ID] = genres[i]
+
+		if genres[i].ID != id && genres[i].Kind == g.Kind && strings.EqualFold(genres[i].Name, g.Name) {
+			return errors.ExistAlready{Entity: "Genre"}
+		}
+	}
+
+	if g.ParentID != 0 {
+		parent, ok := byID[g.ParentID]
+		if !ok || parent.Kind != g.Kind {
+			return errors.InValidDetails{Details: "Parent ID"}
+		}
+
+		// a genre can not be moved under itself, a new genre has no descendants
+		if id != 0 && contains(entities.Descendants(genres, id), g.ParentID) {
+			return errors.InValidDetails{Details: "Parent ID"}
+		}
+	}
+
+	if stored, ok := byID[id]; ok && stored.Kind != g.Kind && hasChildren(genres, id) {
+		return errors.InValidDetails{Details: "Kind"}
+	}
+
+	return nil
+}
+
+// hasChildren tells whether any of the genres has the genre with given id as parent
+func hasChildren(genres []entities.Genre, id int) bool {
+	for i := range genres {
+		if genres[i].ParentID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize trims the name of the genre, a genre sent without a kind is a genre rather than a subject
+func normalize(g entities.Genre) entities.Genre {
+	g.Name = strings.TrimSpace(g.Name)
+	g.Kind = strings.ToLower(strings.TrimSpace(g.Kind))
+
+	if g.Kind == "" {
+		g.Kind = entities.KindGenre
+	}
+
+	return g
+}
+
+// checkDetails validates the genre, all the invalid details are reported
+func checkDetails(g entities.Genre) error {
+	var invalid []string
+
+	if g.Name == "" {
+		invalid = append(invalid, "Name")
+	}
+
+	if g.Kind != entities.KindGenre && g.Kind != entities.KindSubject {
+		invalid = append(invalid, "Kind")
+	}
+
+	if g.ParentID < 0 {
+		invalid = append(invalid, "Parent ID")
+	}
+
+	return errors.InValid(invalid...)
+}
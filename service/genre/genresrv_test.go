@@ -0,0 +1,182 @@
+package genre
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// taxonomy is Fiction > Mystery > Cozy Mystery along with the subject History
+func taxonomy() []entities.Genre {
+	return []entities.Genre{{ID: 1, Name: "Fiction", Kind: entities.KindGenre, Version: 1},
+		{ID: 2, Name: "Mystery", Kind: entities.KindGenre, ParentID: 1, Version: 2},
+		{ID: 3, Name: "Cozy Mystery", Kind: entities.KindGenre, ParentID: 2, Version: 1},
+		{ID: 4, Name: "History", Kind: entities.KindSubject, Version: 1}}
+}
+
+func TestService_PostGenre(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		req    entities.Genre
+		expNew entities.Genre
+		expErr error
+	}{
+		{desc: "top genre", req: entities.Genre{Name: "Poetry", Kind: entities.KindGenre},
+			expNew: entities.Genre{Name: "Poetry", Kind: entities.KindGenre}},
+		{desc: "details are trimmed and the kind defaults to genre", req: entities.Genre{Name: " Thriller ", ParentID: 1},
+			expNew: entities.Genre{Name: "Thriller", Kind: entities.KindGenre, ParentID: 1}},
+		{desc: "same name as a genre of another kind", req: entities.Genre{Name: "Fiction", Kind: "Subject"},
+			expNew: entities.Genre{Name: "Fiction", Kind: entities.KindSubject}},
+		{desc: "invalid details", req: entities.Genre{Name: " ", Kind: "tag", ParentID: -1},
+			expErr: errors.InValidFields{{Details: "Name"}, {Details: "Kind"}, {Details: "Parent ID"}}},
+		{desc: "name is taken", req: entities.Genre{Name: "MYSTERY"}, expErr: errors.ExistAlready{Entity: "Genre"}},
+		{desc: "parent does not exist", req: entities.Genre{Name: "Thriller", ParentID: 9},
+			expErr: errors.InValidDetails{Details: "Parent ID"}},
+		{desc: "parent of another kind", req: entities.Genre{Name: "Ancient History", ParentID: 4},
+			expErr: errors.InValidDetails{Details: "Parent ID"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockGenre(ctrl)
+
+		store.EXPECT().GetGenres(gomock.Any()).Return(taxonomy(), nil).AnyTimes()
+
+		var expRes entities.Genre
+
+		if tc.expErr == nil {
+			expRes = tc.expNew
+			expRes.ID, expRes.Version = 5, 1
+
+			store.EXPECT().CreateGenre(gomock.Any(), tc.expNew).Return(expRes, nil)
+		}
+
+		res, err := New(store, mockTx{}).PostGenre(context.Background(), tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		if res != expRes {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_PutGenre(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		req      entities.Genre
+		expRes   entities.Genre
+		expErr   error
+	}{
+		{desc: "renamed", id: 2, versions: []int{2}, req: entities.Genre{Name: "Crime", ParentID: 1},
+			expRes: entities.Genre{ID: 2, Name: "Crime", Kind: entities.KindGenre, ParentID: 1, Version: 3}},
+		{desc: "moved to the top", id: 3, req: entities.Genre{Name: "Cozy Mystery"},
+			expRes: entities.Genre{ID: 3, Name: "Cozy Mystery", Kind: entities.KindGenre, Version: 2}},
+		{desc: "version does not match", id: 2, versions: []int{1}, req: entities.Genre{Name: "Crime"},
+			expErr: errors.PreconditionFailed{Entity: "Genre", ID: 2}},
+		{desc: "moved under itself", id: 2, req: entities.Genre{Name: "Mystery", ParentID: 2},
+			expErr: errors.InValidDetails{Details: "Parent ID"}},
+		{desc: "moved under a descendant", id: 1, req: entities.Genre{Name: "Fiction", ParentID: 3},
+			expErr: errors.InValidDetails{Details: "Parent ID"}},
+		{desc: "kind of a genre having children", id: 1, req: entities.Genre{Name: "Fiction", Kind: entities.KindSubject},
+			expErr: errors.InValidDetails{Details: "Kind"}},
+		{desc: "name of other genre", id: 3, req: entities.Genre{Name: "fiction"},
+			expErr: errors.ExistAlready{Entity: "Genre"}},
+		{desc: "not found", id: 9, req: entities.Genre{Name: "Crime"},
+			expErr: errors.EntityNotFound{Entity: "Genre", ID: 9}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockGenre(ctrl)
+
+		for _, g := range taxonomy() {
+			store.EXPECT().GetGenreByID(gomock.Any(), g.ID).Return(g, nil).AnyTimes()
+		}
+
+		store.EXPECT().GetGenreByID(gomock.Any(), 9).Return(entities.Genre{},
+			errors.EntityNotFound{Entity: "Genre", ID: 9}).AnyTimes()
+		store.EXPECT().GetGenres(gomock.Any()).Return(taxonomy(), nil).AnyTimes()
+
+		if tc.expErr == nil {
+			req := normalize(tc.req)
+			req.Version = tc.expRes.Version - 1
+			returned := req
+			returned.ID, returned.Version = tc.id, tc.expRes.Version
+
+			store.EXPECT().UpdateGenre(gomock.Any(), tc.id, req).Return(returned, nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		res, err := New(store, mockTx{}).PutGenre(ctx, tc.id, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_DeleteGenre(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		expErr   error
+	}{
+		{desc: "deleted", id: 3},
+		{desc: "deleted when the version matches", id: 4, versions: []int{1}},
+		{desc: "version does not match", id: 3, versions: []int{3}, expErr: errors.PreconditionFailed{Entity: "Genre",
+			ID: 3}},
+		{desc: "genre has children", id: 2, expErr: errors.Conflict{Entity: "Genre", ID: 2, Reason: "has genres"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockGenre(ctrl)
+
+		store.EXPECT().GetGenreByID(gomock.Any(), tc.id).Return(taxonomy()[tc.id-1], nil)
+		store.EXPECT().GetGenres(gomock.Any()).Return(taxonomy(), nil).AnyTimes()
+
+		if tc.expErr == nil {
+			store.EXPECT().DeleteGenre(gomock.Any(), tc.id).Return(nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		err := New(store, mockTx{}).DeleteGenre(ctx, tc.id)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
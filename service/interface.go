@@ -6,15 +6,85 @@ import (
 )
 
 type Book interface {
-	GetBook(ctx context.Context) ([]entities.Book, error)
+	GetBook(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error)
 	GetBookByID(ctx context.Context, id int) (entities.Book, error)
+	GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error)
+	SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error)
+	GetSeriesBooks(ctx context.Context, id int, filter entities.BookFilter) (entities.BookPage, error)
+	GetEditions(ctx context.Context, id int, filter entities.BookFilter) (entities.BookPage, error)
 	PostBook(ctx context.Context, book entities.Book) (entities.Book, error)
 	DeleteBook(ctx context.Context, id int) error
 	PutBook(ctx context.Context, id int, book entities.Book) (entities.Book, error)
+	PatchBook(ctx context.Context, id int, patch entities.Patch) (entities.Book, error)
 }
 
 type Author interface {
+	GetAuthor(ctx context.Context) ([]entities.AuthorDetails, error)
+	GetAuthorByID(ctx context.Context, id int) (entities.AuthorDetails, error)
 	PostAuthor(ctx context.Context, author entities.Author) (entities.Author, error)
 	DeleteAuthor(ctx context.Context, id int) error
 	PutAuthor(ctx context.Context, id int, author entities.Author) (entities.Author, error)
+	PatchAuthor(ctx context.Context, id int, patch entities.Patch) (entities.Author, error)
+}
+
+type Publisher interface {
+	GetPublishers(ctx context.Context) ([]entities.Publisher, error)
+	GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error)
+	PostPublisher(ctx context.Context, p entities.Publisher) (entities.Publisher, error)
+	PutPublisher(ctx context.Context, id int, p entities.Publisher) (entities.Publisher, error)
+	DeletePublisher(ctx context.Context, id int) error
+}
+
+type Genre interface {
+	GetGenres(ctx context.Context) ([]entities.Genre, error)
+	GetGenreByID(ctx context.Context, id int) (entities.Genre, error)
+	PostGenre(ctx context.Context, g entities.Genre) (entities.Genre, error)
+	PutGenre(ctx context.Context, id int, g entities.Genre) (entities.Genre, error)
+	DeleteGenre(ctx context.Context, id int) error
+}
+
+type Series interface {
+	GetSeries(ctx context.Context) ([]entities.Series, error)
+	GetSeriesByID(ctx context.Context, id int) (entities.Series, error)
+	PostSeries(ctx context.Context, s entities.Series) (entities.Series, error)
+	PutSeries(ctx context.Context, id int, s entities.Series) (entities.Series, error)
+	DeleteSeries(ctx context.Context, id int) error
+}
+
+type Member interface {
+	GetMembers(ctx context.Context) ([]entities.Member, error)
+	GetMemberByID(ctx context.Context, id int) (entities.Member, error)
+	PostMember(ctx context.Context, member entities.Member) (entities.Member, error)
+	PutMember(ctx context.Context, id int, member entities.Member) (entities.Member, error)
+	DeleteMember(ctx context.Context, id int) error
+}
+
+type Copy interface {
+	GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error)
+	GetCopyByID(ctx context.Context, id int) (entities.Copy, error)
+	PostCopy(ctx context.Context, bookID int, c entities.Copy) (entities.Copy, error)
+	PutCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error)
+	DeleteCopy(ctx context.Context, id int) error
+}
+
+type Loan interface {
+	GetLoanByID(ctx context.Context, id int) (entities.Loan, error)
+	GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error)
+	GetBookLoans(ctx context.Context, bookID int) ([]entities.Loan, error)
+	Checkout(ctx context.Context, l entities.Loan) (entities.Loan, error)
+	Return(ctx context.Context, id int) (entities.Loan, error)
+	Renew(ctx context.Context, id int) (entities.Loan, error)
+}
+
+type Hold interface {
+	GetHoldByID(ctx context.Context, id int) (entities.Hold, error)
+	GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error)
+	GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error)
+	PlaceHold(ctx context.Context, h entities.Hold) (entities.Hold, error)
+	CancelHold(ctx context.Context, id int) (entities.Hold, error)
+}
+
+type Account interface {
+	GetAccount(ctx context.Context, memberID int) (entities.Account, error)
+	PostEntry(ctx context.Context, memberID int, e entities.LedgerEntry) (entities.LedgerEntry, error)
 }
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+)
+
+// CheckLoans returns Conflict when the copy, member or book being removed has any of the loans, the loans are kept
+// as the lending history even after they are returned
+func CheckLoans(entity string, id int, loans []entities.Loan) error {
+	for i := range loans {
+		if loans[i].Active() {
+			return errors.Conflict{Entity: entity, ID: id, Reason: "has an active loan"}
+		}
+	}
+
+	if len(loans) > 0 {
+		return errors.Conflict{Entity: entity, ID: id, Reason: "has a loan history"}
+	}
+
+	return nil
+}
@@ -0,0 +1,268 @@
+package loans
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+)
+
+// GetHoldByID returns the hold with given id
+func (s Service) GetHoldByID(ctx context.Context, id int) (entities.Hold, error) {
+	return s.hold.GetHoldByID(ctx, id)
+}
+
+// GetBookHolds returns the queue of the book with given id, the waiting and ready holds in the order they were placed
+func (s Service) GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error) {
+	if _, err := s.book.GetBookByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	return s.hold.GetBookHolds(ctx, bookID)
+}
+
+// GetMemberHolds returns the waiting and ready holds of the member with given id
+func (s Service) GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error) {
+	if _, err := s.member.GetMemberByID(ctx, memberID); err != nil {
+		return nil, err
+	}
+
+	return s.hold.GetMemberHolds(ctx, memberID)
+}
+
+// PlaceHold adds the member of the hold to the end of the queue of the book. A hold is placed only when
+// no copy of the book is available, by a member who can borrow and does not have the book already
+func (s Service) PlaceHold(ctx context.Context, h entities.Hold) (entities.Hold, error) {
+	var invalid []string
+
+	if h.BookID <= 0 {
+		invalid = append(invalid, "BookID")
+	}
+
+	if h.MemberID <= 0 {
+		invalid = append(invalid, "MemberID")
+	}
+
+	if err := errors.InValid(invalid...); err != nil {
+		return entities.Hold{}, err
+	}
+
+	var created entities.Hold
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		member, err := s.member.GetMemberByID(ctx, h.MemberID)
+		if isNotFound(err) {
+			return errors.InValidDetails{Details: "MemberID"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = checkMember(member); err != nil {
+			return err
+		}
+
+		_, err = s.book.GetBookByID(ctx, h.BookID)
+		if isNotFound(err) {
+			return errors.InValidDetails{Details: "BookID"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		availability, err := s.copy.GetAvailability(ctx, []int{h.BookID})
+		if err != nil {
+			return err
+		}
+
+		if availability[h.BookID].Available > 0 {
+			return errors.Conflict{Entity: "Book", ID: h.BookID, Reason: "has available copies"}
+		}
+
+		if err = s.checkHolder(ctx, h); err != nil {
+			return err
+		}
+
+		created, err = s.hold.CreateHold(ctx, entities.Hold{BookID: h.BookID, MemberID: member.ID,
+			Status: entities.HoldWaiting, PlacedOn: entities.Today()})
+
+		return err
+	})
+	if err != nil {
+		return entities.Hold{}, err
+	}
+
+	return created, nil
+}
+
+// CancelHold takes the hold with given id out of the queue, the copy of a ready hold goes to the next member
+func (s Service) CancelHold(ctx context.Context, id int) (entities.Hold, error) {
+	return s.closeHold(ctx, id, entities.HoldCancelled)
+}
+
+// ExpireHolds closes the ready holds which were not picked up by their expiry date and passes their copies
+// on, it returns the number of holds expired
+func (s Service) ExpireHolds(ctx context.Context) (int, error) {
+	ready, err := s.hold.GetHoldsByStatus(ctx, entities.HoldReady)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+
+	for i := range ready {
+		if !ready[i].ExpiresOn.Before(entities.Today()) {
+			continue
+		}
+
+		_, err = s.closeHold(ctx, ready[i].ID, entities.HoldExpired)
+		if err != nil && !isConflict(err) {
+			return expired, err
+		}
+
+		// the hold has been picked up or cancelled since it was read
+		if err == nil {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// closeHold sets the status of the queued hold with given id and releases the copy assigned to it
+func (s Service) closeHold(ctx context.Context, id int, status string) (entities.Hold, error) {
+	var closed entities.Hold
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		h, err := s.hold.GetHoldByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !h.Queued() {
+			return errors.Conflict{Entity: "Hold", ID: id, Reason: "is " + h.Status}
+		}
+
+		ready := h.Status == entities.HoldReady
+		h.Status = status
+
+		closed, err = s.hold.UpdateHold(ctx, id, h)
+		if err != nil {
+			return err
+		}
+
+		if !ready || h.CopyID == 0 {
+			return nil
+		}
+
+		return s.releaseHeld(ctx, h.CopyID)
+	})
+	if err != nil {
+		return entities.Hold{}, err
+	}
+
+	return closed, nil
+}
+
+// checkHolder returns an error when the member of the hold is in the queue of the book or has the book on loan
+func (s Service) checkHolder(ctx context.Context, h entities.Hold) error {
+	queue, err := s.hold.GetBookHolds(ctx, h.BookID)
+	if err != nil {
+		return err
+	}
+
+	if _, held := memberHold(queue, h.MemberID); held {
+		return errors.ExistAlready{Entity: "Hold"}
+	}
+
+	loans, err := s.loan.GetActiveLoans(ctx, h.MemberID)
+	if err != nil {
+		return err
+	}
+
+	for i := range loans {
+		if loans[i].BookID == h.BookID {
+			return errors.Conflict{Entity: "Member", ID: h.MemberID, Reason: "has the book on loan"}
+		}
+	}
+
+	return nil
+}
+
+// fulfil closes the hold picked up with the copy lent, the copy assigned to the hold before is released
+func (s Service) fulfil(ctx context.Context, h entities.Hold, copyID int) error {
+	assigned := h.CopyID
+
+	h.Status, h.CopyID = entities.HoldFulfilled, copyID
+
+	if _, err := s.hold.UpdateHold(ctx, h.ID, h); err != nil {
+		return err
+	}
+
+	if assigned == 0 || assigned == copyID {
+		return nil
+	}
+
+	return s.releaseHeld(ctx, assigned)
+}
+
+// releaseHeld releases the copy with given id when it is still on hold
+func (s Service) releaseHeld(ctx context.Context, copyID int) error {
+	c, err := s.copy.GetCopyByID(ctx, copyID)
+	if err != nil {
+		return err
+	}
+
+	if c.Status != entities.CopyOnHold {
+		return nil
+	}
+
+	return Release(ctx, s.hold, s.copy, s.policy, c)
+}
+
+// Release assigns the copy to the first waiting hold of its book for the pickup days of the policy,
+// the copy is available when no member is waiting. It is also used when a copy is made available by hand
+func Release(ctx context.Context, holds datastore.Hold, copies datastore.Copy, p Policy, c entities.Copy) error {
+	queue, err := holds.GetBookHolds(ctx, c.BookID)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range queue {
+		if h.Status != entities.HoldWaiting {
+			continue
+		}
+
+		if err = copies.UpdateCopyStatus(ctx, c.ID, c.Status, entities.CopyOnHold); err != nil {
+			return err
+		}
+
+		today := entities.Today()
+
+		h.CopyID, h.Status = c.ID, entities.HoldReady
+		h.ReadyOn, h.ExpiresOn = today, today.AddDays(p.PickupDays)
+
+		_, err = holds.UpdateHold(ctx, h.ID, h)
+
+		return err
+	}
+
+	if c.Status == entities.CopyAvailable {
+		return nil
+	}
+
+	return copies.UpdateCopyStatus(ctx, c.ID, c.Status, entities.CopyAvailable)
+}
+
+// memberHold returns the hold of the member in the queue
+func memberHold(queue []entities.Hold, memberID int) (entities.Hold, bool) {
+	for i := range queue {
+		if queue[i].MemberID == memberID {
+			return queue[i], true
+		}
+	}
+
+	return entities.Hold{}, false
+}
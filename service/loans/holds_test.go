@@ -0,0 +1,174 @@
+package loans
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestService_PlaceHold(t *testing.T) {
+	placed := entities.Hold{BookID: 4, MemberID: 1, Status: entities.HoldWaiting, PlacedOn: date(0)}
+	allOut := entities.Availability{Total: 1, OnLoan: 1}
+
+	testcases := []struct {
+		desc         string
+		req          entities.Hold
+		availability entities.Availability
+		queue        []entities.Hold
+		loans        []entities.Loan
+		expErr       error
+	}{
+		{desc: "placed", req: entities.Hold{BookID: 4, MemberID: 1}, availability: allOut},
+		{desc: "placed for a book without copies", req: entities.Hold{BookID: 4, MemberID: 1}},
+		{desc: "ids are missing", expErr: errors.InValidFields{{Details: "BookID"}, {Details: "MemberID"}}},
+		{desc: "member is suspended", req: entities.Hold{BookID: 4, MemberID: 2},
+			expErr: errors.Conflict{Entity: "Member", ID: 2, Reason: "is suspended"}},
+		{desc: "book not found", req: entities.Hold{BookID: 6, MemberID: 1},
+			expErr: errors.InValidDetails{Details: "BookID"}},
+		{desc: "member lookup fails", req: entities.Hold{BookID: 4, MemberID: 4}, expErr: errDB},
+		{desc: "book lookup fails", req: entities.Hold{BookID: 7, MemberID: 1}, expErr: errDB},
+		{desc: "copies are available", req: entities.Hold{BookID: 4, MemberID: 1},
+			availability: entities.Availability{Total: 2, Available: 1, OnLoan: 1},
+			expErr:       errors.Conflict{Entity: "Book", ID: 4, Reason: "has available copies"}},
+		{desc: "member is in the queue", req: entities.Hold{BookID: 4, MemberID: 1}, availability: allOut,
+			queue:  []entities.Hold{{ID: 8, BookID: 4, MemberID: 1, Status: entities.HoldWaiting}},
+			expErr: errors.ExistAlready{Entity: "Hold"}},
+		{desc: "member has the book", req: entities.Hold{BookID: 4, MemberID: 1}, availability: allOut,
+			loans:  []entities.Loan{{ID: 3, CopyID: 2, MemberID: 1, BookID: 4}},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has the book on loan"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		s, st := newService(ctrl)
+
+		st.book.EXPECT().GetBookByID(gomock.Any(), 4).Return(entities.Book{ID: 4}, nil).AnyTimes()
+		st.book.EXPECT().GetBookByID(gomock.Any(), 6).Return(entities.Book{},
+			errors.EntityNotFound{Entity: "Book", ID: 6}).AnyTimes()
+		st.book.EXPECT().GetBookByID(gomock.Any(), 7).Return(entities.Book{}, errDB).AnyTimes()
+		st.copy.EXPECT().GetAvailability(gomock.Any(), []int{4}).
+			Return(map[int]entities.Availability{4: tc.availability}, nil).AnyTimes()
+		st.hold.EXPECT().GetBookHolds(gomock.Any(), 4).Return(tc.queue, nil).AnyTimes()
+		st.loan.EXPECT().GetActiveLoans(gomock.Any(), 1).Return(tc.loans, nil).AnyTimes()
+
+		var expRes entities.Hold
+
+		if tc.expErr == nil {
+			expRes = placed
+			expRes.ID, expRes.Version = 8, 1
+
+			st.hold.EXPECT().CreateHold(gomock.Any(), placed).Return(expRes, nil)
+		}
+
+		res, err := s.PlaceHold(context.Background(), tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_CancelHold(t *testing.T) {
+	ready := entities.Hold{ID: 8, BookID: 4, MemberID: 1, CopyID: 5, Status: entities.HoldReady, Version: 2}
+	waiting := entities.Hold{ID: 9, BookID: 4, MemberID: 2, Status: entities.HoldWaiting, Version: 1}
+
+	cancelled := func(h entities.Hold) entities.Hold {
+		h.Status, h.Version = entities.HoldCancelled, h.Version+1
+
+		return h
+	}
+
+	fulfilled := ready
+	fulfilled.Status = entities.HoldFulfilled
+
+	testcases := []struct {
+		desc       string
+		stored     entities.Hold
+		queue      []entities.Hold
+		copyStatus string
+		assigned   entities.Hold
+		expRes     entities.Hold
+		expErr     error
+	}{
+		{desc: "waiting hold cancelled", stored: waiting, expRes: cancelled(waiting)},
+		{desc: "copy is available again", stored: ready, copyStatus: entities.CopyAvailable,
+			expRes: cancelled(ready)},
+		{desc: "copy goes to the next member", stored: ready, queue: []entities.Hold{waiting},
+			copyStatus: entities.CopyOnHold, assigned: entities.Hold{ID: 9, BookID: 4, MemberID: 2, CopyID: 5,
+				Status: entities.HoldReady, ReadyOn: date(0), ExpiresOn: date(3), Version: 1},
+			expRes: cancelled(ready)},
+		{desc: "hold is fulfilled", stored: fulfilled,
+			expErr: errors.Conflict{Entity: "Hold", ID: 8, Reason: "is fulfilled"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		s, st := newService(ctrl)
+
+		st.hold.EXPECT().GetHoldByID(gomock.Any(), tc.stored.ID).Return(tc.stored, nil)
+		st.hold.EXPECT().GetBookHolds(gomock.Any(), 4).Return(tc.queue, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			update := tc.stored
+			update.Status = entities.HoldCancelled
+			cancelled := update
+			cancelled.Version++
+
+			st.hold.EXPECT().UpdateHold(gomock.Any(), tc.stored.ID, update).Return(cancelled, nil)
+		}
+
+		if tc.copyStatus != "" {
+			st.copy.EXPECT().UpdateCopyStatus(gomock.Any(), 5, entities.CopyOnHold, tc.copyStatus).Return(nil)
+		}
+
+		if tc.assigned.ID != 0 {
+			st.hold.EXPECT().UpdateHold(gomock.Any(), tc.assigned.ID, tc.assigned).Return(tc.assigned, nil)
+		}
+
+		res, err := s.CancelHold(context.Background(), tc.stored.ID)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_ExpireHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, st := newService(ctrl)
+
+	overdue := entities.Hold{ID: 8, BookID: 4, MemberID: 1, CopyID: 5, Status: entities.HoldReady,
+		ReadyOn: date(-4), ExpiresOn: date(-1), Version: 2}
+	due := entities.Hold{ID: 9, BookID: 4, MemberID: 2, CopyID: 6, Status: entities.HoldReady, ReadyOn: date(-3),
+		ExpiresOn: date(0), Version: 2}
+
+	expired := overdue
+	expired.Status = entities.HoldExpired
+
+	st.hold.EXPECT().GetHoldsByStatus(gomock.Any(), entities.HoldReady).Return([]entities.Hold{overdue, due}, nil)
+	st.hold.EXPECT().GetHoldByID(gomock.Any(), 8).Return(overdue, nil)
+	st.hold.EXPECT().UpdateHold(gomock.Any(), 8, expired).Return(expired, nil)
+	st.hold.EXPECT().GetBookHolds(gomock.Any(), 4).Return([]entities.Hold{due}, nil)
+	st.copy.EXPECT().UpdateCopyStatus(gomock.Any(), 5, entities.CopyOnHold, entities.CopyAvailable).Return(nil)
+
+	n, err := s.ExpireHolds(context.Background())
+	if n != 1 || err != nil {
+		t.Errorf("Failed. Expected 1 hold to expire Got %v, %v", n, err)
+	}
+}
@@ -0,0 +1,337 @@
+// Package loans is the circulation of the copies, it checks the copies out to the members,
+// records their return, renews the loans and keeps the queues of holds of the books
+package loans
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service/fines"
+	"context"
+	stdErrors "errors"
+	"fmt"
+)
+
+// Policy is the lending policy, a loan lasts Days days and can be renewed MaxRenewals times.
+// A copy assigned to a hold is kept for PickupDays days and the overdue loans are fined by Fines
+type Policy struct {
+	Days        int
+	MaxRenewals int
+	PickupDays  int
+	Fines       fines.Policy
+}
+
+type Service struct {
+	loan   datastore.Loan
+	hold   datastore.Hold
+	copy   datastore.Copy
+	member datastore.Member
+	book   datastore.Book
+	ledger datastore.Ledger
+	tx     datastore.Transactor
+	policy Policy
+}
+
+func New(l datastore.Loan, h datastore.Hold, c datastore.Copy, m datastore.Member, b datastore.Book,
+	ledger datastore.Ledger, tx datastore.Transactor, p Policy) Service {
+	return Service{loan: l, hold: h, copy: c, member: m, book: b, ledger: ledger, tx: tx, policy: p}
+}
+
+// GetLoanByID returns the loan with given id
+func (s Service) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	return s.loan.GetLoanByID(ctx, id)
+}
+
+// GetActiveLoans returns the loans of the member with given id which are not returned yet
+func (s Service) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	if _, err := s.member.GetMemberByID(ctx, memberID); err != nil {
+		return nil, err
+	}
+
+	return s.loan.GetActiveLoans(ctx, memberID)
+}
+
+// GetBookLoans returns all the loans of the copies of the book with given id, the returned loans included
+func (s Service) GetBookLoans(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	if _, err := s.book.GetBookByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	return s.loan.GetLoansByBook(ctx, bookID)
+}
+
+// Checkout lends the copy to the member of the loan from today, the loan is due after the days of the policy.
+// The member must be active with a membership which has not expired, must not owe more than the fines
+// policy allows and the copy must be available, or on hold for the member. The hold of the member on
+// the book is fulfilled by the loan
+func (s Service) Checkout(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	var invalid []string
+
+	if l.CopyID <= 0 {
+		invalid = append(invalid, "CopyID")
+	}
+
+	if l.MemberID <= 0 {
+		invalid = append(invalid, "MemberID")
+	}
+
+	if err := errors.InValid(invalid...); err != nil {
+		return entities.Loan{}, err
+	}
+
+	var created entities.Loan
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		member, err := s.member.GetMemberByID(ctx, l.MemberID)
+		if isNotFound(err) {
+			return errors.InValidDetails{Details: "MemberID"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = checkMember(member); err != nil {
+			return err
+		}
+
+		if err = s.checkBalance(ctx, member); err != nil {
+			return err
+		}
+
+		c, err := s.copy.GetCopyByID(ctx, l.CopyID)
+		if isNotFound(err) {
+			return errors.InValidDetails{Details: "CopyID"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		queue, err := s.hold.GetBookHolds(ctx, c.BookID)
+		if err != nil {
+			return err
+		}
+
+		hold, held := memberHold(queue, member.ID)
+
+		from := entities.CopyAvailable
+		if c.Status == entities.CopyOnHold {
+			if !held || hold.CopyID != c.ID {
+				return errors.Conflict{Entity: "Copy", ID: c.ID, Reason: "is on hold for another member"}
+			}
+
+			from = entities.CopyOnHold
+		}
+
+		// the status is changed only when the copy still has it, so a copy is not lent twice
+		err = s.copy.UpdateCopyStatus(ctx, c.ID, from, entities.CopyOnLoan)
+		if isNotFound(err) {
+			return errors.Conflict{Entity: "Copy", ID: c.ID, Reason: "is not available"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if held {
+			if err = s.fulfil(ctx, hold, c.ID); err != nil {
+				return err
+			}
+		}
+
+		today := entities.Today()
+
+		created, err = s.loan.CreateLoan(ctx, entities.Loan{CopyID: c.ID, MemberID: member.ID, BookID: c.BookID,
+			CheckedOutOn: today, DueOn: today.AddDays(s.policy.Days)})
+
+		return err
+	})
+	if err != nil {
+		return entities.Loan{}, err
+	}
+
+	return created, nil
+}
+
+// Return records the return of the copy of the loan with given id today and fines the member when the loan
+// is overdue. The copy is assigned to the first waiting hold of the book or is available again,
+// unless it has been withdrawn
+func (s Service) Return(ctx context.Context, id int) (entities.Loan, error) {
+	var returned entities.Loan
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		l, err := s.loan.GetLoanByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !l.Active() {
+			return errors.Conflict{Entity: "Loan", ID: id, Reason: "has been returned"}
+		}
+
+		l.ReturnedOn = entities.Today()
+
+		returned, err = s.loan.UpdateLoan(ctx, id, l)
+		if err != nil {
+			return err
+		}
+
+		if err = s.charge(ctx, l); err != nil {
+			return err
+		}
+
+		c, err := s.copy.GetCopyByID(ctx, l.CopyID)
+		if err != nil {
+			return err
+		}
+
+		// a copy which was reported lost has been found
+		if c.Status == entities.CopyOnLoan || c.Status == entities.CopyLost {
+			return Release(ctx, s.hold, s.copy, s.policy, c)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entities.Loan{}, err
+	}
+
+	return returned, nil
+}
+
+// Renew extends the loan with given id by the days of the policy from the due date, or from today when
+// the loan is overdue in which case the member is fined for the days so far. A loan can be renewed only
+// by an active member up to the renewals of the policy, and not while other members are waiting for the book
+func (s Service) Renew(ctx context.Context, id int) (entities.Loan, error) {
+	var renewed entities.Loan
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		l, err := s.loan.GetLoanByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !l.Active() {
+			return errors.Conflict{Entity: "Loan", ID: id, Reason: "has been returned"}
+		}
+
+		if l.Renewals >= s.policy.MaxRenewals {
+			return errors.Conflict{Entity: "Loan", ID: id,
+				Reason: fmt.Sprintf("has reached the limit of %d renewals", s.policy.MaxRenewals)}
+		}
+
+		member, err := s.member.GetMemberByID(ctx, l.MemberID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkMember(member); err != nil {
+			return err
+		}
+
+		queue, err := s.hold.GetBookHolds(ctx, l.BookID)
+		if err != nil {
+			return err
+		}
+
+		for i := range queue {
+			if queue[i].Status == entities.HoldWaiting {
+				return errors.Conflict{Entity: "Loan", ID: id, Reason: "has members waiting for the book"}
+			}
+		}
+
+		if err = s.charge(ctx, l); err != nil {
+			return err
+		}
+
+		from := entities.Today()
+		if l.DueOn.After(from) {
+			from = l.DueOn
+		}
+
+		l.DueOn = from.AddDays(s.policy.Days)
+		l.Renewals++
+
+		renewed, err = s.loan.UpdateLoan(ctx, id, l)
+
+		return err
+	})
+	if err != nil {
+		return entities.Loan{}, err
+	}
+
+	return renewed, nil
+}
+
+// checkMember returns Conflict when the member can not borrow, the member must be active and
+// the membership must not have expired
+func checkMember(m entities.Member) error {
+	if m.Status != entities.MemberActive {
+		return errors.Conflict{Entity: "Member", ID: m.ID, Reason: "is " + m.Status}
+	}
+
+	if m.ExpiresOn.Before(entities.Today()) {
+		return errors.Conflict{Entity: "Member", ID: m.ID, Reason: "has an expired membership"}
+	}
+
+	return nil
+}
+
+// checkBalance returns Conflict when the balance of the member with the fines accruing on the overdue loans
+// is above the limit of the fines policy
+func (s Service) checkBalance(ctx context.Context, m entities.Member) error {
+	balance, accruing, _, err := fines.Owed(ctx, s.ledger, s.loan, s.policy.Fines, m)
+	if err != nil {
+		return err
+	}
+
+	if owed := balance + accruing; owed > s.policy.Fines.BlockAbove {
+		return errors.Conflict{Entity: "Member", ID: m.ID,
+			Reason: fmt.Sprintf("owes %d which is above the limit of %d", owed, s.policy.Fines.BlockAbove)}
+	}
+
+	return nil
+}
+
+// charge adds the fine of the loan for the days it is overdue today to the ledger of the member,
+// nothing is charged once the cap of the membership type is reached
+func (s Service) charge(ctx context.Context, l entities.Loan) error {
+	days := s.policy.Fines.DaysOverdue(l, entities.Today())
+	if days == 0 {
+		return nil
+	}
+
+	member, err := s.member.GetMemberByID(ctx, l.MemberID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.ledger.GetEntries(ctx, l.MemberID)
+	if err != nil {
+		return err
+	}
+
+	fine := s.policy.Fines.Fine(member.MembershipType, l, fines.Charged(entries, l.ID), entities.Today())
+	if fine == 0 {
+		return nil
+	}
+
+	_, err = s.ledger.CreateEntry(ctx, entities.LedgerEntry{MemberID: l.MemberID, LoanID: l.ID,
+		Type: entities.LedgerFine, Amount: fine, Note: fmt.Sprintf("%d days overdue", days),
+		CreatedOn: entities.Today()})
+
+	return err
+}
+
+func isNotFound(err error) bool {
+	var notFound errors.EntityNotFound
+
+	return stdErrors.As(err, &notFound)
+}
+
+func isConflict(err error) bool {
+	var conflict errors.Conflict
+
+	return stdErrors.As(err, &conflict)
+}
@@ -0,0 +1,387 @@
+package loans
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service/fines"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var policy = Policy{Days: 14, MaxRenewals: 2, PickupDays: 3, Fines: fines.Policy{
+	DailyRates: map[string]int{entities.MembershipStandard: 25}, Caps: map[string]int{entities.MembershipStandard: 1000},
+	BlockAbove: 1000}}
+
+// date returns the date days from today
+func date(days int) entities.Date {
+	return entities.Today().AddDays(days)
+}
+
+type stores struct {
+	loan   *datastore.MockLoan
+	hold   *datastore.MockHold
+	copy   *datastore.MockCopy
+	member *datastore.MockMember
+	book   *datastore.MockBook
+	ledger *datastore.MockLedger
+}
+
+// newService returns the service with the mock stores, member 1 is active, member 2 is suspended and
+// member 3 has an expired membership. Copy 1 of book 4 is available, copy 2 is on loan, copy 3 is withdrawn
+// and copy 5 is on hold
+// errDB is returned by the member and copy stores for id 4
+var errDB = errors.DB{Err: fmt.Errorf("connection refused")}
+
+func newService(ctrl *gomock.Controller) (Service, stores) {
+	s := stores{loan: datastore.NewMockLoan(ctrl), hold: datastore.NewMockHold(ctrl), copy: datastore.NewMockCopy(ctrl),
+		member: datastore.NewMockMember(ctrl), book: datastore.NewMockBook(ctrl), ledger: datastore.NewMockLedger(ctrl)}
+
+	members := map[int]entities.Member{
+		1: {ID: 1, MembershipType: entities.MembershipStandard, Status: entities.MemberActive, ExpiresOn: date(30)},
+		2: {ID: 2, Status: entities.MemberSuspended, ExpiresOn: date(30)},
+		3: {ID: 3, Status: entities.MemberActive, ExpiresOn: date(-1)},
+	}
+
+	s.member.EXPECT().GetMemberByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int) (entities.Member, error) {
+			m, ok := members[id]
+			if id == 4 {
+				return entities.Member{}, errDB
+			}
+
+			if !ok {
+				return entities.Member{}, errors.EntityNotFound{Entity: "Member", ID: id}
+			}
+
+			return m, nil
+		}).AnyTimes()
+
+	copies := map[int]entities.Copy{
+		1: {ID: 1, BookID: 4, Status: entities.CopyAvailable},
+		2: {ID: 2, BookID: 4, Status: entities.CopyOnLoan},
+		3: {ID: 3, BookID: 4, Status: entities.CopyWithdrawn},
+		5: {ID: 5, BookID: 4, Status: entities.CopyOnHold},
+	}
+
+	s.copy.EXPECT().GetCopyByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int) (entities.Copy, error) {
+			c, ok := copies[id]
+			if id == 4 {
+				return entities.Copy{}, errDB
+			}
+
+			if !ok {
+				return entities.Copy{}, errors.EntityNotFound{Entity: "Copy", ID: id}
+			}
+
+			return c, nil
+		}).AnyTimes()
+
+	return New(s.loan, s.hold, s.copy, s.member, s.book, s.ledger, mockTx{}, policy), s
+}
+
+// expectFine expects the ledger of member 1 to be read and the fine of loan 1 for the days overdue to be
+// charged unless it is zero
+func expectFine(st stores, charged []entities.LedgerEntry, fine, days int) {
+	st.ledger.EXPECT().GetEntries(gomock.Any(), 1).Return(charged, nil).AnyTimes()
+
+	if fine != 0 {
+		st.ledger.EXPECT().CreateEntry(gomock.Any(), entities.LedgerEntry{MemberID: 1, LoanID: 1,
+			Type: entities.LedgerFine, Amount: fine, Note: fmt.Sprintf("%d days overdue", days), CreatedOn: date(0)}).
+			Return(entities.LedgerEntry{ID: 9}, nil)
+	}
+}
+
+func TestService_Checkout(t *testing.T) {
+	created := func(copyID int) entities.Loan {
+		return entities.Loan{ID: 7, CopyID: copyID, MemberID: 1, BookID: 4, CheckedOutOn: date(0), DueOn: date(14),
+			Version: 1}
+	}
+
+	ready := entities.Hold{ID: 8, BookID: 4, MemberID: 1, CopyID: 5, Status: entities.HoldReady}
+	waiting := entities.Hold{ID: 9, BookID: 4, MemberID: 1, Status: entities.HoldWaiting}
+
+	overdue := entities.Loan{ID: 3, CopyID: 2, MemberID: 1, BookID: 4, CheckedOutOn: date(-30), DueOn: date(-16)}
+
+	testcases := []struct {
+		desc      string
+		req       entities.Loan
+		balance   int
+		active    []entities.Loan
+		queue     []entities.Hold
+		from      string
+		statusErr error
+		fulfilled entities.Hold
+		expRes    entities.Loan
+		expErr    error
+	}{
+		{desc: "checked out", req: entities.Loan{CopyID: 1, MemberID: 1}, from: entities.CopyAvailable,
+			expRes: created(1)},
+		{desc: "ids are missing", expErr: errors.InValidFields{{Details: "CopyID"}, {Details: "MemberID"}}},
+		{desc: "member not found", req: entities.Loan{CopyID: 1, MemberID: 9},
+			expErr: errors.InValidDetails{Details: "MemberID"}},
+		{desc: "member lookup fails", req: entities.Loan{CopyID: 1, MemberID: 4}, expErr: errDB},
+		{desc: "member is suspended", req: entities.Loan{CopyID: 1, MemberID: 2},
+			expErr: errors.Conflict{Entity: "Member", ID: 2, Reason: "is suspended"}},
+		{desc: "membership has expired", req: entities.Loan{CopyID: 1, MemberID: 3},
+			expErr: errors.Conflict{Entity: "Member", ID: 3, Reason: "has an expired membership"}},
+		{desc: "balance at the limit", req: entities.Loan{CopyID: 1, MemberID: 1}, balance: 1000,
+			from: entities.CopyAvailable, expRes: created(1)},
+		{desc: "balance above the limit", req: entities.Loan{CopyID: 1, MemberID: 1}, balance: 1001,
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "owes 1001 which is above the limit of 1000"}},
+		{desc: "fines accruing above the limit", req: entities.Loan{CopyID: 1, MemberID: 1}, balance: 700,
+			active: []entities.Loan{overdue},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "owes 1100 which is above the limit of 1000"}},
+		{desc: "copy not found", req: entities.Loan{CopyID: 9, MemberID: 1},
+			expErr: errors.InValidDetails{Details: "CopyID"}},
+		{desc: "copy lookup fails", req: entities.Loan{CopyID: 4, MemberID: 1}, expErr: errDB},
+		{desc: "copy is not available", req: entities.Loan{CopyID: 2, MemberID: 1}, from: entities.CopyAvailable,
+			statusErr: errors.EntityNotFound{Entity: "Copy", ID: 2},
+			expErr:    errors.Conflict{Entity: "Copy", ID: 2, Reason: "is not available"}},
+		{desc: "copy is on hold for another member", req: entities.Loan{CopyID: 5, MemberID: 1},
+			queue:  []entities.Hold{{ID: 8, BookID: 4, MemberID: 2, CopyID: 5, Status: entities.HoldReady}},
+			expErr: errors.Conflict{Entity: "Copy", ID: 5, Reason: "is on hold for another member"}},
+		{desc: "hold picked up", req: entities.Loan{CopyID: 5, MemberID: 1}, queue: []entities.Hold{ready},
+			from: entities.CopyOnHold, fulfilled: entities.Hold{ID: 8, BookID: 4, MemberID: 1, CopyID: 5,
+				Status: entities.HoldFulfilled}, expRes: created(5)},
+		{desc: "waiting hold fulfilled", req: entities.Loan{CopyID: 1, MemberID: 1}, queue: []entities.Hold{waiting},
+			from: entities.CopyAvailable, fulfilled: entities.Hold{ID: 9, BookID: 4, MemberID: 1, CopyID: 1,
+				Status: entities.HoldFulfilled}, expRes: created(1)},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		s, st := newService(ctrl)
+
+		st.hold.EXPECT().GetBookHolds(gomock.Any(), 4).Return(tc.queue, nil).AnyTimes()
+		st.ledger.EXPECT().GetBalance(gomock.Any(), 1).Return(tc.balance, nil).AnyTimes()
+		st.ledger.EXPECT().GetEntries(gomock.Any(), 1).Return(nil, nil).AnyTimes()
+		st.loan.EXPECT().GetActiveLoans(gomock.Any(), 1).Return(tc.active, nil).AnyTimes()
+
+		if tc.from != "" {
+			st.copy.EXPECT().UpdateCopyStatus(gomock.Any(), tc.req.CopyID, tc.from, entities.CopyOnLoan).
+				Return(tc.statusErr)
+		}
+
+		if tc.fulfilled.ID != 0 {
+			st.hold.EXPECT().UpdateHold(gomock.Any(), tc.fulfilled.ID, tc.fulfilled).Return(tc.fulfilled, nil)
+		}
+
+		if tc.expErr == nil {
+			st.loan.EXPECT().CreateLoan(gomock.Any(), entities.Loan{CopyID: tc.req.CopyID, MemberID: 1, BookID: 4,
+				CheckedOutOn: date(0), DueOn: date(14)}).Return(tc.expRes, nil)
+		}
+
+		res, err := s.Checkout(context.Background(), tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_Return(t *testing.T) {
+	active := entities.Loan{ID: 1, CopyID: 2, MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(11),
+		Version: 1}
+
+	returned := active
+	returned.ReturnedOn = date(0)
+
+	withdrawn := active
+	withdrawn.CopyID = 3
+
+	overdue := active
+	overdue.DueOn = date(-4)
+
+	waiting := entities.Hold{ID: 8, BookID: 4, MemberID: 2, Status: entities.HoldWaiting, PlacedOn: date(-2)}
+
+	testcases := []struct {
+		desc       string
+		stored     entities.Loan
+		charged    []entities.LedgerEntry
+		fine       int
+		queue      []entities.Hold
+		copyStatus string
+		assigned   entities.Hold
+		expRes     entities.Loan
+		expErr     error
+	}{
+		{desc: "returned", stored: active, copyStatus: entities.CopyAvailable, expRes: entities.Loan{ID: 1, CopyID: 2,
+			MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(11), ReturnedOn: date(0), Version: 2}},
+		{desc: "copy assigned to the first waiting hold", stored: active, queue: []entities.Hold{waiting},
+			copyStatus: entities.CopyOnHold, assigned: entities.Hold{ID: 8, BookID: 4, MemberID: 2, CopyID: 2,
+				Status: entities.HoldReady, PlacedOn: date(-2), ReadyOn: date(0), ExpiresOn: date(3)},
+			expRes: entities.Loan{ID: 1, CopyID: 2, MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(11),
+				ReturnedOn: date(0), Version: 2}},
+		{desc: "overdue loan fined", stored: overdue, fine: 100, copyStatus: entities.CopyAvailable,
+			expRes: entities.Loan{ID: 1, CopyID: 2, MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(-4),
+				ReturnedOn: date(0), Version: 2}},
+		{desc: "fine up to the cap", stored: overdue, fine: 50, copyStatus: entities.CopyAvailable,
+			charged: []entities.LedgerEntry{{ID: 5, MemberID: 1, LoanID: 1, Type: entities.LedgerFine, Amount: 950},
+				{ID: 6, MemberID: 1, LoanID: 2, Type: entities.LedgerFine, Amount: 500}},
+			expRes: entities.Loan{ID: 1, CopyID: 2, MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(-4),
+				ReturnedOn: date(0), Version: 2}},
+		{desc: "cap reached", stored: overdue, copyStatus: entities.CopyAvailable,
+			charged: []entities.LedgerEntry{{ID: 5, MemberID: 1, LoanID: 1, Type: entities.LedgerFine, Amount: 1000}},
+			expRes: entities.Loan{ID: 1, CopyID: 2, MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(-4),
+				ReturnedOn: date(0), Version: 2}},
+		{desc: "withdrawn copy stays withdrawn", stored: withdrawn, expRes: entities.Loan{ID: 1, CopyID: 3,
+			MemberID: 1, BookID: 4, CheckedOutOn: date(-3), DueOn: date(11), ReturnedOn: date(0), Version: 2}},
+		{desc: "returned already", stored: returned,
+			expErr: errors.Conflict{Entity: "Loan", ID: 1, Reason: "has been returned"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		s, st := newService(ctrl)
+
+		st.loan.EXPECT().GetLoanByID(gomock.Any(), 1).Return(tc.stored, nil)
+		st.hold.EXPECT().GetBookHolds(gomock.Any(), 4).Return(tc.queue, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			st.loan.EXPECT().UpdateLoan(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+					l.Version++
+
+					return l, nil
+				})
+		}
+
+		expectFine(st, tc.charged, tc.fine, 4)
+
+		if tc.copyStatus != "" {
+			st.copy.EXPECT().UpdateCopyStatus(gomock.Any(), tc.stored.CopyID, entities.CopyOnLoan, tc.copyStatus).
+				Return(nil)
+		}
+
+		if tc.assigned.ID != 0 {
+			st.hold.EXPECT().UpdateHold(gomock.Any(), tc.assigned.ID, tc.assigned).Return(tc.assigned, nil)
+		}
+
+		res, err := s.Return(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_Renew(t *testing.T) {
+	loan := func(memberID int, dueOn entities.Date, renewals int) entities.Loan {
+		return entities.Loan{ID: 1, CopyID: 2, MemberID: memberID, BookID: 4, CheckedOutOn: date(-20), DueOn: dueOn,
+			Renewals: renewals, Version: 3}
+	}
+
+	renewed := func(dueOn entities.Date, renewals int) entities.Loan {
+		l := loan(1, dueOn, renewals)
+		l.Version = 4
+
+		return l
+	}
+
+	returned := loan(1, date(3), 0)
+	returned.ReturnedOn = date(-1)
+
+	testcases := []struct {
+		desc   string
+		stored entities.Loan
+		fine   int
+		queue  []entities.Hold
+		expRes entities.Loan
+		expErr error
+	}{
+		{desc: "extended from the due date", stored: loan(1, date(3), 0), expRes: renewed(date(17), 1)},
+		{desc: "members are waiting", stored: loan(1, date(3), 0),
+			queue:  []entities.Hold{{ID: 8, BookID: 4, MemberID: 2, Status: entities.HoldWaiting}},
+			expErr: errors.Conflict{Entity: "Loan", ID: 1, Reason: "has members waiting for the book"}},
+		{desc: "overdue loan fined and extended from today", stored: loan(1, date(-6), 1), fine: 150,
+			expRes: renewed(date(14), 2)},
+		{desc: "limit reached", stored: loan(1, date(3), 2),
+			expErr: errors.Conflict{Entity: "Loan", ID: 1, Reason: "has reached the limit of 2 renewals"}},
+		{desc: "returned already", stored: returned,
+			expErr: errors.Conflict{Entity: "Loan", ID: 1, Reason: "has been returned"}},
+		{desc: "member is suspended", stored: loan(2, date(3), 0),
+			expErr: errors.Conflict{Entity: "Member", ID: 2, Reason: "is suspended"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		s, st := newService(ctrl)
+
+		st.loan.EXPECT().GetLoanByID(gomock.Any(), 1).Return(tc.stored, nil)
+		st.hold.EXPECT().GetBookHolds(gomock.Any(), 4).Return(tc.queue, nil).AnyTimes()
+		expectFine(st, nil, tc.fine, 6)
+
+		if tc.expErr == nil {
+			st.loan.EXPECT().UpdateLoan(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, id int, l entities.Loan) (entities.Loan, error) {
+					l.Version++
+
+					return l, nil
+				})
+		}
+
+		res, err := s.Renew(context.Background(), 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_GetLoans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, st := newService(ctrl)
+
+	loans := []entities.Loan{{ID: 1, CopyID: 2, MemberID: 1, BookID: 4}}
+
+	st.loan.EXPECT().GetActiveLoans(gomock.Any(), 1).Return(loans, nil)
+	st.loan.EXPECT().GetLoansByBook(gomock.Any(), 4).Return(loans, nil)
+	st.book.EXPECT().GetBookByID(gomock.Any(), 4).Return(entities.Book{ID: 4}, nil)
+	st.book.EXPECT().GetBookByID(gomock.Any(), 5).Return(entities.Book{}, errors.EntityNotFound{Entity: "Book", ID: 5})
+
+	if res, err := s.GetActiveLoans(context.Background(), 1); err != nil || !reflect.DeepEqual(res, loans) {
+		t.Errorf("Failed. Expected %v\tGot %v, %v", loans, res, err)
+	}
+
+	if _, err := s.GetActiveLoans(context.Background(), 9); err != (errors.EntityNotFound{Entity: "Member", ID: 9}) {
+		t.Errorf("Failed. Expected the member not to be found Got %v", err)
+	}
+
+	if res, err := s.GetBookLoans(context.Background(), 4); err != nil || !reflect.DeepEqual(res, loans) {
+		t.Errorf("Failed. Expected %v\tGot %v, %v", loans, res, err)
+	}
+
+	if _, err := s.GetBookLoans(context.Background(), 5); err != (errors.EntityNotFound{Entity: "Book", ID: 5}) {
+		t.Errorf("Failed. Expected the book not to be found Got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package member
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"context"
+	stdErrors "errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// validPhone allows an optional country code followed by the digits of the number
+var validPhone = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+type Service struct {
+	store  datastore.Member
+	loan   datastore.Loan
+	hold   datastore.Hold
+	ledger datastore.Ledger
+	tx     datastore.Transactor
+}
+
+func New(m datastore.Member, l datastore.Loan, h datastore.Hold, lg datastore.Ledger, tx datastore.Transactor) Service {
+	return Service{store: m, loan: l, hold: h, ledger: lg, tx: tx}
+}
+
+// GetMembers returns all the members
+func (s Service) GetMembers(ctx context.Context) ([]entities.Member, error) {
+	return s.store.GetMembers(ctx)
+}
+
+// GetMemberByID returns the member with given id
+func (s Service) GetMemberByID(ctx context.Context, id int) (entities.Member, error) {
+	return s.store.GetMemberByID(ctx, id)
+}
+
+// PostMember adds a new member, a member joins as an active standard member for a year unless set otherwise.
+// Two members can not have the same email
+func (s Service) PostMember(ctx context.Context, m entities.Member) (entities.Member, error) {
+	m = normalize(m)
+
+	if m.MembershipType == "" {
+		m.MembershipType = entities.MembershipStandard
+	}
+
+	if m.Status == "" {
+		m.Status = entities.MemberActive
+	}
+
+	if m.ExpiresOn.IsZero() {
+		m.ExpiresOn = entities.NewDate(time.Now().AddDate(1, 0, 0).Date())
+	}
+
+	if err := checkDetails(m); err != nil {
+		return entities.Member{}, err
+	}
+
+	var created entities.Member
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.checkDuplicate(ctx, 0, m); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = s.store.CreateMember(ctx, m)
+
+		return err
+	})
+	if err != nil {
+		return entities.Member{}, err
+	}
+
+	return created, nil
+}
+
+// PutMember replaces the member with given id, the version of the member is checked against the If-Match versions
+// in the context
+func (s Service) PutMember(ctx context.Context, id int, m entities.Member) (entities.Member, error) {
+	m = normalize(m)
+
+	if err := checkDetails(m); err != nil {
+		return entities.Member{}, err
+	}
+
+	var updated entities.Member
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.store.GetMemberByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Member", id, stored.Version); err != nil {
+			return err
+		}
+
+		if err = s.checkDuplicate(ctx, id, m); err != nil {
+			return err
+		}
+
+		m.Version = stored.Version
+
+		updated, err = s.store.UpdateMember(ctx, id, m)
+
+		return err
+	})
+	if err != nil {
+		return entities.Member{}, err
+	}
+
+	return updated, nil
+}
+
+// DeleteMember removes the member with given id, a member who has borrowed a copy, is in the queue of a book or has
+// ledger entries is kept along with the loans and the entries.
+// The version of the member is checked against the If-Match versions in the context
+func (s Service) DeleteMember(ctx context.Context, id int) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		m, err := s.store.GetMemberByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Member", id, m.Version); err != nil {
+			return err
+		}
+
+		loans, err := s.loan.GetMemberLoans(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckLoans("Member", id, loans); err != nil {
+			return err
+		}
+
+		holds, err := s.hold.GetMemberHolds(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for i := range holds {
+			if holds[i].Queued() {
+				return errors.Conflict{Entity: "Member", ID: id, Reason: "has an active hold"}
+			}
+		}
+
+		if err = s.checkLedger(ctx, id); err != nil {
+			return err
+		}
+
+		return s.store.DeleteMember(ctx, id)
+	})
+}
+
+// checkDuplicate returns ExistAlready when a member other than the one with given id has the same email
+func (s Service) checkDuplicate(ctx context.Context, id int, m entities.Member) error {
+	other, err := s.store.GetMemberByEmail(ctx, m.Email)
+
+	var notFound errors.EntityNotFound
+	if stdErrors.As(err, &notFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if other.ID != id {
+		return errors.ExistAlready{Entity: "Member"}
+	}
+
+	return nil
+}
+
+// normalize trims the details and lower cases the email, so that emails differing only in case are duplicates
+func normalize(m entities.Member) entities.Member {
+	m.FirstName = strings.TrimSpace(m.FirstName)
+	m.LastName = strings.TrimSpace(m.LastName)
+	m.Email = strings.ToLower(strings.TrimSpace(m.Email))
+	m.Phone = strings.TrimSpace(m.Phone)
+	m.Address = strings.TrimSpace(m.Address)
+
+	return m
+}
+
+// checkDetails validates the member, all the invalid details are reported
+func checkDetails(m entities.Member) error {
+	var invalid []string
+
+	if m.FirstName == "" {
+		invalid = append(invalid, "FirstName")
+	}
+
+	if m.LastName == "" {
+		invalid = append(invalid, "LastName")
+	}
+
+	if addr, err := mail.ParseAddress(m.Email); err != nil || addr.Address != m.Email {
+		invalid = append(invalid, "Email")
+	}
+
+	if m.Phone != "" && !validPhone.MatchString(m.Phone) {
+		invalid = append(invalid, "Phone")
+	}
+
+	switch m.MembershipType {
+	case entities.MembershipStandard, entities.MembershipStudent, entities.MembershipPremium:
+	default:
+		invalid = append(invalid, "MembershipType")
+	}
+
+	if m.ExpiresOn.IsZero() {
+		invalid = append(invalid, "ExpiresOn")
+	}
+
+	switch m.Status {
+	case entities.MemberActive, entities.MemberSuspended, entities.MemberCancelled:
+	default:
+		invalid = append(invalid, "Status")
+	}
+
+	return errors.InValid(invalid...)
+}
+
+// checkLedger returns Conflict while the member owes or is owed any amount, the entries of a settled member are kept
+// as the history of the fines and the payments
+func (s Service) checkLedger(ctx context.Context, id int) error {
+	balance, err := s.ledger.GetBalance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if balance != 0 {
+		return errors.Conflict{Entity: "Member", ID: id, Reason: "has an unsettled balance"}
+	}
+
+	entries, err := s.ledger.GetEntries(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		return errors.Conflict{Entity: "Member", ID: id, Reason: "has a ledger history"}
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package member
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// expiresOn is the expiry date of the members in the tests
+var expiresOn = entities.NewDate(2030, 12, 31)
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func member() entities.Member {
+	return entities.Member{FirstName: "Rahul", LastName: "Saini", Email: "rahul@example.com", Phone: "+919876543210",
+		MembershipType: entities.MembershipStudent, ExpiresOn: expiresOn, Status: entities.MemberActive}
+}
+
+// expectEmails sets up the members as the only rows looked up by email
+func expectEmails(store *datastore.MockMember, members ...entities.Member) {
+	store.EXPECT().GetMemberByEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, email string) (entities.Member, error) {
+			for _, m := range members {
+				if m.Email == email {
+					return m, nil
+				}
+			}
+
+			return entities.Member{}, errors.EntityNotFound{Entity: "Member"}
+		}).AnyTimes()
+}
+
+func TestService_PostMember(t *testing.T) {
+	existing := entities.Member{ID: 1, Email: "mg@example.com"}
+
+	defaults := entities.Member{FirstName: "Rahul", LastName: "Saini", Email: "rahul@example.com",
+		MembershipType: entities.MembershipStandard, Status: entities.MemberActive,
+		ExpiresOn: entities.NewDate(time.Now().AddDate(1, 0, 0).Date())}
+
+	testcases := []struct {
+		desc   string
+		req    entities.Member
+		expNew entities.Member
+		expErr error
+	}{
+		{desc: "valid member", req: member(), expNew: member()},
+		{desc: "defaults are set", req: entities.Member{FirstName: " Rahul ", LastName: "Saini",
+			Email: "Rahul@Example.com"}, expNew: defaults},
+		{desc: "invalid details", req: entities.Member{FirstName: "Rahul", Email: "rahul", Phone: "12-34",
+			MembershipType: "gold", Status: "active"},
+			expErr: errors.InValidFields{{Details: "LastName"}, {Details: "Email"}, {Details: "Phone"},
+				{Details: "MembershipType"}}},
+		{desc: "email with a name is invalid", req: entities.Member{FirstName: "Rahul", LastName: "Saini",
+			Email: "Rahul <rahul@example.com>"}, expErr: errors.InValidDetails{Details: "Email"}},
+		{desc: "email is taken", req: entities.Member{FirstName: "MG", LastName: "Verma", Email: "MG@example.com"},
+			expErr: errors.ExistAlready{Entity: "Member"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockMember(ctrl)
+
+		expectEmails(store, existing)
+
+		var expRes entities.Member
+
+		if tc.expErr == nil {
+			expRes = tc.expNew
+			expRes.ID, expRes.Version = 2, 1
+
+			store.EXPECT().CreateMember(gomock.Any(), tc.expNew).Return(expRes, nil)
+		}
+
+		res, err := New(store, datastore.NewMockLoan(ctrl), datastore.NewMockHold(ctrl), datastore.NewMockLedger(ctrl),
+			mockTx{}).PostMember(context.Background(), tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_PutMember(t *testing.T) {
+	stored := member()
+	stored.ID, stored.Version = 1, 2
+
+	taken := entities.Member{ID: 2, Email: "mg@example.com"}
+
+	update := member()
+	update.Status = entities.MemberSuspended
+
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		req      entities.Member
+		expRes   entities.Member
+		expErr   error
+	}{
+		{desc: "updated", id: 1, versions: []int{2}, req: update,
+			expRes: entities.Member{ID: 1, FirstName: "Rahul", LastName: "Saini", Email: "rahul@example.com",
+				Phone: "+919876543210", MembershipType: entities.MembershipStudent, ExpiresOn: expiresOn,
+				Status: entities.MemberSuspended, Version: 3}},
+		{desc: "invalid status", id: 1, req: entities.Member{FirstName: "Rahul", LastName: "Saini",
+			Email: "rahul@example.com", MembershipType: entities.MembershipStudent, ExpiresOn: expiresOn,
+			Status: "left"}, expErr: errors.InValidDetails{Details: "Status"}},
+		{desc: "version does not match", id: 1, versions: []int{1}, req: update,
+			expErr: errors.PreconditionFailed{Entity: "Member", ID: 1}},
+		{desc: "email of other member", id: 1, req: entities.Member{FirstName: "Rahul", LastName: "Saini",
+			Email: "mg@example.com", MembershipType: entities.MembershipStudent, ExpiresOn: expiresOn,
+			Status: entities.MemberActive}, expErr: errors.ExistAlready{Entity: "Member"}},
+		{desc: "not found", id: 5, req: update, expErr: errors.EntityNotFound{Entity: "Member", ID: 5}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockMember(ctrl)
+
+		store.EXPECT().GetMemberByID(gomock.Any(), 1).Return(stored, nil).AnyTimes()
+		store.EXPECT().GetMemberByID(gomock.Any(), 5).Return(entities.Member{},
+			errors.EntityNotFound{Entity: "Member", ID: 5}).AnyTimes()
+		expectEmails(store, stored, taken)
+
+		if tc.expErr == nil {
+			req := tc.req
+			req.Version = tc.expRes.Version - 1
+			returned := req
+			returned.ID, returned.Version = tc.id, tc.expRes.Version
+
+			store.EXPECT().UpdateMember(gomock.Any(), tc.id, req).Return(returned, nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		res, err := New(store, datastore.NewMockLoan(ctrl), datastore.NewMockHold(ctrl), datastore.NewMockLedger(ctrl),
+			mockTx{}).PutMember(ctx, tc.id, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_DeleteMember(t *testing.T) {
+	stored := member()
+	stored.ID, stored.Version = 1, 2
+
+	returned := entities.Loan{ID: 1, MemberID: 1, ReturnedOn: entities.NewDate(2022, 3, 20)}
+	active := entities.Loan{ID: 2, MemberID: 1}
+	fine := entities.LedgerEntry{ID: 1, MemberID: 1, Type: entities.LedgerFine, Amount: 50}
+	payment := entities.LedgerEntry{ID: 2, MemberID: 1, Type: entities.LedgerPayment, Amount: 50}
+
+	testcases := []struct {
+		desc     string
+		versions []int
+		loans    []entities.Loan
+		holds    []entities.Hold
+		balance  int
+		entries  []entities.LedgerEntry
+		expErr   error
+	}{
+		{desc: "deleted"},
+		{desc: "deleted when the version matches", versions: []int{2}},
+		{desc: "version does not match", versions: []int{3}, expErr: errors.PreconditionFailed{Entity: "Member", ID: 1}},
+		{desc: "loan is active", loans: []entities.Loan{returned, active},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has an active loan"}},
+		{desc: "loans are returned", loans: []entities.Loan{returned},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has a loan history"}},
+		{desc: "hold is waiting", holds: []entities.Hold{{ID: 1, MemberID: 1, Status: entities.HoldCancelled},
+			{ID: 2, MemberID: 1, Status: entities.HoldWaiting}},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has an active hold"}},
+		{desc: "holds are done", holds: []entities.Hold{{ID: 1, MemberID: 1, Status: entities.HoldFulfilled},
+			{ID: 2, MemberID: 1, Status: entities.HoldExpired}}},
+		{desc: "balance is due", balance: 50, entries: []entities.LedgerEntry{fine},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has an unsettled balance"}},
+		{desc: "balance is in credit", balance: -20, entries: []entities.LedgerEntry{payment},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has an unsettled balance"}},
+		{desc: "balance is settled", entries: []entities.LedgerEntry{fine, payment},
+			expErr: errors.Conflict{Entity: "Member", ID: 1, Reason: "has a ledger history"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockMember(ctrl)
+
+		store.EXPECT().GetMemberByID(gomock.Any(), 1).Return(stored, nil)
+
+		loanStore := datastore.NewMockLoan(ctrl)
+		loanStore.EXPECT().GetMemberLoans(gomock.Any(), 1).Return(tc.loans, nil).AnyTimes()
+
+		holdStore := datastore.NewMockHold(ctrl)
+		holdStore.EXPECT().GetMemberHolds(gomock.Any(), 1).Return(tc.holds, nil).AnyTimes()
+
+		ledgerStore := datastore.NewMockLedger(ctrl)
+		ledgerStore.EXPECT().GetBalance(gomock.Any(), 1).Return(tc.balance, nil).AnyTimes()
+		ledgerStore.EXPECT().GetEntries(gomock.Any(), 1).Return(tc.entries, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			store.EXPECT().DeleteMember(gomock.Any(), 1).Return(nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		err := New(store, loanStore, holdStore, ledgerStore, mockTx{}).DeleteMember(ctx, 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
@@ -50,18 +50,18 @@ func (mr *MockBookMockRecorder) DeleteBook(ctx, id interface{}) *gomock.Call {
 }
 
 // GetBook mocks base method.
-func (m *MockBook) GetBook(ctx context.Context) ([]entities.Book, error) {
+func (m *MockBook) GetBook(ctx context.Context, filter entities.BookFilter) (entities.BookPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBook", ctx)
-	ret0, _ := ret[0].([]entities.Book)
+	ret := m.ctrl.Call(m, "GetBook", ctx, filter)
+	ret0, _ := ret[0].(entities.BookPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBook indicates an expected call of GetBook.
-func (mr *MockBookMockRecorder) GetBook(ctx interface{}) *gomock.Call {
+func (mr *MockBookMockRecorder) GetBook(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBook", reflect.TypeOf((*MockBook)(nil).GetBook), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBook", reflect.TypeOf((*MockBook)(nil).GetBook), ctx, filter)
 }
 
 // GetBookByID mocks base method.
@@ -79,6 +79,66 @@ func (mr *MockBookMockRecorder) GetBookByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByID", reflect.TypeOf((*MockBook)(nil).GetBookByID), ctx, id)
 }
 
+// GetBookByISBN mocks base method.
+func (m *MockBook) GetBookByISBN(ctx context.Context, isbn string) (entities.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookByISBN", ctx, isbn)
+	ret0, _ := ret[0].(entities.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookByISBN indicates an expected call of GetBookByISBN.
+func (mr *MockBookMockRecorder) GetBookByISBN(ctx, isbn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByISBN", reflect.TypeOf((*MockBook)(nil).GetBookByISBN), ctx, isbn)
+}
+
+// GetEditions mocks base method.
+func (m *MockBook) GetEditions(ctx context.Context, id int, filter entities.BookFilter) (entities.BookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEditions", ctx, id, filter)
+	ret0, _ := ret[0].(entities.BookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEditions indicates an expected call of GetEditions.
+func (mr *MockBookMockRecorder) GetEditions(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEditions", reflect.TypeOf((*MockBook)(nil).GetEditions), ctx, id, filter)
+}
+
+// GetSeriesBooks mocks base method.
+func (m *MockBook) GetSeriesBooks(ctx context.Context, id int, filter entities.BookFilter) (entities.BookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesBooks", ctx, id, filter)
+	ret0, _ := ret[0].(entities.BookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesBooks indicates an expected call of GetSeriesBooks.
+func (mr *MockBookMockRecorder) GetSeriesBooks(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesBooks", reflect.TypeOf((*MockBook)(nil).GetSeriesBooks), ctx, id, filter)
+}
+
+// PatchBook mocks base method.
+func (m *MockBook) PatchBook(ctx context.Context, id int, patch entities.Patch) (entities.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchBook", ctx, id, patch)
+	ret0, _ := ret[0].(entities.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchBook indicates an expected call of PatchBook.
+func (mr *MockBookMockRecorder) PatchBook(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBook", reflect.TypeOf((*MockBook)(nil).PatchBook), ctx, id, patch)
+}
+
 // PostBook mocks base method.
 func (m *MockBook) PostBook(ctx context.Context, book entities.Book) (entities.Book, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +169,21 @@ func (mr *MockBookMockRecorder) PutBook(ctx, id, book interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBook", reflect.TypeOf((*MockBook)(nil).PutBook), ctx, id, book)
 }
 
+// SearchBooks mocks base method.
+func (m *MockBook) SearchBooks(ctx context.Context, query entities.SearchQuery) (entities.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBooks", ctx, query)
+	ret0, _ := ret[0].(entities.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBooks indicates an expected call of SearchBooks.
+func (mr *MockBookMockRecorder) SearchBooks(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBook)(nil).SearchBooks), ctx, query)
+}
+
 // MockAuthor is a mock of Author interface.
 type MockAuthor struct {
 	ctrl     *gomock.Controller
@@ -146,6 +221,51 @@ func (mr *MockAuthorMockRecorder) DeleteAuthor(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockAuthor)(nil).DeleteAuthor), ctx, id)
 }
 
+// GetAuthor mocks base method.
+func (m *MockAuthor) GetAuthor(ctx context.Context) ([]entities.AuthorDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthor", ctx)
+	ret0, _ := ret[0].([]entities.AuthorDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockAuthorMockRecorder) GetAuthor(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockAuthor)(nil).GetAuthor), ctx)
+}
+
+// GetAuthorByID mocks base method.
+func (m *MockAuthor) GetAuthorByID(ctx context.Context, id int) (entities.AuthorDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorByID", ctx, id)
+	ret0, _ := ret[0].(entities.AuthorDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorByID indicates an expected call of GetAuthorByID.
+func (mr *MockAuthorMockRecorder) GetAuthorByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorByID", reflect.TypeOf((*MockAuthor)(nil).GetAuthorByID), ctx, id)
+}
+
+// PatchAuthor mocks base method.
+func (m *MockAuthor) PatchAuthor(ctx context.Context, id int, patch entities.Patch) (entities.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchAuthor", ctx, id, patch)
+	ret0, _ := ret[0].(entities.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchAuthor indicates an expected call of PatchAuthor.
+func (mr *MockAuthorMockRecorder) PatchAuthor(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchAuthor", reflect.TypeOf((*MockAuthor)(nil).PatchAuthor), ctx, id, patch)
+}
+
 // PostAuthor mocks base method.
 func (m *MockAuthor) PostAuthor(ctx context.Context, author entities.Author) (entities.Author, error) {
 	m.ctrl.T.Helper()
@@ -175,3 +295,752 @@ func (mr *MockAuthorMockRecorder) PutAuthor(ctx, id, author interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAuthor", reflect.TypeOf((*MockAuthor)(nil).PutAuthor), ctx, id, author)
 }
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// DeletePublisher mocks base method.
+func (m *MockPublisher) DeletePublisher(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublisher", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePublisher indicates an expected call of DeletePublisher.
+func (mr *MockPublisherMockRecorder) DeletePublisher(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublisher", reflect.TypeOf((*MockPublisher)(nil).DeletePublisher), ctx, id)
+}
+
+// GetPublisherByID mocks base method.
+func (m *MockPublisher) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublisherByID", ctx, id)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublisherByID indicates an expected call of GetPublisherByID.
+func (mr *MockPublisherMockRecorder) GetPublisherByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublisherByID", reflect.TypeOf((*MockPublisher)(nil).GetPublisherByID), ctx, id)
+}
+
+// GetPublishers mocks base method.
+func (m *MockPublisher) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishers", ctx)
+	ret0, _ := ret[0].([]entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishers indicates an expected call of GetPublishers.
+func (mr *MockPublisherMockRecorder) GetPublishers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishers", reflect.TypeOf((*MockPublisher)(nil).GetPublishers), ctx)
+}
+
+// PostPublisher mocks base method.
+func (m *MockPublisher) PostPublisher(ctx context.Context, p entities.Publisher) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostPublisher", ctx, p)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostPublisher indicates an expected call of PostPublisher.
+func (mr *MockPublisherMockRecorder) PostPublisher(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostPublisher", reflect.TypeOf((*MockPublisher)(nil).PostPublisher), ctx, p)
+}
+
+// PutPublisher mocks base method.
+func (m *MockPublisher) PutPublisher(ctx context.Context, id int, p entities.Publisher) (entities.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutPublisher", ctx, id, p)
+	ret0, _ := ret[0].(entities.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutPublisher indicates an expected call of PutPublisher.
+func (mr *MockPublisherMockRecorder) PutPublisher(ctx, id, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPublisher", reflect.TypeOf((*MockPublisher)(nil).PutPublisher), ctx, id, p)
+}
+
+// MockGenre is a mock of Genre interface.
+type MockGenre struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreMockRecorder
+}
+
+// MockGenreMockRecorder is the mock recorder for MockGenre.
+type MockGenreMockRecorder struct {
+	mock *MockGenre
+}
+
+// NewMockGenre creates a new mock instance.
+func NewMockGenre(ctrl *gomock.Controller) *MockGenre {
+	mock := &MockGenre{ctrl: ctrl}
+	mock.recorder = &MockGenreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenre) EXPECT() *MockGenreMockRecorder {
+	return m.recorder
+}
+
+// DeleteGenre mocks base method.
+func (m *MockGenre) DeleteGenre(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreMockRecorder) DeleteGenre(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenre)(nil).DeleteGenre), ctx, id)
+}
+
+// GetGenreByID mocks base method.
+func (m *MockGenre) GetGenreByID(ctx context.Context, id int) (entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreByID", ctx, id)
+	ret0, _ := ret[0].(entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreByID indicates an expected call of GetGenreByID.
+func (mr *MockGenreMockRecorder) GetGenreByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreByID", reflect.TypeOf((*MockGenre)(nil).GetGenreByID), ctx, id)
+}
+
+// GetGenres mocks base method.
+func (m *MockGenre) GetGenres(ctx context.Context) ([]entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreMockRecorder) GetGenres(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenre)(nil).GetGenres), ctx)
+}
+
+// PostGenre mocks base method.
+func (m *MockGenre) PostGenre(ctx context.Context, g entities.Genre) (entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostGenre", ctx, g)
+	ret0, _ := ret[0].(entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostGenre indicates an expected call of PostGenre.
+func (mr *MockGenreMockRecorder) PostGenre(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostGenre", reflect.TypeOf((*MockGenre)(nil).PostGenre), ctx, g)
+}
+
+// PutGenre mocks base method.
+func (m *MockGenre) PutGenre(ctx context.Context, id int, g entities.Genre) (entities.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutGenre", ctx, id, g)
+	ret0, _ := ret[0].(entities.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutGenre indicates an expected call of PutGenre.
+func (mr *MockGenreMockRecorder) PutGenre(ctx, id, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutGenre", reflect.TypeOf((*MockGenre)(nil).PutGenre), ctx, id, g)
+}
+
+// MockSeries is a mock of Series interface.
+type MockSeries struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesMockRecorder
+}
+
+// MockSeriesMockRecorder is the mock recorder for MockSeries.
+type MockSeriesMockRecorder struct {
+	mock *MockSeries
+}
+
+// NewMockSeries creates a new mock instance.
+func NewMockSeries(ctrl *gomock.Controller) *MockSeries {
+	mock := &MockSeries{ctrl: ctrl}
+	mock.recorder = &MockSeriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeries) EXPECT() *MockSeriesMockRecorder {
+	return m.recorder
+}
+
+// DeleteSeries mocks base method.
+func (m *MockSeries) DeleteSeries(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockSeriesMockRecorder) DeleteSeries(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockSeries)(nil).DeleteSeries), ctx, id)
+}
+
+// GetSeries mocks base method.
+func (m *MockSeries) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries", ctx)
+	ret0, _ := ret[0].([]entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockSeriesMockRecorder) GetSeries(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockSeries)(nil).GetSeries), ctx)
+}
+
+// GetSeriesByID mocks base method.
+func (m *MockSeries) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesByID", ctx, id)
+	ret0, _ := ret[0].(entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesByID indicates an expected call of GetSeriesByID.
+func (mr *MockSeriesMockRecorder) GetSeriesByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesByID", reflect.TypeOf((*MockSeries)(nil).GetSeriesByID), ctx, id)
+}
+
+// PostSeries mocks base method.
+func (m *MockSeries) PostSeries(ctx context.Context, s entities.Series) (entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostSeries", ctx, s)
+	ret0, _ := ret[0].(entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostSeries indicates an expected call of PostSeries.
+func (mr *MockSeriesMockRecorder) PostSeries(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSeries", reflect.TypeOf((*MockSeries)(nil).PostSeries), ctx, s)
+}
+
+// PutSeries mocks base method.
+func (m *MockSeries) PutSeries(ctx context.Context, id int, s entities.Series) (entities.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSeries", ctx, id, s)
+	ret0, _ := ret[0].(entities.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutSeries indicates an expected call of PutSeries.
+func (mr *MockSeriesMockRecorder) PutSeries(ctx, id, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSeries", reflect.TypeOf((*MockSeries)(nil).PutSeries), ctx, id, s)
+}
+
+// MockMember is a mock of Member interface.
+type MockMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberMockRecorder
+}
+
+// MockMemberMockRecorder is the mock recorder for MockMember.
+type MockMemberMockRecorder struct {
+	mock *MockMember
+}
+
+// NewMockMember creates a new mock instance.
+func NewMockMember(ctrl *gomock.Controller) *MockMember {
+	mock := &MockMember{ctrl: ctrl}
+	mock.recorder = &MockMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMember) EXPECT() *MockMemberMockRecorder {
+	return m.recorder
+}
+
+// DeleteMember mocks base method.
+func (m *MockMember) DeleteMember(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockMemberMockRecorder) DeleteMember(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockMember)(nil).DeleteMember), ctx, id)
+}
+
+// GetMemberByID mocks base method.
+func (m *MockMember) GetMemberByID(ctx context.Context, id int) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberByID", ctx, id)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberByID indicates an expected call of GetMemberByID.
+func (mr *MockMemberMockRecorder) GetMemberByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberByID", reflect.TypeOf((*MockMember)(nil).GetMemberByID), ctx, id)
+}
+
+// GetMembers mocks base method.
+func (m *MockMember) GetMembers(ctx context.Context) ([]entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx)
+	ret0, _ := ret[0].([]entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockMemberMockRecorder) GetMembers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockMember)(nil).GetMembers), ctx)
+}
+
+// PostMember mocks base method.
+func (m *MockMember) PostMember(ctx context.Context, member entities.Member) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostMember", ctx, member)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostMember indicates an expected call of PostMember.
+func (mr *MockMemberMockRecorder) PostMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMember", reflect.TypeOf((*MockMember)(nil).PostMember), ctx, member)
+}
+
+// PutMember mocks base method.
+func (m *MockMember) PutMember(ctx context.Context, id int, member entities.Member) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMember", ctx, id, member)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMember indicates an expected call of PutMember.
+func (mr *MockMemberMockRecorder) PutMember(ctx, id, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMember", reflect.TypeOf((*MockMember)(nil).PutMember), ctx, id, member)
+}
+
+// MockCopy is a mock of Copy interface.
+type MockCopy struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyMockRecorder
+}
+
+// MockCopyMockRecorder is the mock recorder for MockCopy.
+type MockCopyMockRecorder struct {
+	mock *MockCopy
+}
+
+// NewMockCopy creates a new mock instance.
+func NewMockCopy(ctrl *gomock.Controller) *MockCopy {
+	mock := &MockCopy{ctrl: ctrl}
+	mock.recorder = &MockCopyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopy) EXPECT() *MockCopyMockRecorder {
+	return m.recorder
+}
+
+// DeleteCopy mocks base method.
+func (m *MockCopy) DeleteCopy(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCopy", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCopy indicates an expected call of DeleteCopy.
+func (mr *MockCopyMockRecorder) DeleteCopy(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCopy", reflect.TypeOf((*MockCopy)(nil).DeleteCopy), ctx, id)
+}
+
+// GetCopies mocks base method.
+func (m *MockCopy) GetCopies(ctx context.Context, bookID int) ([]entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopies", ctx, bookID)
+	ret0, _ := ret[0].([]entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopies indicates an expected call of GetCopies.
+func (mr *MockCopyMockRecorder) GetCopies(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopies", reflect.TypeOf((*MockCopy)(nil).GetCopies), ctx, bookID)
+}
+
+// GetCopyByID mocks base method.
+func (m *MockCopy) GetCopyByID(ctx context.Context, id int) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopyByID", ctx, id)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopyByID indicates an expected call of GetCopyByID.
+func (mr *MockCopyMockRecorder) GetCopyByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopyByID", reflect.TypeOf((*MockCopy)(nil).GetCopyByID), ctx, id)
+}
+
+// PostCopy mocks base method.
+func (m *MockCopy) PostCopy(ctx context.Context, bookID int, c entities.Copy) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostCopy", ctx, bookID, c)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostCopy indicates an expected call of PostCopy.
+func (mr *MockCopyMockRecorder) PostCopy(ctx, bookID, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostCopy", reflect.TypeOf((*MockCopy)(nil).PostCopy), ctx, bookID, c)
+}
+
+// PutCopy mocks base method.
+func (m *MockCopy) PutCopy(ctx context.Context, id int, c entities.Copy) (entities.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutCopy", ctx, id, c)
+	ret0, _ := ret[0].(entities.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutCopy indicates an expected call of PutCopy.
+func (mr *MockCopyMockRecorder) PutCopy(ctx, id, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCopy", reflect.TypeOf((*MockCopy)(nil).PutCopy), ctx, id, c)
+}
+
+// MockLoan is a mock of Loan interface.
+type MockLoan struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanMockRecorder
+}
+
+// MockLoanMockRecorder is the mock recorder for MockLoan.
+type MockLoanMockRecorder struct {
+	mock *MockLoan
+}
+
+// NewMockLoan creates a new mock instance.
+func NewMockLoan(ctrl *gomock.Controller) *MockLoan {
+	mock := &MockLoan{ctrl: ctrl}
+	mock.recorder = &MockLoanMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoan) EXPECT() *MockLoanMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockLoan) Checkout(ctx context.Context, l entities.Loan) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, l)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockLoanMockRecorder) Checkout(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockLoan)(nil).Checkout), ctx, l)
+}
+
+// GetActiveLoans mocks base method.
+func (m *MockLoan) GetActiveLoans(ctx context.Context, memberID int) ([]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveLoans", ctx, memberID)
+	ret0, _ := ret[0].([]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveLoans indicates an expected call of GetActiveLoans.
+func (mr *MockLoanMockRecorder) GetActiveLoans(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveLoans", reflect.TypeOf((*MockLoan)(nil).GetActiveLoans), ctx, memberID)
+}
+
+// GetBookLoans mocks base method.
+func (m *MockLoan) GetBookLoans(ctx context.Context, bookID int) ([]entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookLoans", ctx, bookID)
+	ret0, _ := ret[0].([]entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookLoans indicates an expected call of GetBookLoans.
+func (mr *MockLoanMockRecorder) GetBookLoans(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookLoans", reflect.TypeOf((*MockLoan)(nil).GetBookLoans), ctx, bookID)
+}
+
+// GetLoanByID mocks base method.
+func (m *MockLoan) GetLoanByID(ctx context.Context, id int) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanByID", ctx, id)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanByID indicates an expected call of GetLoanByID.
+func (mr *MockLoanMockRecorder) GetLoanByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanByID", reflect.TypeOf((*MockLoan)(nil).GetLoanByID), ctx, id)
+}
+
+// Renew mocks base method.
+func (m *MockLoan) Renew(ctx context.Context, id int) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, id)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockLoanMockRecorder) Renew(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockLoan)(nil).Renew), ctx, id)
+}
+
+// Return mocks base method.
+func (m *MockLoan) Return(ctx context.Context, id int) (entities.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", ctx, id)
+	ret0, _ := ret[0].(entities.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockLoanMockRecorder) Return(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockLoan)(nil).Return), ctx, id)
+}
+
+// MockHold is a mock of Hold interface.
+type MockHold struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldMockRecorder
+}
+
+// MockHoldMockRecorder is the mock recorder for MockHold.
+type MockHoldMockRecorder struct {
+	mock *MockHold
+}
+
+// NewMockHold creates a new mock instance.
+func NewMockHold(ctrl *gomock.Controller) *MockHold {
+	mock := &MockHold{ctrl: ctrl}
+	mock.recorder = &MockHoldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHold) EXPECT() *MockHoldMockRecorder {
+	return m.recorder
+}
+
+// CancelHold mocks base method.
+func (m *MockHold) CancelHold(ctx context.Context, id int) (entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelHold", ctx, id)
+	ret0, _ := ret[0].(entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelHold indicates an expected call of CancelHold.
+func (mr *MockHoldMockRecorder) CancelHold(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelHold", reflect.TypeOf((*MockHold)(nil).CancelHold), ctx, id)
+}
+
+// GetBookHolds mocks base method.
+func (m *MockHold) GetBookHolds(ctx context.Context, bookID int) ([]entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookHolds", ctx, bookID)
+	ret0, _ := ret[0].([]entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookHolds indicates an expected call of GetBookHolds.
+func (mr *MockHoldMockRecorder) GetBookHolds(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookHolds", reflect.TypeOf((*MockHold)(nil).GetBookHolds), ctx, bookID)
+}
+
+// GetHoldByID mocks base method.
+func (m *MockHold) GetHoldByID(ctx context.Context, id int) (entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldByID", ctx, id)
+	ret0, _ := ret[0].(entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldByID indicates an expected call of GetHoldByID.
+func (mr *MockHoldMockRecorder) GetHoldByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldByID", reflect.TypeOf((*MockHold)(nil).GetHoldByID), ctx, id)
+}
+
+// GetMemberHolds mocks base method.
+func (m *MockHold) GetMemberHolds(ctx context.Context, memberID int) ([]entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberHolds", ctx, memberID)
+	ret0, _ := ret[0].([]entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberHolds indicates an expected call of GetMemberHolds.
+func (mr *MockHoldMockRecorder) GetMemberHolds(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberHolds", reflect.TypeOf((*MockHold)(nil).GetMemberHolds), ctx, memberID)
+}
+
+// PlaceHold mocks base method.
+func (m *MockHold) PlaceHold(ctx context.Context, h entities.Hold) (entities.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", ctx, h)
+	ret0, _ := ret[0].(entities.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockHoldMockRecorder) PlaceHold(ctx, h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockHold)(nil).PlaceHold), ctx, h)
+}
+
+// MockAccount is a mock of Account interface.
+type MockAccount struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountMockRecorder
+}
+
+// MockAccountMockRecorder is the mock recorder for MockAccount.
+type MockAccountMockRecorder struct {
+	mock *MockAccount
+}
+
+// NewMockAccount creates a new mock instance.
+func NewMockAccount(ctrl *gomock.Controller) *MockAccount {
+	mock := &MockAccount{ctrl: ctrl}
+	mock.recorder = &MockAccountMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccount) EXPECT() *MockAccountMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockAccount) GetAccount(ctx context.Context, memberID int) (entities.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, memberID)
+	ret0, _ := ret[0].(entities.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountMockRecorder) GetAccount(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccount)(nil).GetAccount), ctx, memberID)
+}
+
+// PostEntry mocks base method.
+func (m *MockAccount) PostEntry(ctx context.Context, memberID int, e entities.LedgerEntry) (entities.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", ctx, memberID, e)
+	ret0, _ := ret[0].(entities.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockAccountMockRecorder) PostEntry(ctx, memberID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*MockAccount)(nil).PostEntry), ctx, memberID, e)
+}
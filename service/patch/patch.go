@@ -0,0 +1,48 @@
+// Package patch applies the JSON Merge Patch and JSON Patch documents of the partial updates to the entities.
+package patch
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"encoding/json"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Apply applies the patch to the json form of v and decodes the result back into v,
+// v must be a pointer. The error is InValidDetails when the patch can not be applied
+func Apply(v interface{}, p entities.Patch) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	switch p.Type {
+	case entities.MergePatch:
+		doc, err = jsonpatch.MergePatch(doc, p.Doc)
+	case entities.JSONPatch:
+		var ops jsonpatch.Patch
+
+		ops, err = jsonpatch.DecodePatch(p.Doc)
+		if err == nil {
+			doc, err = ops.Apply(doc)
+		}
+	default:
+		return errors.InValidDetails{Details: "Content-Type"}
+	}
+
+	if err != nil {
+		return errors.InValidDetails{Details: "patch"}
+	}
+
+	// fields removed by the patch are left empty instead of keeping their old value
+	elem := reflect.ValueOf(v).Elem()
+	elem.Set(reflect.Zero(elem.Type()))
+
+	if err = json.Unmarshal(doc, v); err != nil {
+		return errors.InValidDetails{Details: "patch"}
+	}
+
+	return nil
+}
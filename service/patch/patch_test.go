@@ -0,0 +1,52 @@
+package patch
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	author := entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+		PenName: "Verma"}
+
+	testcases := []struct {
+		desc   string
+		patch  entities.Patch
+		expRes entities.Author
+		expErr error
+	}{
+		{"merge patch", entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":"HCV"}`)},
+			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2),
+				PenName: "HCV"}, nil},
+		{"merge patch removing a field", entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"dob":null}`)},
+			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", PenName: "Verma"}, nil},
+		{"json patch", entities.Patch{Type: entities.JSONPatch,
+			Doc: []byte(`[{"op":"test","path":"/last_name","value":"Verma"},{"op":"remove","path":"/pen_name"}]`)},
+			entities.Author{ID: 1, FirstName: "HC", LastName: "Verma", Dob: entities.NewDate(1999, 12, 2)}, nil},
+		{"failed json patch test", entities.Patch{Type: entities.JSONPatch,
+			Doc: []byte(`[{"op":"test","path":"/last_name","value":"Sharma"}]`)},
+			entities.Author{}, errors.InValidDetails{Details: "patch"}},
+		{"invalid merge patch", entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":`)},
+			entities.Author{}, errors.InValidDetails{Details: "patch"}},
+		{"wrong type of a field", entities.Patch{Type: entities.MergePatch, Doc: []byte(`{"pen_name":5}`)},
+			entities.Author{}, errors.InValidDetails{Details: "patch"}},
+		{"unknown media type", entities.Patch{Type: "text/plain", Doc: []byte(`{}`)},
+			entities.Author{}, errors.InValidDetails{Details: "Content-Type"}},
+	}
+
+	// the result is only checked when the patch is applied, v is not usable after an error
+	for i, tc := range testcases {
+		res := author
+
+		err := Apply(&res, tc.patch)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if err == nil && res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package publisher
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"context"
+	stdErrors "errors"
+	"net/url"
+	"strings"
+)
+
+type Service struct {
+	store datastore.Publisher
+	book  datastore.Book
+	tx    datastore.Transactor
+}
+
+func New(p datastore.Publisher, b datastore.Book, tx datastore.Transactor) Service {
+	return Service{store: p, book: b, tx: tx}
+}
+
+// GetPublishers returns all the publishers
+func (s Service) GetPublishers(ctx context.Context) ([]entities.Publisher, error) {
+	return s.store.GetPublishers(ctx)
+}
+
+// GetPublisherByID returns the publisher with given id
+func (s Service) GetPublisherByID(ctx context.Context, id int) (entities.Publisher, error) {
+	return s.store.GetPublisherByID(ctx, id)
+}
+
+// PostPublisher adds a new publisher, two publishers can not have the same name
+func (s Service) PostPublisher(ctx context.Context, p entities.Publisher) (entities.Publisher, error) {
+	p = normalize(p)
+
+	if err := checkDetails(p); err != nil {
+		return entities.Publisher{}, err
+	}
+
+	var created entities.Publisher
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.checkDuplicate(ctx, 0, p); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = s.store.CreatePublisher(ctx, p)
+
+		return err
+	})
+	if err != nil {
+		return entities.Publisher{}, err
+	}
+
+	return created, nil
+}
+
+// PutPublisher replaces the publisher with given id, the version of the publisher is checked against the If-Match
+// versions in the context
+func (s Service) PutPublisher(ctx context.Context, id int, p entities.Publisher) (entities.Publisher, error) {
+	p = normalize(p)
+
+	if err := checkDetails(p); err != nil {
+		return entities.Publisher{}, err
+	}
+
+	var updated entities.Publisher
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.store.GetPublisherByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Publisher", id, stored.Version); err != nil {
+			return err
+		}
+
+		if err = s.checkDuplicate(ctx, id, p); err != nil {
+			return err
+		}
+
+		p.Version = stored.Version
+
+		updated, err = s.store.UpdatePublisher(ctx, id, p)
+
+		return err
+	})
+	if err != nil {
+		return entities.Publisher{}, err
+	}
+
+	return updated, nil
+}
+
+// DeletePublisher removes the publisher with given id, a publisher of any book can not be removed. The version of
+// the publisher is checked against the If-Match versions in the context
+func (s Service) DeletePublisher(ctx context.Context, id int) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		p, err := s.store.GetPublisherByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Publisher", id, p.Version); err != nil {
+			return err
+		}
+
+		page, err := s.book.GetBooks(ctx, entities.BookFilter{PublisherID: id, Limit: 1})
+		if err != nil {
+			return err
+		}
+
+		if page.Total > 0 {
+			return errors.Conflict{Entity: "Publisher", ID: id, Reason: "has books"}
+		}
+
+		return s.store.DeletePublisher(ctx, id)
+	})
+}
+
+// checkDuplicate returns ExistAlready when a publisher other than the one with given id has the same name
+func (s Service) checkDuplicate(ctx context.Context, id int, p entities.Publisher) error {
+	other, err := s.store.GetPublisherByName(ctx, p.Name)
+
+	var notFound errors.EntityNotFound
+	if stdErrors.As(err, &notFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if other.ID != id {
+		return errors.ExistAlready{Entity: "Publisher"}
+	}
+
+	return nil
+}
+
+// normalize trims the details of the publisher
+func normalize(p entities.Publisher) entities.Publisher {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Website = strings.TrimSpace(p.Website)
+
+	return p
+}
+
+// checkDetails validates the publisher, all the invalid details are reported. The website is optional
+func checkDetails(p entities.Publisher) error {
+	var invalid []string
+
+	if p.Name == "" {
+		invalid = append(invalid, "Name")
+	}
+
+	if p.Website != "" {
+		u, err := url.ParseRequestURI(p.Website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalid = append(invalid, "Website")
+		}
+	}
+
+	return errors.InValid(invalid...)
+}
@@ -0,0 +1,187 @@
+package publisher
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// expectNames sets up the publishers as the only rows looked up by name, the names are compared ignoring the case
+// the same as in the stores
+func expectNames(store *datastore.MockPublisher, publishers ...entities.Publisher) {
+	store.EXPECT().GetPublisherByName(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, name string) (entities.Publisher, error) {
+			for _, p := range publishers {
+				if strings.EqualFold(p.Name, name) {
+					return p, nil
+				}
+			}
+
+			return entities.Publisher{}, errors.EntityNotFound{Entity: "Publisher"}
+		}).AnyTimes()
+}
+
+func TestService_PostPublisher(t *testing.T) {
+	existing := entities.Publisher{ID: 1, Name: "Arihanth"}
+
+	testcases := []struct {
+		desc   string
+		req    entities.Publisher
+		expNew entities.Publisher
+		expErr error
+	}{
+		{desc: "valid publisher", req: entities.Publisher{Name: "Oxford", Website: "https://global.oup.com"},
+			expNew: entities.Publisher{Name: "Oxford", Website: "https://global.oup.com"}},
+		{desc: "details are trimmed", req: entities.Publisher{Name: " Oxford "},
+			expNew: entities.Publisher{Name: "Oxford"}},
+		{desc: "invalid details", req: entities.Publisher{Name: " ", Website: "oup.com"},
+			expErr: errors.InValidFields{{Details: "Name"}, {Details: "Website"}}},
+		{desc: "name is taken", req: entities.Publisher{Name: "ARIHANTH"},
+			expErr: errors.ExistAlready{Entity: "Publisher"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockPublisher(ctrl)
+
+		expectNames(store, existing)
+
+		var expRes entities.Publisher
+
+		if tc.expErr == nil {
+			expRes = tc.expNew
+			expRes.ID, expRes.Version = 2, 1
+
+			store.EXPECT().CreatePublisher(gomock.Any(), tc.expNew).Return(expRes, nil)
+		}
+
+		res, err := New(store, datastore.NewMockBook(ctrl), mockTx{}).PostPublisher(context.Background(), tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_PutPublisher(t *testing.T) {
+	stored := entities.Publisher{ID: 1, Name: "Arihanth", Version: 2}
+	taken := entities.Publisher{ID: 2, Name: "Scholastic", Version: 1}
+
+	update := entities.Publisher{Name: "Arihant Publications", Website: "http://arihantbooks.com"}
+
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		req      entities.Publisher
+		expRes   entities.Publisher
+		expErr   error
+	}{
+		{desc: "updated", id: 1, versions: []int{2}, req: update, expRes: entities.Publisher{ID: 1,
+			Name: "Arihant Publications", Website: "http://arihantbooks.com", Version: 3}},
+		{desc: "invalid website", id: 1, req: entities.Publisher{Name: "Arihanth", Website: "ftp://arihant"},
+			expErr: errors.InValidDetails{Details: "Website"}},
+		{desc: "version does not match", id: 1, versions: []int{1}, req: update,
+			expErr: errors.PreconditionFailed{Entity: "Publisher", ID: 1}},
+		{desc: "name of other publisher", id: 1, req: entities.Publisher{Name: "scholastic"},
+			expErr: errors.ExistAlready{Entity: "Publisher"}},
+		{desc: "not found", id: 5, req: update, expErr: errors.EntityNotFound{Entity: "Publisher", ID: 5}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockPublisher(ctrl)
+
+		store.EXPECT().GetPublisherByID(gomock.Any(), 1).Return(stored, nil).AnyTimes()
+		store.EXPECT().GetPublisherByID(gomock.Any(), 5).Return(entities.Publisher{},
+			errors.EntityNotFound{Entity: "Publisher", ID: 5}).AnyTimes()
+		expectNames(store, stored, taken)
+
+		if tc.expErr == nil {
+			req := tc.req
+			req.Version = tc.expRes.Version - 1
+			returned := req
+			returned.ID, returned.Version = tc.id, tc.expRes.Version
+
+			store.EXPECT().UpdatePublisher(gomock.Any(), tc.id, req).Return(returned, nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		res, err := New(store, datastore.NewMockBook(ctrl), mockTx{}).PutPublisher(ctx, tc.id, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_DeletePublisher(t *testing.T) {
+	stored := entities.Publisher{ID: 1, Name: "Arihanth", Version: 2}
+
+	testcases := []struct {
+		desc     string
+		versions []int
+		books    int
+		expErr   error
+	}{
+		{desc: "deleted"},
+		{desc: "deleted when the version matches", versions: []int{2}},
+		{desc: "version does not match", versions: []int{3},
+			expErr: errors.PreconditionFailed{Entity: "Publisher", ID: 1}},
+		{desc: "publisher has books", books: 2, expErr: errors.Conflict{Entity: "Publisher", ID: 1,
+			Reason: "has books"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockPublisher(ctrl)
+		book := datastore.NewMockBook(ctrl)
+
+		store.EXPECT().GetPublisherByID(gomock.Any(), 1).Return(stored, nil)
+		book.EXPECT().GetBooks(gomock.Any(), entities.BookFilter{PublisherID: 1, Limit: 1}).
+			Return(entities.BookPage{Total: tc.books}, nil).AnyTimes()
+
+		if tc.expErr == nil {
+			store.EXPECT().DeletePublisher(gomock.Any(), 1).Return(nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		err := New(store, book, mockTx{}).DeletePublisher(ctx, 1)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. Expected %v\tGot %v", i, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,100 @@
+package series
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"ThreeLayer/service"
+	"context"
+	"strings"
+)
+
+type Service struct {
+	store datastore.Series
+	tx    datastore.Transactor
+}
+
+func New(s datastore.Series, tx datastore.Transactor) Service {
+	return Service{store: s, tx: tx}
+}
+
+// GetSeries returns all the series
+func (s Service) GetSeries(ctx context.Context) ([]entities.Series, error) {
+	return s.store.GetSeries(ctx)
+}
+
+// GetSeriesByID returns the series with given id
+func (s Service) GetSeriesByID(ctx context.Context, id int) (entities.Series, error) {
+	return s.store.GetSeriesByID(ctx, id)
+}
+
+// PostSeries adds a new series, two series can have the same name as only the volumes tell them apart
+func (s Service) PostSeries(ctx context.Context, series entities.Series) (entities.Series, error) {
+	series.Name = strings.TrimSpace(series.Name)
+
+	if series.Name == "" {
+		return entities.Series{}, errors.InValidDetails{Details: "Name"}
+	}
+
+	return s.store.CreateSeries(ctx, series)
+}
+
+// PutSeries replaces the series with given id, the version of the series is checked against the If-Match versions
+// in the context
+func (s Service) PutSeries(ctx context.Context, id int, series entities.Series) (entities.Series, error) {
+	series.Name = strings.TrimSpace(series.Name)
+
+	if series.Name == "" {
+		return entities.Series{}, errors.InValidDetails{Details: "Name"}
+	}
+
+	var updated entities.Series
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := s.store.GetSeriesByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Series", id, stored.Version); err != nil {
+			return err
+		}
+
+		series.Version = stored.Version
+
+		updated, err = s.store.UpdateSeries(ctx, id, series)
+
+		return err
+	})
+	if err != nil {
+		return entities.Series{}, err
+	}
+
+	return updated, nil
+}
+
+// DeleteSeries removes the series with given id, a series having volumes can not be removed. The version of
+// the series is checked against the If-Match versions in the context
+func (s Service) DeleteSeries(ctx context.Context, id int) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		series, err := s.store.GetSeriesByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = service.CheckVersion(ctx, "Series", id, series.Version); err != nil {
+			return err
+		}
+
+		volumes, err := s.store.GetVolumes(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(volumes) != 0 {
+			return errors.Conflict{Entity: "Series", ID: id, Reason: "has books"}
+		}
+
+		return s.store.DeleteSeries(ctx, id)
+	})
+}
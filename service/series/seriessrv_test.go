@@ -0,0 +1,150 @@
+package series
+
+import (
+	"ThreeLayer/datastore"
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mockTx runs the unit of work without a transaction
+type mockTx struct{}
+
+func (mockTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestService_PostSeries(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		req    entities.Series
+		expNew entities.Series
+		expErr error
+	}{
+		{desc: "name is trimmed", req: entities.Series{Name: " Dune "}, expNew: entities.Series{Name: "Dune"}},
+		{desc: "name is missing", req: entities.Series{Name: " "}, expErr: errors.InValidDetails{Details: "Name"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockSeries(ctrl)
+
+		var expRes entities.Series
+
+		if tc.expErr == nil {
+			expRes = tc.expNew
+			expRes.ID, expRes.Version = 1, 1
+
+			store.EXPECT().CreateSeries(gomock.Any(), tc.expNew).Return(expRes, nil)
+		}
+
+		res, err := New(store, mockTx{}).PostSeries(context.Background(), tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		if res != expRes {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_PutSeries(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		req      entities.Series
+		expRes   entities.Series
+		expErr   error
+	}{
+		{desc: "renamed", id: 1, versions: []int{2}, req: entities.Series{Name: "Dune Chronicles"},
+			expRes: entities.Series{ID: 1, Name: "Dune Chronicles", Version: 3}},
+		{desc: "version does not match", id: 1, versions: []int{1}, req: entities.Series{Name: "Dune"},
+			expErr: errors.PreconditionFailed{Entity: "Series", ID: 1}},
+		{desc: "name is missing", id: 1, req: entities.Series{}, expErr: errors.InValidDetails{Details: "Name"}},
+		{desc: "not found", id: 9, req: entities.Series{Name: "Dune"},
+			expErr: errors.EntityNotFound{Entity: "Series", ID: 9}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockSeries(ctrl)
+
+		store.EXPECT().GetSeriesByID(gomock.Any(), 1).Return(entities.Series{ID: 1, Name: "Dune", Version: 2},
+			nil).AnyTimes()
+		store.EXPECT().GetSeriesByID(gomock.Any(), 9).Return(entities.Series{},
+			errors.EntityNotFound{Entity: "Series", ID: 9}).AnyTimes()
+
+		if tc.expErr == nil {
+			store.EXPECT().UpdateSeries(gomock.Any(), tc.id, entities.Series{Name: tc.req.Name, Version: 2}).
+				Return(entities.Series{ID: tc.id, Name: tc.req.Name, Version: 3}, nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		res, err := New(store, mockTx{}).PutSeries(ctx, tc.id, tc.req)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		if res != tc.expRes {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expRes, res)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestService_DeleteSeries(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		id       int
+		versions []int
+		volumes  map[int]int
+		expErr   error
+	}{
+		{desc: "deleted", id: 1, volumes: map[int]int{}},
+		{desc: "deleted when the version matches", id: 1, versions: []int{2}, volumes: map[int]int{}},
+		{desc: "version does not match", id: 1, versions: []int{1}, expErr: errors.PreconditionFailed{Entity: "Series",
+			ID: 1}},
+		{desc: "series has books", id: 1, volumes: map[int]int{1: 4, 2: 7},
+			expErr: errors.Conflict{Entity: "Series", ID: 1, Reason: "has books"}},
+	}
+
+	for i, tc := range testcases {
+		ctrl := gomock.NewController(t)
+		store := datastore.NewMockSeries(ctrl)
+
+		store.EXPECT().GetSeriesByID(gomock.Any(), tc.id).Return(entities.Series{ID: 1, Name: "Dune", Version: 2}, nil)
+
+		if tc.volumes != nil {
+			store.EXPECT().GetVolumes(gomock.Any(), tc.id).Return(tc.volumes, nil)
+		}
+
+		if tc.expErr == nil {
+			store.EXPECT().DeleteSeries(gomock.Any(), tc.id).Return(nil)
+		}
+
+		ctx := context.Background()
+		if tc.versions != nil {
+			ctx = context.WithValue(ctx, entities.IfMatch, tc.versions)
+		}
+
+		err := New(store, mockTx{}).DeleteSeries(ctx, tc.id)
+		if !reflect.DeepEqual(err, tc.expErr) {
+			t.Errorf("[TEST%d]Failed. %s Expected %v\tGot %v", i, tc.desc, tc.expErr, err)
+		}
+
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"ThreeLayer/entities"
+	"ThreeLayer/errors"
+	"context"
+)
+
+// CheckVersion returns PreconditionFailed when the If-Match versions are set in the context and none of them
+// is the stored version of the entity
+func CheckVersion(ctx context.Context, entity string, id, version int) error {
+	versions, ok := ctx.Value(entities.IfMatch).([]int)
+	if !ok {
+		return nil
+	}
+
+	for _, v := range versions {
+		if v == version {
+			return nil
+		}
+	}
+
+	return errors.PreconditionFailed{Entity: entity, ID: id}
+}